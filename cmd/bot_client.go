@@ -21,7 +21,7 @@ func main() {
 
 	game := backend.NewGame()
 	game.IsAuthoritative = false
-	view := frontend.NewView(game)
+	view := frontend.NewView(game, frontend.DefaultKeyMap())
 	game.Start()
 
 	conn, err := grpc.Dial(*address, grpc.WithInsecure())
@@ -34,8 +34,11 @@ func main() {
 
 	bots := bot.NewBots(game)
 	player := bots.AddBot("Bob")
+	if player == nil {
+		log.Fatal("can not add the bot to the game")
+	}
 
-	err = client.Connect(grpcClient, player.ID(), player.Name, "")
+	err = client.Connect(grpcClient, player.ID(), player.Name, "", "")
 	if err != nil {
 		log.Fatalf("connect request failed %v", err)
 	}
@@ -45,6 +48,7 @@ func main() {
 	bots.Start()
 
 	err = <-view.Done
+	client.Disconnect()
 	if err != nil {
 		log.Fatal(err)
 	}
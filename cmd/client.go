@@ -3,6 +3,9 @@ package main
 // Connects to a server for play.
 
 import (
+	"context"
+	"flag"
+	"fmt"
 	"log"
 	"os"
 	"regexp"
@@ -16,6 +19,7 @@ import (
 	"github.com/mortenson/grpc-game-example/proto"
 	"github.com/rivo/tview"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
 )
 
 const (
@@ -26,15 +30,25 @@ const (
 
 type connectInfo struct {
 	PlayerName string
+	Color      string
 	Address    string
 	Password   string
+	Spectate   bool
+	RoomID     string
+	UseTLS     bool
+	Compress   bool
+	TLS        client.TLSConfig
 }
 
 // It feels wrong to have this much frontend code in a command file, but this
 // is done as the frontend package has no awareness of the client/server model,
 // and as a result should not have UIs like this.
 // Maybe, if anything, it shows how you can compose tview applications?
-func connectApp(info *connectInfo) *tview.Application {
+// The connect callback is called when the form is submitted. If it fails, the
+// error is displayed so that the user can change fields and try again. If the
+// server has more than one room, the user picks one before connecting.
+func connectApp(listRooms func(info connectInfo) ([]*proto.Room, error), connect func(info connectInfo) error) *tview.Application {
+	info := connectInfo{}
 	app := tview.NewApplication()
 	flex := tview.NewFlex().
 		SetDirection(tview.FlexRow)
@@ -45,6 +59,69 @@ func connectApp(info *connectInfo) *tview.Application {
 		SetText(" Use the tab key to change fields, and enter to submit")
 	errors.SetBackgroundColor(backgroundColor)
 	form := tview.NewForm()
+	rooms := tview.NewList()
+	rooms.SetBorder(true).
+		SetTitle("Choose a room").
+		SetBackgroundColor(backgroundColor)
+	pages := tview.NewPages()
+	showForm := func() {
+		pages.SwitchToPage("form")
+		app.SetFocus(form)
+	}
+	finish := func() {
+		if err := connect(info); err != nil {
+			showForm()
+			errors.SetText(" " + status.Convert(err).Message())
+			return
+		}
+		app.Stop()
+	}
+	showRooms := func(serverRooms []*proto.Room) {
+		rooms.Clear()
+		for _, room := range serverRooms {
+			roomID := room.Id
+			rooms.AddItem(room.Name, fmt.Sprintf("%d players", room.Players), 0, func() {
+				info.RoomID = roomID
+				finish()
+			})
+		}
+		rooms.AddItem("Back", "Return to the connect form", 'b', showForm)
+		errors.SetText(" Use the arrow keys to choose a room, and enter to join")
+		pages.SwitchToPage("rooms")
+		app.SetFocus(rooms)
+	}
+	submit := func(spectate bool) {
+		info.Spectate = spectate
+		info.RoomID = ""
+		info.PlayerName = form.GetFormItem(0).(*tview.InputField).GetText()
+		_, info.Color = form.GetFormItem(1).(*tview.DropDown).GetCurrentOption()
+		info.Address = form.GetFormItem(2).(*tview.InputField).GetText()
+		info.Password = form.GetFormItem(3).(*tview.InputField).GetText()
+		info.UseTLS = form.GetFormItem(4).(*tview.Checkbox).IsChecked()
+		info.TLS.CAFile = form.GetFormItem(5).(*tview.InputField).GetText()
+		info.TLS.CertFile = form.GetFormItem(6).(*tview.InputField).GetText()
+		info.TLS.KeyFile = form.GetFormItem(7).(*tview.InputField).GetText()
+		info.Compress = form.GetFormItem(8).(*tview.Checkbox).IsChecked()
+		// Spectators don't need a name.
+		if (info.PlayerName == "" && !info.Spectate) || info.Address == "" {
+			errors.SetText(" All fields are required.")
+			return
+		}
+		if !info.UseTLS && (info.TLS.CAFile != "" || info.TLS.CertFile != "" || info.TLS.KeyFile != "") {
+			errors.SetText(" Certificates are only used with TLS.")
+			return
+		}
+		serverRooms, err := listRooms(info)
+		if err != nil {
+			errors.SetText(" " + status.Convert(err).Message())
+			return
+		}
+		if len(serverRooms) > 1 {
+			showRooms(serverRooms)
+			return
+		}
+		finish()
+	}
 	re := regexp.MustCompile("^[a-zA-Z0-9]+$")
 	form.AddInputField("Player name", "", 16, func(textCheck string, lastChar rune) bool {
 		result := re.MatchString(textCheck)
@@ -53,17 +130,19 @@ func connectApp(info *connectInfo) *tview.Application {
 		}
 		return result
 	}, nil).
+		AddDropDown("Color", backend.PlayerColors, 0, nil).
 		AddInputField("Server address", ":8888", 32, nil, nil).
 		AddPasswordField("Server password", "", 32, '*', nil).
+		AddCheckbox("Use TLS", false, nil).
+		AddInputField("CA certificate", "", 32, nil, nil).
+		AddInputField("Client certificate", "", 32, nil, nil).
+		AddInputField("Client key", "", 32, nil, nil).
+		AddCheckbox("Use compression", false, nil).
 		AddButton("Connect", func() {
-			info.PlayerName = form.GetFormItem(0).(*tview.InputField).GetText()
-			info.Address = form.GetFormItem(1).(*tview.InputField).GetText()
-			info.Password = form.GetFormItem(2).(*tview.InputField).GetText()
-			if info.PlayerName == "" || info.Address == "" {
-				errors.SetText(" All fields are required.")
-				return
-			}
-			app.Stop()
+			submit(false)
+		}).
+		AddButton("Spectate", func() {
+			submit(true)
 		}).
 		AddButton("Quit", func() {
 			app.Stop()
@@ -73,43 +152,123 @@ func connectApp(info *connectInfo) *tview.Application {
 		SetFieldBackgroundColor(fieldColor).
 		SetBackgroundColor(backgroundColor)
 	flex.AddItem(errors, 1, 1, false)
-	flex.AddItem(form, 0, 1, false)
+	pages.AddPage("form", form, true, true).
+		AddPage("rooms", rooms, true, false)
+	flex.AddItem(pages, 0, 1, false)
 	app.SetRoot(flex, true).SetFocus(form)
 	return app
 }
 
+// dial opens a connection to the server using the connect info's options.
+func dial(info connectInfo) (*grpc.ClientConn, error) {
+	var tlsConfig *client.TLSConfig
+	if info.UseTLS {
+		tlsConfig = &info.TLS
+	}
+	dialOption, err := client.DialOption(tlsConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	dialOptions := []grpc.DialOption{dialOption}
+	if info.Compress {
+		dialOptions = append(dialOptions, client.CompressionDialOption())
+	}
+
+	return grpc.Dial(info.Address, dialOptions...)
+}
+
+// listRooms fetches the rooms that the server hosts.
+func listRooms(info connectInfo) ([]*proto.Room, error) {
+	conn, err := dial(info)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+	resp, err := proto.NewGameClient(conn).ListRooms(context.Background(), &proto.ListRoomsRequest{})
+	if err != nil {
+		return nil, err
+	}
+	return resp.Rooms, nil
+}
+
+// connect dials the server and connects a new player, returning an error
+// instead of exiting so that connecting can be retried from the form. Failed
+// attempts are retried a few times first. Players are resumed if the last
+// session was on the same server and room, and the server still has the
+// player.
+func connect(game *backend.Game, view *frontend.View, info connectInfo) (*client.GameClient, error) {
+	gameClient := client.NewGameClient(game, view)
+	gameClient.Spectator = info.Spectate
+	gameClient.RoomID = info.RoomID
+	view.SetSpectating(info.Spectate)
+
+	// Sessions are a convenience, so the player just starts over if they
+	// can't be loaded or saved.
+	playerID := uuid.New()
+	sessionPath, err := client.DefaultSessionPath()
+	keepSession := err == nil && !info.Spectate
+	if keepSession {
+		session, err := client.LoadSession(sessionPath)
+		if err == nil && session.Matches(info.Address, info.RoomID) {
+			playerID = session.PlayerID
+			gameClient.ReconnectToken = session.ReconnectToken
+		}
+	}
+	dialer := func() (proto.GameClient, func() error, error) {
+		conn, err := dial(info)
+		if err != nil {
+			return nil, nil, err
+		}
+		return proto.NewGameClient(conn), conn.Close, nil
+	}
+	err = gameClient.DialAndConnect(dialer, playerID, info.PlayerName, info.Password, info.Color)
+	if err != nil {
+		return nil, err
+	}
+	if keepSession {
+		client.SaveSession(sessionPath, client.Session{
+			Address:        info.Address,
+			RoomID:         info.RoomID,
+			PlayerID:       playerID,
+			ReconnectToken: gameClient.ReconnectToken,
+		})
+	}
+	return gameClient, nil
+}
+
 func main() {
 	if !termutil.Isatty(os.Stdin.Fd()) {
 		panic("this program must be run in a terminal")
 	}
 
+	fogRadius := flag.Int("fog", 0, "Only show entities within this many cells that are in line of sight.")
+	flag.Parse()
+
 	game := backend.NewGame()
 	game.IsAuthoritative = false
-	view := frontend.NewView(game)
+	view := frontend.NewView(game, frontend.DefaultKeyMap())
+	view.FogRadius = *fogRadius
 	game.Start()
 
-	info := connectInfo{}
-	connectApp := connectApp(&info)
+	var gameClient *client.GameClient
+	connectApp := connectApp(listRooms, func(info connectInfo) error {
+		var err error
+		gameClient, err = connect(game, view, info)
+		return err
+	})
 	connectApp.Run()
 
-	conn, err := grpc.Dial(info.Address, grpc.WithInsecure())
-	if err != nil {
-		log.Fatalf("can not connect with server %v", err)
-	}
-
-	grpcClient := proto.NewGameClient(conn)
-	client := client.NewGameClient(game, view)
-
-	playerID := uuid.New()
-	err = client.Connect(grpcClient, playerID, info.PlayerName, info.Password)
-	if err != nil {
-		log.Fatalf("connect request failed %v", err)
+	// The user quit without connecting.
+	if gameClient == nil {
+		return
 	}
-	client.Start()
+	gameClient.Start()
 
 	view.Start()
 
-	err = <-view.Done
+	err := <-view.Done
+	gameClient.Disconnect()
 	if err != nil {
 		log.Fatal(err)
 	}
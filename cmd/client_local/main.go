@@ -24,6 +24,8 @@ func main() {
 	}
 
 	numBots := flag.Int("bots", 1, "The number of bots to play against.")
+	horde := flag.Bool("horde", false, "Fight waves of bots instead of a fixed number.")
+	fogRadius := flag.Int("fog", 0, "Only show entities within this many cells that are in line of sight.")
 	flag.Parse()
 
 	currentPlayer := backend.Player{
@@ -33,10 +35,12 @@ func main() {
 		CurrentPosition: backend.Coordinate{X: -1, Y: -5},
 	}
 	game := backend.NewGame()
+	game.HordeMode = *horde
 	game.AddEntity(&currentPlayer)
 
-	view := frontend.NewView(game)
+	view := frontend.NewView(game, frontend.DefaultKeyMap())
 	view.CurrentPlayer = currentPlayer.ID()
+	view.FogRadius = *fogRadius
 
 	bots := bot.NewBots(game)
 	for i := 0; i < *numBots; i++ {
@@ -46,6 +50,9 @@ func main() {
 	game.Start()
 	view.Start()
 	bots.Start()
+	if *horde {
+		bots.StartHorde()
+	}
 
 	err := <-view.Done
 	if err != nil {
@@ -0,0 +1,82 @@
+package main
+
+// Connects many headless clients to a server, which is useful for load
+// testing. Each client moves and fires randomly, or replays a recording.
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mortenson/grpc-game-example/pkg/backend"
+	"github.com/mortenson/grpc-game-example/pkg/client"
+	"github.com/mortenson/grpc-game-example/proto"
+	"google.golang.org/grpc"
+)
+
+func main() {
+	address := flag.String("address", ":8888", "The server address.")
+	password := flag.String("password", "", "The server password.")
+	numClients := flag.Int("clients", 4, "The number of clients to connect.")
+	interval := flag.Duration("interval", 200*time.Millisecond, "How often each client acts.")
+	compress := flag.Bool("gzip", false, "Compress messages with gzip.")
+	record := flag.String("record", "", "A file to record the first client's actions to.")
+	replay := flag.String("replay", "", "A recording for each client to replay instead of acting randomly.")
+	flag.Parse()
+
+	dialOptions := []grpc.DialOption{grpc.WithInsecure()}
+	if *compress {
+		dialOptions = append(dialOptions, client.CompressionDialOption())
+	}
+	conn, err := grpc.Dial(*address, dialOptions...)
+	if err != nil {
+		log.Fatalf("can not connect with server %v", err)
+	}
+	grpcClient := proto.NewGameClient(conn)
+
+	var wg sync.WaitGroup
+	for i := 0; i < *numClients; i++ {
+		game := backend.NewGame()
+		game.IsAuthoritative = false
+		game.Start()
+
+		headlessClient := client.NewHeadlessGameClient(game)
+		err := headlessClient.Connect(grpcClient, uuid.New(), fmt.Sprintf("Headless%d", i), *password, "")
+		if err != nil {
+			log.Printf("connect request failed %v", err)
+			continue
+		}
+		headlessClient.Start()
+		if *record != "" && i == 0 {
+			file, err := os.Create(*record)
+			if err != nil {
+				log.Fatalf("can not create recording %v", err)
+			}
+			defer file.Close()
+			headlessClient.StartRecording(file)
+		}
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if *replay == "" {
+				headlessClient.RunActions(client.RandomActions, *interval)
+				return
+			}
+			file, err := os.Open(*replay)
+			if err != nil {
+				log.Printf("can not open recording %v", err)
+				return
+			}
+			defer file.Close()
+			if err := headlessClient.ReplayFrom(file); err != nil {
+				log.Printf("replay failed %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+}
@@ -7,28 +7,76 @@ import (
 	"fmt"
 	"log"
 	"net"
+	"time"
 
 	"github.com/mortenson/grpc-game-example/pkg/backend"
 	"github.com/mortenson/grpc-game-example/pkg/bot"
 	"github.com/mortenson/grpc-game-example/pkg/server"
 	"github.com/mortenson/grpc-game-example/proto"
 
+	"golang.org/x/crypto/bcrypt"
 	"google.golang.org/grpc"
 )
 
 func main() {
 	port := flag.Int("port", 8888, "The port to listen on.")
-	password := flag.String("password", "", "The server password.")
+	passwordHash := flag.String("password-hash", "", "A bcrypt hash of the server password.")
+	hashPassword := flag.String("hash-password", "", "Prints the bcrypt hash of a password, then exits.")
 	numBots := flag.Int("bots", 0, "The number of bots to add to the server.")
+	configPath := flag.String("config", "", "A JSON file of game parameters. If empty, defaults are used.")
+	numRooms := flag.Int("rooms", 0, "The number of rooms to host in addition to the default room.")
+	rateLimit := flag.Float64("rate-limit", 0, "The number of messages per second each client can send, besides heartbeats. Zero disables the limit.")
+	rateBurst := flag.Int("rate-burst", 20, "The number of messages a client can send at once before being rate limited.")
+	broadcastInterval := flag.Duration("broadcast-interval", 0, "How often to broadcast batched changes, for example 33ms. Zero broadcasts changes immediately.")
+	interestRadius := flag.Int("interest-radius", 0, "How far from their player, in cells, clients are sent changes to entities. Zero sends every change.")
+	reconnectWindow := flag.Duration("reconnect-window", 30*time.Second, "How long players stay in the game after losing their connection, so their client can reconnect.")
+	statsPath := flag.String("stats", "", "A JSON file to keep players' career stats in. If empty, career stats aren't kept.")
+	tlsCert := flag.String("tls-cert", "", "A TLS certificate file. If empty, TLS is not used.")
+	tlsKey := flag.String("tls-key", "", "The TLS certificate's key file.")
+	tlsClientCA := flag.String("tls-client-ca", "", "A CA certificate file used to verify client certificates.")
 	flag.Parse()
 
+	if *hashPassword != "" {
+		hash, err := bcrypt.GenerateFromPassword([]byte(*hashPassword), bcrypt.DefaultCost)
+		if err != nil {
+			log.Fatalf("failed to hash password: %v", err)
+		}
+		fmt.Println(string(hash))
+		return
+	}
+	if *passwordHash != "" {
+		if _, err := bcrypt.Cost([]byte(*passwordHash)); err != nil {
+			log.Fatalf("invalid password hash: %v", err)
+		}
+	}
+
+	config := backend.DefaultConfig()
+	if *configPath != "" {
+		loaded, err := backend.LoadConfig(*configPath)
+		if err != nil {
+			log.Fatalf("failed to load config: %v", err)
+		}
+		config = loaded
+	}
+
 	log.Printf("listening on port %d", *port)
 	lis, err := net.Listen("tcp", fmt.Sprintf(":%d", *port))
 	if err != nil {
 		log.Fatalf("failed to listen: %v", err)
 	}
 
-	game := backend.NewGame()
+	game, err := backend.NewGameFromConfig(config)
+	if err != nil {
+		log.Fatalf("invalid config: %v", err)
+	}
+	var statsStore backend.StatsStore
+	if *statsPath != "" {
+		statsStore = backend.NewFileStatsStore(*statsPath)
+		game.StatsStore = statsStore
+		game.Metrics.StatsStoreError = func(err error) {
+			log.Printf("failed to record career stats: %v", err)
+		}
+	}
 
 	bots := bot.NewBots(game)
 	for i := 0; i < *numBots; i++ {
@@ -37,9 +85,41 @@ func main() {
 
 	game.Start()
 	bots.Start()
+	if game.HordeMode {
+		bots.StartHorde()
+	}
 
-	s := grpc.NewServer()
-	server := server.NewGameServer(game, *password)
+	serverOptions := []grpc.ServerOption{}
+	if *tlsCert != "" {
+		tlsConfig := server.TLSConfig{
+			CertFile:     *tlsCert,
+			KeyFile:      *tlsKey,
+			ClientCAFile: *tlsClientCA,
+		}
+		tlsOption, err := tlsConfig.ServerOption()
+		if err != nil {
+			log.Fatalf("invalid TLS configuration: %v", err)
+		}
+		serverOptions = append(serverOptions, tlsOption)
+	} else {
+		log.Print("serving without TLS")
+	}
+
+	s := grpc.NewServer(serverOptions...)
+	server := server.NewGameServer(game, *passwordHash, *broadcastInterval)
+	server.SetRateLimit(*rateLimit, *rateBurst)
+	server.SetInterestRadius(*interestRadius)
+	server.SetReconnectWindow(*reconnectWindow)
+	for i := 0; i < *numRooms; i++ {
+		roomGame, err := backend.NewGameFromConfig(config)
+		if err != nil {
+			log.Fatalf("invalid config: %v", err)
+		}
+		roomGame.StatsStore = statsStore
+		roomGame.Metrics = game.Metrics
+		roomGame.Start()
+		server.AddRoom(fmt.Sprintf("Room %d", i+1), roomGame)
+	}
 	proto.RegisterGameServer(s, server)
 
 	if err := s.Serve(lis); err != nil {
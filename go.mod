@@ -9,5 +9,6 @@ require (
 	github.com/golang/protobuf v1.3.5
 	github.com/google/uuid v1.1.1
 	github.com/rivo/tview v0.0.0-20200329194346-7cc182c5846e
+	golang.org/x/crypto v0.0.0-20211117183948-ae814b36b871
 	google.golang.org/grpc v1.28.0
 )
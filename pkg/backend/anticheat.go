@@ -0,0 +1,50 @@
+package backend
+
+import (
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// A player is suspicious if more than suspiciousFireLimit of their lasers
+	// are throttled within suspiciousFireWindow. Normal clients throttle
+	// themselves, so should rarely be throttled by the server.
+	suspiciousFireWindow = 10 * time.Second
+	suspiciousFireLimit  = 10
+)
+
+// recordRejectedFire records that a player fired faster than allowed.
+func (game *Game) recordRejectedFire(playerID uuid.UUID, created time.Time) {
+	rejections := append(game.rejectedFires[playerID], created)
+	// Forget rejections that are outside of the window.
+	i := 0
+	for i < len(rejections) && rejections[i].Before(created.Add(-suspiciousFireWindow)) {
+		i++
+	}
+	game.rejectedFires[playerID] = rejections[i:]
+}
+
+// SuspiciousPlayers returns the players who have recently tried to fire
+// faster than allowed too many times. Operators can review or kick these
+// players.
+func (game *Game) SuspiciousPlayers() []uuid.UUID {
+	windowStart := time.Now().Add(-suspiciousFireWindow)
+	suspicious := []uuid.UUID{}
+	for playerID, rejections := range game.rejectedFires {
+		count := 0
+		for _, rejection := range rejections {
+			if rejection.After(windowStart) {
+				count++
+			}
+		}
+		if count > suspiciousFireLimit {
+			suspicious = append(suspicious, playerID)
+		}
+	}
+	sort.Slice(suspicious, func(i, j int) bool {
+		return suspicious[i].String() < suspicious[j].String()
+	})
+	return suspicious
+}
@@ -0,0 +1,77 @@
+package backend_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mortenson/grpc-game-example/pkg/backend"
+	"github.com/mortenson/grpc-game-example/pkg/backend/testutil"
+)
+
+// fireAt returns actions for a player firing at times relative to now.
+func fireAt(game *backend.Game, name string, now time.Time, offsets ...time.Duration) []backend.Action {
+	actions := make([]backend.Action, 0, len(offsets))
+	for _, offset := range offsets {
+		actions = append(actions, backend.LaserAction{
+			ID:        uuid.New(),
+			OwnerID:   testutil.Player(game, name).ID(),
+			Direction: backend.DirectionUp,
+			Created:   now.Add(offset),
+		})
+	}
+	return actions
+}
+
+// repeat returns an offset repeated a number of times.
+func repeat(offset time.Duration, times int) []time.Duration {
+	offsets := make([]time.Duration, times)
+	for i := range offsets {
+		offsets[i] = offset
+	}
+	return offsets
+}
+
+func TestSuspiciousPlayers(t *testing.T) {
+	tests := []struct {
+		name    string
+		offsets []time.Duration
+		flagged bool
+	}{
+		{
+			name: "fires at the allowed rate",
+			offsets: []time.Duration{
+				-11 * time.Second, -10 * time.Second, -9 * time.Second, -8 * time.Second,
+				-7 * time.Second, -6 * time.Second, -5 * time.Second, -4 * time.Second,
+				-3 * time.Second, -2 * time.Second, -time.Second, 0,
+			},
+			flagged: false,
+		},
+		{name: "spams lasers", offsets: repeat(0, 12), flagged: true},
+		{name: "spams up to the limit", offsets: repeat(0, 11), flagged: false},
+		{name: "spammed a while ago", offsets: repeat(-20*time.Second, 12), flagged: false},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			game := testutil.NewGame(
+				testutil.WithPlayerAt("alice", 0, 0),
+				testutil.WithPlayerAt("bob", 3, 0),
+			)
+			now := time.Now()
+			actions := fireAt(game, "alice", now, test.offsets...)
+			actions = append(actions, fireAt(game, "bob", now, -time.Second, 0)...)
+			testutil.RunActions(game, actions...)
+			suspicious := game.SuspiciousPlayers()
+			if flagged := len(suspicious) == 1 && suspicious[0] == testutil.Player(game, "alice").ID(); flagged != test.flagged {
+				t.Errorf("got suspicious players %v, want alice flagged %v", suspicious, test.flagged)
+			}
+			if test.flagged {
+				// Players who leave are forgotten.
+				game.RemovePlayerAndOwned(testutil.Player(game, "alice").ID())
+				if suspicious := game.SuspiciousPlayers(); len(suspicious) != 0 {
+					t.Errorf("got suspicious players %v after alice left", suspicious)
+				}
+			}
+		})
+	}
+}
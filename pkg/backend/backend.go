@@ -1,8 +1,13 @@
 package backend
 
 import (
+	"encoding/binary"
+	"errors"
 	"fmt"
 	"math"
+	"math/rand"
+	"sort"
+	"strings"
 	"sync"
 	"time"
 
@@ -16,6 +21,10 @@ const (
 	moveThrottle            = 100 * time.Millisecond
 	laserThrottle           = 500 * time.Millisecond
 	laserSpeed              = 50
+	projectileSpawnOffset   = 1
+	recentKillLimit         = 10
+	removalGracePeriod      = 500 * time.Millisecond
+	actionQueueSize         = 64
 )
 
 // Game is the backend engine for the game. It can be used regardless of how
@@ -32,14 +41,194 @@ type Game struct {
 	RoundWinner     uuid.UUID
 	WaitForRound    bool
 	IsAuthoritative bool
-	spawnPointIndex int
+	// RecentKills contains the last few kills, oldest first.
+	RecentKills []Kill
+	// MaxLasersPerPlayer limits how many lasers a player can have in play at
+	// once. A value of zero means there is no limit.
+	MaxLasersPerPlayer int
+	spawnPointIndex    int
+	// StartingScore is the score players have when they join and when a new
+	// round starts. If LoseScoreOnDeath is true, players lose a point when they
+	// are killed, which allows for modes that count down.
+	StartingScore    int
+	LoseScoreOnDeath bool
+	// Rand is used for random game events, and can be replaced with a seeded
+	// source to make them deterministic.
+	Rand *rand.Rand
+	// PowerUpDropChance is the chance, between 0 and 1, that a killed player
+	// drops a power-up.
+	PowerUpDropChance float64
+	// SimulateMovement and ResolveCollisions control which parts of the
+	// engine run locally. For example, a client can predict movement while
+	// leaving collisions to the server. Both must be set before Start is
+	// called. Kills are only decided by authoritative games.
+	SimulateMovement  bool
+	ResolveCollisions bool
+	// BotKillsScore decides if bots score points for kills. Disabling this
+	// keeps bots from affecting the leaderboard in matches with humans.
+	BotKillsScore bool
+	// Tunables, which can be changed using NewGameFromConfig.
+	roundOverScore   int
+	newRoundWaitTime time.Duration
+	moveThrottle     time.Duration
+	laserThrottle    time.Duration
+	hazardDamage     int
+	mineDamage       int
+	// rejectedFires records when each player's lasers were throttled.
+	rejectedFires map[uuid.UUID][]time.Time
+	// spectators is how many clients are watching without playing.
+	spectators int
+	// Events publishes engine events, such as kills, to subscribers.
+	Events *EventBus
+	// DefaultDirection is the direction players face before they first try
+	// to move.
+	DefaultDirection Direction
+	// RespawnMode decides where killed players respawn.
+	RespawnMode RespawnMode
+	// ScoreDecay takes points from players who haven't scored recently.
+	ScoreDecay ScoreDecay
+	// lastScored records when each player last scored, for score decay.
+	lastScored map[uuid.UUID]time.Time
+	// IDGenerator creates IDs for entities created by the engine, and can be
+	// replaced to make IDs predictable in tests.
+	IDGenerator func() uuid.UUID
+	// FreezeTag splits players into teams. Hit players are frozen instead of
+	// killed until a teammate moves next to them, and a team wins the round
+	// when all of its opponents are frozen.
+	FreezeTag bool
+	// Screen maps coordinates for frontends that draw the game with a fixed
+	// origin and scale.
+	Screen ScreenTransform
+	// RoundSummary summarizes the last round that was over.
+	RoundSummary Summary
+	// stats are collected during a round for its summary.
+	stats matchStats
+	// EmitTrails sends a TrailChange with the cells lasers passed through
+	// whenever collisions are checked.
+	EmitTrails      bool
+	lastTrailUpdate time.Time
+	// RemovalGracePeriod is how long removed entities are tombstoned, during
+	// which updates for them are ignored so they are not brought back by late
+	// messages. Adding an entity again clears its tombstone.
+	RemovalGracePeriod time.Duration
+	tombstones         map[uuid.UUID]time.Time
+	// moveSequences counts each entity's moves, for MoveChange.MoveSequence.
+	moveSequences map[uuid.UUID]uint32
+	// HordeMode is a cooperative mode where players fight waves of bots,
+	// which are spawned by the bot package. Wave is the current wave.
+	HordeMode bool
+	Wave      int
+	// SpawnProtection is how long players can't be hurt after respawning.
+	// While protected, players blink every BlinkInterval.
+	SpawnProtection time.Duration
+	BlinkInterval   time.Duration
+	// Loadout is given to players who are added without one.
+	Loadout []WeaponKind
+	// hits are the laser and player pairs that overlapped when collisions
+	// were last checked, so that a lingering overlap is only handled once.
+	hits map[collisionPair]bool
+	// The collision loop is idle while it has no work to do, to save CPU on
+	// empty servers, and is woken by adding a player or laser, or by starting
+	// a timed round.
+	idle bool
+	wake chan struct{}
+	// Phase is the match's current phase. Games that start in PhaseLobby
+	// disable combat until every player is ready, or until LobbyTimeout has
+	// passed since the game was started, if it is set.
+	Phase        Phase
+	LobbyTimeout time.Duration
+	lobbyStarted time.Time
+	// RoundDuration limits how long rounds last, after which the player with
+	// the highest score wins. Zero lets rounds last until someone reaches the
+	// round over score. GoldenGoalGrace is how much longer rounds last if
+	// lasers are still in flight when time is up.
+	RoundDuration   time.Duration
+	GoldenGoalGrace time.Duration
+	roundStarted    time.Time
+	// Metrics are reported to while the game runs.
+	Metrics Metrics
+	// RegenDelay is how long players must go without being hit before they
+	// start regenerating health. Zero disables regeneration.
+	RegenDelay time.Duration
+	// RandomModifier applies a modifier chosen from Modifiers when the game is
+	// started. Modifier is the name of the active modifier, if any.
+	RandomModifier bool
+	Modifiers      []Modifier
+	Modifier       string
+	// CollisionPriority is the order entity types are resolved in when they
+	// share a cell, by name: "player", "laser", "powerUp", "healthPack", or
+	// "wall". Types that aren't listed are resolved last. Kills are applied
+	// once every cell is resolved, so killed players still collect pickups in
+	// their cell even if "player" comes first. Only modes that handle hits
+	// right away, like horde mode, are affected by that order. Lasers are
+	// removed after the rest of their cell, wherever they are listed.
+	CollisionPriority []string
+	// TeamColors are the names of the teams' colors, starting with team one.
+	// Lasers fired by players on a team are drawn in its color.
+	TeamColors []string
+	// HitRadius is how far, in cells, a laser can be from a player and still
+	// hit them. Zero requires lasers to be in the same cell as players.
+	HitRadius int
+	// SelfHitGrace is how many collision checks pass after a laser is fired
+	// before it can hit the player who fired it. Zero never lets lasers hit
+	// their owner.
+	SelfHitGrace int
+	// RearImmunity is how many collision checks after moving that players
+	// can't be hit by lasers from behind, which travel in the direction the
+	// player is facing. Zero disables the immunity.
+	RearImmunity int
+	// HealthPackDropChance is the chance of a health pack being dropped when
+	// a player is killed, which heals players by HealthPackAmount.
+	HealthPackDropChance float64
+	HealthPackAmount     int
+	// MaxMinesPerPlayer limits how many mines a player can have deployed at
+	// once. A value of zero means there is no limit.
+	MaxMinesPerPlayer int
+	// LaserMaxAge is how long lasers can fly before they are despawned, so
+	// that lasers that never hit anything don't stay in play forever. Zero
+	// lets lasers fly until they hit something.
+	LaserMaxAge time.Duration
+	// Observer is told about every change to the game's state, such as
+	// entities being added or moved. It defaults to a NopObserver.
+	Observer Observer
+	// RecentExplosions contains the last few mine explosions, oldest first.
+	RecentExplosions []Explosion
+	// pendingKills are kills found while resolving collisions, which haven't
+	// been applied yet.
+	pendingKills []pendingKill
+	// ProjectileSpawnOffset is how many cells ahead of the firing entity
+	// lasers are spawned. Shots are rejected if a wall is in the way.
+	ProjectileSpawnOffset int
+	// DuplicateNames decides if players joining with a name that is already
+	// taken are rejected or renamed.
+	DuplicateNames DuplicateNameMode
+	// SpeedMultiplier speeds up or slows down the game, scaling throttles,
+	// the collision interval, round and spawn protection durations, the
+	// hazard, regen, and score decay timers, and the speed of lasers fired
+	// while it's set. It should be set before the game is started.
+	SpeedMultiplier float64
+	// StatsStore records each player's career stats when a round is over. If
+	// nil, career stats aren't kept.
+	StatsStore StatsStore
+	// MaxPlayers is how many players, not counting bots or spectators, can be
+	// in the game at once. Zero doesn't limit players.
+	MaxPlayers int
+	// MaxEntities is how many entities can be in the game at once, after
+	// which AddEntity fails. Zero doesn't limit entities.
+	MaxEntities int
+}
+
+// collisionPair identifies a laser overlapping a player.
+type collisionPair struct {
+	laserID  uuid.UUID
+	playerID uuid.UUID
 }
 
 // NewGame constructs a new Game struct.
 func NewGame() *Game {
 	game := Game{
 		Entities:        make(map[uuid.UUID]Identifier),
-		ActionChannel:   make(chan Action, 1),
+		ActionChannel:   make(chan Action, actionQueueSize),
 		lastAction:      make(map[string]time.Time),
 		ChangeChannel:   make(chan Change, 1),
 		IsAuthoritative: true,
@@ -47,15 +236,65 @@ func NewGame() *Game {
 		Score:           make(map[uuid.UUID]int),
 		gameMap:         MapDefault,
 		spawnPointIndex: 0,
+		rejectedFires:   make(map[uuid.UUID][]time.Time),
+		Events:          NewEventBus(),
+		lastScored:      make(map[uuid.UUID]time.Time),
+		tombstones:      make(map[uuid.UUID]time.Time),
+		wake:            make(chan struct{}, 1),
+		moveSequences:   make(map[uuid.UUID]uint32),
 	}
+	game.Rand = rand.New(rand.NewSource(time.Now().UnixNano()))
+	game.PowerUpDropChance = defaultPowerUpDropChance
+	game.HealthPackDropChance = defaultHealthPackDropChance
+	game.HealthPackAmount = defaultHealthPackAmount
+	game.MaxMinesPerPlayer = defaultMaxMinesPerPlayer
+	game.IDGenerator = uuid.New
+	game.stats = newMatchStats(time.Now())
+	game.roundStarted = time.Now()
+	game.RemovalGracePeriod = removalGracePeriod
+	game.BlinkInterval = spawnBlinkInterval
+	game.Loadout = DefaultLoadout
+	game.Modifiers = DefaultModifiers
+	game.CollisionPriority = DefaultCollisionPriority
+	game.TeamColors = DefaultTeamColors
+	game.DefaultDirection = DirectionUp
+	game.SimulateMovement = true
+	game.ResolveCollisions = true
+	game.roundOverScore = roundOverScore
+	game.newRoundWaitTime = newRoundWaitTime
+	game.moveThrottle = moveThrottle
+	game.laserThrottle = laserThrottle
+	game.hazardDamage = hazardDamage
+	game.mineDamage = mineDamage
+	game.ProjectileSpawnOffset = projectileSpawnOffset
+	game.SpeedMultiplier = 1
+	game.LaserMaxAge = defaultLaserMaxAge
+	game.Observer = NopObserver{}
 	return &game
 }
 
 // Start begins the main game loop, which waits for new actions and updates the
 // game state occordinly.
 func (game *Game) Start() {
+	if game.RandomModifier && game.IsAuthoritative {
+		game.Mu.Lock()
+		game.applyRandomModifier()
+		game.Mu.Unlock()
+	}
 	go game.watchActions()
-	go game.watchCollisions()
+	if game.ResolveCollisions {
+		go game.watchCollisions()
+	}
+	if game.IsAuthoritative {
+		go game.watchHazards()
+		go game.watchScoreDecay()
+		go game.watchProtection()
+		go game.watchRegen()
+		go game.watchLaserAge()
+		if game.Phase == PhaseLobby {
+			go game.watchLobby()
+		}
+	}
 }
 
 // watchActions waits for new actions to come in and performs them.
@@ -71,87 +310,308 @@ func (game *Game) watchActions() {
 	}
 }
 
+// Step performs the next queued action, if any, and then checks collisions
+// once, without starting any goroutines or waiting. This lets tests and tools
+// advance the game one beat at a time, so it shouldn't be used on games that
+// were started. True is returned if an action was taken from the queue.
+func (game *Game) Step() bool {
+	game.Mu.Lock()
+	defer game.Mu.Unlock()
+	stepped := false
+	select {
+	case action := <-game.ActionChannel:
+		stepped = true
+		if !game.WaitForRound {
+			action.Perform(game)
+		}
+	default:
+	}
+	if game.ResolveCollisions {
+		game.resolveCollisions()
+		game.Metrics.collisionCheck()
+	}
+	return stepped
+}
+
+// SubmitActions enqueues a slice of actions in order, without blocking. If the
+// action queue fills up, the remaining actions are dropped. The queue's size
+// can be changed with Config.ActionQueueSize. The number of actions that were
+// accepted is returned.
+// To have a set of actions performed without collisions being checked between
+// them, wrap them in an ActionBatch and submit that instead.
+func (game *Game) SubmitActions(actions []Action) int {
+	accepted := 0
+	for _, action := range actions {
+		select {
+		case game.ActionChannel <- action:
+			accepted++
+		default:
+			return accepted
+		}
+	}
+	return accepted
+}
+
 // watchCollisions checks for entity collisions - al we care about now is when
 // a laser and a player collide but this could probably be more generalized.
+// Checks are paused while there is nothing to check, see pauseIfIdle.
 func (game *Game) watchCollisions() {
 	for {
 		game.Mu.Lock()
-		spawnPoints := game.GetMapByType()[MapTypeSpawn]
-		for _, entities := range game.getCollisionMap() {
-			if len(entities) <= 1 {
-				continue
-			}
-			// Get the first laser, if present.
-			hasLaser := false
-			var laserOwnerID uuid.UUID
-			for _, entity := range entities {
-				laser, ok := entity.(*Laser)
-				if ok {
-					hasLaser = true
-					laserOwnerID = laser.OwnerID
-					break
-				}
+		idle := game.pauseIfIdle()
+		if !idle {
+			game.resolveCollisions()
+			game.Metrics.collisionCheck()
+		}
+		game.Mu.Unlock()
+		if idle {
+			<-game.wake
+			continue
+		}
+		time.Sleep(game.scaleDuration(collisionCheckFrequency))
+	}
+}
+
+// resolveCollisions handles lasers hitting players, walls and each other, and
+// players triggering mines. The game should be locked by the caller.
+func (game *Game) resolveCollisions() {
+	if game.EmitTrails {
+		game.emitTrails(time.Now())
+	}
+	now := time.Now()
+	hits := make(map[collisionPair]bool)
+	for position, entities := range game.getCollisionMap() {
+		if len(entities) <= 1 {
+			continue
+		}
+		entities = game.sortByCollisionPriority(entities)
+		// Get the lasers, if present.
+		lasers := []*Laser{}
+		hasWall := false
+		for _, entity := range entities {
+			switch entity := entity.(type) {
+			case *Laser:
+				lasers = append(lasers, entity)
+			case *Wall:
+				hasWall = true
 			}
-			if !hasLaser {
+		}
+		hasLaser := len(lasers) > 0
+		sortLasersByOwner(lasers)
+		// hitLasers are the lasers that hit a player in the cell.
+		hitLasers := make(map[*Laser]bool)
+		// Handle entities in the order of the game's collision priority,
+		// skipping any that were removed or replaced while handling other
+		// entities or cells.
+		for _, entity := range entities {
+			if !game.isLive(entity) {
 				continue
 			}
-			// Handle entities that collided with the laser.
-			for _, entity := range entities {
-				switch entity.(type) {
-				case *Player:
-					// If the game isn't authoritative, another system decides
-					// when players die and score is changed.
+			switch entity.(type) {
+			case *PowerUp:
+				game.collectPowerUp(entity.(*PowerUp), entities, position)
+			case *HealthPack:
+				game.collectHealthPack(entity.(*HealthPack), entities, position)
+			case *Player:
+				// Every laser in the cell hits the player, and the kill is
+				// credited by applyPendingKills. Players who were killed
+				// right away, or moved by a swap laser, stop being hit.
+				player := entity.(*Player)
+				for _, laser := range lasers {
+					// If the game isn't authoritative, another system
+					// decides when players die and score is changed, so
+					// lasers just stop at players.
 					if !game.IsAuthoritative {
+						hitLasers[laser] = true
 						continue
 					}
-					player := entity.(*Player)
-					// Don't allow players to kill themselves.
-					if player.ID() == laserOwnerID {
-						continue
+					if !game.isLive(player) || player.Position() != position {
+						break
 					}
-					// Choose the next spawn point.
-					spawnPoint := spawnPoints[game.spawnPointIndex%len(spawnPoints)]
-					game.spawnPointIndex++
-					player.Move(spawnPoint)
-					change := PlayerRespawnChange{
-						Player:     player,
-						KilledByID: laserOwnerID,
+					if game.hitPlayer(player, laser, now, hits) {
+						hitLasers[laser] = true
 					}
-					game.sendChange(change)
-					game.AddScore(laserOwnerID)
-					if game.Score[laserOwnerID] >= roundOverScore {
-						game.queueNewRound(laserOwnerID)
-					}
-				case *Laser:
-					change := RemoveEntityChange{
-						Entity: entity,
-					}
-					game.sendChange(change)
-					game.RemoveEntity(entity.ID())
 				}
+			case *Wall:
+				// Walls are damaged by authoritative games, which tell
+				// clients when walls are destroyed.
+				wall := entity.(*Wall)
+				if !hasLaser || !game.IsAuthoritative || !wall.damage() {
+					continue
+				}
+				change := RemoveEntityChange{
+					Entity: entity,
+				}
+				game.sendChange(change)
+				game.RemoveEntity(entity.ID())
 			}
 		}
-		// Remove lasers that hit walls.
-		collisionMap := game.getCollisionMap()
-		for _, wall := range game.GetMapByType()[MapTypeWall] {
-			entities, ok := collisionMap[wall]
-			if !ok {
+		// Lasers are removed once the rest of the cell is resolved, if they
+		// hit a wall, a player, or each other. Piercing lasers pass through
+		// players, and lasers pass over pickups and mines.
+		hitEachOther := len(lasers) > 1
+		for _, laser := range lasers {
+			if !game.isLive(laser) || !(hasWall || hitEachOther || (hitLasers[laser] && !laser.Piercing)) {
 				continue
 			}
-			for _, entity := range entities {
-				switch entity.(type) {
-				case *Laser:
-					change := RemoveEntityChange{
-						Entity: entity,
-					}
-					game.sendChange(change)
-					game.RemoveEntity(entity.ID())
+			game.sendChange(RemoveEntityChange{
+				Entity: laser,
+			})
+			game.RemoveEntity(laser.ID())
+		}
+	}
+	// Lasers also hit players near them, if the game has a hit radius.
+	if game.HitRadius > 0 && game.IsAuthoritative {
+		game.resolveNearMisses(now, hits)
+	}
+	game.triggerMines(now)
+	game.applyPendingKills()
+	game.checkRoundTime(now)
+	game.hits = hits
+	// Remove lasers that hit walls.
+	collisionMap := game.getCollisionMap()
+	for _, wall := range game.GetMapByType()[MapTypeWall] {
+		entities, ok := collisionMap[wall]
+		if !ok {
+			continue
+		}
+		for _, entity := range entities {
+			switch entity.(type) {
+			case *Laser:
+				change := RemoveEntityChange{
+					Entity: entity,
 				}
+				game.sendChange(change)
+				game.RemoveEntity(entity.ID())
 			}
 		}
-		game.Mu.Unlock()
-		time.Sleep(collisionCheckFrequency)
 	}
+	// Remove lasers that left the map, which maps without outer walls allow.
+	min, max := game.Bounds()
+	for _, entity := range game.Entities {
+		laser, ok := entity.(*Laser)
+		if !ok {
+			continue
+		}
+		position := laser.Position()
+		if position.X >= min.X && position.X <= max.X && position.Y >= min.Y && position.Y <= max.Y {
+			continue
+		}
+		game.sendChange(RemoveEntityChange{
+			Entity: laser,
+		})
+		game.RemoveEntity(laser.ID())
+	}
+}
+
+// hitPlayer handles a laser hitting a player, and returns false if the hit
+// was ignored. Hits are recorded in hits, and are only handled once until the
+// laser and player separate. Kills are applied by applyPendingKills. The
+// laser is passed in rather than looked up, as it may already have been
+// removed while resolving its cell.
+func (game *Game) hitPlayer(player *Player, laser *Laser, now time.Time, hits map[collisionPair]bool) bool {
+	// Don't allow players to kill themselves while their laser is in its
+	// grace period, or anyone outside of play.
+	if player.Protected(now) || !game.canFight() {
+		return false
+	}
+	// Players who just moved may be covered from behind.
+	if game.coveredFromBehind(player, laser, now) {
+		return false
+	}
+	laserOwnerID := laser.OwnerID
+	if player.ID() == laserOwnerID {
+		if !game.canHitOwner(laser, now) {
+			return false
+		}
+		// No one is credited for players killing themselves.
+		laserOwnerID = uuid.Nil
+	}
+	pair := collisionPair{laserID: laser.ID(), playerID: player.ID()}
+	hits[pair] = true
+	if game.hits[pair] {
+		return false
+	}
+	if laser.Swap {
+		game.swapPositions(player, laserOwnerID)
+		return true
+	}
+	player.LastHit = now
+	game.kill(player, laserOwnerID)
+	return true
+}
+
+// respawn moves a killed player to where the respawn mode decides, with full
+// health. Maps without safe cells leave the player where they are.
+func (game *Game) respawn(player *Player, killerID uuid.UUID) {
+	if position, ok := game.respawnPosition(player); ok {
+		game.MoveEntity(player, position)
+	}
+	player.Damage = 0
+	// Throttles from before the player died shouldn't delay their first
+	// actions after respawning.
+	game.clearLastActionTimes(player.ID())
+	change := PlayerRespawnChange{
+		Player:     player,
+		KilledByID: killerID,
+	}
+	game.sendChange(change)
+	game.protect(player, time.Now())
+}
+
+// FacingDirection returns the direction a player is facing, which is the
+// game's default direction if the player has never tried to move.
+func (game *Game) FacingDirection(player *Player) Direction {
+	if !player.HasFacing {
+		return game.DefaultDirection
+	}
+	return player.Facing
+}
+
+// isBlocked checks if a position is blocked by the map's walls, or by player
+// and wall entities.
+func (game *Game) isBlocked(position Coordinate) bool {
+	for _, wall := range game.GetMapByType()[MapTypeWall] {
+		if position == wall {
+			return true
+		}
+	}
+	for _, entity := range game.getCollisionMap()[position] {
+		switch entity.(type) {
+		case *Player, *Wall:
+			return true
+		}
+	}
+	return false
+}
+
+// EntitiesWithin returns entities whose Chebyshev distance from the center is
+// at most the radius, nearest first. The game should be read locked by the
+// caller.
+func (game *Game) EntitiesWithin(center Coordinate, radius int) []Identifier {
+	entities := []Identifier{}
+	// Laser positions change over time, so distances are only calculated once.
+	distances := make(map[uuid.UUID]int)
+	for _, entity := range game.Entities {
+		positioner, ok := entity.(Positioner)
+		if !ok {
+			continue
+		}
+		distance := center.ChebyshevDistance(positioner.Position())
+		if distance <= radius {
+			entities = append(entities, entity)
+			distances[entity.ID()] = distance
+		}
+	}
+	sort.Slice(entities, func(i, j int) bool {
+		distanceI := distances[entities[i].ID()]
+		distanceJ := distances[entities[j].ID()]
+		if distanceI != distanceJ {
+			return distanceI < distanceJ
+		}
+		return entities[i].ID().String() < entities[j].ID().String()
+	})
+	return entities
 }
 
 // getCollisionMap maps coordinates to sets of entities.
@@ -168,14 +628,63 @@ func (game *Game) getCollisionMap() map[Coordinate][]Identifier {
 	return collisionMap
 }
 
-// AddEntity adds an entity to the game.
-func (game *Game) AddEntity(entity Identifier) {
+// entityCapReached checks if the game has as many entities as it can have.
+func (game *Game) entityCapReached() bool {
+	return game.MaxEntities > 0 && len(game.Entities) >= game.MaxEntities
+}
+
+// AddEntity adds an entity to the game. New players are given the starting
+// score. An error is returned if the game already has MaxEntities entities,
+// unless the entity replaces one with the same ID.
+func (game *Game) AddEntity(entity Identifier) error {
+	if _, ok := game.Entities[entity.ID()]; !ok && game.entityCapReached() {
+		return newError(ErrorCodeEntityCapReached, nil, "the game can have at most %d entities", game.MaxEntities)
+	}
+	if player, ok := entity.(*Player); ok && game.FreezeTag && player.Team == 0 {
+		player.Team = game.smallestTeam()
+	}
+	if player, ok := entity.(*Player); ok && player.Loadout == nil {
+		player.Loadout = append([]WeaponKind{}, game.Loadout...)
+	}
+	delete(game.tombstones, entity.ID())
+	previous := game.Entities[entity.ID()]
 	game.Entities[entity.ID()] = entity
+	game.observe(Mutation{
+		Kind:     MutationAdd,
+		EntityID: entity.ID(),
+		Entity:   entity,
+		Previous: previous,
+	})
+	switch entity.(type) {
+	case *Player, *Laser:
+		game.wakeCollisions()
+	}
+	if _, ok := entity.(*Player); ok {
+		if _, ok := game.Score[entity.ID()]; !ok && game.StartingScore != 0 {
+			game.SetScore(entity.ID(), game.StartingScore)
+		}
+	}
+	return nil
 }
 
-// UpdateEntity updates an entity.
+// UpdateEntity updates an entity, unless it was removed within the removal
+// grace period.
 func (game *Game) UpdateEntity(entity Identifier) {
+	if game.isTombstoned(entity.ID(), time.Now()) {
+		return
+	}
+	previous := game.Entities[entity.ID()]
 	game.Entities[entity.ID()] = entity
+	game.observe(Mutation{
+		Kind:     MutationUpdate,
+		EntityID: entity.ID(),
+		Entity:   entity,
+		Previous: previous,
+	})
+	switch entity.(type) {
+	case *Player, *Laser:
+		game.wakeCollisions()
+	}
 }
 
 // GetEntity gets an entity from the game.
@@ -183,37 +692,117 @@ func (game *Game) GetEntity(id uuid.UUID) Identifier {
 	return game.Entities[id]
 }
 
-// RemoveEntity removes an entity from the game.
+// FindEntities returns the entities that match a predicate, in no particular
+// order. Like GetScore, it locks the game itself, so the predicate must not
+// lock the game.
+func (game *Game) FindEntities(predicate func(Identifier) bool) []Identifier {
+	game.Mu.RLock()
+	defer game.Mu.RUnlock()
+	found := []Identifier{}
+	for _, entity := range game.Entities {
+		if predicate(entity) {
+			found = append(found, entity)
+		}
+	}
+	return found
+}
+
+// RemoveEntity removes an entity from the game, tombstoning it for the
+// removal grace period.
 func (game *Game) RemoveEntity(id uuid.UUID) {
+	entity, found := game.Entities[id]
 	delete(game.Entities, id)
+	delete(game.moveSequences, id)
+	if found {
+		game.observe(Mutation{
+			Kind:     MutationRemove,
+			EntityID: id,
+			Entity:   entity,
+		})
+	}
+	if game.RemovalGracePeriod <= 0 {
+		return
+	}
+	now := time.Now()
+	for tombstoneID, removedAt := range game.tombstones {
+		if now.Sub(removedAt) >= game.RemovalGracePeriod {
+			delete(game.tombstones, tombstoneID)
+		}
+	}
+	game.tombstones[id] = now
+}
+
+// isTombstoned checks if an entity was removed within the grace period.
+func (game *Game) isTombstoned(id uuid.UUID, now time.Time) bool {
+	removedAt, ok := game.tombstones[id]
+	return ok && now.Sub(removedAt) < game.RemovalGracePeriod
+}
+
+// isLive checks if an entity is still the one in the game with its ID, as
+// entities found earlier in a loop may have been removed or replaced since.
+func (game *Game) isLive(entity Identifier) bool {
+	return game.Entities[entity.ID()] == entity
+}
+
+// RemovePlayerAndOwned removes a player and the lasers they fired, returning
+// the removed entities.
+func (game *Game) RemovePlayerAndOwned(playerID uuid.UUID) []Identifier {
+	removed := []Identifier{}
+	for id, entity := range game.Entities {
+		laser, ok := entity.(*Laser)
+		if id == playerID || (ok && laser.OwnerID == playerID) {
+			removed = append(removed, entity)
+			game.RemoveEntity(id)
+		}
+	}
+	delete(game.rejectedFires, playerID)
+	delete(game.lastScored, playerID)
+	return removed
 }
 
 // startNewRound resets the game state in order to
 // start a new round.
 func (game *Game) startNewRound() {
 	game.WaitForRound = false
-	game.Score = map[uuid.UUID]int{}
+	game.ResetScores()
+	game.lastScored = map[uuid.UUID]time.Time{}
+	game.stats = newMatchStats(time.Now())
+	game.roundStarted = time.Now()
 	i := 0
-	spawnPoints := game.GetMapByType()[MapTypeSpawn]
+	spawnPoints := game.SafeSpawnCells()
 	for _, entity := range game.Entities {
 		player, ok := entity.(*Player)
 		if !ok {
 			continue
 		}
-		player.Move(spawnPoints[i%len(spawnPoints)])
+		if len(spawnPoints) > 0 {
+			game.MoveEntity(player, spawnPoints[i%len(spawnPoints)])
+		}
+		player.Damage = 0
+		player.Frozen = false
+		if game.StartingScore != 0 {
+			game.SetScore(player.ID(), game.StartingScore)
+		}
 		i++
 	}
+	game.wakeCollisions()
 	game.sendChange(RoundStartChange{})
 }
 
 // queueNewRound queues a new round to start.
 func (game *Game) queueNewRound(roundWinner uuid.UUID) {
 	game.WaitForRound = true
-	game.NewRoundAt = time.Now().Add(newRoundWaitTime)
+	waitTime := game.scaleDuration(game.newRoundWaitTime)
+	game.NewRoundAt = time.Now().Add(waitTime)
 	game.RoundWinner = roundWinner
+	game.RoundSummary = game.MatchSummary()
+	if game.StatsStore != nil {
+		go game.recordCareerStats(game.StatsStore, game.roundCareerStats(roundWinner))
+	}
 	game.sendChange(RoundOverChange{})
+	game.Events.Publish(RoundOverEvent{WinnerID: roundWinner})
 	go func() {
-		time.Sleep(newRoundWaitTime)
+		time.Sleep(waitTime)
 		game.Mu.Lock()
 		game.startNewRound()
 		game.Mu.Unlock()
@@ -222,16 +811,108 @@ func (game *Game) queueNewRound(roundWinner uuid.UUID) {
 
 // AddScore increments an entity's score.
 func (game *Game) AddScore(id uuid.UUID) {
-	game.Score[id]++
+	game.SetScore(id, game.Score[id]+1)
+	game.lastScored[id] = time.Now()
+}
+
+// GetScore returns an entity's score. Unlike most game methods, it locks the
+// game itself, so it can be used by callers that don't hold the lock.
+func (game *Game) GetScore(id uuid.UUID) int {
+	game.Mu.RLock()
+	defer game.Mu.RUnlock()
+	return game.Score[id]
+}
+
+// AllScores returns a copy of every entity's score. Like GetScore, it locks
+// the game itself.
+func (game *Game) AllScores() map[uuid.UUID]int {
+	game.Mu.RLock()
+	defer game.Mu.RUnlock()
+	scores := make(map[uuid.UUID]int, len(game.Score))
+	for id, score := range game.Score {
+		scores[id] = score
+	}
+	return scores
+}
+
+// isBot checks if an entity is a player controlled by a bot.
+func (game *Game) isBot(id uuid.UUID) bool {
+	player, ok := game.GetEntity(id).(*Player)
+	return ok && player.Bot
 }
 
-// checkLastActionTime checks the last time an action was performed.
-func (game *Game) checkLastActionTime(actionKey string, created time.Time, throttle time.Duration) bool {
+// RemoveScore decrements an entity's score.
+func (game *Game) RemoveScore(id uuid.UUID) {
+	game.SetScore(id, game.Score[id]-1)
+}
+
+// Kill records a player being killed by another player.
+type Kill struct {
+	// KillerID is uuid.Nil if no one is credited for the kill, such as when
+	// a player dies to a hazard.
+	KillerID uuid.UUID
+	VictimID uuid.UUID
+	// Position is where the victim was killed.
+	Position Coordinate
+	Time     time.Time
+}
+
+// AddKill records a kill for the round's stats and publishes it as an event,
+// forgetting the oldest kills once there are more than recentKillLimit.
+func (game *Game) AddKill(killerID uuid.UUID, victimID uuid.UUID, position Coordinate) {
+	kill := Kill{
+		KillerID: killerID,
+		VictimID: victimID,
+		Position: position,
+		Time:     time.Now(),
+	}
+	game.RecentKills = append(game.RecentKills, kill)
+	game.stats.recordKill(killerID, victimID)
+	if len(game.RecentKills) > recentKillLimit {
+		game.RecentKills = game.RecentKills[len(game.RecentKills)-recentKillLimit:]
+	}
+	game.Events.Publish(kill)
+}
+
+// LeaderboardEntry contains a player's score.
+type LeaderboardEntry struct {
+	PlayerID uuid.UUID
+	Name     string
+	Score    int
+}
+
+// Leaderboard returns the score of every player, sorted by score and then by
+// name.
+func (game *Game) Leaderboard() []LeaderboardEntry {
+	leaderboard := make([]LeaderboardEntry, 0)
+	for _, entity := range game.Entities {
+		player, ok := entity.(*Player)
+		if !ok {
+			continue
+		}
+		leaderboard = append(leaderboard, LeaderboardEntry{
+			PlayerID: player.ID(),
+			Name:     player.Name,
+			Score:    game.Score[player.ID()],
+		})
+	}
+	sort.Slice(leaderboard, func(i, j int) bool {
+		if leaderboard[i].Score != leaderboard[j].Score {
+			return leaderboard[i].Score > leaderboard[j].Score
+		}
+		return strings.ToLower(leaderboard[i].Name) < strings.ToLower(leaderboard[j].Name)
+	})
+	return leaderboard
+}
+
+// checkLastActionTime checks the last time an action was performed, and
+// returns an error if it was too recent.
+func (game *Game) checkLastActionTime(actionKey string, created time.Time, throttle time.Duration) error {
 	lastAction, ok := game.lastAction[actionKey]
-	if ok && lastAction.After(created.Add(-1*throttle)) {
-		return false
+	if ok && lastAction.After(created.Add(-1*game.scaleDuration(throttle))) {
+		return newError(ErrorCodeActionThrottled, nil, "the action was performed less than %v ago", game.scaleDuration(throttle))
 	}
-	return true
+	return nil
 }
 
 // updateLastActionTime sets the last action time.
@@ -240,6 +921,17 @@ func (game *Game) updateLastActionTime(actionKey string, created time.Time) {
 	game.lastAction[actionKey] = created
 }
 
+// clearLastActionTimes forgets when an entity last performed any action, so
+// that none of its actions are throttled.
+func (game *Game) clearLastActionTimes(id uuid.UUID) {
+	suffix := ":" + id.String()
+	for actionKey := range game.lastAction {
+		if strings.HasSuffix(actionKey, suffix) {
+			delete(game.lastAction, actionKey)
+		}
+	}
+}
+
 // sendChange sends a change to the change channel.
 func (game *Game) sendChange(change Change) {
 	select {
@@ -267,6 +959,52 @@ func (c1 Coordinate) Distance(c2 Coordinate) int {
 	return int(math.Sqrt(math.Pow(float64(c2.X-c1.X), 2) + math.Pow(float64(c2.Y-c1.Y), 2)))
 }
 
+// ChebyshevDistance calculates the number of moves, including diagonal ones,
+// between two coordinates.
+func (c1 Coordinate) ChebyshevDistance(c2 Coordinate) int {
+	dx := c2.X - c1.X
+	if dx < 0 {
+		dx = -dx
+	}
+	dy := c2.Y - c1.Y
+	if dy < 0 {
+		dy = -dy
+	}
+	if dx > dy {
+		return dx
+	}
+	return dy
+}
+
+// MarshalBinary encodes a coordinate compactly, as two zigzag varints, so
+// that coordinates near the origin take as little as two bytes.
+func (c1 Coordinate) MarshalBinary() ([]byte, error) {
+	data := make([]byte, binary.MaxVarintLen64*2)
+	n := binary.PutVarint(data, int64(c1.X))
+	n += binary.PutVarint(data[n:], int64(c1.Y))
+	return data[:n], nil
+}
+
+// UnmarshalBinary decodes a coordinate encoded by MarshalBinary.
+func (c1 *Coordinate) UnmarshalBinary(data []byte) error {
+	x, n := binary.Varint(data)
+	if n <= 0 {
+		return newError(ErrorCodeInvalidCoordinate, nil, "invalid coordinate X")
+	}
+	y, m := binary.Varint(data[n:])
+	if m <= 0 {
+		return newError(ErrorCodeInvalidCoordinate, nil, "invalid coordinate Y")
+	}
+	if n+m != len(data) {
+		return newError(ErrorCodeInvalidCoordinate, nil, "unexpected data after coordinate")
+	}
+	if int64(int(x)) != x || int64(int(y)) != y {
+		return newError(ErrorCodeInvalidCoordinate, nil, "coordinate out of range")
+	}
+	c1.X, c1.Y = int(x), int(y)
+	return nil
+}
+
 // Direction is used to represent Direction constants.
 type Direction int
 
@@ -279,6 +1017,28 @@ const (
 	DirectionStop
 )
 
+// moves checks if the direction moves an entity, which is false for
+// DirectionStop and for values that are not direction constants.
+func (direction Direction) moves() bool {
+	return direction >= DirectionUp && direction < DirectionStop
+}
+
+// offset returns how a coordinate changes when moving one cell in the
+// direction.
+func (direction Direction) offset() Coordinate {
+	switch direction {
+	case DirectionUp:
+		return Coordinate{Y: -1}
+	case DirectionDown:
+		return Coordinate{Y: 1}
+	case DirectionLeft:
+		return Coordinate{X: -1}
+	case DirectionRight:
+		return Coordinate{X: 1}
+	}
+	return Coordinate{}
+}
+
 // Identifier is an entity that provides an ID method.
 type Identifier interface {
 	ID() uuid.UUID
@@ -313,6 +1073,25 @@ type MoveChange struct {
 	Entity    Identifier
 	Direction Direction
 	Position  Coordinate
+	Sequence  uint32
+	// Distance is how many cells the entity moved, which is more than one
+	// when dashing.
+	Distance int
+	Dash     bool
+	// Teleport is true if the entity was moved by SetPosition or a swap
+	// laser, in which case From is where it was moved from. Teleports
+	// override clients' predicted moves.
+	Teleport bool
+	From     Coordinate
+	// MoveSequence counts the entity's moves. It is assigned before the
+	// change is sent, so receivers can tell when a change was dropped.
+	MoveSequence uint32
+}
+
+// nextMoveSequence numbers a move of an entity for its MoveChange.
+func (game *Game) nextMoveSequence(id uuid.UUID) uint32 {
+	game.moveSequences[id]++
+	return game.moveSequences[id]
 }
 
 // RoundOverChange indicates that a round is over. Information about the new
@@ -350,33 +1129,101 @@ type PlayerRespawnChange struct {
 // engine can choose to reject Actions if they are invalid or performed too
 // frequently.
 type Action interface {
-	Perform(game *Game)
+	Perform(game *Game) ActionResult
+}
+
+// ActionResult describes whether an action was performed.
+type ActionResult int
+
+// Contains action result constants.
+const (
+	ActionAccepted ActionResult = iota
+	ActionRejectedThrottled
+	ActionRejectedInvalid
+)
+
+// actionResult converts an error from trying an action to its result.
+func actionResult(err error) ActionResult {
+	switch {
+	case err == nil:
+		return ActionAccepted
+	case errors.Is(err, ErrActionThrottled):
+		return ActionRejectedThrottled
+	}
+	return ActionRejectedInvalid
+}
+
+// PerformAction performs an action right away, returning the result. Unlike
+// sending actions to the action channel, this lets callers know if the action
+// was rejected.
+func (game *Game) PerformAction(action Action) ActionResult {
+	game.Mu.Lock()
+	defer game.Mu.Unlock()
+	if game.WaitForRound {
+		return ActionRejectedInvalid
+	}
+	return action.Perform(game)
 }
 
-// MoveAction is sent when a user presses an arrow key.
+// ActionBatch performs multiple actions in order. As actions are performed
+// while the game is locked, the batch is atomic relative to collision checks.
+type ActionBatch []Action
+
+// Perform performs every action in the batch. If any action is rejected, the
+// first rejection is returned.
+func (batch ActionBatch) Perform(game *Game) ActionResult {
+	result := ActionAccepted
+	for _, action := range batch {
+		actionResult := action.Perform(game)
+		if result == ActionAccepted {
+			result = actionResult
+		}
+	}
+	return result
+}
+
+// MoveAction is sent when a user presses an arrow key. The sequence is
+// optional, and is passed on to the resulting MoveChange so that clients can
+// match predicted moves with confirmed ones.
 type MoveAction struct {
 	Direction Direction
 	ID        uuid.UUID
 	Created   time.Time
+	Sequence  uint32
 }
 
 // Perform contains backend logic required to move an entity.
-func (action MoveAction) Perform(game *Game) {
-	entity := game.GetEntity(action.ID)
+func (action MoveAction) Perform(game *Game) ActionResult {
+	return actionResult(action.Try(game))
+}
+
+// Try moves an entity like Perform, but returns an error saying why the move
+// was rejected, if it was. The game should be locked by the caller.
+func (action MoveAction) Try(game *Game) error {
+	if !game.SimulateMovement || !action.Direction.moves() {
+		return newError(ErrorCodeInvalidMove, nil, "entities can not move %v", action.Direction)
+	}
+	entity := game.actingEntity(action.ID)
 	if entity == nil {
-		return
+		return newError(ErrorCodeEntityNotFound, nil, "entity %s does not exist", action.ID)
 	}
-	mover, ok := entity.(Mover)
-	if !ok {
-		return
+	if _, ok := entity.(Mover); !ok {
+		return newError(ErrorCodeInvalidMove, nil, "entity %s can not be moved", action.ID)
 	}
 	positioner, ok := entity.(Positioner)
 	if !ok {
-		return
+		return newError(ErrorCodeInvalidMove, nil, "entity %s has no position", action.ID)
 	}
 	actionKey := fmt.Sprintf("%T:%s", action, entity.ID().String())
-	if !game.checkLastActionTime(actionKey, action.Created, moveThrottle) {
-		return
+	if player, ok := entity.(*Player); ok {
+		if player.Frozen {
+			return newError(ErrorCodeInvalidMove, nil, "player %s is frozen", action.ID)
+		}
+		player.Facing = action.Direction
+		player.HasFacing = true
+	}
+	if err := game.checkLastActionTime(actionKey, action.Created, game.entityMoveThrottle(entity)); err != nil {
+		return err
 	}
 	position := positioner.Position()
 	// Move the entity.
@@ -390,29 +1237,27 @@ func (action MoveAction) Perform(game *Game) {
 	case DirectionRight:
 		position.X++
 	}
-	// Check if position collides with a wall.
-	for _, wall := range game.GetMapByType()[MapTypeWall] {
-		if position == wall {
-			return
-		}
+	if game.isBlocked(position) {
+		return newError(ErrorCodeInvalidMove, nil, "entity %s is blocked", action.ID)
 	}
-	// Check if position collides with a player.
-	collidingEntities, ok := game.getCollisionMap()[position]
-	if ok {
-		for _, entity := range collidingEntities {
-			_, ok := entity.(*Player)
-			if ok {
-				return
-			}
+	game.MoveEntity(entity, position)
+	if player, ok := entity.(*Player); ok {
+		player.LastMoved = action.Created
+		game.recordSafePosition(player)
+		if game.FreezeTag && game.IsAuthoritative {
+			game.unfreezeTeammates(player)
 		}
 	}
-	mover.Move(position)
 	// Inform the client that the entity moved.
 	change := MoveChange{
-		Entity:    entity,
-		Direction: action.Direction,
-		Position:  position,
+		Entity:       entity,
+		Direction:    action.Direction,
+		Position:     position,
+		Sequence:     action.Sequence,
+		Distance:     1,
+		MoveSequence: game.nextMoveSequence(entity.ID()),
 	}
 	game.sendChange(change)
 	game.updateLastActionTime(actionKey, action.Created)
+	return nil
 }
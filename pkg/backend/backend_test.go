@@ -0,0 +1,821 @@
+package backend_test
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mortenson/grpc-game-example/pkg/backend"
+	"github.com/mortenson/grpc-game-example/pkg/backend/testutil"
+)
+
+// moveAt returns a move action created long enough after start that earlier
+// moves don't throttle it.
+func moveAt(game *backend.Game, name string, direction backend.Direction, start time.Time, i int) backend.MoveAction {
+	return backend.MoveAction{
+		ID:        testutil.Player(game, name).ID(),
+		Direction: direction,
+		Created:   start.Add(time.Duration(i) * time.Second),
+	}
+}
+
+func TestSubmitActions(t *testing.T) {
+	start := time.Now()
+	tests := []struct {
+		name       string
+		queueSize  int
+		directions []backend.Direction
+		accepted   int
+		want       backend.Coordinate
+	}{
+		{
+			name:       "applies in order",
+			queueSize:  3,
+			directions: []backend.Direction{backend.DirectionRight, backend.DirectionRight, backend.DirectionDown},
+			accepted:   3,
+			want:       backend.Coordinate{X: 2, Y: 1},
+		},
+		{
+			name:       "drops actions once the queue is full",
+			queueSize:  2,
+			directions: []backend.Direction{backend.DirectionLeft, backend.DirectionUp, backend.DirectionUp},
+			accepted:   2,
+			want:       backend.Coordinate{X: -1, Y: -1},
+		},
+		{
+			name:       "empty batch",
+			queueSize:  1,
+			directions: nil,
+			accepted:   0,
+			want:       backend.Coordinate{},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			config := backend.DefaultConfig()
+			config.ActionQueueSize = test.queueSize
+			game := testutil.NewGameFromConfig(t, config, testutil.WithPlayerAt("alice", 0, 0))
+			actions := []backend.Action{}
+			for i, direction := range test.directions {
+				actions = append(actions, moveAt(game, "alice", direction, start, i))
+			}
+			if accepted := game.SubmitActions(actions); accepted != test.accepted {
+				t.Fatalf("accepted %d actions, want %d", accepted, test.accepted)
+			}
+			for game.Step() {
+			}
+			if position := testutil.Player(game, "alice").Position(); position != test.want {
+				t.Errorf("player is at %+v, want %+v", position, test.want)
+			}
+			moves := 0
+			for _, change := range testutil.DrainChanges(game) {
+				move, ok := change.(backend.MoveChange)
+				if !ok {
+					continue
+				}
+				if move.Direction != test.directions[moves] {
+					t.Errorf("move %d went %v, want %v", moves, move.Direction, test.directions[moves])
+				}
+				moves++
+			}
+			if moves != test.accepted {
+				t.Errorf("got %d moves, want %d", moves, test.accepted)
+			}
+		})
+	}
+}
+
+func TestStep(t *testing.T) {
+	start := time.Now()
+	tests := []struct {
+		name              string
+		waitForRound      bool
+		resolveCollisions bool
+		// stepped is what each step returns, with alice moving right twice
+		// into a laser two cells away.
+		stepped []bool
+		want    backend.Coordinate
+		killed  bool
+		checks  int
+	}{
+		{
+			name:              "one action per step",
+			resolveCollisions: true,
+			stepped:           []bool{true},
+			want:              backend.Coordinate{X: 1},
+			killed:            false,
+			checks:            1,
+		},
+		{
+			name:              "collisions are checked after each action",
+			resolveCollisions: true,
+			stepped:           []bool{true, true},
+			want:              backend.Coordinate{X: 2},
+			killed:            true,
+			checks:            2,
+		},
+		{
+			name:              "empty queues still check collisions",
+			resolveCollisions: true,
+			stepped:           []bool{true, true, false},
+			want:              backend.Coordinate{X: 2},
+			killed:            true,
+			checks:            3,
+		},
+		{
+			name:              "collisions resolved elsewhere",
+			resolveCollisions: false,
+			stepped:           []bool{true, true, false},
+			want:              backend.Coordinate{X: 2},
+			killed:            false,
+			checks:            0,
+		},
+		{
+			name:              "actions are dropped between rounds",
+			waitForRound:      true,
+			resolveCollisions: true,
+			stepped:           []bool{true, true, false},
+			want:              backend.Coordinate{},
+			killed:            false,
+			checks:            3,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			game := testutil.NewGame(
+				testutil.WithPlayerAt("alice", 0, 0),
+				testutil.WithPlayerAt("bob", 0, 3),
+			)
+			game.WaitForRound = test.waitForRound
+			game.ResolveCollisions = test.resolveCollisions
+			checks := 0
+			game.Metrics.CollisionCheck = func() {
+				checks++
+			}
+			alice := testutil.Player(game, "alice")
+			addStillLaser(game, testutil.Player(game, "bob").ID(), backend.Coordinate{X: 2})
+			game.SubmitActions([]backend.Action{
+				moveAt(game, "alice", backend.DirectionRight, start, 0),
+				moveAt(game, "alice", backend.DirectionRight, start, 1),
+			})
+			for i, want := range test.stepped {
+				if stepped := game.Step(); stepped != want {
+					t.Fatalf("step %d returned %v, want %v", i, stepped, want)
+				}
+			}
+			killed := len(game.RecentKills) == 1
+			if killed != test.killed {
+				t.Fatalf("got kills %+v, want killed %v", game.RecentKills, test.killed)
+			}
+			if position := alice.Position(); !killed && position != test.want {
+				t.Errorf("alice is at %+v, want %+v", position, test.want)
+			}
+			if checks != test.checks {
+				t.Errorf("got %d collision checks, want %d", checks, test.checks)
+			}
+		})
+	}
+}
+
+func TestActionBatch(t *testing.T) {
+	start := time.Now()
+	tests := []struct {
+		name   string
+		batch  func(game *backend.Game) backend.ActionBatch
+		result backend.ActionResult
+		want   backend.Coordinate
+	}{
+		{
+			name: "every action is performed",
+			batch: func(game *backend.Game) backend.ActionBatch {
+				return backend.ActionBatch{
+					moveAt(game, "alice", backend.DirectionRight, start, 0),
+					moveAt(game, "alice", backend.DirectionDown, start, 1),
+				}
+			},
+			result: backend.ActionAccepted,
+			want:   backend.Coordinate{X: 1, Y: 1},
+		},
+		{
+			name: "first rejection is returned",
+			batch: func(game *backend.Game) backend.ActionBatch {
+				return backend.ActionBatch{
+					moveAt(game, "alice", backend.DirectionRight, start, 0),
+					moveAt(game, "alice", backend.DirectionStop, start, 1),
+					moveAt(game, "alice", backend.DirectionRight, start, 0),
+					moveAt(game, "alice", backend.DirectionDown, start, 2),
+				}
+			},
+			result: backend.ActionRejectedInvalid,
+			want:   backend.Coordinate{X: 1, Y: 1},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			game := testutil.NewGame(testutil.WithPlayerAt("alice", 0, 0))
+			if result := game.PerformAction(test.batch(game)); result != test.result {
+				t.Errorf("got result %v, want %v", result, test.result)
+			}
+			if position := testutil.Player(game, "alice").Position(); position != test.want {
+				t.Errorf("player is at %+v, want %+v", position, test.want)
+			}
+		})
+	}
+}
+
+func TestLeaderboard(t *testing.T) {
+	tests := []struct {
+		name   string
+		scores map[string]int
+		want   []string
+	}{
+		{name: "no players", scores: map[string]int{}, want: []string{}},
+		{
+			name:   "highest score first",
+			scores: map[string]int{"alice": 1, "bob": 3, "carol": 2},
+			want:   []string{"bob", "carol", "alice"},
+		},
+		{
+			name:   "ties are sorted by name, ignoring case",
+			scores: map[string]int{"bob": 2, "Carol": 2, "alice": 2, "dave": 5},
+			want:   []string{"dave", "alice", "bob", "Carol"},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			game := testutil.NewGame()
+			for name, score := range test.scores {
+				testutil.WithPlayerAt(name, 0, 0)(game)
+				game.SetScore(testutil.Player(game, name).ID(), score)
+			}
+			// Scores kept for anything other than players aren't shown.
+			game.Score[game.IDGenerator()] = 100
+			leaderboard := game.Leaderboard()
+			if len(leaderboard) != len(test.want) {
+				t.Fatalf("got %d entries, want %d", len(leaderboard), len(test.want))
+			}
+			for i, entry := range leaderboard {
+				if entry.Name != test.want[i] || entry.Score != test.scores[entry.Name] {
+					t.Errorf("entry %d is %s with %d, want %s with %d", i, entry.Name, entry.Score, test.want[i], test.scores[test.want[i]])
+				}
+			}
+		})
+	}
+}
+
+func TestAddKill(t *testing.T) {
+	tests := []struct {
+		name  string
+		kills int
+		want  int
+	}{
+		{name: "no kills", kills: 0, want: 0},
+		{name: "kills are kept", kills: 3, want: 3},
+		{name: "old kills are forgotten", kills: 25, want: 10},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			game := testutil.NewGame(testutil.WithSequentialIDs())
+			published := 0
+			game.Events.Subscribe(backend.Kill{}, func(event backend.Event) {
+				published++
+			})
+			victims := []uuid.UUID{}
+			for i := 0; i < test.kills; i++ {
+				victim := game.IDGenerator()
+				victims = append(victims, victim)
+				game.AddKill(uuid.Nil, victim, backend.Coordinate{X: i})
+			}
+			if len(game.RecentKills) != test.want {
+				t.Fatalf("got %d recent kills, want %d", len(game.RecentKills), test.want)
+			}
+			// The newest kills are kept, oldest first.
+			for i, kill := range game.RecentKills {
+				if want := victims[test.kills-test.want+i]; kill.VictimID != want {
+					t.Errorf("kill %d is of %s, want %s", i, kill.VictimID, want)
+				}
+			}
+			if published != test.kills {
+				t.Errorf("published %d kills, want %d", published, test.kills)
+			}
+		})
+	}
+}
+
+func TestMoveSequence(t *testing.T) {
+	game := testutil.NewGame(
+		testutil.WithPlayerAt("alice", 0, 0),
+		testutil.WithPlayerAt("bob", 0, 3),
+	)
+	start := time.Now()
+	changes := testutil.RunActions(game,
+		moveAt(game, "alice", backend.DirectionRight, start, 0),
+		moveAt(game, "bob", backend.DirectionRight, start, 0),
+		moveAt(game, "alice", backend.DirectionRight, start, 1),
+		// Throttled moves don't use up a sequence number.
+		moveAt(game, "alice", backend.DirectionRight, start, 1),
+		moveAt(game, "alice", backend.DirectionDown, start, 2),
+	)
+	got := make(map[string][]uint32)
+	for _, change := range changes {
+		if move, ok := change.(backend.MoveChange); ok {
+			name := move.Entity.(*backend.Player).Name
+			got[name] = append(got[name], move.MoveSequence)
+		}
+	}
+	want := map[string][]uint32{
+		"alice": {1, 2, 3},
+		"bob":   {1},
+	}
+	for name, sequences := range want {
+		if fmt.Sprint(got[name]) != fmt.Sprint(sequences) {
+			t.Errorf("%s moved with sequences %v, want %v", name, got[name], sequences)
+		}
+	}
+}
+
+func TestRemovePlayerAndOwned(t *testing.T) {
+	game := testutil.NewGame(
+		testutil.WithPlayerAt("alice", 0, 0),
+		testutil.WithPlayerAt("bob", 0, 3),
+	)
+	alice := testutil.Player(game, "alice")
+	bob := testutil.Player(game, "bob")
+	aliceLasers := []*backend.Laser{
+		addStillLaser(game, alice.ID(), backend.Coordinate{X: 2}),
+		addStillLaser(game, alice.ID(), backend.Coordinate{X: 4}),
+	}
+	bobLaser := addStillLaser(game, bob.ID(), backend.Coordinate{X: -2})
+
+	removed := game.RemovePlayerAndOwned(alice.ID())
+	if len(removed) != 3 {
+		t.Errorf("removed %d entities, want alice and her lasers", len(removed))
+	}
+	for _, entity := range []backend.Identifier{alice, aliceLasers[0], aliceLasers[1]} {
+		if game.GetEntity(entity.ID()) != nil {
+			t.Errorf("%T %s was not removed", entity, entity.ID())
+		}
+	}
+	for _, entity := range []backend.Identifier{bob, bobLaser} {
+		if game.GetEntity(entity.ID()) == nil {
+			t.Errorf("%T %s was removed", entity, entity.ID())
+		}
+	}
+	if removed := game.RemovePlayerAndOwned(uuid.New()); len(removed) != 0 {
+		t.Errorf("removed %d entities for an unknown player", len(removed))
+	}
+}
+
+func TestStartingScore(t *testing.T) {
+	tests := []struct {
+		name          string
+		startingScore int
+		existing      bool
+		want          int
+	}{
+		{name: "no starting score", startingScore: 0, want: 0},
+		{name: "new players start with the score", startingScore: 5, want: 5},
+		{name: "players who rejoin keep their score", startingScore: 5, existing: true, want: 2},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			game := testutil.NewGame()
+			game.StartingScore = test.startingScore
+			player := &backend.Player{
+				IdentifierBase: backend.IdentifierBase{UUID: uuid.New()},
+				Name:           "alice",
+			}
+			if test.existing {
+				game.Score[player.ID()] = 2
+			}
+			game.AddEntity(player)
+			if score := game.Score[player.ID()]; score != test.want {
+				t.Errorf("player has score %d, want %d", score, test.want)
+			}
+		})
+	}
+}
+
+func TestLoseScoreOnDeath(t *testing.T) {
+	tests := []struct {
+		name             string
+		loseScoreOnDeath bool
+		// kill kills alice, who is on a hazard, returning the ID of her
+		// killer.
+		kill       func(game *backend.Game, alice, bob *backend.Player) uuid.UUID
+		wantKiller int
+		wantVictim int
+	}{
+		{
+			name:             "kills score without losing score",
+			loseScoreOnDeath: false,
+			kill:             laserKill,
+			wantKiller:       6,
+			wantVictim:       5,
+		},
+		{
+			name:             "killed players lose a point",
+			loseScoreOnDeath: true,
+			kill:             laserKill,
+			wantKiller:       6,
+			wantVictim:       4,
+		},
+		{
+			name:             "players who kill themselves lose a point",
+			loseScoreOnDeath: true,
+			kill: func(game *backend.Game, alice, bob *backend.Player) uuid.UUID {
+				game.SelfHitGrace = 1
+				laser := addStillLaser(game, alice.ID(), alice.Position())
+				laser.StartTime = time.Now().Add(-time.Minute)
+				game.Step()
+				return uuid.Nil
+			},
+			wantVictim: 4,
+		},
+		{
+			name:             "players killed by hazards lose a point",
+			loseScoreOnDeath: true,
+			kill:             hazardKill,
+			wantVictim:       4,
+		},
+		{
+			name:             "players killed by hazards keep their score",
+			loseScoreOnDeath: false,
+			kill:             hazardKill,
+			wantVictim:       5,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			config := testutil.MapConfig(
+				"S    ",
+				"  ~  ",
+				"    S",
+			)
+			config.StartingScore = 5
+			config.LoseScoreOnDeath = test.loseScoreOnDeath
+			game := testutil.NewGameFromConfig(t, config,
+				testutil.WithPlayerAt("alice", 0, 0),
+				testutil.WithPlayerAt("bob", 2, 1),
+			)
+			alice := testutil.Player(game, "alice")
+			bob := testutil.Player(game, "bob")
+			killerID := test.kill(game, alice, bob)
+			if len(game.RecentKills) != 1 || game.RecentKills[0].VictimID != alice.ID() {
+				t.Fatalf("got kills %+v, want alice to be killed", game.RecentKills)
+			}
+			if score := game.Score[alice.ID()]; score != test.wantVictim {
+				t.Errorf("victim has score %d, want %d", score, test.wantVictim)
+			}
+			wantBob := 5
+			if killerID == bob.ID() {
+				wantBob = test.wantKiller
+			}
+			if score := game.Score[bob.ID()]; score != wantBob {
+				t.Errorf("bob has score %d, want %d", score, wantBob)
+			}
+		})
+	}
+}
+
+// laserKill has bob's laser hit alice.
+func laserKill(game *backend.Game, alice, bob *backend.Player) uuid.UUID {
+	addStillLaser(game, bob.ID(), alice.Position())
+	game.Step()
+	return bob.ID()
+}
+
+// hazardKill has alice die to the hazard she is standing on.
+func hazardKill(game *backend.Game, alice, bob *backend.Player) uuid.UUID {
+	alice.Damage = backend.MaxHealth - 1
+	game.ApplyHazardDamage()
+	return uuid.Nil
+}
+
+func TestEntitiesWithin(t *testing.T) {
+	tests := []struct {
+		name   string
+		center backend.Coordinate
+		radius int
+		want   []string
+	}{
+		{name: "only the center", center: backend.Coordinate{}, radius: 0, want: []string{"alice"}},
+		{name: "diagonals are as near as sides", center: backend.Coordinate{}, radius: 1, want: []string{"alice", "bob", "carol"}},
+		{name: "nearest first", center: backend.Coordinate{}, radius: 3, want: []string{"alice", "bob", "carol", "dave"}},
+		{name: "off center", center: backend.Coordinate{X: 3, Y: -3}, radius: 2, want: []string{"dave"}},
+		{name: "nothing in range", center: backend.Coordinate{X: -5, Y: 5}, radius: 2, want: []string{}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			game := testutil.NewGame(
+				testutil.WithSequentialIDs(),
+				testutil.WithPlayerAt("alice", 0, 0),
+				testutil.WithPlayerAt("bob", 1, 1),
+				testutil.WithPlayerAt("carol", -1, 0),
+				testutil.WithPlayerAt("dave", 3, -2),
+			)
+			names := []string{}
+			for _, entity := range game.EntitiesWithin(test.center, test.radius) {
+				if player, ok := entity.(*backend.Player); ok {
+					names = append(names, player.Name)
+				}
+			}
+			if fmt.Sprint(names) != fmt.Sprint(test.want) {
+				t.Errorf("got %v, want %v", names, test.want)
+			}
+		})
+	}
+}
+
+func TestEngineFlags(t *testing.T) {
+	tests := []struct {
+		simulateMovement  bool
+		resolveCollisions bool
+	}{
+		{simulateMovement: true, resolveCollisions: true},
+		{simulateMovement: true, resolveCollisions: false},
+		{simulateMovement: false, resolveCollisions: true},
+		{simulateMovement: false, resolveCollisions: false},
+	}
+	for _, test := range tests {
+		name := fmt.Sprintf("movement %v collisions %v", test.simulateMovement, test.resolveCollisions)
+		t.Run(name, func(t *testing.T) {
+			game := testutil.NewGame(
+				testutil.WithPlayerAt("alice", 0, 0),
+				testutil.WithPlayerAt("bob", -3, 0),
+				testutil.WithPlayerAt("carol", 3, 3),
+			)
+			game.SimulateMovement = test.simulateMovement
+			game.ResolveCollisions = test.resolveCollisions
+			alice := testutil.Player(game, "alice")
+			bob := testutil.Player(game, "bob")
+			carol := testutil.Player(game, "carol")
+			addStillLaser(game, carol.ID(), bob.Position())
+			game.SubmitActions([]backend.Action{moveAt(game, "alice", backend.DirectionRight, time.Now(), 0)})
+			game.Step()
+			moved := alice.Position() == backend.Coordinate{X: 1}
+			if moved != test.simulateMovement {
+				t.Errorf("moved is %v, want %v", moved, test.simulateMovement)
+			}
+			killed := len(game.RecentKills) == 1
+			if killed != test.resolveCollisions {
+				t.Errorf("killed is %v, want %v", killed, test.resolveCollisions)
+			}
+		})
+	}
+}
+
+func TestMoveActionResult(t *testing.T) {
+	start := time.Now()
+	tests := []struct {
+		name string
+		// previous is how long before the move the player last moved, if
+		// they did.
+		previous  time.Duration
+		direction backend.Direction
+		unknown   bool
+		waiting   bool
+		result    backend.ActionResult
+	}{
+		{name: "accepted", direction: backend.DirectionLeft, result: backend.ActionAccepted},
+		{name: "throttled", previous: 50 * time.Millisecond, direction: backend.DirectionLeft, result: backend.ActionRejectedThrottled},
+		{name: "after the throttle", previous: 100 * time.Millisecond, direction: backend.DirectionLeft, result: backend.ActionAccepted},
+		{name: "blocked", direction: backend.DirectionRight, result: backend.ActionRejectedInvalid},
+		{name: "not moving", direction: backend.DirectionStop, result: backend.ActionRejectedInvalid},
+		{name: "unknown player", direction: backend.DirectionLeft, unknown: true, result: backend.ActionRejectedInvalid},
+		{name: "between rounds", direction: backend.DirectionLeft, waiting: true, result: backend.ActionRejectedInvalid},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			game := testutil.NewGame(
+				testutil.WithPlayerAt("alice", 0, 0),
+				testutil.WithPlayerAt("bob", 1, 0),
+			)
+			alice := testutil.Player(game, "alice")
+			if test.previous > 0 {
+				previous := backend.MoveAction{ID: alice.ID(), Direction: backend.DirectionUp, Created: start.Add(-test.previous)}
+				if result := game.PerformAction(previous); result != backend.ActionAccepted {
+					t.Fatalf("previous move got %v", result)
+				}
+			}
+			game.WaitForRound = test.waiting
+			id := alice.ID()
+			if test.unknown {
+				id = uuid.New()
+			}
+			move := backend.MoveAction{ID: id, Direction: test.direction, Created: start}
+			if result := game.PerformAction(move); result != test.result {
+				t.Errorf("got result %v, want %v", result, test.result)
+			}
+		})
+	}
+}
+
+func TestBotKillsScore(t *testing.T) {
+	tests := []struct {
+		name          string
+		bot           bool
+		botKillsScore bool
+		want          int
+	}{
+		{name: "players score", bot: false, botKillsScore: false, want: 1},
+		{name: "bots don't score", bot: true, botKillsScore: false, want: 0},
+		{name: "bots score if allowed", bot: true, botKillsScore: true, want: 1},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			game := testutil.NewGame(
+				testutil.WithPlayerAt("alice", 0, 0),
+				testutil.WithPlayerAt("bob", 3, 3),
+			)
+			game.BotKillsScore = test.botKillsScore
+			alice := testutil.Player(game, "alice")
+			bob := testutil.Player(game, "bob")
+			bob.Bot = test.bot
+			laserKill(game, alice, bob)
+			if len(game.RecentKills) != 1 || game.RecentKills[0].KillerID != bob.ID() {
+				t.Fatalf("got kills %+v, want alice killed by bob", game.RecentKills)
+			}
+			if score := game.Score[bob.ID()]; score != test.want {
+				t.Errorf("killer has score %d, want %d", score, test.want)
+			}
+		})
+	}
+}
+
+func TestIDGenerator(t *testing.T) {
+	tests := []struct {
+		name string
+		// create has the engine create an entity, returning it.
+		create func(game *backend.Game) backend.Identifier
+		want   string
+	}{
+		{
+			name: "fired lasers",
+			create: func(game *backend.Game) backend.Identifier {
+				game.PerformAction(backend.FireAction{
+					PlayerID:  testutil.Player(game, "alice").ID(),
+					Direction: backend.DirectionLeft,
+				})
+				return testutil.Entities(game, &backend.Laser{})[0]
+			},
+			want: "00000000-0000-0000-0000-000000000003",
+		},
+		{
+			name: "dropped power-ups",
+			create: func(game *backend.Game) backend.Identifier {
+				game.PowerUpDropChance = 1
+				laserKill(game, testutil.Player(game, "alice"), testutil.Player(game, "bob"))
+				return testutil.Entities(game, &backend.PowerUp{})[0]
+			},
+			// The laser that killed alice has the third ID.
+			want: "00000000-0000-0000-0000-000000000004",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			game := testutil.NewGame(
+				testutil.WithSequentialIDs(),
+				testutil.WithPlayerAt("alice", 0, 0),
+				testutil.WithPlayerAt("bob", 3, 3),
+			)
+			if id := test.create(game).ID(); id.String() != test.want {
+				t.Errorf("got ID %s, want %s", id, test.want)
+			}
+		})
+	}
+}
+
+func TestRemovalGracePeriod(t *testing.T) {
+	tests := []struct {
+		name        string
+		gracePeriod time.Duration
+		// update runs after the laser at alice's position is removed.
+		update func(game *backend.Game, laser *backend.Laser)
+		live   bool
+	}{
+		{
+			name:        "late updates are ignored",
+			gracePeriod: time.Second,
+			update: func(game *backend.Game, laser *backend.Laser) {
+				game.UpdateEntity(laser)
+			},
+			live: false,
+		},
+		{
+			name:        "without a grace period",
+			gracePeriod: 0,
+			update: func(game *backend.Game, laser *backend.Laser) {
+				game.UpdateEntity(laser)
+			},
+			live: true,
+		},
+		{
+			name:        "after the grace period",
+			gracePeriod: 10 * time.Millisecond,
+			update: func(game *backend.Game, laser *backend.Laser) {
+				time.Sleep(20 * time.Millisecond)
+				game.UpdateEntity(laser)
+			},
+			live: true,
+		},
+		{
+			name:        "adding again clears the tombstone",
+			gracePeriod: time.Second,
+			update: func(game *backend.Game, laser *backend.Laser) {
+				game.AddEntity(laser)
+				game.UpdateEntity(laser)
+			},
+			live: true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			game := testutil.NewGame(
+				testutil.WithPlayerAt("alice", 0, 0),
+				testutil.WithPlayerAt("bob", 3, 3),
+			)
+			game.RemovalGracePeriod = test.gracePeriod
+			alice := testutil.Player(game, "alice")
+			laser := addStillLaser(game, testutil.Player(game, "bob").ID(), alice.Position())
+			game.RemoveEntity(laser.ID())
+			test.update(game, laser)
+			if live := game.GetEntity(laser.ID()) != nil; live != test.live {
+				t.Fatalf("laser in the game is %v, want %v", live, test.live)
+			}
+			// Stale lasers must not hit anyone.
+			game.Step()
+			if killed := len(game.RecentKills) == 1; killed != test.live {
+				t.Errorf("killed is %v, want %v", killed, test.live)
+			}
+		})
+	}
+}
+
+func TestFindEntities(t *testing.T) {
+	game := testutil.NewGame(
+		testutil.WithPlayerAt("alice", 0, 0),
+		testutil.WithPlayerAt("bob", 3, 0),
+	)
+	alice := testutil.Player(game, "alice")
+	bob := testutil.Player(game, "bob")
+	aliceLasers := []uuid.UUID{
+		addStillLaser(game, alice.ID(), backend.Coordinate{Y: 5}).ID(),
+		addStillLaser(game, alice.ID(), backend.Coordinate{Y: 6}).ID(),
+	}
+	bobLaser := addStillLaser(game, bob.ID(), backend.Coordinate{Y: 7}).ID()
+	tests := []struct {
+		name      string
+		predicate func(entity backend.Identifier) bool
+		want      []uuid.UUID
+	}{
+		{
+			name: "lasers owned by a player",
+			predicate: func(entity backend.Identifier) bool {
+				laser, ok := entity.(*backend.Laser)
+				return ok && laser.OwnerID == alice.ID()
+			},
+			want: aliceLasers,
+		},
+		{
+			name: "players",
+			predicate: func(entity backend.Identifier) bool {
+				_, ok := entity.(*backend.Player)
+				return ok
+			},
+			want: []uuid.UUID{alice.ID(), bob.ID()},
+		},
+		{
+			name:      "every entity",
+			predicate: func(entity backend.Identifier) bool { return true },
+			want:      append([]uuid.UUID{alice.ID(), bob.ID(), bobLaser}, aliceLasers...),
+		},
+		{
+			name:      "no entities",
+			predicate: func(entity backend.Identifier) bool { return false },
+			want:      []uuid.UUID{},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			found := game.FindEntities(test.predicate)
+			if found == nil {
+				t.Fatal("got nil, want an empty slice when nothing matches")
+			}
+			want := make(map[uuid.UUID]bool)
+			for _, id := range test.want {
+				want[id] = true
+			}
+			for _, entity := range found {
+				if !want[entity.ID()] {
+					t.Errorf("found %v, which doesn't match", entity)
+				}
+				delete(want, entity.ID())
+			}
+			if len(found) != len(test.want) || len(want) != 0 {
+				t.Errorf("found %d entities, want %d", len(found), len(test.want))
+			}
+		})
+	}
+}
@@ -0,0 +1,60 @@
+package backend
+
+import (
+	"sort"
+)
+
+// DefaultCollisionPriority collects power-ups and health packs before players
+// are hit, so that a player who is hit on one still gets it, even in modes
+// that handle hits right away.
+var DefaultCollisionPriority = []string{"powerUp", "healthPack", "player", "laser", "wall"}
+
+// collisionTypeName returns the name of an entity's type in collision
+// priorities.
+func collisionTypeName(entity Identifier) string {
+	switch entity.(type) {
+	case *Player:
+		return "player"
+	case *Laser:
+		return "laser"
+	case *PowerUp:
+		return "powerUp"
+	case *HealthPack:
+		return "healthPack"
+	case *Wall:
+		return "wall"
+	}
+	return ""
+}
+
+// IsCollisionTypeName checks if a name can be used in collision priorities.
+func IsCollisionTypeName(name string) bool {
+	for _, typeName := range DefaultCollisionPriority {
+		if name == typeName {
+			return true
+		}
+	}
+	return false
+}
+
+// sortByCollisionPriority returns the entities in a cell in the order they
+// should be resolved in.
+func (game *Game) sortByCollisionPriority(entities []Identifier) []Identifier {
+	ranks := make(map[string]int)
+	for i, name := range game.CollisionPriority {
+		if _, ok := ranks[name]; !ok {
+			ranks[name] = i
+		}
+	}
+	rank := func(entity Identifier) int {
+		if i, ok := ranks[collisionTypeName(entity)]; ok {
+			return i
+		}
+		return len(game.CollisionPriority)
+	}
+	sorted := append([]Identifier{}, entities...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return rank(sorted[i]) < rank(sorted[j])
+	})
+	return sorted
+}
@@ -0,0 +1,56 @@
+package backend_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/mortenson/grpc-game-example/pkg/backend"
+	"github.com/mortenson/grpc-game-example/pkg/backend/testutil"
+)
+
+func TestCollisionPriority(t *testing.T) {
+	tests := []struct {
+		name     string
+		priority []string
+		// collected is true if alice collects the power-up she is hit on.
+		collected bool
+	}{
+		{name: "default", priority: backend.DefaultCollisionPriority, collected: true},
+		{name: "power-ups first", priority: []string{"powerUp", "player"}, collected: true},
+		{name: "players first", priority: []string{"player", "laser", "powerUp"}, collected: false},
+		{name: "unlisted types last", priority: []string{"player"}, collected: false},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			config := testutil.MapConfig(
+				"S    ",
+				"     ",
+				"     ",
+			)
+			game := testutil.NewGameFromConfig(t, config,
+				testutil.WithPlayerAt("alice", 0, 0),
+				testutil.WithPlayerAt("bot", 2, 1),
+			)
+			game.CollisionPriority = test.priority
+			// Horde mode respawns humans right away, so the order decides
+			// if alice is still there to collect the power-up.
+			game.HordeMode = true
+			testutil.Player(game, "bot").Bot = true
+			alice := testutil.Player(game, "alice")
+			game.AddEntity(&backend.PowerUp{
+				IdentifierBase:  backend.IdentifierBase{UUID: game.IDGenerator()},
+				CurrentPosition: alice.Position(),
+			})
+			hit(game, "bot", "alice")
+			if alice.Position() == (backend.Coordinate{}) {
+				t.Fatal("alice was not respawned")
+			}
+			if collected := alice.RapidFireUntil.After(time.Now()); collected != test.collected {
+				t.Errorf("collected is %v, want %v", collected, test.collected)
+			}
+			if remaining := len(testutil.Entities(game, &backend.PowerUp{})) == 1; remaining == test.collected {
+				t.Errorf("remaining is %v, want %v", remaining, !test.collected)
+			}
+		})
+	}
+}
@@ -0,0 +1,490 @@
+package backend
+
+import (
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"os"
+	"time"
+	"unicode/utf8"
+)
+
+// maxConfigSize limits how many bytes of a config file are read, so that huge
+// files aren't loaded into memory before the map size limits are checked.
+const maxConfigSize = 16 * 1024 * 1024
+
+// Config contains the game's tunable parameters.
+type Config struct {
+	// Map is the game map, one string per row. Walls are "█" and spawn points
+	// are "S".
+	Map                   []string
+	RoundOverScore        int
+	NewRoundWaitTime      time.Duration
+	MoveThrottle          time.Duration
+	LaserThrottle         time.Duration
+	MaxLasersPerPlayer    int
+	StartingScore         int
+	LoseScoreOnDeath      bool
+	PowerUpDropChance     float64
+	BotKillsScore         bool
+	DefaultDirection      Direction
+	RespawnMode           RespawnMode
+	ScoreDecay            ScoreDecay
+	FreezeTag             bool
+	RemovalGracePeriod    time.Duration
+	HordeMode             bool
+	SpawnProtection       time.Duration
+	BlinkInterval         time.Duration
+	HitRadius             int
+	RandomModifier        bool
+	RegenDelay            time.Duration
+	CollisionPriority     []string
+	TeamColors            []string
+	Lobby                 bool
+	LobbyTimeout          time.Duration
+	RoundDuration         time.Duration
+	GoldenGoalGrace       time.Duration
+	ProjectileSpawnOffset int
+	DuplicateNames        DuplicateNameMode
+	SpeedMultiplier       float64
+	MaxPlayers            int
+	MaxEntities           int
+	SelfHitGrace          int
+	RearImmunity          int
+	HealthPackDropChance  float64
+	HealthPackAmount      int
+	MaxMinesPerPlayer     int
+	LaserMaxAge           time.Duration
+	// ActionQueueSize is how many actions can be waiting to be performed
+	// before SubmitActions drops them.
+	ActionQueueSize int
+	// MaxMapWidth, MaxMapHeight, and MaxMapCells limit the size of the map.
+	// Zero means no limit.
+	MaxMapWidth  int
+	MaxMapHeight int
+	MaxMapCells  int
+}
+
+// configFile is the JSON representation of Config, which uses duration
+// strings like "100ms".
+type configFile struct {
+	Map                   []string `json:"map"`
+	RoundOverScore        int      `json:"roundOverScore"`
+	NewRoundWaitTime      string   `json:"newRoundWaitTime"`
+	MoveThrottle          string   `json:"moveThrottle"`
+	LaserThrottle         string   `json:"laserThrottle"`
+	MaxLasersPerPlayer    int      `json:"maxLasersPerPlayer"`
+	StartingScore         int      `json:"startingScore"`
+	LoseScoreOnDeath      bool     `json:"loseScoreOnDeath"`
+	PowerUpDropChance     float64  `json:"powerUpDropChance"`
+	BotKillsScore         bool     `json:"botKillsScore"`
+	DefaultDirection      string   `json:"defaultDirection"`
+	RespawnMode           string   `json:"respawnMode"`
+	ScoreDecayWindow      string   `json:"scoreDecayWindow"`
+	ScoreDecayPoints      int      `json:"scoreDecayPoints"`
+	FreezeTag             bool     `json:"freezeTag"`
+	RemovalGracePeriod    string   `json:"removalGracePeriod"`
+	HordeMode             bool     `json:"hordeMode"`
+	SpawnProtection       string   `json:"spawnProtection"`
+	BlinkInterval         string   `json:"blinkInterval"`
+	HitRadius             int      `json:"hitRadius"`
+	RandomModifier        bool     `json:"randomModifier"`
+	RegenDelay            string   `json:"regenDelay"`
+	CollisionPriority     []string `json:"collisionPriority"`
+	TeamColors            []string `json:"teamColors"`
+	Lobby                 bool     `json:"lobby"`
+	LobbyTimeout          string   `json:"lobbyTimeout"`
+	RoundDuration         string   `json:"roundDuration"`
+	GoldenGoalGrace       string   `json:"goldenGoalGrace"`
+	ProjectileSpawnOffset int      `json:"projectileSpawnOffset"`
+	DuplicateNames        string   `json:"duplicateNames"`
+	SpeedMultiplier       float64  `json:"speedMultiplier"`
+	MaxPlayers            int      `json:"maxPlayers"`
+	MaxEntities           int      `json:"maxEntities"`
+	SelfHitGrace          int      `json:"selfHitGrace"`
+	RearImmunity          int      `json:"rearImmunity"`
+	HealthPackDropChance  float64  `json:"healthPackDropChance"`
+	HealthPackAmount      int      `json:"healthPackAmount"`
+	MaxMinesPerPlayer     int      `json:"maxMinesPerPlayer"`
+	LaserMaxAge           string   `json:"laserMaxAge"`
+	ActionQueueSize       int      `json:"actionQueueSize"`
+	MaxMapWidth           int      `json:"maxMapWidth"`
+	MaxMapHeight          int      `json:"maxMapHeight"`
+	MaxMapCells           int      `json:"maxMapCells"`
+}
+
+// configDirections maps direction names in config files to directions.
+var configDirections = map[string]Direction{
+	"up":    DirectionUp,
+	"down":  DirectionDown,
+	"left":  DirectionLeft,
+	"right": DirectionRight,
+}
+
+// configRespawnModes maps respawn mode names in config files to modes.
+var configRespawnModes = map[string]RespawnMode{
+	"spawnPoints": RespawnModeSpawnPoints,
+	"origin":      RespawnModeOrigin,
+	"lastSafe":    RespawnModeLastSafe,
+}
+
+// configDuplicateNameModes maps duplicate name mode names in config files to
+// modes.
+var configDuplicateNameModes = map[string]DuplicateNameMode{
+	"reject": DuplicateNameReject,
+	"rename": DuplicateNameRename,
+}
+
+// DefaultConfig returns the parameters used by NewGame. Slices are copied, so
+// that changing or decoding into the config doesn't change the defaults.
+func DefaultConfig() Config {
+	gameMap := make([]string, 0, len(MapDefault))
+	for _, row := range MapDefault {
+		gameMap = append(gameMap, string(row))
+	}
+	return Config{
+		Map:                   gameMap,
+		RoundOverScore:        roundOverScore,
+		NewRoundWaitTime:      newRoundWaitTime,
+		MoveThrottle:          moveThrottle,
+		LaserThrottle:         laserThrottle,
+		PowerUpDropChance:     defaultPowerUpDropChance,
+		DefaultDirection:      DirectionUp,
+		RemovalGracePeriod:    removalGracePeriod,
+		BlinkInterval:         spawnBlinkInterval,
+		CollisionPriority:     append([]string{}, DefaultCollisionPriority...),
+		TeamColors:            append([]string{}, DefaultTeamColors...),
+		ProjectileSpawnOffset: projectileSpawnOffset,
+		SpeedMultiplier:       1,
+		HealthPackDropChance:  defaultHealthPackDropChance,
+		HealthPackAmount:      defaultHealthPackAmount,
+		MaxMinesPerPlayer:     defaultMaxMinesPerPlayer,
+		LaserMaxAge:           defaultLaserMaxAge,
+		ActionQueueSize:       actionQueueSize,
+		MaxMapWidth:           defaultMaxMapWidth,
+		MaxMapHeight:          defaultMaxMapHeight,
+		MaxMapCells:           defaultMaxMapCells,
+	}
+}
+
+// LoadConfig reads a JSON config file. Parameters missing from the file use
+// their default values.
+func LoadConfig(path string) (Config, error) {
+	defaults := DefaultConfig()
+	file := configFile{
+		Map:                   defaults.Map,
+		RoundOverScore:        defaults.RoundOverScore,
+		NewRoundWaitTime:      defaults.NewRoundWaitTime.String(),
+		MoveThrottle:          defaults.MoveThrottle.String(),
+		LaserThrottle:         defaults.LaserThrottle.String(),
+		MaxLasersPerPlayer:    defaults.MaxLasersPerPlayer,
+		PowerUpDropChance:     defaults.PowerUpDropChance,
+		DefaultDirection:      "up",
+		RespawnMode:           "spawnPoints",
+		DuplicateNames:        "reject",
+		ScoreDecayWindow:      defaults.ScoreDecay.Window.String(),
+		RemovalGracePeriod:    defaults.RemovalGracePeriod.String(),
+		SpawnProtection:       defaults.SpawnProtection.String(),
+		BlinkInterval:         defaults.BlinkInterval.String(),
+		RegenDelay:            defaults.RegenDelay.String(),
+		CollisionPriority:     defaults.CollisionPriority,
+		TeamColors:            defaults.TeamColors,
+		LobbyTimeout:          defaults.LobbyTimeout.String(),
+		RoundDuration:         defaults.RoundDuration.String(),
+		GoldenGoalGrace:       defaults.GoldenGoalGrace.String(),
+		ProjectileSpawnOffset: defaults.ProjectileSpawnOffset,
+		SpeedMultiplier:       defaults.SpeedMultiplier,
+		HealthPackDropChance:  defaults.HealthPackDropChance,
+		HealthPackAmount:      defaults.HealthPackAmount,
+		MaxMinesPerPlayer:     defaults.MaxMinesPerPlayer,
+		LaserMaxAge:           defaults.LaserMaxAge.String(),
+		ActionQueueSize:       defaults.ActionQueueSize,
+		MaxMapWidth:           defaults.MaxMapWidth,
+		MaxMapHeight:          defaults.MaxMapHeight,
+		MaxMapCells:           defaults.MaxMapCells,
+	}
+	data, err := readConfigFile(path)
+	if err != nil {
+		return Config{}, err
+	}
+	if err := json.Unmarshal(data, &file); err != nil {
+		return Config{}, newError(ErrorCodeInvalidConfig, err, "invalid config file: %v", err)
+	}
+	config := Config{
+		Map:                   file.Map,
+		RoundOverScore:        file.RoundOverScore,
+		MaxLasersPerPlayer:    file.MaxLasersPerPlayer,
+		StartingScore:         file.StartingScore,
+		LoseScoreOnDeath:      file.LoseScoreOnDeath,
+		PowerUpDropChance:     file.PowerUpDropChance,
+		BotKillsScore:         file.BotKillsScore,
+		FreezeTag:             file.FreezeTag,
+		HordeMode:             file.HordeMode,
+		HitRadius:             file.HitRadius,
+		RandomModifier:        file.RandomModifier,
+		CollisionPriority:     file.CollisionPriority,
+		TeamColors:            file.TeamColors,
+		Lobby:                 file.Lobby,
+		ProjectileSpawnOffset: file.ProjectileSpawnOffset,
+		SpeedMultiplier:       file.SpeedMultiplier,
+		MaxPlayers:            file.MaxPlayers,
+		MaxEntities:           file.MaxEntities,
+		SelfHitGrace:          file.SelfHitGrace,
+		RearImmunity:          file.RearImmunity,
+		HealthPackDropChance:  file.HealthPackDropChance,
+		HealthPackAmount:      file.HealthPackAmount,
+		MaxMinesPerPlayer:     file.MaxMinesPerPlayer,
+		ActionQueueSize:       file.ActionQueueSize,
+		MaxMapWidth:           file.MaxMapWidth,
+		MaxMapHeight:          file.MaxMapHeight,
+		MaxMapCells:           file.MaxMapCells,
+	}
+	config.ScoreDecay.Points = file.ScoreDecayPoints
+	if config.NewRoundWaitTime, err = parseConfigDuration(file.NewRoundWaitTime); err != nil {
+		return Config{}, err
+	}
+	if config.MoveThrottle, err = parseConfigDuration(file.MoveThrottle); err != nil {
+		return Config{}, err
+	}
+	if config.LaserThrottle, err = parseConfigDuration(file.LaserThrottle); err != nil {
+		return Config{}, err
+	}
+	if config.ScoreDecay.Window, err = parseConfigDuration(file.ScoreDecayWindow); err != nil {
+		return Config{}, err
+	}
+	if config.RemovalGracePeriod, err = parseConfigDuration(file.RemovalGracePeriod); err != nil {
+		return Config{}, err
+	}
+	if config.SpawnProtection, err = parseConfigDuration(file.SpawnProtection); err != nil {
+		return Config{}, err
+	}
+	if config.BlinkInterval, err = parseConfigDuration(file.BlinkInterval); err != nil {
+		return Config{}, err
+	}
+	if config.RegenDelay, err = parseConfigDuration(file.RegenDelay); err != nil {
+		return Config{}, err
+	}
+	if config.LobbyTimeout, err = parseConfigDuration(file.LobbyTimeout); err != nil {
+		return Config{}, err
+	}
+	if config.RoundDuration, err = parseConfigDuration(file.RoundDuration); err != nil {
+		return Config{}, err
+	}
+	if config.GoldenGoalGrace, err = parseConfigDuration(file.GoldenGoalGrace); err != nil {
+		return Config{}, err
+	}
+	if config.LaserMaxAge, err = parseConfigDuration(file.LaserMaxAge); err != nil {
+		return Config{}, err
+	}
+	direction, ok := configDirections[file.DefaultDirection]
+	if !ok {
+		return Config{}, newError(ErrorCodeInvalidConfig, nil, "invalid direction in config file: %q", file.DefaultDirection)
+	}
+	config.DefaultDirection = direction
+	respawnMode, ok := configRespawnModes[file.RespawnMode]
+	if !ok {
+		return Config{}, newError(ErrorCodeInvalidConfig, nil, "invalid respawn mode in config file: %q", file.RespawnMode)
+	}
+	config.RespawnMode = respawnMode
+	duplicateNames, ok := configDuplicateNameModes[file.DuplicateNames]
+	if !ok {
+		return Config{}, newError(ErrorCodeInvalidConfig, nil, "invalid duplicate name mode in config file: %q", file.DuplicateNames)
+	}
+	config.DuplicateNames = duplicateNames
+	return config, config.Validate()
+}
+
+// readConfigFile reads a config file, refusing to read files larger than
+// maxConfigSize so that huge files aren't loaded into memory.
+func readConfigFile(path string) ([]byte, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, newError(ErrorCodeInvalidConfig, err, "%v", err)
+	}
+	defer file.Close()
+	data, err := ioutil.ReadAll(io.LimitReader(file, maxConfigSize+1))
+	if err != nil {
+		return nil, newError(ErrorCodeInvalidConfig, err, "%v", err)
+	}
+	if len(data) > maxConfigSize {
+		return nil, newError(ErrorCodeInvalidConfig, nil, "the config file can be at most %d bytes", maxConfigSize)
+	}
+	return data, nil
+}
+
+// parseConfigDuration parses a duration from a config file.
+func parseConfigDuration(value string) (time.Duration, error) {
+	duration, err := time.ParseDuration(value)
+	if err != nil {
+		return 0, newError(ErrorCodeInvalidConfig, err, "invalid duration in config file: %v", err)
+	}
+	return duration, nil
+}
+
+// Validate checks that the config can be used to create a game.
+func (config Config) Validate() error {
+	if len(config.Map) == 0 {
+		return newError(ErrorCodeInvalidConfig, nil, "the map is empty")
+	}
+	if config.MaxMapWidth < 0 || config.MaxMapHeight < 0 || config.MaxMapCells < 0 {
+		return newError(ErrorCodeInvalidConfig, nil, "the map size limits can not be negative")
+	}
+	if config.MaxMapHeight > 0 && len(config.Map) > config.MaxMapHeight {
+		return newError(ErrorCodeInvalidConfig, nil, "the map can be at most %d rows tall", config.MaxMapHeight)
+	}
+	// Rows that are too long in bytes are rejected before being decoded.
+	if config.MaxMapWidth > 0 {
+		for _, row := range config.Map {
+			if len(row) > config.MaxMapWidth*utf8.UTFMax {
+				return newError(ErrorCodeInvalidConfig, nil, "the map can be at most %d columns wide", config.MaxMapWidth)
+			}
+		}
+	}
+	width := utf8.RuneCountInString(config.Map[0])
+	if config.MaxMapWidth > 0 && width > config.MaxMapWidth {
+		return newError(ErrorCodeInvalidConfig, nil, "the map can be at most %d columns wide", config.MaxMapWidth)
+	}
+	if config.MaxMapCells > 0 && width*len(config.Map) > config.MaxMapCells {
+		return newError(ErrorCodeInvalidConfig, nil, "the map can have at most %d cells", config.MaxMapCells)
+	}
+	hasSpawn := false
+	for _, row := range config.Map {
+		runes := []rune(row)
+		if len(runes) != width {
+			return newError(ErrorCodeInvalidConfig, nil, "all map rows must be the same width")
+		}
+		for _, col := range runes {
+			if col == 'S' {
+				hasSpawn = true
+			}
+		}
+	}
+	if width == 0 {
+		return newError(ErrorCodeInvalidConfig, nil, "the map is empty")
+	}
+	if !hasSpawn {
+		return newError(ErrorCodeInvalidConfig, nil, "the map has no spawn points")
+	}
+	if config.RoundOverScore < 1 {
+		return newError(ErrorCodeInvalidConfig, nil, "the round over score must be at least one")
+	}
+	if config.NewRoundWaitTime < 0 || config.MoveThrottle < 0 || config.LaserThrottle < 0 || config.RemovalGracePeriod < 0 ||
+		config.SpawnProtection < 0 || config.BlinkInterval < 0 || config.RegenDelay < 0 ||
+		config.LobbyTimeout < 0 || config.RoundDuration < 0 || config.GoldenGoalGrace < 0 ||
+		config.LaserMaxAge < 0 {
+		return newError(ErrorCodeInvalidConfig, nil, "durations can not be negative")
+	}
+	if config.PowerUpDropChance < 0 || config.PowerUpDropChance > 1 {
+		return newError(ErrorCodeInvalidConfig, nil, "the power-up drop chance must be between 0 and 1")
+	}
+	if config.MaxLasersPerPlayer < 0 {
+		return newError(ErrorCodeInvalidConfig, nil, "the max lasers per player can not be negative")
+	}
+	if config.ScoreDecay.Window < 0 || config.ScoreDecay.Points < 0 {
+		return newError(ErrorCodeInvalidConfig, nil, "score decay can not be negative")
+	}
+	if config.HitRadius < 0 {
+		return newError(ErrorCodeInvalidConfig, nil, "the hit radius can not be negative")
+	}
+	if config.ProjectileSpawnOffset < 0 {
+		return newError(ErrorCodeInvalidConfig, nil, "the projectile spawn offset can not be negative")
+	}
+	if config.SpeedMultiplier <= 0 {
+		return newError(ErrorCodeInvalidConfig, nil, "the speed multiplier must be positive")
+	}
+	if config.MaxPlayers < 0 {
+		return newError(ErrorCodeInvalidConfig, nil, "the max players can not be negative")
+	}
+	if config.MaxEntities < 0 {
+		return newError(ErrorCodeInvalidConfig, nil, "the max entities can not be negative")
+	}
+	if config.SelfHitGrace < 0 {
+		return newError(ErrorCodeInvalidConfig, nil, "the self-hit grace can not be negative")
+	}
+	if config.RearImmunity < 0 {
+		return newError(ErrorCodeInvalidConfig, nil, "the rear immunity can not be negative")
+	}
+	if config.HealthPackDropChance < 0 || config.HealthPackDropChance > 1 {
+		return newError(ErrorCodeInvalidConfig, nil, "the health pack drop chance must be between 0 and 1")
+	}
+	if config.HealthPackAmount < 0 {
+		return newError(ErrorCodeInvalidConfig, nil, "the health pack amount can not be negative")
+	}
+	if config.MaxMinesPerPlayer < 0 {
+		return newError(ErrorCodeInvalidConfig, nil, "the max mines per player can not be negative")
+	}
+	if config.ActionQueueSize < 1 {
+		return newError(ErrorCodeInvalidConfig, nil, "the action queue size must be at least one")
+	}
+	for _, name := range config.CollisionPriority {
+		if !IsCollisionTypeName(name) {
+			return newError(ErrorCodeInvalidConfig, nil, "invalid type in collision priority: %q", name)
+		}
+	}
+	for _, color := range config.TeamColors {
+		if !IsPlayerColor(color) {
+			return newError(ErrorCodeInvalidConfig, nil, "invalid team color: %q", color)
+		}
+	}
+	if !config.DefaultDirection.moves() {
+		return newError(ErrorCodeInvalidConfig, nil, "the default direction must be up, down, left, or right")
+	}
+	return nil
+}
+
+// NewGameFromConfig constructs a new Game struct using the given parameters.
+func NewGameFromConfig(config Config) (*Game, error) {
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+	game := NewGame()
+	game.ActionChannel = make(chan Action, config.ActionQueueSize)
+	game.gameMap = make([][]rune, 0, len(config.Map))
+	for _, row := range config.Map {
+		game.gameMap = append(game.gameMap, []rune(row))
+	}
+	game.roundOverScore = config.RoundOverScore
+	game.newRoundWaitTime = config.NewRoundWaitTime
+	game.moveThrottle = config.MoveThrottle
+	game.laserThrottle = config.LaserThrottle
+	game.MaxLasersPerPlayer = config.MaxLasersPerPlayer
+	game.StartingScore = config.StartingScore
+	game.LoseScoreOnDeath = config.LoseScoreOnDeath
+	game.PowerUpDropChance = config.PowerUpDropChance
+	game.BotKillsScore = config.BotKillsScore
+	game.DefaultDirection = config.DefaultDirection
+	game.RespawnMode = config.RespawnMode
+	game.ScoreDecay = config.ScoreDecay
+	game.FreezeTag = config.FreezeTag
+	game.RemovalGracePeriod = config.RemovalGracePeriod
+	game.HordeMode = config.HordeMode
+	game.SpawnProtection = config.SpawnProtection
+	game.BlinkInterval = config.BlinkInterval
+	game.HitRadius = config.HitRadius
+	game.RandomModifier = config.RandomModifier
+	game.RegenDelay = config.RegenDelay
+	game.ProjectileSpawnOffset = config.ProjectileSpawnOffset
+	game.DuplicateNames = config.DuplicateNames
+	game.MaxPlayers = config.MaxPlayers
+	game.MaxEntities = config.MaxEntities
+	game.SelfHitGrace = config.SelfHitGrace
+	game.RearImmunity = config.RearImmunity
+	game.HealthPackDropChance = config.HealthPackDropChance
+	game.HealthPackAmount = config.HealthPackAmount
+	game.MaxMinesPerPlayer = config.MaxMinesPerPlayer
+	game.SpeedMultiplier = config.SpeedMultiplier
+	if config.Lobby {
+		game.Phase = PhaseLobby
+	}
+	game.LobbyTimeout = config.LobbyTimeout
+	game.RoundDuration = config.RoundDuration
+	game.GoldenGoalGrace = config.GoldenGoalGrace
+	game.LaserMaxAge = config.LaserMaxAge
+	if config.CollisionPriority != nil {
+		game.CollisionPriority = config.CollisionPriority
+	}
+	if config.TeamColors != nil {
+		game.TeamColors = config.TeamColors
+	}
+	return game, nil
+}
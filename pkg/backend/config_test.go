@@ -0,0 +1,274 @@
+package backend_test
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mortenson/grpc-game-example/pkg/backend"
+	"github.com/mortenson/grpc-game-example/pkg/backend/testutil"
+)
+
+func TestLoadConfig(t *testing.T) {
+	dir, err := ioutil.TempDir("", "config")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	tests := []struct {
+		name string
+		json string
+		// want changes the default config into the expected one.
+		want func(config *backend.Config)
+		ok   bool
+	}{
+		{
+			name: "defaults",
+			json: `{}`,
+			want: func(config *backend.Config) {},
+			ok:   true,
+		},
+		{
+			name: "settings",
+			json: `{
+				"map": ["S █", "   "],
+				"roundOverScore": 3,
+				"moveThrottle": "50ms",
+				"newRoundWaitTime": "1m",
+				"maxLasersPerPlayer": 2,
+				"defaultDirection": "left"
+			}`,
+			want: func(config *backend.Config) {
+				config.Map = []string{"S █", "   "}
+				config.RoundOverScore = 3
+				config.MoveThrottle = 50 * time.Millisecond
+				config.NewRoundWaitTime = time.Minute
+				config.MaxLasersPerPlayer = 2
+				config.DefaultDirection = backend.DirectionLeft
+			},
+			ok: true,
+		},
+		{name: "malformed JSON", json: `{"roundOverScore": `, ok: false},
+		{name: "wrong type", json: `{"roundOverScore": "3"}`, ok: false},
+		{name: "invalid duration", json: `{"moveThrottle": "fast"}`, ok: false},
+		{name: "negative duration", json: `{"laserThrottle": "-1s"}`, ok: false},
+		{name: "invalid direction", json: `{"defaultDirection": "diagonal"}`, ok: false},
+		{name: "invalid respawn mode", json: `{"respawnMode": "anywhere"}`, ok: false},
+		{name: "no round over score", json: `{"roundOverScore": 0}`, ok: false},
+		{name: "empty map", json: `{"map": []}`, ok: false},
+		{name: "no spawn points", json: `{"map": ["  ", "  "]}`, ok: false},
+		{name: "uneven rows", json: `{"map": ["S  ", "  "]}`, ok: false},
+		{name: "drop chance above one", json: `{"powerUpDropChance": 1.5}`, ok: false},
+		{name: "negative hit radius", json: `{"hitRadius": -1}`, ok: false},
+		{
+			name: "collision priority",
+			json: `{"collisionPriority": ["wall", "player"]}`,
+			want: func(config *backend.Config) {
+				config.CollisionPriority = []string{"wall", "player"}
+			},
+			ok: true,
+		},
+		{
+			name: "duplicate names",
+			json: `{"duplicateNames": "rename"}`,
+			want: func(config *backend.Config) {
+				config.DuplicateNames = backend.DuplicateNameRename
+			},
+			ok: true,
+		},
+		{name: "invalid duplicate name mode", json: `{"duplicateNames": "ignore"}`, ok: false},
+		{name: "negative projectile spawn offset", json: `{"projectileSpawnOffset": -1}`, ok: false},
+		{name: "negative speed multiplier", json: `{"speedMultiplier": -2}`, ok: false},
+		{name: "zero speed multiplier", json: `{"speedMultiplier": 0}`, ok: false},
+		{name: "negative self-hit grace", json: `{"selfHitGrace": -1}`, ok: false},
+		{name: "invalid health pack drop chance", json: `{"healthPackDropChance": 1.5}`, ok: false},
+		{name: "negative health pack amount", json: `{"healthPackAmount": -1}`, ok: false},
+		{name: "negative rear immunity", json: `{"rearImmunity": -1}`, ok: false},
+		{name: "unknown collision type", json: `{"collisionPriority": ["player", "ghost"]}`, ok: false},
+		{
+			name: "team colors",
+			json: `{"teamColors": ["green", "yellow"]}`,
+			want: func(config *backend.Config) {
+				config.TeamColors = []string{"green", "yellow"}
+			},
+			ok: true,
+		},
+		{name: "invalid team color", json: `{"teamColors": ["red", "plaid"]}`, ok: false},
+		{
+			name: "round time limit",
+			json: `{"roundDuration": "5m", "goldenGoalGrace": "3s"}`,
+			want: func(config *backend.Config) {
+				config.RoundDuration = 5 * time.Minute
+				config.GoldenGoalGrace = 3 * time.Second
+			},
+			ok: true,
+		},
+		{name: "negative round duration", json: `{"roundDuration": "-1m"}`, ok: false},
+		{name: "negative golden goal grace", json: `{"goldenGoalGrace": "-1s"}`, ok: false},
+		{
+			name: "laser max age",
+			json: `{"laserMaxAge": "30s"}`,
+			want: func(config *backend.Config) {
+				config.LaserMaxAge = 30 * time.Second
+			},
+			ok: true,
+		},
+		{name: "negative laser max age", json: `{"laserMaxAge": "-1s"}`, ok: false},
+		{
+			name: "action queue size",
+			json: `{"actionQueueSize": 8}`,
+			want: func(config *backend.Config) {
+				config.ActionQueueSize = 8
+			},
+			ok: true,
+		},
+		{name: "no action queue", json: `{"actionQueueSize": 0}`, ok: false},
+	}
+	for i, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			path := filepath.Join(dir, fmt.Sprintf("config%d.json", i))
+			if err := ioutil.WriteFile(path, []byte(test.json), 0600); err != nil {
+				t.Fatal(err)
+			}
+			config, err := backend.LoadConfig(path)
+			if (err == nil) != test.ok {
+				t.Fatalf("got error %v, want ok %v", err, test.ok)
+			}
+			if err != nil {
+				if !errors.Is(err, backend.ErrInvalidConfig) {
+					t.Errorf("got error %v, want an invalid config error", err)
+				}
+				return
+			}
+			want := backend.DefaultConfig()
+			test.want(&want)
+			if !reflect.DeepEqual(config, want) {
+				t.Errorf("got config %+v, want %+v", config, want)
+			}
+		})
+	}
+	if _, err := backend.LoadConfig(filepath.Join(dir, "missing.json")); !errors.Is(err, backend.ErrInvalidConfig) {
+		t.Errorf("got error %v for a missing file, want an invalid config error", err)
+	}
+	// Loading configs doesn't change the defaults.
+	if priority := backend.DefaultCollisionPriority; priority[0] != "powerUp" || priority[1] != "healthPack" {
+		t.Errorf("the default collision priority was changed to %v", priority)
+	}
+	if colors := backend.DefaultTeamColors; colors[0] != "red" || colors[1] != "blue" {
+		t.Errorf("the default team colors were changed to %v", colors)
+	}
+}
+
+func TestNewGameFromConfig(t *testing.T) {
+	config := testutil.MapConfig(
+		"     ",
+		"  S  ",
+		"     ",
+	)
+	config.MoveThrottle = 0
+	config.MaxLasersPerPlayer = 2
+	config.StartingScore = 5
+	config.DefaultDirection = backend.DirectionDown
+	config.ActionQueueSize = 8
+	game := testutil.NewGameFromConfig(t, config, testutil.WithPlayerAt("alice", -1, 0))
+	if width, height := game.GetMapDimensions(); width != 5 || height != 3 {
+		t.Errorf("map is %dx%d, want 5x3", width, height)
+	}
+	if game.MaxLasersPerPlayer != 2 || game.DefaultDirection != backend.DirectionDown {
+		t.Errorf("got max lasers %d and direction %v, want 2 and down", game.MaxLasersPerPlayer, game.DefaultDirection)
+	}
+	if size := cap(game.ActionChannel); size != 8 {
+		t.Errorf("action queue holds %d actions, want 8", size)
+	}
+	alice := testutil.Player(game, "alice")
+	if score := game.Score[alice.ID()]; score != 5 {
+		t.Errorf("player joined with score %d, want 5", score)
+	}
+	// Without a move throttle, moves can be made at the same time.
+	now := time.Now()
+	testutil.RunActions(game,
+		backend.MoveAction{ID: alice.ID(), Direction: backend.DirectionRight, Created: now},
+		backend.MoveAction{ID: alice.ID(), Direction: backend.DirectionRight, Created: now},
+	)
+	if position := alice.Position(); position != (backend.Coordinate{X: 1}) {
+		t.Errorf("player is at %+v, want both moves to be made", position)
+	}
+
+	config.RoundOverScore = 0
+	if _, err := backend.NewGameFromConfig(config); !errors.Is(err, backend.ErrInvalidConfig) {
+		t.Errorf("got error %v for an invalid config, want an invalid config error", err)
+	}
+}
+
+func TestMapSizeLimits(t *testing.T) {
+	// rows returns a map of the given size, with a spawn point.
+	rows := func(width, height int, cell string) []string {
+		gameMap := make([]string, height)
+		for i := range gameMap {
+			gameMap[i] = strings.Repeat(cell, width)
+		}
+		gameMap[0] = "S" + gameMap[0][len(cell):]
+		return gameMap
+	}
+	defaults := backend.DefaultConfig()
+	width, height, cells := defaults.MaxMapWidth, defaults.MaxMapHeight, defaults.MaxMapCells
+	tests := []struct {
+		name    string
+		gameMap []string
+		limits  func(config *backend.Config)
+		ok      bool
+	}{
+		{name: "within the limits", gameMap: rows(width, cells/width, " "), ok: true},
+		{name: "too wide", gameMap: rows(width+1, 1, " "), ok: false},
+		{name: "too tall", gameMap: rows(1, height+1, " "), ok: false},
+		{name: "too many cells", gameMap: rows(width, cells/width+1, " "), ok: false},
+		{name: "wide characters", gameMap: rows(width, 2, "█"), ok: true},
+		{
+			name:    "very long rows",
+			gameMap: append(rows(2, 1, " "), strings.Repeat(" ", 1000000)),
+			ok:      false,
+		},
+		{
+			name:    "custom limits",
+			gameMap: rows(11, 1, " "),
+			limits:  func(config *backend.Config) { config.MaxMapWidth = 10 },
+			ok:      false,
+		},
+		{
+			name:    "no limits",
+			gameMap: rows(width+1, cells/width+1, " "),
+			limits: func(config *backend.Config) {
+				config.MaxMapWidth, config.MaxMapHeight, config.MaxMapCells = 0, 0, 0
+			},
+			ok: true,
+		},
+		{
+			name:    "negative limits",
+			gameMap: rows(2, 2, " "),
+			limits:  func(config *backend.Config) { config.MaxMapCells = -1 },
+			ok:      false,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			config := backend.DefaultConfig()
+			config.Map = test.gameMap
+			if test.limits != nil {
+				test.limits(&config)
+			}
+			err := config.Validate()
+			if (err == nil) != test.ok {
+				t.Fatalf("got error %v, want ok %v", err, test.ok)
+			}
+			if err != nil && !errors.Is(err, backend.ErrInvalidConfig) {
+				t.Errorf("got error %v, want an invalid config error", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,133 @@
+package backend
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+)
+
+// playerNamePattern matches the names players can join with.
+var playerNamePattern = regexp.MustCompile("^[a-zA-Z0-9]+$")
+
+// DuplicateNameMode decides what happens when a player joins with a name that
+// is already taken.
+type DuplicateNameMode int
+
+// Contains duplicate name mode constants. DuplicateNameRename adds the lowest
+// number to the name that makes it unique, so a second "bob" becomes "bob2".
+const (
+	DuplicateNameReject DuplicateNameMode = iota
+	DuplicateNameRename
+)
+
+// ConnectAction is sent when a player joins the game. The player's icon is
+// the first letter of their name, and they start at a random spawn point.
+type ConnectAction struct {
+	PlayerID uuid.UUID
+	Name     string
+	// Color is the name of the player's color, which should be one of
+	// PlayerColors. If empty, the default color is used.
+	Color string
+}
+
+// Validate checks if the player can join the game. The game should be locked
+// by the caller.
+func (action ConnectAction) Validate(game *Game) error {
+	if !playerNamePattern.MatchString(action.Name) {
+		return newError(ErrorCodeInvalidAction, nil, "invalid name provided")
+	}
+	if action.Color != "" && !IsPlayerColor(action.Color) {
+		return newError(ErrorCodeInvalidAction, nil, "invalid color provided")
+	}
+	if game.GetEntity(action.PlayerID) != nil {
+		return newError(ErrorCodeInvalidAction, nil, "duplicate player ID provided")
+	}
+	if game.MaxPlayers > 0 && game.HumanCount() >= game.MaxPlayers {
+		return newError(ErrorCodeGameFull, nil, "the game is full, only %d players can join", game.MaxPlayers)
+	}
+	if game.entityCapReached() {
+		return newError(ErrorCodeEntityCapReached, nil, "the game can have at most %d entities", game.MaxEntities)
+	}
+	if game.DuplicateNames == DuplicateNameReject && game.PlayerByName(action.Name) != nil {
+		return newError(ErrorCodeInvalidAction, nil, "name is already taken")
+	}
+	return nil
+}
+
+// Perform adds the player to the game.
+func (action ConnectAction) Perform(game *Game) ActionResult {
+	player := action.AddPlayer(game)
+	if player == nil {
+		return ActionRejectedInvalid
+	}
+	game.sendChange(AddEntityChange{
+		Entity: player,
+	})
+	return ActionAccepted
+}
+
+// AddPlayer adds the player to the game without sending a change, for callers
+// that inform clients of the new player themselves, since changes are dropped
+// when the change channel is full. Nil is returned if the player can't join.
+// The game should be locked by the caller.
+func (action ConnectAction) AddPlayer(game *Game) *Player {
+	if action.Validate(game) != nil {
+		return nil
+	}
+	name := game.uniqueName(action.Name)
+	icon, _ := utf8.DecodeRuneInString(strings.ToUpper(name))
+	player := &Player{
+		IdentifierBase:  IdentifierBase{action.PlayerID},
+		Name:            name,
+		Icon:            icon,
+		Color:           action.Color,
+		CurrentPosition: game.joinPosition(),
+	}
+	if game.AddEntity(player) != nil {
+		return nil
+	}
+	return player
+}
+
+// HumanCount returns how many players in the game aren't bots. The game should
+// be locked by the caller.
+func (game *Game) HumanCount() int {
+	count := 0
+	for _, player := range game.Players() {
+		if !player.Bot {
+			count++
+		}
+	}
+	return count
+}
+
+// uniqueName returns the name with the lowest number added to it that no
+// other player has. Names are compared ignoring case, like PlayerByName. The
+// game should be locked by the caller.
+func (game *Game) uniqueName(name string) string {
+	if game.PlayerByName(name) == nil {
+		return name
+	}
+	for i := 2; ; i++ {
+		candidate := fmt.Sprintf("%s%d", name, i)
+		if game.PlayerByName(candidate) == nil {
+			return candidate
+		}
+	}
+}
+
+// joinPosition chooses a random spawn point for a new player, avoiding any
+// that are blocked.
+func (game *Game) joinPosition() Coordinate {
+	spawnPoints := game.SafeSpawnCells()
+	if len(spawnPoints) == 0 {
+		spawnPoints = game.GetMapByType()[MapTypeSpawn]
+	}
+	if len(spawnPoints) == 0 {
+		return Coordinate{}
+	}
+	return spawnPoints[game.Rand.Intn(len(spawnPoints))]
+}
@@ -0,0 +1,153 @@
+package backend_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/mortenson/grpc-game-example/pkg/backend"
+	"github.com/mortenson/grpc-game-example/pkg/backend/testutil"
+)
+
+func TestConnectAction(t *testing.T) {
+	bobID := uuid.New()
+	tests := []struct {
+		name       string
+		action     backend.ConnectAction
+		maxPlayers int
+		duplicates backend.DuplicateNameMode
+		result     backend.ActionResult
+		wantName   string
+	}{
+		{
+			name:     "adds a named player",
+			action:   backend.ConnectAction{PlayerID: bobID, Name: "bob", Color: "fuchsia"},
+			result:   backend.ActionAccepted,
+			wantName: "bob",
+		},
+		{
+			name:   "invalid names",
+			action: backend.ConnectAction{PlayerID: bobID, Name: "bob!"},
+			result: backend.ActionRejectedInvalid,
+		},
+		{
+			name:   "invalid colors",
+			action: backend.ConnectAction{PlayerID: bobID, Name: "bob", Color: "plaid"},
+			result: backend.ActionRejectedInvalid,
+		},
+		{
+			name:       "full games",
+			action:     backend.ConnectAction{PlayerID: bobID, Name: "bob"},
+			maxPlayers: 1,
+			result:     backend.ActionRejectedInvalid,
+		},
+		{
+			name:   "taken names",
+			action: backend.ConnectAction{PlayerID: bobID, Name: "Alice"},
+			result: backend.ActionRejectedInvalid,
+		},
+		{
+			name:       "renamed duplicates",
+			action:     backend.ConnectAction{PlayerID: bobID, Name: "alice"},
+			duplicates: backend.DuplicateNameRename,
+			result:     backend.ActionAccepted,
+			wantName:   "alice2",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			config := testutil.MapConfig(
+				"S   ",
+				"    ",
+				"   S",
+			)
+			game := testutil.NewGameFromConfig(t, config, testutil.WithPlayerAt("alice", 0, 0))
+			game.MaxPlayers = test.maxPlayers
+			game.DuplicateNames = test.duplicates
+			testutil.DrainChanges(game)
+			if result := game.PerformAction(test.action); result != test.result {
+				t.Fatalf("got result %v, want %v", result, test.result)
+			}
+			changes := testutil.DrainChanges(game)
+			player, ok := game.GetEntity(bobID).(*backend.Player)
+			if test.result != backend.ActionAccepted {
+				if ok || len(changes) != 0 {
+					t.Errorf("got player %+v and changes %+v, want neither", player, changes)
+				}
+				return
+			}
+			if !ok {
+				t.Fatal("the player was not added")
+			}
+			if player.Name != test.wantName || player.Color != test.action.Color {
+				t.Errorf("got %q in %q, want %q in %q", player.Name, player.Color, test.wantName, test.action.Color)
+			}
+			if position := player.Position(); position != (backend.Coordinate{X: -2, Y: -1}) && position != (backend.Coordinate{X: 1, Y: 1}) {
+				t.Errorf("player joined at %+v, want a spawn point", position)
+			}
+			if len(changes) != 1 {
+				t.Fatalf("got changes %+v, want the player to be added", changes)
+			}
+			if change, ok := changes[0].(backend.AddEntityChange); !ok || change.Entity != player {
+				t.Errorf("got change %+v, want the player to be added", changes[0])
+			}
+		})
+	}
+}
+
+func TestConnectActionDuplicateID(t *testing.T) {
+	game := testutil.NewGame(testutil.WithPlayerAt("alice", 0, 0))
+	alice := testutil.Player(game, "alice")
+	result := game.PerformAction(backend.ConnectAction{PlayerID: alice.ID(), Name: "bob"})
+	if result != backend.ActionRejectedInvalid {
+		t.Errorf("got result %v, want a duplicate ID to be rejected", result)
+	}
+	if player := testutil.Player(game, "alice"); player != alice {
+		t.Error("alice was replaced")
+	}
+}
+
+func TestMaxPlayers(t *testing.T) {
+	tests := []struct {
+		name       string
+		maxPlayers int
+		humans     int
+		bots       int
+		ok         bool
+	}{
+		{name: "no limit", maxPlayers: 0, humans: 5, ok: true},
+		{name: "under the limit", maxPlayers: 3, humans: 2, ok: true},
+		{name: "at the limit", maxPlayers: 3, humans: 3, ok: false},
+		{name: "bots don't count", maxPlayers: 3, humans: 2, bots: 4, ok: true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			game := testutil.NewGame()
+			game.MaxPlayers = test.maxPlayers
+			for i := 0; i < test.humans; i++ {
+				testutil.WithPlayerAt(fmt.Sprintf("player%d", i), i, 0)(game)
+			}
+			for i := 0; i < test.bots; i++ {
+				testutil.WithPlayerAt(fmt.Sprintf("bot%d", i), i, 1)(game)
+				testutil.Player(game, fmt.Sprintf("bot%d", i)).Bot = true
+			}
+			action := backend.ConnectAction{PlayerID: uuid.New(), Name: "alice"}
+			err := action.Validate(game)
+			if (err == nil) != test.ok {
+				t.Fatalf("got error %v, want ok %v", err, test.ok)
+			}
+			if err != nil && !errors.Is(err, backend.ErrGameFull) {
+				t.Errorf("got error %v, want the game to be full", err)
+			}
+			if test.ok {
+				return
+			}
+			// Players leaving free up their slot.
+			game.RemovePlayerAndOwned(testutil.Player(game, "player0").ID())
+			if err := action.Validate(game); err != nil {
+				t.Errorf("got error %v after a player left, want none", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,63 @@
+package backend_test
+
+import (
+	"errors"
+	"math"
+	"testing"
+
+	"github.com/mortenson/grpc-game-example/pkg/backend"
+)
+
+func TestCoordinateBinary(t *testing.T) {
+	tests := []struct {
+		name       string
+		coordinate backend.Coordinate
+		// size is the number of bytes the coordinate is encoded in.
+		size int
+	}{
+		{name: "origin", coordinate: backend.Coordinate{}, size: 2},
+		{name: "small negatives", coordinate: backend.Coordinate{X: -1, Y: -64}, size: 2},
+		{name: "small positives", coordinate: backend.Coordinate{X: 1, Y: 63}, size: 2},
+		{name: "just over a byte", coordinate: backend.Coordinate{X: -65, Y: 64}, size: 4},
+		{name: "large negatives", coordinate: backend.Coordinate{X: math.MinInt32, Y: -1000000}, size: 8},
+		{name: "large positives", coordinate: backend.Coordinate{X: math.MaxInt32, Y: 1000000}, size: 8},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			data, err := test.coordinate.MarshalBinary()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(data) != test.size {
+				t.Errorf("encoded in %d bytes, want %d", len(data), test.size)
+			}
+			var got backend.Coordinate
+			if err := got.UnmarshalBinary(data); err != nil {
+				t.Fatal(err)
+			}
+			if got != test.coordinate {
+				t.Errorf("got %+v, want %+v", got, test.coordinate)
+			}
+		})
+	}
+}
+
+func TestCoordinateUnmarshalBinaryErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		data []byte
+	}{
+		{name: "no data", data: nil},
+		{name: "no Y", data: []byte{0x02}},
+		{name: "truncated varint", data: []byte{0x02, 0x80}},
+		{name: "trailing data", data: []byte{0x02, 0x04, 0x06}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var coordinate backend.Coordinate
+			if err := coordinate.UnmarshalBinary(test.data); !errors.Is(err, backend.ErrInvalidCoordinate) {
+				t.Errorf("got error %v, want an invalid coordinate error", err)
+			}
+		})
+	}
+}
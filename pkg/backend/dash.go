@@ -0,0 +1,92 @@
+package backend
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	dashDistance = 4
+	dashThrottle = 2 * time.Second
+)
+
+// DashAction is sent when a player dashes, moving several cells at once in the
+// direction they are facing. Dashes stop before walls and other players, and
+// have a longer cooldown than moves. The sequence works like MoveAction's.
+type DashAction struct {
+	PlayerID uuid.UUID
+	Created  time.Time
+	Sequence uint32
+}
+
+// Perform moves the player as far as they can dash.
+func (action DashAction) Perform(game *Game) ActionResult {
+	return actionResult(action.Try(game))
+}
+
+// Try dashes like Perform, but returns an error saying why the dash was
+// rejected, if it was. The game should be locked by the caller.
+func (action DashAction) Try(game *Game) error {
+	if !game.SimulateMovement {
+		return newError(ErrorCodeInvalidMove, nil, "players can not dash")
+	}
+	entity := game.actingEntity(action.PlayerID)
+	if entity == nil {
+		return newError(ErrorCodeEntityNotFound, nil, "player %s does not exist", action.PlayerID)
+	}
+	player, ok := entity.(*Player)
+	if !ok || player.Frozen {
+		return newError(ErrorCodeInvalidMove, nil, "entity %s can not dash", action.PlayerID)
+	}
+	actionKey := fmt.Sprintf("%T:%s", action, player.ID().String())
+	if err := game.checkLastActionTime(actionKey, action.Created, dashThrottle); err != nil {
+		return err
+	}
+	facing := game.FacingDirection(player)
+	step := Coordinate{}
+	switch facing {
+	case DirectionUp:
+		step.Y = -1
+	case DirectionDown:
+		step.Y = 1
+	case DirectionLeft:
+		step.X = -1
+	case DirectionRight:
+		step.X = 1
+	default:
+		return newError(ErrorCodeInvalidMove, nil, "player %s is not facing a direction", action.PlayerID)
+	}
+	position := player.Position()
+	distance := 0
+	for distance < dashDistance {
+		next := position.Add(step)
+		if game.isBlocked(next) {
+			break
+		}
+		position = next
+		distance++
+	}
+	if distance == 0 {
+		return newError(ErrorCodeInvalidMove, nil, "player %s is blocked", action.PlayerID)
+	}
+	game.MoveEntity(player, position)
+	player.LastMoved = action.Created
+	game.recordSafePosition(player)
+	if game.FreezeTag && game.IsAuthoritative {
+		game.unfreezeTeammates(player)
+	}
+	change := MoveChange{
+		Entity:       player,
+		Direction:    facing,
+		Position:     position,
+		Sequence:     action.Sequence,
+		Distance:     distance,
+		Dash:         true,
+		MoveSequence: game.nextMoveSequence(player.ID()),
+	}
+	game.sendChange(change)
+	game.updateLastActionTime(actionKey, action.Created)
+	return nil
+}
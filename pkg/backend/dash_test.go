@@ -0,0 +1,129 @@
+package backend_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/mortenson/grpc-game-example/pkg/backend"
+	"github.com/mortenson/grpc-game-example/pkg/backend/testutil"
+)
+
+// dashGame returns a game with alice at the origin facing a direction, on a
+// map with a wall three cells to her right.
+func dashGame(t *testing.T, facing backend.Direction, options ...testutil.Option) *backend.Game {
+	t.Helper()
+	config := testutil.MapConfig(
+		"S          ",
+		"        █  ",
+		"           ",
+	)
+	options = append([]testutil.Option{testutil.WithPlayerAt("alice", 0, 0)}, options...)
+	game := testutil.NewGameFromConfig(t, config, options...)
+	alice := testutil.Player(game, "alice")
+	alice.Facing = facing
+	alice.HasFacing = true
+	return game
+}
+
+func TestDashAction(t *testing.T) {
+	tests := []struct {
+		name     string
+		facing   backend.Direction
+		options  []testutil.Option
+		result   backend.ActionResult
+		want     backend.Coordinate
+		distance int
+	}{
+		{
+			name:     "dashes several cells",
+			facing:   backend.DirectionLeft,
+			result:   backend.ActionAccepted,
+			want:     backend.Coordinate{X: -4},
+			distance: 4,
+		},
+		{
+			name:     "stops before walls",
+			facing:   backend.DirectionRight,
+			result:   backend.ActionAccepted,
+			want:     backend.Coordinate{X: 2},
+			distance: 2,
+		},
+		{
+			name:     "stops before players",
+			facing:   backend.DirectionLeft,
+			options:  []testutil.Option{testutil.WithPlayerAt("bob", -2, 0)},
+			result:   backend.ActionAccepted,
+			want:     backend.Coordinate{X: -1},
+			distance: 1,
+		},
+		{
+			name:    "blocked dashes are rejected",
+			facing:  backend.DirectionLeft,
+			options: []testutil.Option{testutil.WithPlayerAt("bob", -1, 0)},
+			result:  backend.ActionRejectedInvalid,
+			want:    backend.Coordinate{},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			game := dashGame(t, test.facing, test.options...)
+			alice := testutil.Player(game, "alice")
+			result := game.PerformAction(backend.DashAction{
+				PlayerID: alice.ID(),
+				Created:  time.Now(),
+			})
+			if result != test.result {
+				t.Fatalf("got result %v, want %v", result, test.result)
+			}
+			if position := alice.Position(); position != test.want {
+				t.Errorf("player is at %+v, want %+v", position, test.want)
+			}
+			moves := []backend.MoveChange{}
+			for _, change := range testutil.DrainChanges(game) {
+				if move, ok := change.(backend.MoveChange); ok {
+					moves = append(moves, move)
+				}
+			}
+			if test.result != backend.ActionAccepted {
+				if len(moves) != 0 {
+					t.Errorf("got moves %+v for a rejected dash", moves)
+				}
+				return
+			}
+			if len(moves) != 1 {
+				t.Fatalf("got %d moves, want 1", len(moves))
+			}
+			move := moves[0]
+			if !move.Dash || move.Position != test.want || move.Distance != test.distance || move.Direction != test.facing {
+				t.Errorf("got move %+v, want a dash of %d to %+v", move, test.distance, test.want)
+			}
+		})
+	}
+}
+
+func TestDashCooldown(t *testing.T) {
+	tests := []struct {
+		name   string
+		after  time.Duration
+		result backend.ActionResult
+	}{
+		{name: "dashing again too soon", after: time.Second, result: backend.ActionRejectedThrottled},
+		{name: "dashing again after the cooldown", after: 2 * time.Second, result: backend.ActionAccepted},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			game := dashGame(t, backend.DirectionLeft)
+			alice := testutil.Player(game, "alice")
+			start := time.Now()
+			result := game.PerformAction(backend.DashAction{PlayerID: alice.ID(), Created: start})
+			if result != backend.ActionAccepted {
+				t.Fatalf("first dash got %v", result)
+			}
+			alice.Facing = backend.DirectionRight
+			result = game.PerformAction(backend.DashAction{PlayerID: alice.ID(), Created: start.Add(test.after)})
+			if result != test.result {
+				t.Errorf("got result %v, want %v", result, test.result)
+			}
+		})
+	}
+}
@@ -0,0 +1,71 @@
+package backend
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const scoreDecayInterval = time.Second
+
+// ScoreDecay discourages camping by taking points from players who haven't
+// scored recently. A zero Window disables decay.
+type ScoreDecay struct {
+	// Window is how long players can go without scoring before they lose
+	// points.
+	Window time.Duration
+	// Points is how many points are lost every second once the window has
+	// passed.
+	Points int
+}
+
+// ScoreChange occurs when a player's score changes outside of a kill.
+type ScoreChange struct {
+	Change
+	PlayerID uuid.UUID
+	Score    int
+}
+
+// watchScoreDecay periodically applies score decay.
+func (game *Game) watchScoreDecay() {
+	for {
+		time.Sleep(game.scaleDuration(scoreDecayInterval))
+		game.Mu.Lock()
+		game.applyScoreDecay(time.Now())
+		game.Mu.Unlock()
+	}
+}
+
+// applyScoreDecay takes points from players who haven't scored within the
+// decay window, without going below zero. The game should be locked by the
+// caller.
+func (game *Game) applyScoreDecay(now time.Time) {
+	if game.ScoreDecay.Window <= 0 || game.ScoreDecay.Points <= 0 || game.WaitForRound {
+		return
+	}
+	for _, entity := range game.Entities {
+		if _, ok := entity.(*Player); !ok {
+			continue
+		}
+		id := entity.ID()
+		lastScored, ok := game.lastScored[id]
+		if !ok {
+			// Start the window for players who have never scored.
+			game.lastScored[id] = now
+			continue
+		}
+		score := game.Score[id]
+		if now.Sub(lastScored) < game.scaleDuration(game.ScoreDecay.Window) || score <= 0 {
+			continue
+		}
+		score -= game.ScoreDecay.Points
+		if score < 0 {
+			score = 0
+		}
+		game.SetScore(id, score)
+		game.sendChange(ScoreChange{
+			PlayerID: id,
+			Score:    score,
+		})
+	}
+}
@@ -0,0 +1,73 @@
+package backend_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/mortenson/grpc-game-example/pkg/backend"
+	"github.com/mortenson/grpc-game-example/pkg/backend/testutil"
+)
+
+func TestScoreDecay(t *testing.T) {
+	tests := []struct {
+		name   string
+		window time.Duration
+		score  int
+		// scored is how long ago the player last scored, if ever.
+		scored time.Duration
+		never  bool
+		want   int
+	}{
+		{name: "inactive players decay", window: 10 * time.Second, score: 5, scored: 20 * time.Second, want: 3},
+		{name: "active players don't decay", window: 10 * time.Second, score: 5, scored: 5 * time.Second, want: 5},
+		{name: "scores don't go below zero", window: 10 * time.Second, score: 1, scored: 20 * time.Second, want: 0},
+		{name: "zero scores don't change", window: 10 * time.Second, score: 0, scored: 20 * time.Second, want: 0},
+		{name: "players who never scored start the window", window: 10 * time.Second, score: 5, never: true, want: 5},
+		{name: "disabled", window: 0, score: 5, scored: 20 * time.Second, want: 5},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			game := testutil.NewGame(testutil.WithPlayerAt("alice", 0, 0))
+			game.ScoreDecay = backend.ScoreDecay{Window: test.window, Points: 2}
+			alice := testutil.Player(game, "alice")
+			now := time.Now()
+			game.Score[alice.ID()] = test.score
+			if !test.never {
+				game.SetLastScored(alice.ID(), now.Add(-test.scored))
+			}
+			game.ApplyScoreDecay(now)
+			if score := game.Score[alice.ID()]; score != test.want {
+				t.Errorf("player has score %d, want %d", score, test.want)
+			}
+			changes := []backend.ScoreChange{}
+			for _, change := range testutil.DrainChanges(game) {
+				if change, ok := change.(backend.ScoreChange); ok {
+					changes = append(changes, change)
+				}
+			}
+			if decayed := test.want != test.score; decayed != (len(changes) == 1) {
+				t.Fatalf("got changes %+v, want one only if the score decayed", changes)
+			}
+			if len(changes) == 1 && (changes[0].PlayerID != alice.ID() || changes[0].Score != test.want) {
+				t.Errorf("got change %+v, want alice at %d", changes[0], test.want)
+			}
+		})
+	}
+}
+
+func TestScoreDecayWindowStarts(t *testing.T) {
+	game := testutil.NewGame(testutil.WithPlayerAt("alice", 0, 0))
+	game.ScoreDecay = backend.ScoreDecay{Window: 10 * time.Second, Points: 1}
+	alice := testutil.Player(game, "alice")
+	game.Score[alice.ID()] = 5
+	now := time.Now()
+	game.ApplyScoreDecay(now)
+	game.ApplyScoreDecay(now.Add(5 * time.Second))
+	if score := game.Score[alice.ID()]; score != 5 {
+		t.Fatalf("player has score %d within the window, want 5", score)
+	}
+	game.ApplyScoreDecay(now.Add(10 * time.Second))
+	if score := game.Score[alice.ID()]; score != 4 {
+		t.Errorf("player has score %d after the window, want 4", score)
+	}
+}
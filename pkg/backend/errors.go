@@ -0,0 +1,71 @@
+package backend
+
+import (
+	"fmt"
+)
+
+// ErrorCode identifies a kind of engine error, so that callers can handle
+// errors without matching their messages.
+type ErrorCode int
+
+// Contains error code constants.
+const (
+	ErrorCodeUnknown ErrorCode = iota
+	ErrorCodeEntityNotFound
+	ErrorCodeInvalidMove
+	ErrorCodeInvalidAction
+	ErrorCodeInvalidConfig
+	ErrorCodeInvalidSnapshot
+	ErrorCodeGameFull
+	ErrorCodeInvalidCoordinate
+	ErrorCodeEntityCapReached
+	ErrorCodeActionThrottled
+)
+
+// Error is an error returned by the engine. Errors with the same code match
+// using errors.Is, so they can be compared to the Err variables below.
+type Error struct {
+	Code    ErrorCode
+	Message string
+	// Err is the error that caused this one, if any.
+	Err error
+}
+
+// Errors that engine errors can be compared to using errors.Is.
+var (
+	ErrEntityNotFound    = &Error{Code: ErrorCodeEntityNotFound, Message: "entity not found"}
+	ErrInvalidMove       = &Error{Code: ErrorCodeInvalidMove, Message: "invalid move"}
+	ErrInvalidAction     = &Error{Code: ErrorCodeInvalidAction, Message: "invalid action"}
+	ErrInvalidConfig     = &Error{Code: ErrorCodeInvalidConfig, Message: "invalid config"}
+	ErrInvalidSnapshot   = &Error{Code: ErrorCodeInvalidSnapshot, Message: "invalid snapshot"}
+	ErrGameFull          = &Error{Code: ErrorCodeGameFull, Message: "game is full"}
+	ErrInvalidCoordinate = &Error{Code: ErrorCodeInvalidCoordinate, Message: "invalid coordinate"}
+	ErrEntityCapReached  = &Error{Code: ErrorCodeEntityCapReached, Message: "entity cap reached"}
+	ErrActionThrottled   = &Error{Code: ErrorCodeActionThrottled, Message: "action throttled"}
+)
+
+// Error returns the error's message.
+func (err *Error) Error() string {
+	return err.Message
+}
+
+// Unwrap returns the error that caused this one, if any.
+func (err *Error) Unwrap() error {
+	return err.Err
+}
+
+// Is checks if the target is an engine error with the same code.
+func (err *Error) Is(target error) bool {
+	other, ok := target.(*Error)
+	return ok && other.Code == err.Code
+}
+
+// newError constructs an Error with a formatted message, which wraps cause if
+// it isn't nil.
+func newError(code ErrorCode, cause error, format string, args ...interface{}) error {
+	return &Error{
+		Code:    code,
+		Message: fmt.Sprintf(format, args...),
+		Err:     cause,
+	}
+}
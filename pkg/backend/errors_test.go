@@ -0,0 +1,164 @@
+package backend_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mortenson/grpc-game-example/pkg/backend"
+	"github.com/mortenson/grpc-game-example/pkg/backend/testutil"
+)
+
+func TestErrorIs(t *testing.T) {
+	cause := errors.New("disk full")
+	tests := []struct {
+		name   string
+		err    error
+		target error
+		is     bool
+	}{
+		{name: "same code", err: &backend.Error{Code: backend.ErrorCodeGameFull, Message: "full"}, target: backend.ErrGameFull, is: true},
+		{name: "other code", err: &backend.Error{Code: backend.ErrorCodeGameFull, Message: "full"}, target: backend.ErrInvalidMove, is: false},
+		{name: "wrapped", err: fmt.Errorf("joining: %w", &backend.Error{Code: backend.ErrorCodeGameFull}), target: backend.ErrGameFull, is: true},
+		{name: "cause", err: &backend.Error{Code: backend.ErrorCodeInvalidConfig, Err: cause}, target: cause, is: true},
+		{name: "other errors", err: cause, target: backend.ErrInvalidConfig, is: false},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if is := errors.Is(test.err, test.target); is != test.is {
+				t.Errorf("errors.Is is %v, want %v", is, test.is)
+			}
+		})
+	}
+}
+
+func TestErrorCodes(t *testing.T) {
+	tests := []struct {
+		name string
+		// operation returns an error from a game with alice in it.
+		operation func(game *backend.Game) error
+		target    error
+	}{
+		{
+			name: "invalid names",
+			operation: func(game *backend.Game) error {
+				return backend.ConnectAction{PlayerID: uuid.New(), Name: "b o b"}.Validate(game)
+			},
+			target: backend.ErrInvalidAction,
+		},
+		{
+			name: "full games",
+			operation: func(game *backend.Game) error {
+				game.MaxPlayers = 1
+				return backend.ConnectAction{PlayerID: uuid.New(), Name: "bob"}.Validate(game)
+			},
+			target: backend.ErrGameFull,
+		},
+		{
+			name: "missing entities",
+			operation: func(game *backend.Game) error {
+				return game.SetPosition(uuid.New(), backend.Coordinate{})
+			},
+			target: backend.ErrEntityNotFound,
+		},
+		{
+			name: "invalid configs",
+			operation: func(game *backend.Game) error {
+				config := backend.DefaultConfig()
+				config.RoundOverScore = 0
+				_, err := backend.NewGameFromConfig(config)
+				return err
+			},
+			target: backend.ErrInvalidConfig,
+		},
+		{
+			name: "invalid snapshots",
+			operation: func(game *backend.Game) error {
+				return game.Restore([]byte("{"))
+			},
+			target: backend.ErrInvalidSnapshot,
+		},
+		{
+			name: "invalid coordinates",
+			operation: func(game *backend.Game) error {
+				var coordinate backend.Coordinate
+				return coordinate.UnmarshalBinary([]byte{})
+			},
+			target: backend.ErrInvalidCoordinate,
+		},
+		{
+			name: "entity cap",
+			operation: func(game *backend.Game) error {
+				game.MaxEntities = 1
+				return game.AddEntity(&backend.PowerUp{IdentifierBase: backend.IdentifierBase{UUID: uuid.New()}})
+			},
+			target: backend.ErrEntityCapReached,
+		},
+		{
+			name: "entity cap for lasers",
+			operation: func(game *backend.Game) error {
+				game.MaxEntities = 1
+				alice := testutil.Player(game, "alice")
+				return backend.LaserAction{ID: uuid.New(), OwnerID: alice.ID(), Direction: backend.DirectionUp, Created: time.Now()}.Try(game)
+			},
+			target: backend.ErrEntityCapReached,
+		},
+		{
+			name: "entity cap for players",
+			operation: func(game *backend.Game) error {
+				game.MaxEntities = 1
+				return backend.ConnectAction{PlayerID: uuid.New(), Name: "bob"}.Validate(game)
+			},
+			target: backend.ErrEntityCapReached,
+		},
+		{
+			name: "invalid moves",
+			operation: func(game *backend.Game) error {
+				alice := testutil.Player(game, "alice")
+				return backend.MoveAction{ID: alice.ID(), Direction: backend.DirectionStop, Created: time.Now()}.Try(game)
+			},
+			target: backend.ErrInvalidMove,
+		},
+		{
+			name: "throttled moves",
+			operation: func(game *backend.Game) error {
+				alice := testutil.Player(game, "alice")
+				move := backend.MoveAction{ID: alice.ID(), Direction: backend.DirectionUp, Created: time.Now()}
+				if err := move.Try(game); err != nil {
+					return err
+				}
+				return move.Try(game)
+			},
+			target: backend.ErrActionThrottled,
+		},
+		{
+			name: "throttled lasers",
+			operation: func(game *backend.Game) error {
+				alice := testutil.Player(game, "alice")
+				fire := backend.LaserAction{OwnerID: alice.ID(), Direction: backend.DirectionUp, Created: time.Now()}
+				fire.ID = uuid.New()
+				if err := fire.Try(game); err != nil {
+					return err
+				}
+				fire.ID = uuid.New()
+				return fire.Try(game)
+			},
+			target: backend.ErrActionThrottled,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			game := testutil.NewGame(testutil.WithPlayerAt("alice", 0, 0))
+			err := test.operation(game)
+			var engineErr *backend.Error
+			if !errors.As(err, &engineErr) {
+				t.Fatalf("got error %v, want an engine error", err)
+			}
+			if !errors.Is(err, test.target) {
+				t.Errorf("got error %v with code %v, want %v", err, engineErr.Code, test.target)
+			}
+		})
+	}
+}
@@ -0,0 +1,61 @@
+package backend
+
+import (
+	"reflect"
+	"sync"
+
+	"github.com/google/uuid"
+)
+
+// Event is published to an EventBus. Events are matched to subscribers by
+// their type, so each kind of event should be its own struct.
+type Event interface{}
+
+// EventHandler is called when an event is published.
+type EventHandler func(event Event)
+
+// EventBus lets subsystems subscribe to specific kinds of events, without
+// consuming the change channel which is used for rendering.
+type EventBus struct {
+	mu       sync.RWMutex
+	handlers map[reflect.Type][]EventHandler
+}
+
+// NewEventBus constructs a new EventBus.
+func NewEventBus() *EventBus {
+	return &EventBus{
+		handlers: make(map[reflect.Type][]EventHandler),
+	}
+}
+
+// Subscribe calls the handler for each published event that has the same type
+// as the example, for instance bus.Subscribe(Kill{}, handler). Handlers are
+// called while the game is locked, so should be quick and must not lock the
+// game.
+func (bus *EventBus) Subscribe(example Event, handler EventHandler) {
+	eventType := reflect.TypeOf(example)
+	bus.mu.Lock()
+	defer bus.mu.Unlock()
+	bus.handlers[eventType] = append(bus.handlers[eventType], handler)
+}
+
+// Publish calls the handlers subscribed to the event's type.
+func (bus *EventBus) Publish(event Event) {
+	bus.mu.RLock()
+	handlers := bus.handlers[reflect.TypeOf(event)]
+	bus.mu.RUnlock()
+	for _, handler := range handlers {
+		handler(event)
+	}
+}
+
+// RoundOverEvent is published when a player wins a round.
+type RoundOverEvent struct {
+	WinnerID uuid.UUID
+}
+
+// PowerUpCollectedEvent is published when a player collects a power-up.
+type PowerUpCollectedEvent struct {
+	PlayerID  uuid.UUID
+	PowerUpID uuid.UUID
+}
@@ -0,0 +1,86 @@
+package backend_test
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/mortenson/grpc-game-example/pkg/backend"
+	"github.com/mortenson/grpc-game-example/pkg/backend/testutil"
+)
+
+func TestEventBus(t *testing.T) {
+	kill := backend.Kill{VictimID: uuid.New()}
+	roundOver := backend.RoundOverEvent{WinnerID: uuid.New()}
+	tests := []struct {
+		name      string
+		example   backend.Event
+		published []backend.Event
+		want      []backend.Event
+	}{
+		{
+			name:      "only events of the subscribed type",
+			example:   backend.Kill{},
+			published: []backend.Event{roundOver, kill, roundOver, kill},
+			want:      []backend.Event{kill, kill},
+		},
+		{
+			name:      "no events of the subscribed type",
+			example:   backend.PowerUpCollectedEvent{},
+			published: []backend.Event{roundOver, kill},
+			want:      []backend.Event{},
+		},
+		{
+			name:      "pointers are a different type",
+			example:   &backend.Kill{},
+			published: []backend.Event{kill, &kill},
+			want:      []backend.Event{&kill},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			bus := backend.NewEventBus()
+			received := [][]backend.Event{{}, {}}
+			// Every subscriber to a type receives its events.
+			for i := range received {
+				i := i
+				bus.Subscribe(test.example, func(event backend.Event) {
+					received[i] = append(received[i], event)
+				})
+			}
+			for _, event := range test.published {
+				bus.Publish(event)
+			}
+			for i, events := range received {
+				if len(events) != len(test.want) {
+					t.Fatalf("subscriber %d got %d events, want %d", i, len(events), len(test.want))
+				}
+				for j, event := range events {
+					if event != test.want[j] {
+						t.Errorf("subscriber %d got event %d %+v, want %+v", i, j, event, test.want[j])
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestRoundOverEvent(t *testing.T) {
+	config := backend.DefaultConfig()
+	config.RoundOverScore = 2
+	game := testutil.NewGameFromConfig(t, config,
+		testutil.WithPlayerAt("alice", 0, 0),
+		testutil.WithPlayerAt("bob", 3, 3),
+	)
+	winners := []uuid.UUID{}
+	game.Events.Subscribe(backend.RoundOverEvent{}, func(event backend.Event) {
+		winners = append(winners, event.(backend.RoundOverEvent).WinnerID)
+	})
+	killRepeatedly(game, 1)
+	if len(winners) != 0 {
+		t.Fatalf("got winners %v before the round was over", winners)
+	}
+	killRepeatedly(game, 1)
+	if bob := testutil.Player(game, "bob"); len(winners) != 1 || winners[0] != bob.ID() {
+		t.Errorf("got winners %v, want bob", winners)
+	}
+}
@@ -0,0 +1,63 @@
+package backend
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// SnapshotMigrations lets tests replace migrations from older snapshot
+// versions.
+var SnapshotMigrations = snapshotMigrations
+
+// ApplyHazardDamage lets tests damage players on hazards without waiting for
+// the hazard loop.
+func (game *Game) ApplyHazardDamage() {
+	game.applyHazardDamage()
+}
+
+// ApplyScoreDecay lets tests apply score decay at a given time.
+func (game *Game) ApplyScoreDecay(now time.Time) {
+	game.applyScoreDecay(now)
+}
+
+// SetLastScored lets tests set when a player last scored.
+func (game *Game) SetLastScored(id uuid.UUID, lastScored time.Time) {
+	game.lastScored[id] = lastScored
+}
+
+// Protect lets tests protect a player as if they just respawned.
+func (game *Game) Protect(player *Player, now time.Time) {
+	game.protect(player, now)
+}
+
+// UpdateProtection lets tests blink protected players at a given time.
+func (game *Game) UpdateProtection(now time.Time) {
+	game.updateProtection(now)
+}
+
+// ApplyRegen lets tests heal players at a given time.
+func (game *Game) ApplyRegen(now time.Time) {
+	game.applyRegen(now)
+}
+
+// CheckLobby lets tests check if the lobby has finished at a given time, as if
+// the lobby was started at another time.
+func (game *Game) CheckLobby(started time.Time, now time.Time) {
+	game.lobbyStarted = started
+	game.checkLobby(now)
+}
+
+// CollisionCheckFrequency lets tests age lasers by a number of collision
+// checks.
+const CollisionCheckFrequency = collisionCheckFrequency
+
+// CheckRoundTime lets tests check if the round is over at a given time.
+func (game *Game) CheckRoundTime(now time.Time) {
+	game.checkRoundTime(now)
+}
+
+// DespawnOldLasers lets tests despawn old lasers at a given time.
+func (game *Game) DespawnOldLasers(now time.Time) {
+	game.despawnOldLasers(now)
+}
@@ -0,0 +1,115 @@
+package backend
+
+import (
+	"bytes"
+
+	"github.com/google/uuid"
+)
+
+// freezeTagTeams is how many teams players are split into in freeze tag.
+const freezeTagTeams = 2
+
+// DefaultTeamColors are the colors of teams one and two.
+var DefaultTeamColors = []string{"red", "blue"}
+
+// StatusChange occurs when a player is frozen or unfrozen, or when their spawn
+// protection blinks or ends.
+type StatusChange struct {
+	Change
+	Player *Player
+}
+
+// smallestTeam returns the team with the fewest players, preferring lower
+// numbers when teams are the same size.
+func (game *Game) smallestTeam() int {
+	counts := make(map[int]int)
+	for _, entity := range game.Entities {
+		if player, ok := entity.(*Player); ok {
+			counts[player.Team]++
+		}
+	}
+	smallest := 1
+	for team := 2; team <= freezeTagTeams; team++ {
+		if counts[team] < counts[smallest] {
+			smallest = team
+		}
+	}
+	return smallest
+}
+
+// TeamColor returns the name of a team's color, which is empty if the team
+// has no color.
+func (game *Game) TeamColor(team int) string {
+	if team < 1 || team > len(game.TeamColors) {
+		return ""
+	}
+	return game.TeamColors[team-1]
+}
+
+// areTeammates checks if two players are on the same team.
+func areTeammates(a *Player, b *Player) bool {
+	return a.Team != 0 && a.Team == b.Team
+}
+
+// freeze freezes a player hit by an opponent, and ends the round if every
+// opponent of the shooter is frozen. Hazards have no shooter, so one of the
+// player's opponents is treated as the shooter.
+func (game *Game) freeze(player *Player, shooterID uuid.UUID) {
+	shooter, ok := game.GetEntity(shooterID).(*Player)
+	if shooterID == uuid.Nil {
+		shooter, ok = game.opponentOf(player)
+	} else if !ok || areTeammates(player, shooter) {
+		return
+	}
+	if player.Frozen {
+		return
+	}
+	player.Frozen = true
+	game.sendChange(StatusChange{
+		Player: player,
+	})
+	if !ok {
+		return
+	}
+	for _, entity := range game.Entities {
+		opponent, ok := entity.(*Player)
+		if ok && !areTeammates(opponent, shooter) && !opponent.Frozen {
+			return
+		}
+	}
+	game.queueNewRound(shooter.ID())
+}
+
+// opponentOf returns the opponent of a player with the lowest ID, if the
+// player has any.
+func (game *Game) opponentOf(player *Player) (*Player, bool) {
+	var opponent *Player
+	for _, entity := range game.Entities {
+		other, ok := entity.(*Player)
+		if !ok || other == player || areTeammates(player, other) {
+			continue
+		}
+		if opponent == nil || bytes.Compare(other.UUID[:], opponent.UUID[:]) < 0 {
+			opponent = other
+		}
+	}
+	return opponent, opponent != nil
+}
+
+// unfreezeTeammates unfreezes frozen teammates next to a player.
+func (game *Game) unfreezeTeammates(player *Player) {
+	position := player.Position()
+	for _, entity := range game.Entities {
+		teammate, ok := entity.(*Player)
+		if !ok || !teammate.Frozen || teammate == player || !areTeammates(player, teammate) {
+			continue
+		}
+		if position.ChebyshevDistance(teammate.Position()) > 1 {
+			continue
+		}
+		teammate.Frozen = false
+		game.sendChange(StatusChange{
+			Player: teammate,
+		})
+	}
+}
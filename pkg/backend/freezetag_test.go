@@ -0,0 +1,116 @@
+package backend_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/mortenson/grpc-game-example/pkg/backend"
+	"github.com/mortenson/grpc-game-example/pkg/backend/testutil"
+)
+
+// freezeTagGame returns a freeze tag game with alice and carol on team 1, and
+// bob and dave on team 2.
+func freezeTagGame() *backend.Game {
+	game := testutil.NewGame(
+		testutil.WithPlayerAt("alice", 0, 0),
+		testutil.WithPlayerAt("bob", 3, 3),
+		testutil.WithPlayerAt("carol", -2, 0),
+		testutil.WithPlayerAt("dave", 3, -3),
+	)
+	game.FreezeTag = true
+	for name, team := range map[string]int{"alice": 1, "bob": 2, "carol": 1, "dave": 2} {
+		testutil.Player(game, name).Team = team
+	}
+	return game
+}
+
+// hit has a player's laser hit another player.
+func hit(game *backend.Game, shooter, target string) {
+	addStillLaser(game, testutil.Player(game, shooter).ID(), testutil.Player(game, target).Position())
+	game.Step()
+}
+
+func TestFreezeTag(t *testing.T) {
+	tests := []struct {
+		name string
+		// play makes players hit and move.
+		play   func(game *backend.Game)
+		frozen map[string]bool
+		winner string
+	}{
+		{
+			name: "opponents freeze players",
+			play: func(game *backend.Game) {
+				hit(game, "bob", "alice")
+			},
+			frozen: map[string]bool{"alice": true},
+		},
+		{
+			name: "teammates don't freeze players",
+			play: func(game *backend.Game) {
+				hit(game, "carol", "alice")
+			},
+			frozen: map[string]bool{},
+		},
+		{
+			name: "teammates unfreeze players by moving next to them",
+			play: func(game *backend.Game) {
+				hit(game, "bob", "alice")
+				game.PerformAction(moveAt(game, "carol", backend.DirectionRight, time.Now(), 0))
+			},
+			frozen: map[string]bool{},
+		},
+		{
+			name: "opponents don't unfreeze players",
+			play: func(game *backend.Game) {
+				hit(game, "bob", "alice")
+				game.MoveEntity(testutil.Player(game, "dave"), backend.Coordinate{X: 2, Y: 0})
+				game.PerformAction(moveAt(game, "dave", backend.DirectionLeft, time.Now(), 0))
+			},
+			frozen: map[string]bool{"alice": true},
+		},
+		{
+			name: "frozen players can't move",
+			play: func(game *backend.Game) {
+				hit(game, "bob", "carol")
+				game.PerformAction(moveAt(game, "carol", backend.DirectionRight, time.Now(), 0))
+			},
+			frozen: map[string]bool{"carol": true},
+		},
+		{
+			name: "freezing every opponent wins",
+			play: func(game *backend.Game) {
+				hit(game, "bob", "alice")
+				hit(game, "dave", "carol")
+			},
+			frozen: map[string]bool{"alice": true, "carol": true},
+			winner: "dave",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			game := freezeTagGame()
+			test.play(game)
+			for _, name := range []string{"alice", "bob", "carol", "dave"} {
+				if frozen := testutil.Player(game, name).Frozen; frozen != test.frozen[name] {
+					t.Errorf("%s frozen is %v, want %v", name, frozen, test.frozen[name])
+				}
+			}
+			if len(game.RecentKills) != 0 {
+				t.Errorf("got kills %+v, want players frozen instead", game.RecentKills)
+			}
+			if test.frozen["carol"] && testutil.Player(game, "carol").Position() != (backend.Coordinate{X: -2}) {
+				t.Error("a frozen player moved")
+			}
+			if test.winner == "" {
+				if game.WaitForRound {
+					t.Error("the round ended")
+				}
+				return
+			}
+			if winner := testutil.Player(game, test.winner).ID(); !game.WaitForRound || game.RoundWinner != winner {
+				t.Errorf("round winner is %s, want %s", game.RoundWinner, test.winner)
+			}
+		})
+	}
+}
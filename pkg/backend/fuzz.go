@@ -0,0 +1,97 @@
+// +build gofuzz
+
+package backend
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// fuzzStepSize is how many bytes of fuzz input describe one action.
+const fuzzStepSize = 4
+
+// Fuzz is a go-fuzz target that performs random moves and fires, including
+// invalid directions and IDs that are not in the game. It panics if an action
+// causes a panic, or if a player ends up outside of the map or in a wall.
+//
+// Each action is read from four bytes: the action type, the player, the
+// direction, and how many 10ms ticks pass before the action is created.
+func Fuzz(data []byte) int {
+	game := NewGame()
+	players := []uuid.UUID{}
+	for i, spawnPoint := range game.GetMapByType()[MapTypeSpawn] {
+		if i == 2 {
+			break
+		}
+		player := &Player{
+			IdentifierBase:  IdentifierBase{uuid.New()},
+			Name:            fmt.Sprintf("fuzz%d", i),
+			Icon:            'F',
+			CurrentPosition: spawnPoint,
+		}
+		game.AddEntity(player)
+		players = append(players, player.ID())
+	}
+	created := time.Now()
+	accepted := false
+	for len(data) >= fuzzStepSize {
+		step := data[:fuzzStepSize]
+		data = data[fuzzStepSize:]
+		// One past the last player is an ID that is not in the game.
+		id := uuid.New()
+		if index := int(step[1]) % (len(players) + 1); index < len(players) {
+			id = players[index]
+		}
+		direction := Direction(int8(step[2]))
+		created = created.Add(time.Duration(step[3]) * 10 * time.Millisecond)
+		var action Action
+		switch step[0] % 2 {
+		case 0:
+			action = MoveAction{
+				ID:        id,
+				Direction: direction,
+				Created:   created,
+			}
+		case 1:
+			action = FireAction{
+				PlayerID:  id,
+				Direction: direction,
+			}
+		}
+		game.Mu.Lock()
+		if action.Perform(game) == ActionAccepted {
+			accepted = true
+		}
+		game.resolveCollisions()
+		game.checkFuzzInvariants()
+		game.Mu.Unlock()
+	}
+	if accepted {
+		return 1
+	}
+	return 0
+}
+
+// checkFuzzInvariants panics if any player is outside of the map or in a wall.
+func (game *Game) checkFuzzInvariants() {
+	min, max := game.Bounds()
+	walls := make(map[Coordinate]bool)
+	for _, wall := range game.GetMapByType()[MapTypeWall] {
+		walls[wall] = true
+	}
+	for _, entity := range game.Entities {
+		player, ok := entity.(*Player)
+		if !ok {
+			continue
+		}
+		position := player.Position()
+		if position.X < min.X || position.X > max.X || position.Y < min.Y || position.Y > max.Y {
+			panic(fmt.Sprintf("player %s is out of bounds at %+v", player.ID(), position))
+		}
+		if walls[position] {
+			panic(fmt.Sprintf("player %s is in a wall at %+v", player.ID(), position))
+		}
+	}
+}
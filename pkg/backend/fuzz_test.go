@@ -0,0 +1,116 @@
+package backend_test
+
+import (
+	"fmt"
+	"io/ioutil"
+	"math/rand"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mortenson/grpc-game-example/pkg/backend"
+	"github.com/mortenson/grpc-game-example/pkg/backend/testutil"
+)
+
+// performRandomActions decodes actions the same way as the go-fuzz target,
+// four bytes per action, and performs them on a game with two players. It
+// fails the test if a player ends up outside of the map or in a wall.
+func performRandomActions(t *testing.T, data []byte) {
+	t.Helper()
+	game := testutil.NewGame()
+	players := []uuid.UUID{}
+	for i, spawnPoint := range game.GetMapByType()[backend.MapTypeSpawn] {
+		if i == 2 {
+			break
+		}
+		player := &backend.Player{
+			IdentifierBase:  backend.IdentifierBase{UUID: uuid.New()},
+			Name:            fmt.Sprintf("fuzz%d", i),
+			Icon:            'F',
+			CurrentPosition: spawnPoint,
+		}
+		game.AddEntity(player)
+		players = append(players, player.ID())
+	}
+	min, max := game.Bounds()
+	walls := make(map[backend.Coordinate]bool)
+	for _, wall := range game.GetMapByType()[backend.MapTypeWall] {
+		walls[wall] = true
+	}
+	created := time.Now()
+	for i := 0; len(data) >= 4; i++ {
+		step := data[:4]
+		data = data[4:]
+		// One past the last player is an ID that is not in the game.
+		id := uuid.New()
+		if index := int(step[1]) % (len(players) + 1); index < len(players) {
+			id = players[index]
+		}
+		direction := backend.Direction(int8(step[2]))
+		created = created.Add(time.Duration(step[3]) * 10 * time.Millisecond)
+		var action backend.Action = backend.MoveAction{ID: id, Direction: direction, Created: created}
+		if step[0]%2 == 1 {
+			action = backend.FireAction{PlayerID: id, Direction: direction}
+		}
+		game.PerformAction(action)
+		game.Step()
+		for _, entity := range game.Entities {
+			player, ok := entity.(*backend.Player)
+			if !ok {
+				continue
+			}
+			position := player.Position()
+			if position.X < min.X || position.X > max.X || position.Y < min.Y || position.Y > max.Y {
+				t.Fatalf("after action %d, %s is out of bounds at %+v", i, player.Name, position)
+			}
+			if walls[position] {
+				t.Fatalf("after action %d, %s is in a wall at %+v", i, player.Name, position)
+			}
+		}
+	}
+}
+
+func TestRandomActions(t *testing.T) {
+	tests := []struct {
+		name string
+		data []byte
+	}{
+		{name: "no actions", data: nil},
+		{name: "partial action", data: []byte{0, 0, 1}},
+		{name: "unknown players", data: []byte{0, 2, 1, 10, 1, 2, 2, 10, 0, 2, 3, 10}},
+		{name: "invalid directions", data: []byte{0, 0, 0x7f, 10, 1, 1, 0x80, 10, 0, 0, 0xff, 10}},
+		{name: "no time between actions", data: []byte{0, 0, 1, 0, 0, 0, 1, 0, 1, 0, 1, 0, 1, 0, 1, 0}},
+	}
+	corpus, err := filepath.Glob(filepath.Join("testdata", "fuzz", "corpus", "*"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(corpus) == 0 {
+		t.Fatal("the seed corpus is empty")
+	}
+	for _, path := range corpus {
+		data, err := ioutil.ReadFile(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		tests = append(tests, struct {
+			name string
+			data []byte
+		}{name: "corpus " + filepath.Base(path), data: data})
+	}
+	// Long random sequences walk players into walls and the edge of the map.
+	for seed := int64(1); seed <= 5; seed++ {
+		data := make([]byte, 4*500)
+		rand.New(rand.NewSource(seed)).Read(data)
+		tests = append(tests, struct {
+			name string
+			data []byte
+		}{name: fmt.Sprintf("random seed %d", seed), data: data})
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			performRandomActions(t, test.data)
+		})
+	}
+}
@@ -0,0 +1,64 @@
+package backend
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	hazardDamage   = 10
+	hazardInterval = 500 * time.Millisecond
+)
+
+// DamageChange occurs when a player is damaged by a hazard, or heals. Amount
+// is the damage that was dealt, which is negative when the player heals.
+type DamageChange struct {
+	Change
+	Player *Player
+	Amount int
+}
+
+// watchHazards damages players standing on hazard tiles, such as lava.
+func (game *Game) watchHazards() {
+	for {
+		time.Sleep(game.scaleDuration(hazardInterval))
+		game.Mu.Lock()
+		game.applyHazardDamage()
+		game.Mu.Unlock()
+	}
+}
+
+// applyHazardDamage damages every player standing on a hazard tile. Players
+// who run out of health are killed the way the game mode decides, and no one
+// is credited for the kill. Frozen players aren't damaged. The game should be
+// locked by the caller.
+func (game *Game) applyHazardDamage() {
+	if game.WaitForRound || !game.canFight() {
+		return
+	}
+	hazards := make(map[Coordinate]bool)
+	for _, hazard := range game.GetMapByType()[MapTypeHazard] {
+		hazards[hazard] = true
+	}
+	if len(hazards) == 0 {
+		return
+	}
+	for _, entity := range game.Entities {
+		player, ok := entity.(*Player)
+		if !ok || !hazards[player.Position()] || player.Protected(time.Now()) || player.Frozen {
+			continue
+		}
+		player.Damage += game.hazardDamage
+		player.LastHit = time.Now()
+		game.sendChange(DamageChange{
+			Player: player,
+			Amount: game.hazardDamage,
+		})
+		if player.Health() > 0 {
+			continue
+		}
+		game.kill(player, uuid.Nil)
+	}
+	game.applyPendingKills()
+}
@@ -0,0 +1,163 @@
+package backend_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mortenson/grpc-game-example/pkg/backend"
+	"github.com/mortenson/grpc-game-example/pkg/backend/testutil"
+)
+
+func TestHazardDamage(t *testing.T) {
+	tests := []struct {
+		name      string
+		x, y      int
+		protected bool
+		waiting   bool
+		damaged   bool
+	}{
+		{name: "on a hazard", x: 0, y: 0, damaged: true},
+		{name: "next to a hazard", x: 1, y: 0, damaged: false},
+		{name: "protected", x: 0, y: 0, protected: true, damaged: false},
+		{name: "between rounds", x: 0, y: 0, waiting: true, damaged: false},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			config := testutil.MapConfig(
+				"S    ",
+				"  ~  ",
+				"     ",
+			)
+			game := testutil.NewGameFromConfig(t, config, testutil.WithPlayerAt("alice", test.x, test.y))
+			alice := testutil.Player(game, "alice")
+			if test.protected {
+				alice.ProtectedUntil = time.Now().Add(time.Minute)
+			}
+			game.WaitForRound = test.waiting
+			game.ApplyHazardDamage()
+			if damaged := alice.Health() < backend.MaxHealth; damaged != test.damaged {
+				t.Fatalf("damaged is %v, want %v", damaged, test.damaged)
+			}
+			changes := 0
+			for _, change := range testutil.DrainChanges(game) {
+				if change, ok := change.(backend.DamageChange); ok && change.Player == alice && change.Amount > 0 {
+					changes++
+				}
+			}
+			if damaged := changes == 1; damaged != test.damaged {
+				t.Errorf("got %d damage changes, want one only if damaged", changes)
+			}
+		})
+	}
+}
+
+func TestHazardKills(t *testing.T) {
+	config := testutil.MapConfig(
+		"S    ",
+		"  ~  ",
+		"     ",
+	)
+	game := testutil.NewGameFromConfig(t, config, testutil.WithPlayerAt("alice", 0, 0))
+	alice := testutil.Player(game, "alice")
+	health := alice.Health()
+	ticks := 0
+	for len(game.RecentKills) == 0 {
+		if ticks == backend.MaxHealth {
+			t.Fatal("the player was never killed")
+		}
+		game.ApplyHazardDamage()
+		ticks++
+		if len(game.RecentKills) == 0 && alice.Health() >= health {
+			t.Fatalf("health went from %d to %d after tick %d", health, alice.Health(), ticks)
+		}
+		health = alice.Health()
+	}
+	if ticks < 2 {
+		t.Errorf("the player was killed after %d ticks, want damage over time", ticks)
+	}
+	kill := game.RecentKills[0]
+	if kill.KillerID != uuid.Nil || kill.VictimID != alice.ID() || kill.Position != (backend.Coordinate{}) {
+		t.Errorf("got kill %+v, want alice killed by no one on the hazard", kill)
+	}
+	if alice.Position() != (backend.Coordinate{X: -2, Y: -1}) {
+		t.Errorf("player respawned at %+v, want the spawn point", alice.Position())
+	}
+	if alice.Health() != backend.MaxHealth {
+		t.Errorf("player respawned with %d health", alice.Health())
+	}
+}
+
+func TestHazardKillModes(t *testing.T) {
+	tests := []struct {
+		name  string
+		setup func(game *backend.Game)
+		// check checks the game after alice is killed by the hazard.
+		check func(t *testing.T, game *backend.Game, alice *backend.Player)
+	}{
+		{
+			name: "players respawn",
+			check: func(t *testing.T, game *backend.Game, alice *backend.Player) {
+				if len(game.RecentKills) != 1 || alice.Position() == (backend.Coordinate{}) {
+					t.Errorf("alice wasn't killed and respawned, got kills %+v", game.RecentKills)
+				}
+			},
+		},
+		{
+			name: "freeze tag freezes players",
+			setup: func(game *backend.Game) {
+				game.FreezeTag = true
+				testutil.Player(game, "alice").Team = 1
+				testutil.Player(game, "bob").Team = 2
+			},
+			check: func(t *testing.T, game *backend.Game, alice *backend.Player) {
+				if !alice.Frozen || len(game.RecentKills) != 0 {
+					t.Errorf("alice wasn't frozen instead of killed, got kills %+v", game.RecentKills)
+				}
+				// Alice's whole team is frozen, so her opponent wins.
+				if !game.WaitForRound || game.RoundWinner != testutil.Player(game, "bob").ID() {
+					t.Error("bob didn't win the round")
+				}
+			},
+		},
+		{
+			name: "horde mode removes bots",
+			setup: func(game *backend.Game) {
+				game.HordeMode = true
+				testutil.Player(game, "alice").Bot = true
+			},
+			check: func(t *testing.T, game *backend.Game, alice *backend.Player) {
+				if game.GetEntity(alice.ID()) != nil {
+					t.Error("alice wasn't removed")
+				}
+				if score := game.Score[testutil.Player(game, "bob").ID()]; score != 1 {
+					t.Errorf("bob has score %d, want 1 for the bot's death", score)
+				}
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			config := testutil.MapConfig(
+				"S    ",
+				"  ~  ",
+				"     ",
+			)
+			game := testutil.NewGameFromConfig(t, config,
+				testutil.WithPlayerAt("alice", 0, 0),
+				testutil.WithPlayerAt("bob", 1, 1),
+			)
+			if test.setup != nil {
+				test.setup(game)
+			}
+			alice := testutil.Player(game, "alice")
+			for ticks := 0; alice.Health() > 0 && ticks < backend.MaxHealth; ticks++ {
+				game.ApplyHazardDamage()
+				if alice.Health() == backend.MaxHealth || game.GetEntity(alice.ID()) == nil {
+					break
+				}
+			}
+			test.check(t, game, alice)
+		})
+	}
+}
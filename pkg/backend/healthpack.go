@@ -0,0 +1,70 @@
+package backend
+
+const (
+	defaultHealthPackDropChance = 0.1
+	defaultHealthPackAmount     = 25
+)
+
+// HealthPack is sometimes dropped where a player is killed. Players who walk
+// over one heal, up to full health.
+type HealthPack struct {
+	IdentifierBase
+	Positioner
+	CurrentPosition Coordinate
+}
+
+// Position determines the health pack position.
+func (healthPack *HealthPack) Position() Coordinate {
+	return healthPack.CurrentPosition
+}
+
+// dropHealthPack randomly drops a health pack at a position, based on the
+// game's drop chance.
+func (game *Game) dropHealthPack(position Coordinate) {
+	if game.HealthPackDropChance <= 0 || game.Rand.Float64() >= game.HealthPackDropChance {
+		return
+	}
+	healthPack := &HealthPack{
+		IdentifierBase:  IdentifierBase{game.IDGenerator()},
+		CurrentPosition: position,
+	}
+	if game.AddEntity(healthPack) != nil {
+		return
+	}
+	game.sendChange(AddEntityChange{
+		Entity: healthPack,
+	})
+}
+
+// collectHealthPack heals the first damaged player that collided with a
+// health pack and is still in its cell. Players at full health leave the
+// pack for others. Health is decided by authoritative games.
+func (game *Game) collectHealthPack(healthPack *HealthPack, entities []Identifier, position Coordinate) {
+	if !game.IsAuthoritative {
+		return
+	}
+	var player *Player
+	for _, entity := range entities {
+		entityPlayer, ok := entity.(*Player)
+		if ok && game.isLive(entityPlayer) && entityPlayer.Position() == position && entityPlayer.Damage > 0 {
+			player = entityPlayer
+			break
+		}
+	}
+	if player == nil {
+		return
+	}
+	heal := game.HealthPackAmount
+	if heal > player.Damage {
+		heal = player.Damage
+	}
+	player.Damage -= heal
+	game.sendChange(DamageChange{
+		Player: player,
+		Amount: -heal,
+	})
+	game.sendChange(RemoveEntityChange{
+		Entity: healthPack,
+	})
+	game.RemoveEntity(healthPack.ID())
+}
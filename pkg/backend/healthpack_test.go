@@ -0,0 +1,95 @@
+package backend_test
+
+import (
+	"testing"
+
+	"github.com/mortenson/grpc-game-example/pkg/backend"
+	"github.com/mortenson/grpc-game-example/pkg/backend/testutil"
+)
+
+func TestHealthPackDrops(t *testing.T) {
+	tests := []struct {
+		name       string
+		dropChance float64
+		kills      int
+		drops      int
+	}{
+		{name: "never dropped", dropChance: 0, kills: 5, drops: 0},
+		{name: "always dropped", dropChance: 1, kills: 5, drops: 5},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			game := testutil.NewGame(
+				testutil.WithPlayerAt("alice", 0, 0),
+				testutil.WithPlayerAt("bob", 3, 3),
+			)
+			game.PowerUpDropChance = 0
+			game.HealthPackDropChance = test.dropChance
+			deaths := killRepeatedly(game, test.kills)
+			dropped := testutil.Entities(game, &backend.HealthPack{})
+			if len(dropped) != test.drops {
+				t.Fatalf("got %d health packs, want %d", len(dropped), test.drops)
+			}
+			died := make(map[backend.Coordinate]bool)
+			for _, position := range deaths {
+				died[position] = true
+			}
+			for _, entity := range dropped {
+				healthPack := entity.(*backend.HealthPack)
+				if !died[healthPack.Position()] {
+					t.Errorf("health pack dropped at %+v, where no one died", healthPack.Position())
+				}
+			}
+		})
+	}
+}
+
+func TestCollectHealthPack(t *testing.T) {
+	tests := []struct {
+		name          string
+		damage        int
+		amount        int
+		authoritative bool
+		wantDamage    int
+		collected     bool
+	}{
+		{name: "heals damaged players", damage: 50, amount: 25, authoritative: true, wantDamage: 25, collected: true},
+		{name: "heals up to full health", damage: 10, amount: 25, authoritative: true, wantDamage: 0, collected: true},
+		{name: "left by players at full health", damage: 0, amount: 25, authoritative: true, wantDamage: 0, collected: false},
+		{name: "left for the server", damage: 50, amount: 25, authoritative: false, wantDamage: 50, collected: false},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			game := testutil.NewGame(testutil.WithPlayerAt("alice", 0, 0))
+			game.IsAuthoritative = test.authoritative
+			game.HealthPackAmount = test.amount
+			alice := testutil.Player(game, "alice")
+			alice.Damage = test.damage
+			healthPack := &backend.HealthPack{
+				IdentifierBase:  backend.IdentifierBase{UUID: game.IDGenerator()},
+				CurrentPosition: alice.Position(),
+			}
+			game.AddEntity(healthPack)
+			testutil.DrainChanges(game)
+			game.Step()
+			if alice.Damage != test.wantDamage {
+				t.Errorf("alice has %d damage, want %d", alice.Damage, test.wantDamage)
+			}
+			if collected := game.GetEntity(healthPack.ID()) == nil; collected != test.collected {
+				t.Fatalf("collected is %v, want %v", collected, test.collected)
+			}
+			healed, removed := false, false
+			for _, change := range testutil.DrainChanges(game) {
+				switch change := change.(type) {
+				case backend.DamageChange:
+					healed = change.Player == alice && change.Amount == test.wantDamage-test.damage
+				case backend.RemoveEntityChange:
+					removed = change.Entity == healthPack
+				}
+			}
+			if healed != test.collected || removed != test.collected {
+				t.Errorf("healed is %v and removed is %v, want both %v", healed, removed, test.collected)
+			}
+		})
+	}
+}
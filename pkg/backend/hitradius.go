@@ -0,0 +1,37 @@
+package backend
+
+import (
+	"time"
+)
+
+// resolveNearMisses handles lasers hitting players who are within the hit
+// radius but not in the same cell, which are handled like any other
+// collision. The game should be locked by the caller.
+func (game *Game) resolveNearMisses(now time.Time, hits map[collisionPair]bool) {
+	lasers := []*Laser{}
+	for _, entity := range game.Entities {
+		if laser, ok := entity.(*Laser); ok {
+			lasers = append(lasers, laser)
+		}
+	}
+	for _, laser := range lasers {
+		if !game.isLive(laser) {
+			continue
+		}
+		position := laser.Position()
+		for _, player := range game.Players() {
+			distance := position.ChebyshevDistance(player.Position())
+			if distance == 0 || distance > game.HitRadius {
+				continue
+			}
+			if !game.hitPlayer(player, laser, now, hits) || laser.Piercing {
+				continue
+			}
+			game.sendChange(RemoveEntityChange{
+				Entity: laser,
+			})
+			game.RemoveEntity(laser.ID())
+			break
+		}
+	}
+}
@@ -0,0 +1,70 @@
+package backend
+
+import (
+	"github.com/google/uuid"
+)
+
+const (
+	hordeFirstWaveSize = 2
+	hordeWaveGrowth    = 2
+)
+
+// WaveChange occurs when a new wave of enemies starts in horde mode.
+type WaveChange struct {
+	Change
+	Wave    int
+	Enemies int
+}
+
+// WaveSize returns how many enemies are in a wave, starting from wave one.
+func WaveSize(wave int) int {
+	return hordeFirstWaveSize + (wave-1)*hordeWaveGrowth
+}
+
+// StartWave advances to the next wave, returning how many enemies should be
+// spawned for it.
+func (game *Game) StartWave() int {
+	game.Wave++
+	size := WaveSize(game.Wave)
+	game.sendChange(WaveChange{
+		Wave:    game.Wave,
+		Enemies: size,
+	})
+	return size
+}
+
+// CountBots counts the players controlled by bots.
+func (game *Game) CountBots() int {
+	count := 0
+	for _, entity := range game.Entities {
+		if player, ok := entity.(*Player); ok && player.Bot {
+			count++
+		}
+	}
+	return count
+}
+
+// hordeKill handles a player being hit in horde mode. Bots can't hurt each
+// other, and killed bots are removed instead of respawning. Every human
+// player scores when a bot is killed, as they play cooperatively.
+func (game *Game) hordeKill(player *Player, killerID uuid.UUID) {
+	killerIsBot := game.isBot(killerID)
+	if player.Bot && killerIsBot {
+		return
+	}
+	game.AddKill(killerID, player.ID(), player.Position())
+	if !player.Bot {
+		game.respawn(player, killerID)
+		return
+	}
+	game.dropPowerUp(player.Position())
+	game.sendChange(RemoveEntityChange{
+		Entity: player,
+	})
+	game.RemoveEntity(player.ID())
+	for _, entity := range game.Entities {
+		if human, ok := entity.(*Player); ok && !human.Bot {
+			game.AddScore(human.ID())
+		}
+	}
+}
@@ -0,0 +1,108 @@
+package backend_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/mortenson/grpc-game-example/pkg/backend"
+	"github.com/mortenson/grpc-game-example/pkg/backend/testutil"
+)
+
+// addBots adds bots in a row below the origin, returning their names.
+func addBots(game *backend.Game, count int) []string {
+	names := []string{}
+	for i := 0; i < count; i++ {
+		name := fmt.Sprintf("bot%d", game.Wave*100+i)
+		testutil.WithPlayerAt(name, i-count/2, 3)(game)
+		testutil.Player(game, name).Bot = true
+		names = append(names, name)
+	}
+	return names
+}
+
+func TestWaveSize(t *testing.T) {
+	tests := []struct {
+		wave int
+		want int
+	}{
+		{wave: 1, want: 2},
+		{wave: 2, want: 4},
+		{wave: 5, want: 10},
+	}
+	for _, test := range tests {
+		if size := backend.WaveSize(test.wave); size != test.want {
+			t.Errorf("wave %d has %d enemies, want %d", test.wave, size, test.want)
+		}
+	}
+}
+
+func TestHordeKill(t *testing.T) {
+	tests := []struct {
+		name    string
+		shooter string
+		target  string
+		removed bool
+		killed  bool
+		// score is each human's score after the hit.
+		score int
+	}{
+		{name: "humans kill bots", shooter: "alice", target: "bot0", removed: true, killed: true, score: 1},
+		{name: "bots kill humans", shooter: "bot0", target: "alice", removed: false, killed: true, score: 0},
+		{name: "bots don't hurt bots", shooter: "bot0", target: "bot1", removed: false, killed: false, score: 0},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			game := testutil.NewGame(
+				testutil.WithPlayerAt("alice", 0, 0),
+				testutil.WithPlayerAt("carol", -3, -3),
+			)
+			game.HordeMode = true
+			addBots(game, 2)
+			target := testutil.Player(game, test.target)
+			hit(game, test.shooter, test.target)
+			if removed := game.GetEntity(target.ID()) == nil; removed != test.removed {
+				t.Errorf("removed is %v, want %v", removed, test.removed)
+			}
+			if killed := len(game.RecentKills) == 1; killed != test.killed {
+				t.Errorf("killed is %v, want %v", killed, test.killed)
+			}
+			// Humans share their score.
+			for _, name := range []string{"alice", "carol"} {
+				if score := game.Score[testutil.Player(game, name).ID()]; score != test.score {
+					t.Errorf("%s has score %d, want %d", name, score, test.score)
+				}
+			}
+		})
+	}
+}
+
+func TestHordeWaves(t *testing.T) {
+	game := testutil.NewGame(testutil.WithPlayerAt("alice", 0, 0))
+	game.HordeMode = true
+	previous := 0
+	for wave := 1; wave <= 3; wave++ {
+		size := game.StartWave()
+		if game.Wave != wave {
+			t.Fatalf("got wave %d, want %d", game.Wave, wave)
+		}
+		if size <= previous {
+			t.Errorf("wave %d has %d enemies, want more than the last wave's %d", wave, size, previous)
+		}
+		previous = size
+		changes := []backend.WaveChange{}
+		for _, change := range testutil.DrainChanges(game) {
+			if change, ok := change.(backend.WaveChange); ok {
+				changes = append(changes, change)
+			}
+		}
+		if len(changes) != 1 || changes[0].Wave != wave || changes[0].Enemies != size {
+			t.Errorf("got changes %+v, want wave %d with %d enemies", changes, wave, size)
+		}
+		for _, name := range addBots(game, size) {
+			hit(game, "alice", name)
+		}
+		if bots := game.CountBots(); bots != 0 {
+			t.Fatalf("%d bots are left after clearing wave %d", bots, wave)
+		}
+	}
+}
@@ -0,0 +1,52 @@
+package backend
+
+// Players returns the players in the game, in no particular order.
+func (game *Game) Players() []*Player {
+	players := []*Player{}
+	for _, entity := range game.Entities {
+		if player, ok := entity.(*Player); ok {
+			players = append(players, player)
+		}
+	}
+	return players
+}
+
+// pauseIfIdle pauses the collision loop if it has no work to do, and returns
+// true if it is paused. The loop also times rounds and removes lasers that
+// left the map, so it only pauses when no players or lasers are in the game and
+// no round is being timed. Mines can't go off without players. The game should
+// be locked by the caller.
+func (game *Game) pauseIfIdle() bool {
+	if !game.hasCollisionWork() {
+		game.idle = true
+	}
+	return game.idle
+}
+
+// hasCollisionWork checks if the collision loop has anything to do.
+func (game *Game) hasCollisionWork() bool {
+	if game.RoundDuration > 0 && !game.WaitForRound && game.IsAuthoritative && game.canFight() {
+		return true
+	}
+	for _, entity := range game.Entities {
+		switch entity.(type) {
+		case *Player, *Laser:
+			return true
+		}
+	}
+	return false
+}
+
+// wakeCollisions resumes the collision loop once it has work to do, such as
+// when a player joins, a laser is fired, or a timed round starts. The game
+// should be locked by the caller.
+func (game *Game) wakeCollisions() {
+	if !game.idle || !game.hasCollisionWork() {
+		return
+	}
+	game.idle = false
+	select {
+	case game.wake <- struct{}{}:
+	default:
+	}
+}
@@ -0,0 +1,50 @@
+package backend_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/mortenson/grpc-game-example/pkg/backend"
+	"github.com/mortenson/grpc-game-example/pkg/backend/testutil"
+)
+
+func TestIdleCollisions(t *testing.T) {
+	game := testutil.NewGame()
+	checks := 0
+	game.Metrics.CollisionCheck = func() {
+		checks++
+	}
+	countChecks := func() int {
+		game.Mu.RLock()
+		defer game.Mu.RUnlock()
+		return checks
+	}
+	game.Start()
+	// The steps change the same running game in order, with the game locked.
+	steps := []struct {
+		name    string
+		change  func(game *backend.Game)
+		working bool
+	}{
+		{name: "idle without players", change: func(game *backend.Game) {}, working: false},
+		{name: "resumes when a player joins", change: testutil.WithPlayerAt("alice", 0, 0), working: true},
+		{
+			name: "pauses when the last player leaves",
+			change: func(game *backend.Game) {
+				game.RemoveEntity(testutil.Player(game, "alice").ID())
+			},
+			working: false,
+		},
+		{name: "resumes when another player joins", change: testutil.WithPlayerAt("bob", 0, 0), working: true},
+	}
+	for _, step := range steps {
+		game.Mu.Lock()
+		step.change(game)
+		game.Mu.Unlock()
+		before := countChecks()
+		time.Sleep(100 * time.Millisecond)
+		if working := countChecks() > before; working != step.working {
+			t.Errorf("%s: working is %v, want %v", step.name, working, step.working)
+		}
+	}
+}
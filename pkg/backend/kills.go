@@ -0,0 +1,109 @@
+package backend
+
+import (
+	"bytes"
+	"sort"
+
+	"github.com/google/uuid"
+)
+
+// sortLasersByOwner sorts lasers by owner ID and then by ID, so that lasers in
+// the same cell hit players in the same order no matter how they were found.
+func sortLasersByOwner(lasers []*Laser) {
+	sort.Slice(lasers, func(i, j int) bool {
+		ownerI, ownerJ := lasers[i].OwnerID, lasers[j].OwnerID
+		if ownerI != ownerJ {
+			return bytes.Compare(ownerI[:], ownerJ[:]) < 0
+		}
+		idI, idJ := lasers[i].ID(), lasers[j].ID()
+		return bytes.Compare(idI[:], idJ[:]) < 0
+	})
+}
+
+// pendingKill is a kill found while resolving collisions. Kills are applied
+// once every collision has been resolved, so that players who kill each other
+// at the same time are handled the same way regardless of the order cells
+// are checked in.
+type pendingKill struct {
+	victim   *Player
+	killerID uuid.UUID
+}
+
+// kill handles a player who ran out of health the way the game mode decides.
+// Players are frozen in freeze tag, and killed right away in horde mode.
+// Otherwise the kill is pending until applyPendingKills. The killer ID is
+// uuid.Nil for hazards. The game should be locked by the caller.
+func (game *Game) kill(player *Player, killerID uuid.UUID) {
+	switch {
+	case game.FreezeTag:
+		game.freeze(player, killerID)
+	case game.HordeMode:
+		game.hordeKill(player, killerID)
+	default:
+		game.pendingKills = append(game.pendingKills, pendingKill{
+			victim:   player,
+			killerID: killerID,
+		})
+	}
+}
+
+// applyPendingKills applies the kills found while resolving collisions, in
+// order of victim ID and then killer ID. Players who kill each other both die
+// and both score. Players hit by several lasers only die once, and the kill is
+// credited to the killer with the lowest ID. If several killers reach the
+// round over score, the one with the highest score wins the round, and ties
+// go to the lowest ID. The game should be locked by the caller.
+func (game *Game) applyPendingKills() {
+	kills := game.pendingKills
+	game.pendingKills = nil
+	sort.Slice(kills, func(i, j int) bool {
+		victimI, victimJ := kills[i].victim.ID(), kills[j].victim.ID()
+		if victimI != victimJ {
+			return bytes.Compare(victimI[:], victimJ[:]) < 0
+		}
+		return bytes.Compare(kills[i].killerID[:], kills[j].killerID[:]) < 0
+	})
+	killed := make(map[uuid.UUID]bool)
+	scorers := []uuid.UUID{}
+	for _, kill := range kills {
+		victim := kill.victim
+		if killed[victim.ID()] || !game.isLive(victim) {
+			continue
+		}
+		killed[victim.ID()] = true
+		game.AddKill(kill.killerID, victim.ID(), victim.Position())
+		game.dropPowerUp(victim.Position())
+		game.dropHealthPack(victim.Position())
+		game.respawn(victim, kill.killerID)
+		// Victims lose a point however they died, even if the kill doesn't
+		// score.
+		if game.LoseScoreOnDeath {
+			game.RemoveScore(victim.ID())
+		}
+		if kill.killerID == uuid.Nil || (!game.BotKillsScore && game.isBot(kill.killerID)) {
+			continue
+		}
+		game.AddScore(kill.killerID)
+		scorers = append(scorers, kill.killerID)
+	}
+	if game.WaitForRound {
+		return
+	}
+	winner := uuid.Nil
+	for _, id := range scorers {
+		score := game.Score[id]
+		if score < game.roundOverScore {
+			continue
+		}
+		if winner != uuid.Nil {
+			best := game.Score[winner]
+			if score < best || (score == best && bytes.Compare(id[:], winner[:]) >= 0) {
+				continue
+			}
+		}
+		winner = id
+	}
+	if winner != uuid.Nil {
+		game.queueNewRound(winner)
+	}
+}
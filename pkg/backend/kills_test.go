@@ -0,0 +1,115 @@
+package backend_test
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/mortenson/grpc-game-example/pkg/backend"
+	"github.com/mortenson/grpc-game-example/pkg/backend/testutil"
+)
+
+func TestSimultaneousKills(t *testing.T) {
+	// shot is a laser fired by the shooter that is already on the target.
+	type shot struct {
+		shooter, target string
+	}
+	tests := []struct {
+		name           string
+		shots          []shot
+		roundOverScore int
+		bobScore       int
+		wantScores     map[string]int
+		wantKills      map[string]string
+		wantWinner     string
+	}{
+		{
+			name:           "mutual kills",
+			shots:          []shot{{"alice", "bob"}, {"bob", "alice"}},
+			roundOverScore: 10,
+			wantScores:     map[string]int{"alice": 1, "bob": 1, "carol": 0},
+			wantKills:      map[string]string{"alice": "bob", "bob": "alice"},
+		},
+		{
+			name:           "victims of several lasers die once",
+			shots:          []shot{{"carol", "alice"}, {"bob", "alice"}},
+			roundOverScore: 10,
+			wantScores:     map[string]int{"alice": 0, "bob": 1, "carol": 0},
+			wantKills:      map[string]string{"alice": "bob"},
+		},
+		{
+			name:           "mutual kills that end the round go to the lowest ID",
+			shots:          []shot{{"bob", "alice"}, {"alice", "bob"}},
+			roundOverScore: 1,
+			wantScores:     map[string]int{"alice": 1, "bob": 1, "carol": 0},
+			wantKills:      map[string]string{"alice": "bob", "bob": "alice"},
+			wantWinner:     "alice",
+		},
+		{
+			name:           "mutual kills that end the round go to the highest score",
+			shots:          []shot{{"alice", "bob"}, {"bob", "alice"}},
+			roundOverScore: 2,
+			bobScore:       1,
+			wantScores:     map[string]int{"alice": 1, "bob": 2, "carol": 0},
+			wantKills:      map[string]string{"alice": "bob", "bob": "alice"},
+			wantWinner:     "bob",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			// Kills shouldn't depend on the order collisions are found in,
+			// so each case is checked several times.
+			for i := 0; i < 20; i++ {
+				config := testutil.MapConfig(
+					"S      ",
+					"       ",
+					"      S",
+				)
+				config.RoundOverScore = test.roundOverScore
+				game := testutil.NewGameFromConfig(t, config,
+					testutil.WithSequentialIDs(),
+					testutil.WithPlayerAt("alice", -2, 0),
+					testutil.WithPlayerAt("bob", 0, 0),
+					testutil.WithPlayerAt("carol", 2, 0),
+				)
+				id := func(name string) uuid.UUID {
+					return testutil.Player(game, name).ID()
+				}
+				game.Score[id("bob")] = test.bobScore
+				for _, shot := range test.shots {
+					addStillLaser(game, id(shot.shooter), testutil.Player(game, shot.target).Position())
+				}
+				game.Step()
+				for name, want := range test.wantScores {
+					if score := game.Score[id(name)]; score != want {
+						t.Fatalf("%s has score %d, want %d", name, score, want)
+					}
+				}
+				kills := map[string]string{}
+				for _, kill := range game.RecentKills {
+					victim := game.GetEntity(kill.VictimID).(*backend.Player).Name
+					if _, ok := kills[victim]; ok {
+						t.Fatalf("%s was killed more than once", victim)
+					}
+					kills[victim] = game.GetEntity(kill.KillerID).(*backend.Player).Name
+				}
+				if len(kills) != len(test.wantKills) {
+					t.Fatalf("got kills %v, want %v", kills, test.wantKills)
+				}
+				for victim, killer := range test.wantKills {
+					if kills[victim] != killer {
+						t.Fatalf("got kills %v, want %v", kills, test.wantKills)
+					}
+				}
+				if test.wantWinner == "" {
+					if game.WaitForRound {
+						t.Fatal("the round is over, want it to continue")
+					}
+					continue
+				}
+				if !game.WaitForRound || game.RoundWinner != id(test.wantWinner) {
+					t.Fatalf("round winner is %s, want %s", game.RoundWinner, test.wantWinner)
+				}
+			}
+		})
+	}
+}
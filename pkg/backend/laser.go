@@ -8,6 +8,12 @@ import (
 	"github.com/google/uuid"
 )
 
+const (
+	defaultLaserMaxAge = 10 * time.Second
+	// laserAgeInterval is how often lasers are checked for despawning.
+	laserAgeInterval = time.Second
+)
+
 // Laser is an entity that is fired by players.
 type Laser struct {
 	IdentifierBase
@@ -16,13 +22,53 @@ type Laser struct {
 	Direction       Direction
 	StartTime       time.Time
 	OwnerID         uuid.UUID
+	// Piercing lasers pass through players, hitting every player in their
+	// path until they hit a wall.
+	Piercing bool
+	// Team is the team of the player who fired the laser, and zero if they
+	// weren't on a team. Lasers are drawn in their team's color.
+	Team int
+	// Swap lasers don't hurt the players they hit, and instead swap their
+	// position with the position of the player who fired the laser.
+	Swap bool
+	// SpeedMultiplier is the game's speed multiplier when the laser was
+	// fired, which scales how fast it moves. It's ignored unless it's
+	// positive.
+	SpeedMultiplier float64
 }
 
 // Position returns the laser position, which is calculated at runtime based on
 // when the laser was fired.
 func (laser *Laser) Position() Coordinate {
-	difference := time.Now().Sub(laser.StartTime)
-	moves := int(math.Floor(float64(difference.Milliseconds()) / float64(laserSpeed)))
+	return laser.positionAfter(laser.movesAt(time.Now()))
+}
+
+// Trail returns the cells the laser entered after one time, up to and
+// including another, in the order they were entered.
+func (laser *Laser) Trail(from time.Time, to time.Time) []Coordinate {
+	trail := []Coordinate{}
+	first := laser.movesAt(from) + 1
+	if first < 0 {
+		first = 0
+	}
+	for moves := first; moves <= laser.movesAt(to); moves++ {
+		trail = append(trail, laser.positionAfter(moves))
+	}
+	return trail
+}
+
+// movesAt returns how many cells the laser has moved at a given time.
+func (laser *Laser) movesAt(t time.Time) int {
+	difference := t.Sub(laser.StartTime)
+	speed := float64(laserSpeed)
+	if laser.SpeedMultiplier > 0 {
+		speed /= laser.SpeedMultiplier
+	}
+	return int(math.Floor(float64(difference.Milliseconds()) / speed))
+}
+
+// positionAfter returns the laser position after it has moved some cells.
+func (laser *Laser) positionAfter(moves int) Coordinate {
 	position := laser.InitialPosition
 	switch laser.Direction {
 	case DirectionUp:
@@ -43,40 +89,258 @@ type LaserAction struct {
 	ID        uuid.UUID
 	OwnerID   uuid.UUID
 	Created   time.Time
+	Piercing  bool
+	Swap      bool
 }
 
 // Perform spawns a laser next to the player who fired it.
-func (action LaserAction) Perform(game *Game) {
-	entity := game.GetEntity(action.OwnerID)
+func (action LaserAction) Perform(game *Game) ActionResult {
+	return actionResult(action.Try(game))
+}
+
+// Try fires a laser like Perform, but returns an error saying why the laser
+// was rejected, if it was. The game should be locked by the caller.
+func (action LaserAction) Try(game *Game) error {
+	if !action.Direction.moves() || !game.canFight() {
+		return newError(ErrorCodeInvalidAction, nil, "lasers can not be fired %v", action.Direction)
+	}
+	entity := game.actingEntity(action.OwnerID)
 	if entity == nil {
-		return
+		return newError(ErrorCodeEntityNotFound, nil, "entity %s does not exist", action.OwnerID)
+	}
+	positioner, ok := entity.(Positioner)
+	if !ok {
+		return newError(ErrorCodeInvalidAction, nil, "entity %s has no position", action.OwnerID)
+	}
+	if player, ok := entity.(*Player); ok && player.Frozen {
+		return newError(ErrorCodeInvalidAction, nil, "player %s is frozen", action.OwnerID)
 	}
 	actionKey := fmt.Sprintf("%T:%s", action, entity.ID().String())
-	if !game.checkLastActionTime(actionKey, action.Created, laserThrottle) {
-		return
+	throttle := game.laserThrottle
+	if player, ok := entity.(*Player); ok && player.RapidFireUntil.After(action.Created) {
+		throttle /= 2
+	}
+	if err := game.checkLastActionTime(actionKey, action.Created, throttle); err != nil {
+		game.recordRejectedFire(action.OwnerID, action.Created)
+		return err
+	}
+	if game.MaxLasersPerPlayer > 0 && game.countLasers(action.OwnerID) >= game.MaxLasersPerPlayer {
+		return newError(ErrorCodeActionThrottled, nil, "entity %s can only have %d lasers at once", action.OwnerID, game.MaxLasersPerPlayer)
 	}
 	laser := Laser{
-		InitialPosition: entity.(Positioner).Position(),
+		InitialPosition: positioner.Position(),
 		StartTime:       action.Created,
 		Direction:       action.Direction,
 		IdentifierBase:  IdentifierBase{action.ID},
 		OwnerID:         action.OwnerID,
+		Piercing:        action.Piercing,
+		Swap:            action.Swap,
+		SpeedMultiplier: game.SpeedMultiplier,
 	}
-	// Initialize the laser to the side of the player.
-	switch action.Direction {
-	case DirectionUp:
-		laser.InitialPosition.Y--
-	case DirectionDown:
-		laser.InitialPosition.Y++
-	case DirectionLeft:
-		laser.InitialPosition.X--
-	case DirectionRight:
-		laser.InitialPosition.X++
+	if player, ok := entity.(*Player); ok {
+		laser.Team = player.Team
+	}
+	// Initialize the laser ahead of the player. Lasers can't be fired through
+	// walls, so the shot is rejected if one is in the way.
+	for i := 0; i < game.ProjectileSpawnOffset; i++ {
+		laser.InitialPosition = laser.InitialPosition.Add(action.Direction.offset())
+		if game.isWall(laser.InitialPosition) {
+			return newError(ErrorCodeInvalidAction, nil, "lasers can not be fired through walls")
+		}
+	}
+	if err := game.AddEntity(&laser); err != nil {
+		return err
 	}
-	game.AddEntity(&laser)
 	change := AddEntityChange{
 		Entity: &laser,
 	}
 	game.sendChange(change)
 	game.updateLastActionTime(actionKey, action.Created)
+	return nil
+}
+
+// isWall checks if a position is a map wall or a wall entity. The game should
+// be locked by the caller.
+func (game *Game) isWall(position Coordinate) bool {
+	if game.mapTypeAt(position) == MapTypeWall {
+		return true
+	}
+	for _, entity := range game.getCollisionMap()[position] {
+		if _, ok := entity.(*Wall); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// canHitOwner checks if a laser's self-hit grace period has passed, after
+// which it can hit the player who fired it. The game should be locked by the
+// caller.
+func (game *Game) canHitOwner(laser *Laser, now time.Time) bool {
+	if game.SelfHitGrace <= 0 {
+		return false
+	}
+	grace := time.Duration(game.SelfHitGrace) * game.scaleDuration(collisionCheckFrequency)
+	return now.Sub(laser.StartTime) >= grace
+}
+
+// coveredFromBehind checks if a player who just moved is immune to a laser
+// coming from behind them, as the game's rear immunity allows. The game should
+// be locked by the caller.
+func (game *Game) coveredFromBehind(player *Player, laser *Laser, now time.Time) bool {
+	if game.RearImmunity <= 0 || laser.Direction != game.FacingDirection(player) {
+		return false
+	}
+	immunity := time.Duration(game.RearImmunity) * game.scaleDuration(collisionCheckFrequency)
+	return now.Sub(player.LastMoved) < immunity
+}
+
+// countLasers counts the lasers in play that were fired by an entity.
+func (game *Game) countLasers(ownerID uuid.UUID) int {
+	count := 0
+	for _, entity := range game.Entities {
+		laser, ok := entity.(*Laser)
+		if ok && laser.OwnerID == ownerID {
+			count++
+		}
+	}
+	return count
+}
+
+// FireAction is sent when a player fires a laser. Unlike LaserAction, the
+// laser's ID and start time are chosen by the engine. If the direction is
+// DirectionStop, the laser is fired in the direction the player is facing.
+// Players with a piercing or swap laser as their active weapon fire that kind
+// of laser.
+type FireAction struct {
+	PlayerID  uuid.UUID
+	Direction Direction
+	Piercing  bool
+}
+
+// Perform fires a laser from the player's position. Firing shares its cooldown
+// with LaserAction.
+func (action FireAction) Perform(game *Game) ActionResult {
+	if !game.canFight() {
+		return ActionRejectedInvalid
+	}
+	entity := game.actingEntity(action.PlayerID)
+	if entity == nil {
+		return ActionRejectedInvalid
+	}
+	if _, ok := entity.(Positioner); !ok {
+		return ActionRejectedInvalid
+	}
+	player, isPlayer := entity.(*Player)
+	direction := action.Direction
+	if direction == DirectionStop {
+		if !isPlayer {
+			return ActionRejectedInvalid
+		}
+		direction = game.FacingDirection(player)
+	}
+	piercing := action.Piercing
+	if isPlayer && player.Weapon() == WeaponPiercingLaser {
+		piercing = true
+	}
+	return LaserAction{
+		ID:        game.IDGenerator(),
+		OwnerID:   action.PlayerID,
+		Direction: direction,
+		Created:   time.Now(),
+		Piercing:  piercing,
+		Swap:      isPlayer && player.Weapon() == WeaponSwapLaser,
+	}.Perform(game)
+}
+
+// swapPositions swaps the positions of a player hit by a swap laser and the
+// player who fired it. Nothing happens if either player would land in a wall.
+// Both moves are sent as teleports, as neither player asked to move. The game
+// should be locked by the caller.
+func (game *Game) swapPositions(target *Player, shooterID uuid.UUID) {
+	shooter, ok := game.GetEntity(shooterID).(*Player)
+	if !ok || shooter == target {
+		return
+	}
+	shooterPosition, targetPosition := shooter.Position(), target.Position()
+	if game.isWall(shooterPosition) || game.isWall(targetPosition) {
+		return
+	}
+	game.MoveEntity(shooter, targetPosition)
+	game.MoveEntity(target, shooterPosition)
+	game.sendChange(MoveChange{
+		Entity:       shooter,
+		Direction:    DirectionStop,
+		Position:     targetPosition,
+		From:         shooterPosition,
+		Teleport:     true,
+		MoveSequence: game.nextMoveSequence(shooter.ID()),
+	})
+	game.sendChange(MoveChange{
+		Entity:       target,
+		Direction:    DirectionStop,
+		Position:     shooterPosition,
+		From:         targetPosition,
+		Teleport:     true,
+		MoveSequence: game.nextMoveSequence(target.ID()),
+	})
+}
+
+// TrailChange occurs when a laser moves, and contains the cells it entered
+// since the last change. It is only sent if the game's EmitTrails is true.
+type TrailChange struct {
+	Change
+	Laser *Laser
+	Cells []Coordinate
+}
+
+// emitTrails sends the cells each laser entered since trails were last
+// emitted. The game should be locked by the caller.
+func (game *Game) emitTrails(now time.Time) {
+	if !game.lastTrailUpdate.IsZero() {
+		for _, entity := range game.Entities {
+			laser, ok := entity.(*Laser)
+			if !ok {
+				continue
+			}
+			cells := laser.Trail(game.lastTrailUpdate, now)
+			if len(cells) == 0 {
+				continue
+			}
+			game.sendChange(TrailChange{
+				Laser: laser,
+				Cells: cells,
+			})
+		}
+	}
+	game.lastTrailUpdate = now
+}
+
+// watchLaserAge periodically despawns lasers that have been flying for too
+// long.
+func (game *Game) watchLaserAge() {
+	for {
+		time.Sleep(laserAgeInterval)
+		game.Mu.Lock()
+		game.despawnOldLasers(time.Now())
+		game.Mu.Unlock()
+	}
+}
+
+// despawnOldLasers removes lasers fired longer than the game's max laser age
+// ago. The game should be locked by the caller.
+func (game *Game) despawnOldLasers(now time.Time) {
+	if game.LaserMaxAge <= 0 {
+		return
+	}
+	for _, entity := range game.Entities {
+		laser, ok := entity.(*Laser)
+		if !ok || now.Sub(laser.StartTime) < game.scaleDuration(game.LaserMaxAge) {
+			continue
+		}
+		game.sendChange(RemoveEntityChange{
+			Entity: laser,
+		})
+		game.RemoveEntity(laser.ID())
+	}
 }
@@ -0,0 +1,773 @@
+package backend_test
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mortenson/grpc-game-example/pkg/backend"
+	"github.com/mortenson/grpc-game-example/pkg/backend/testutil"
+)
+
+func TestFireAction(t *testing.T) {
+	tests := []struct {
+		name      string
+		facing    backend.Direction
+		direction backend.Direction
+		result    backend.ActionResult
+		want      backend.Coordinate
+		wantDir   backend.Direction
+	}{
+		{
+			name:      "fires in the given direction",
+			facing:    backend.DirectionStop,
+			direction: backend.DirectionRight,
+			result:    backend.ActionAccepted,
+			want:      backend.Coordinate{X: 1, Y: 0},
+			wantDir:   backend.DirectionRight,
+		},
+		{
+			name:      "stationary players fire the default direction",
+			facing:    backend.DirectionStop,
+			direction: backend.DirectionStop,
+			result:    backend.ActionAccepted,
+			want:      backend.Coordinate{X: 0, Y: -1},
+			wantDir:   backend.DirectionUp,
+		},
+		{
+			name:      "stationary players fire the way they face",
+			facing:    backend.DirectionLeft,
+			direction: backend.DirectionStop,
+			result:    backend.ActionAccepted,
+			want:      backend.Coordinate{X: -1, Y: 0},
+			wantDir:   backend.DirectionLeft,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			game := testutil.NewGame(testutil.WithPlayerAt("alice", 0, 0))
+			alice := testutil.Player(game, "alice")
+			if test.facing != backend.DirectionStop {
+				alice.Facing = test.facing
+				alice.HasFacing = true
+			}
+			result := game.PerformAction(backend.FireAction{
+				PlayerID:  alice.ID(),
+				Direction: test.direction,
+			})
+			if result != test.result {
+				t.Fatalf("got result %v, want %v", result, test.result)
+			}
+			fired := testutil.Entities(game, &backend.Laser{})
+			if len(fired) != 1 {
+				t.Fatalf("got %d lasers, want 1", len(fired))
+			}
+			laser := fired[0].(*backend.Laser)
+			if laser.OwnerID != alice.ID() {
+				t.Errorf("laser is owned by %s, want %s", laser.OwnerID, alice.ID())
+			}
+			if laser.InitialPosition != test.want {
+				t.Errorf("laser starts at %+v, want %+v", laser.InitialPosition, test.want)
+			}
+			if laser.Direction != test.wantDir {
+				t.Errorf("laser moves %v, want %v", laser.Direction, test.wantDir)
+			}
+			added := false
+			for _, change := range testutil.DrainChanges(game) {
+				if change, ok := change.(backend.AddEntityChange); ok && change.Entity == laser {
+					added = true
+				}
+			}
+			if !added {
+				t.Error("no AddEntityChange was sent for the laser")
+			}
+		})
+	}
+}
+
+func TestFireActionRejected(t *testing.T) {
+	tests := []struct {
+		name   string
+		fire   func(game *backend.Game, alice *backend.Player) backend.Action
+		result backend.ActionResult
+	}{
+		{
+			name: "unknown player",
+			fire: func(game *backend.Game, alice *backend.Player) backend.Action {
+				return backend.FireAction{PlayerID: uuid.New(), Direction: backend.DirectionUp}
+			},
+			result: backend.ActionRejectedInvalid,
+		},
+		{
+			name: "cooldown",
+			fire: func(game *backend.Game, alice *backend.Player) backend.Action {
+				return backend.ActionBatch{
+					backend.FireAction{PlayerID: alice.ID(), Direction: backend.DirectionUp},
+					backend.FireAction{PlayerID: alice.ID(), Direction: backend.DirectionDown},
+				}
+			},
+			result: backend.ActionRejectedThrottled,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			game := testutil.NewGame(testutil.WithPlayerAt("alice", 0, 0))
+			alice := testutil.Player(game, "alice")
+			if result := game.PerformAction(test.fire(game, alice)); result != test.result {
+				t.Errorf("got result %v, want %v", result, test.result)
+			}
+			if len(testutil.Entities(game, &backend.Laser{})) > 1 {
+				t.Errorf("got %d lasers, want at most 1", len(testutil.Entities(game, &backend.Laser{})))
+			}
+		})
+	}
+}
+
+func TestMaxLasersPerPlayer(t *testing.T) {
+	tests := []struct {
+		name    string
+		max     int
+		shots   int
+		removed int
+		fired   int
+	}{
+		{name: "no limit", max: 0, shots: 4, fired: 4},
+		{name: "stops at the cap", max: 2, shots: 4, fired: 2},
+		{name: "fires again once a laser is removed", max: 2, shots: 4, removed: 1, fired: 3},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			game := testutil.NewGame(
+				testutil.WithPlayerAt("alice", 0, 0),
+				testutil.WithMaxLasersPerPlayer(test.max),
+			)
+			alice := testutil.Player(game, "alice")
+			start := time.Now()
+			fire := func(i int) backend.ActionResult {
+				return game.PerformAction(backend.LaserAction{
+					ID:        uuid.New(),
+					OwnerID:   alice.ID(),
+					Direction: backend.DirectionDown,
+					Created:   start.Add(time.Duration(i) * time.Second),
+				})
+			}
+			fired := 0
+			for i := 0; i < test.shots; i++ {
+				if i == test.shots/2 {
+					for _, laser := range testutil.Entities(game, &backend.Laser{})[:test.removed] {
+						game.RemoveEntity(laser.ID())
+					}
+				}
+				if fire(i) == backend.ActionAccepted {
+					fired++
+				}
+			}
+			if fired != test.fired {
+				t.Errorf("fired %d lasers, want %d", fired, test.fired)
+			}
+			if test.max > 0 && len(testutil.Entities(game, &backend.Laser{})) > test.max {
+				t.Errorf("got %d lasers in play, want at most %d", len(testutil.Entities(game, &backend.Laser{})), test.max)
+			}
+		})
+	}
+}
+
+func TestDefaultDirection(t *testing.T) {
+	tests := []struct {
+		name             string
+		defaultDirection backend.Direction
+		// moved is the direction the player tried to move in, if any.
+		moved backend.Direction
+		want  backend.Direction
+	}{
+		{name: "never moved", defaultDirection: backend.DirectionUp, moved: backend.DirectionStop, want: backend.DirectionUp},
+		{name: "configured default", defaultDirection: backend.DirectionLeft, moved: backend.DirectionStop, want: backend.DirectionLeft},
+		{name: "moved", defaultDirection: backend.DirectionLeft, moved: backend.DirectionDown, want: backend.DirectionDown},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			config := backend.DefaultConfig()
+			config.DefaultDirection = test.defaultDirection
+			game := testutil.NewGameFromConfig(t, config, testutil.WithPlayerAt("alice", 0, 0))
+			alice := testutil.Player(game, "alice")
+			if test.moved != backend.DirectionStop {
+				move := backend.MoveAction{ID: alice.ID(), Direction: test.moved, Created: time.Now()}
+				if result := game.PerformAction(move); result != backend.ActionAccepted {
+					t.Fatalf("move got %v", result)
+				}
+			}
+			if facing := game.FacingDirection(alice); facing != test.want {
+				t.Errorf("player faces %v, want %v", facing, test.want)
+			}
+			result := game.PerformAction(backend.FireAction{
+				PlayerID:  alice.ID(),
+				Direction: backend.DirectionStop,
+			})
+			if result != backend.ActionAccepted {
+				t.Fatalf("fire got %v", result)
+			}
+			fired := testutil.Entities(game, &backend.Laser{})
+			if len(fired) != 1 || fired[0].(*backend.Laser).Direction != test.want {
+				t.Errorf("got lasers %+v, want one fired %v", fired, test.want)
+			}
+		})
+	}
+}
+
+func TestPiercingLasers(t *testing.T) {
+	tests := []struct {
+		name     string
+		piercing bool
+		killed   []string
+		// removed is whether the laser was removed before it reached the
+		// wall.
+		removed bool
+	}{
+		{name: "lasers stop at the first player", piercing: false, killed: []string{"alice"}, removed: true},
+		{name: "piercing lasers hit every player in a row", piercing: true, killed: []string{"alice", "bob"}, removed: false},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			game := testutil.NewGame(
+				testutil.WithPlayerAt("alice", 0, 0),
+				testutil.WithPlayerAt("bob", 1, 0),
+				testutil.WithPlayerAt("carol", -3, -3),
+				testutil.WithWallAt(2, 0),
+			)
+			laser := &backend.Laser{
+				IdentifierBase:  backend.IdentifierBase{UUID: game.IDGenerator()},
+				InitialPosition: backend.Coordinate{},
+				Direction:       backend.DirectionRight,
+				StartTime:       time.Now(),
+				OwnerID:         testutil.Player(game, "carol").ID(),
+				Piercing:        test.piercing,
+			}
+			game.AddEntity(laser)
+			// Move the laser along one cell at a time, from alice to bob to
+			// the wall.
+			removed := false
+			for cell := 0; cell <= 2; cell++ {
+				laser.StartTime = time.Now().Add(-time.Duration(cell) * 50 * time.Millisecond)
+				game.Step()
+				if cell < 2 && game.GetEntity(laser.ID()) == nil {
+					removed = true
+					break
+				}
+			}
+			if removed != test.removed {
+				t.Errorf("removed is %v, want %v", removed, test.removed)
+			}
+			if game.GetEntity(laser.ID()) != nil {
+				t.Error("the laser was not removed")
+			}
+			killed := []string{}
+			for _, kill := range game.RecentKills {
+				killed = append(killed, game.GetEntity(kill.VictimID).(*backend.Player).Name)
+			}
+			if fmt.Sprint(killed) != fmt.Sprint(test.killed) {
+				t.Errorf("killed %v, want %v", killed, test.killed)
+			}
+		})
+	}
+}
+
+func TestLasersCollide(t *testing.T) {
+	tests := []struct {
+		name     string
+		piercing bool
+	}{
+		{name: "lasers", piercing: false},
+		{name: "piercing lasers", piercing: true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			game := testutil.NewGame(
+				testutil.WithPlayerAt("alice", -3, -3),
+				testutil.WithPlayerAt("bob", 3, 3),
+			)
+			aliceLaser := addStillLaser(game, testutil.Player(game, "alice").ID(), backend.Coordinate{})
+			bobLaser := addStillLaser(game, testutil.Player(game, "bob").ID(), backend.Coordinate{})
+			aliceLaser.Piercing = test.piercing
+			bobLaser.Piercing = test.piercing
+			game.Step()
+			if len(testutil.Entities(game, &backend.Laser{})) != 0 {
+				t.Errorf("got lasers %+v, want lasers that collided to be removed", testutil.Entities(game, &backend.Laser{}))
+			}
+		})
+	}
+}
+
+func TestLaserTrail(t *testing.T) {
+	start := time.Now()
+	tests := []struct {
+		name      string
+		direction backend.Direction
+		from, to  time.Duration
+		// speedMultiplier is the laser's speed multiplier, if any.
+		speedMultiplier float64
+		want            []backend.Coordinate
+	}{
+		{
+			name:      "multiple cells",
+			direction: backend.DirectionRight,
+			from:      60 * time.Millisecond,
+			to:        210 * time.Millisecond,
+			want:      []backend.Coordinate{{X: 2}, {X: 3}, {X: 4}},
+		},
+		{
+			name:      "negative direction",
+			direction: backend.DirectionUp,
+			from:      0,
+			to:        110 * time.Millisecond,
+			want:      []backend.Coordinate{{Y: -1}, {Y: -2}},
+		},
+		{
+			name:      "within a cell",
+			direction: backend.DirectionLeft,
+			from:      60 * time.Millisecond,
+			to:        90 * time.Millisecond,
+			want:      []backend.Coordinate{},
+		},
+		{
+			name:      "from before the laser was fired",
+			direction: backend.DirectionDown,
+			from:      -time.Second,
+			to:        60 * time.Millisecond,
+			want:      []backend.Coordinate{{}, {Y: 1}},
+		},
+		{
+			name:            "double speed",
+			direction:       backend.DirectionRight,
+			from:            0,
+			to:              110 * time.Millisecond,
+			speedMultiplier: 2,
+			want:            []backend.Coordinate{{X: 1}, {X: 2}, {X: 3}, {X: 4}},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			laser := &backend.Laser{
+				Direction:       test.direction,
+				StartTime:       start,
+				SpeedMultiplier: test.speedMultiplier,
+			}
+			trail := laser.Trail(start.Add(test.from), start.Add(test.to))
+			if fmt.Sprint(trail) != fmt.Sprint(test.want) {
+				t.Errorf("got trail %v, want %v", trail, test.want)
+			}
+		})
+	}
+}
+
+func TestTrailChange(t *testing.T) {
+	tests := []struct {
+		name       string
+		emitTrails bool
+	}{
+		{name: "trails", emitTrails: true},
+		{name: "no trails", emitTrails: false},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			game := testutil.NewGame()
+			game.EmitTrails = test.emitTrails
+			laser := &backend.Laser{
+				IdentifierBase: backend.IdentifierBase{UUID: game.IDGenerator()},
+				Direction:      backend.DirectionRight,
+				StartTime:      time.Now(),
+			}
+			game.AddEntity(laser)
+			game.Step()
+			time.Sleep(120 * time.Millisecond)
+			game.Step()
+			cells := []backend.Coordinate{}
+			for _, change := range testutil.DrainChanges(game) {
+				if change, ok := change.(backend.TrailChange); ok && change.Laser == laser {
+					cells = append(cells, change.Cells...)
+				}
+			}
+			if !test.emitTrails {
+				if len(cells) != 0 {
+					t.Errorf("got trail %v, want none", cells)
+				}
+				return
+			}
+			if len(cells) < 2 {
+				t.Fatalf("got trail %v, want the cells entered while sleeping", cells)
+			}
+			// The trail has every cell the laser entered, in order.
+			for i, cell := range cells {
+				if want := (backend.Coordinate{X: cells[0].X + i}); cell != want {
+					t.Errorf("cell %d is %+v, want %+v", i, cell, want)
+				}
+			}
+			if first := cells[0]; first.X < 1 || first.X > 2 {
+				t.Errorf("trail starts at %+v, want the cell after the first step", first)
+			}
+		})
+	}
+}
+
+func TestLingeringHits(t *testing.T) {
+	away := backend.Coordinate{X: 2, Y: 2}
+	tests := []struct {
+		name string
+		// positions are where alice is moved before each step.
+		positions []backend.Coordinate
+		score     int
+	}{
+		{name: "scores once", positions: []backend.Coordinate{{}, {}, {}, {}}, score: 1},
+		{name: "scores again after separating", positions: []backend.Coordinate{{}, {}, away, {}}, score: 2},
+		{name: "scores again after separating twice", positions: []backend.Coordinate{{}, away, {}, away, {}}, score: 3},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			game := testutil.NewGame(
+				testutil.WithPlayerAt("alice", 0, 0),
+				testutil.WithPlayerAt("bob", -3, -3),
+			)
+			// Alice respawns where she is, so a piercing laser stays on her.
+			game.RespawnMode = backend.RespawnModeOrigin
+			alice := testutil.Player(game, "alice")
+			bob := testutil.Player(game, "bob")
+			addStillLaser(game, bob.ID(), backend.Coordinate{}).Piercing = true
+			for _, position := range test.positions {
+				game.MoveEntity(alice, position)
+				game.Step()
+			}
+			if score := game.Score[bob.ID()]; score != test.score {
+				t.Errorf("bob has score %d, want %d", score, test.score)
+			}
+		})
+	}
+}
+
+func TestHitRadius(t *testing.T) {
+	tests := []struct {
+		name   string
+		radius int
+		laser  backend.Coordinate
+		hit    bool
+	}{
+		{name: "exact hits without a radius", radius: 0, laser: backend.Coordinate{}, hit: true},
+		{name: "near misses without a radius", radius: 0, laser: backend.Coordinate{X: 1}, hit: false},
+		{name: "exact hits with a radius", radius: 1, laser: backend.Coordinate{}, hit: true},
+		{name: "adjacent cells", radius: 1, laser: backend.Coordinate{Y: -1}, hit: true},
+		{name: "diagonal cells", radius: 1, laser: backend.Coordinate{X: 1, Y: 1}, hit: true},
+		{name: "outside the radius", radius: 1, laser: backend.Coordinate{X: 2, Y: 1}, hit: false},
+		{name: "a larger radius", radius: 2, laser: backend.Coordinate{X: 2, Y: -2}, hit: true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			game := testutil.NewGame(
+				testutil.WithPlayerAt("alice", 0, 0),
+				testutil.WithPlayerAt("bob", -5, -5),
+			)
+			game.HitRadius = test.radius
+			bob := testutil.Player(game, "bob")
+			laser := addStillLaser(game, bob.ID(), test.laser)
+			game.Step()
+			if hit := game.Score[bob.ID()] == 1; hit != test.hit {
+				t.Errorf("hit is %v, want %v", hit, test.hit)
+			}
+			// Lasers that hit are removed.
+			if removed := game.GetEntity(laser.ID()) == nil; removed != test.hit {
+				t.Errorf("removed is %v, want %v", removed, test.hit)
+			}
+		})
+	}
+}
+
+func TestProjectileSpawnOffset(t *testing.T) {
+	tests := []struct {
+		name      string
+		offset    int
+		direction backend.Direction
+		result    backend.ActionResult
+		want      backend.Coordinate
+	}{
+		{name: "no offset", offset: 0, direction: backend.DirectionUp, result: backend.ActionAccepted, want: backend.Coordinate{X: 0, Y: 1}},
+		{name: "one cell ahead", offset: 1, direction: backend.DirectionUp, result: backend.ActionAccepted, want: backend.Coordinate{X: 0, Y: 0}},
+		{name: "two cells ahead", offset: 2, direction: backend.DirectionLeft, result: backend.ActionAccepted, want: backend.Coordinate{X: -2, Y: 1}},
+		{name: "into a map wall", offset: 2, direction: backend.DirectionUp, result: backend.ActionRejectedInvalid},
+		{name: "into a wall entity", offset: 1, direction: backend.DirectionRight, result: backend.ActionRejectedInvalid},
+		{name: "past a wall entity", offset: 2, direction: backend.DirectionRight, result: backend.ActionRejectedInvalid},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			config := testutil.MapConfig(
+				"S █  ",
+				"     ",
+				"     ",
+			)
+			game := testutil.NewGameFromConfig(t, config,
+				testutil.WithPlayerAt("alice", 0, 1),
+				testutil.WithWallAt(1, 1),
+			)
+			game.ProjectileSpawnOffset = test.offset
+			result := game.PerformAction(backend.LaserAction{
+				ID:        uuid.New(),
+				OwnerID:   testutil.Player(game, "alice").ID(),
+				Direction: test.direction,
+				Created:   time.Now(),
+			})
+			if result != test.result {
+				t.Fatalf("got result %v, want %v", result, test.result)
+			}
+			fired := testutil.Entities(game, &backend.Laser{})
+			if test.result != backend.ActionAccepted {
+				if len(fired) != 0 {
+					t.Errorf("got %d lasers, want none", len(fired))
+				}
+				return
+			}
+			if len(fired) != 1 || fired[0].(*backend.Laser).InitialPosition != test.want {
+				t.Errorf("got lasers %+v, want one at %+v", fired, test.want)
+			}
+		})
+	}
+}
+
+func TestSelfHitGrace(t *testing.T) {
+	tests := []struct {
+		name  string
+		grace int
+		// age is how many collision checks ago the laser was fired.
+		age    int
+		target string
+		killed bool
+	}{
+		{name: "owners are never hit without a grace period", grace: 0, age: 100, target: "alice", killed: false},
+		{name: "just fired lasers don't hit their owner", grace: 3, age: 0, target: "alice", killed: false},
+		{name: "lasers in their grace period don't hit their owner", grace: 3, age: 2, target: "alice", killed: false},
+		{name: "lasers hit their owner after the grace period", grace: 3, age: 3, target: "alice", killed: true},
+		{name: "just fired lasers hit others", grace: 3, age: 0, target: "bob", killed: true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			game := testutil.NewGame(
+				testutil.WithPlayerAt("alice", 0, 0),
+				testutil.WithPlayerAt("bob", 3, 0),
+			)
+			game.SelfHitGrace = test.grace
+			alice := testutil.Player(game, "alice")
+			target := testutil.Player(game, test.target)
+			laser := addStillLaser(game, alice.ID(), target.Position())
+			laser.StartTime = time.Now().Add(-time.Duration(test.age) * backend.CollisionCheckFrequency)
+			game.Step()
+			if killed := len(game.RecentKills) == 1 && game.RecentKills[0].VictimID == target.ID(); killed != test.killed {
+				t.Fatalf("got kills %+v, want %s killed %v", game.RecentKills, test.target, test.killed)
+			}
+			// No one scores when players kill themselves.
+			wantScore := 0
+			if test.killed && target != alice {
+				wantScore = 1
+			}
+			if score := game.Score[alice.ID()]; score != wantScore {
+				t.Errorf("alice has score %d, want %d", score, wantScore)
+			}
+			if test.killed && game.RecentKills[0].KillerID != uuid.Nil && target == alice {
+				t.Error("alice was credited for a self-kill, want no killer")
+			}
+		})
+	}
+}
+
+func TestRearImmunity(t *testing.T) {
+	tests := []struct {
+		name     string
+		immunity int
+		// laser is the direction the laser travels in, and alice moves right.
+		laser backend.Direction
+		// age is how many collision checks ago alice moved.
+		age    int
+		killed bool
+	}{
+		{name: "hits from behind", immunity: 3, laser: backend.DirectionRight, age: 0, killed: false},
+		{name: "hits from the front", immunity: 3, laser: backend.DirectionLeft, age: 0, killed: true},
+		{name: "hits from the side", immunity: 3, laser: backend.DirectionUp, age: 0, killed: true},
+		{name: "hits from behind after the immunity", immunity: 3, laser: backend.DirectionRight, age: 3, killed: true},
+		{name: "no immunity", immunity: 0, laser: backend.DirectionRight, age: 0, killed: true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			game := testutil.NewGame(
+				testutil.WithPlayerAt("alice", 0, 0),
+				testutil.WithPlayerAt("bob", 5, 5),
+			)
+			game.RearImmunity = test.immunity
+			alice := testutil.Player(game, "alice")
+			bob := testutil.Player(game, "bob")
+			moved := time.Now().Add(-time.Duration(test.age) * backend.CollisionCheckFrequency)
+			if result := game.PerformAction(backend.MoveAction{ID: alice.ID(), Direction: backend.DirectionRight, Created: moved}); result != backend.ActionAccepted {
+				t.Fatalf("got result %v, want alice to move", result)
+			}
+			game.AddEntity(&backend.Laser{
+				IdentifierBase:  backend.IdentifierBase{UUID: game.IDGenerator()},
+				InitialPosition: alice.Position(),
+				Direction:       test.laser,
+				StartTime:       time.Now(),
+				OwnerID:         bob.ID(),
+			})
+			game.Step()
+			if killed := len(game.RecentKills) == 1; killed != test.killed {
+				t.Errorf("got kills %+v, want killed %v", game.RecentKills, test.killed)
+			}
+		})
+	}
+}
+
+func TestLaserTeamColor(t *testing.T) {
+	tests := []struct {
+		name       string
+		team       int
+		teamColors []string
+		wantTeam   int
+		wantColor  string
+	}{
+		{name: "red team", team: 1, teamColors: backend.DefaultTeamColors, wantTeam: 1, wantColor: "red"},
+		{name: "blue team", team: 2, teamColors: backend.DefaultTeamColors, wantTeam: 2, wantColor: "blue"},
+		{name: "no team", team: 0, teamColors: backend.DefaultTeamColors, wantTeam: 0, wantColor: ""},
+		{name: "configured colors", team: 1, teamColors: []string{"green", "yellow"}, wantTeam: 1, wantColor: "green"},
+		{name: "teams without a color", team: 3, teamColors: backend.DefaultTeamColors, wantTeam: 3, wantColor: ""},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			game := testutil.NewGame(testutil.WithPlayerAt("alice", 0, 0))
+			game.TeamColors = test.teamColors
+			testutil.Player(game, "alice").Team = test.team
+			if result := game.PerformAction(fireAt(game, "alice", time.Now(), 0)[0]); result != backend.ActionAccepted {
+				t.Fatalf("got result %v, want the laser to be fired", result)
+			}
+			fired := testutil.Entities(game, &backend.Laser{})
+			if len(fired) != 1 {
+				t.Fatalf("got %d lasers, want one", len(fired))
+			}
+			laser := fired[0].(*backend.Laser)
+			if laser.Team != test.wantTeam {
+				t.Errorf("laser is on team %d, want %d", laser.Team, test.wantTeam)
+			}
+			if color := game.TeamColor(laser.Team); color != test.wantColor {
+				t.Errorf("laser is %q, want %q", color, test.wantColor)
+			}
+		})
+	}
+}
+
+func TestLaserMaxAge(t *testing.T) {
+	tests := []struct {
+		name      string
+		maxAge    time.Duration
+		age       time.Duration
+		despawned bool
+	}{
+		{name: "young lasers", maxAge: 10 * time.Second, age: 9 * time.Second, despawned: false},
+		{name: "old lasers", maxAge: 10 * time.Second, age: 10 * time.Second, despawned: true},
+		{name: "no max age", maxAge: 0, age: time.Hour, despawned: false},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			game := testutil.NewGame(testutil.WithPlayerAt("alice", 0, 0))
+			game.LaserMaxAge = test.maxAge
+			now := time.Now()
+			// The laser flies up into empty space, so it never hits anything.
+			laser := &backend.Laser{
+				IdentifierBase:  backend.IdentifierBase{UUID: game.IDGenerator()},
+				InitialPosition: backend.Coordinate{X: 3},
+				Direction:       backend.DirectionUp,
+				StartTime:       now.Add(-test.age),
+				OwnerID:         testutil.Player(game, "alice").ID(),
+			}
+			game.AddEntity(laser)
+			testutil.DrainChanges(game)
+			game.DespawnOldLasers(now)
+			if despawned := game.GetEntity(laser.ID()) == nil; despawned != test.despawned {
+				t.Fatalf("despawned is %v, want %v", despawned, test.despawned)
+			}
+			removed := false
+			for _, change := range testutil.DrainChanges(game) {
+				if change, ok := change.(backend.RemoveEntityChange); ok && change.Entity == laser {
+					removed = true
+				}
+			}
+			if removed != test.despawned {
+				t.Errorf("got a RemoveEntityChange %v, want %v", removed, test.despawned)
+			}
+		})
+	}
+}
+
+func TestSwapLaser(t *testing.T) {
+	tests := []struct {
+		name string
+		// wall is where a wall is built after the players got in place.
+		wall    *backend.Coordinate
+		swapped bool
+	}{
+		{name: "swaps positions", swapped: true},
+		{name: "shooters in walls", wall: &backend.Coordinate{}, swapped: false},
+		{name: "targets in walls", wall: &backend.Coordinate{X: 3}, swapped: false},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			game := testutil.NewGame(
+				testutil.WithPlayerAt("alice", 0, 0),
+				testutil.WithPlayerAt("bob", 3, 0),
+			)
+			alice := testutil.Player(game, "alice")
+			bob := testutil.Player(game, "bob")
+			if test.wall != nil {
+				testutil.WithWallAt(test.wall.X, test.wall.Y)(game)
+			}
+			laser := addStillLaser(game, alice.ID(), bob.Position())
+			laser.Swap = true
+			testutil.DrainChanges(game)
+			game.Step()
+			if len(game.RecentKills) != 0 || bob.Damage != 0 {
+				t.Errorf("got kills %+v and damage %d, want swap lasers not to hurt", game.RecentKills, bob.Damage)
+			}
+			wantAlice, wantBob := backend.Coordinate{}, backend.Coordinate{X: 3}
+			if test.swapped {
+				wantAlice, wantBob = wantBob, wantAlice
+			}
+			if alice.Position() != wantAlice || bob.Position() != wantBob {
+				t.Errorf("alice is at %+v and bob at %+v, want %+v and %+v", alice.Position(), bob.Position(), wantAlice, wantBob)
+			}
+			moves := map[string]backend.MoveChange{}
+			for _, change := range testutil.DrainChanges(game) {
+				if move, ok := change.(backend.MoveChange); ok {
+					moves[move.Entity.(*backend.Player).Name] = move
+				}
+			}
+			if !test.swapped {
+				if len(moves) != 0 {
+					t.Errorf("got moves %+v, want none", moves)
+				}
+				return
+			}
+			if move := moves["alice"]; !move.Teleport || move.From != wantBob || move.Position != wantAlice {
+				t.Errorf("got move %+v for alice, want a teleport to bob", move)
+			}
+			if move := moves["bob"]; !move.Teleport || move.From != wantAlice || move.Position != wantBob {
+				t.Errorf("got move %+v for bob, want a teleport to alice", move)
+			}
+		})
+	}
+}
+
+func TestFireSwapLaser(t *testing.T) {
+	game := testutil.NewGame(testutil.WithPlayerAt("alice", 0, 0))
+	alice := testutil.Player(game, "alice")
+	alice.Loadout = []backend.WeaponKind{backend.WeaponSwapLaser}
+	if result := game.PerformAction(backend.FireAction{PlayerID: alice.ID(), Direction: backend.DirectionUp}); result != backend.ActionAccepted {
+		t.Fatalf("got result %v, want the laser to be fired", result)
+	}
+	fired := testutil.Entities(game, &backend.Laser{})
+	if len(fired) != 1 {
+		t.Fatalf("got %d lasers, want one", len(fired))
+	}
+	if laser := fired[0].(*backend.Laser); !laser.Swap || laser.Piercing {
+		t.Errorf("got laser %+v, want a swap laser", laser)
+	}
+}
@@ -0,0 +1,58 @@
+package backend
+
+// Layered is an entity that should be drawn above or below other entities in
+// the same cell. Entities with higher z-indexes are drawn on top.
+type Layered interface {
+	ZIndex() int
+}
+
+// Z-indexes for built in entities. Walls are on top, as lasers that reach
+// them are about to be removed.
+const (
+	zIndexMine = iota + 1
+	zIndexPowerUp
+	zIndexHealthPack
+	zIndexLaser
+	zIndexPlayer
+	zIndexWall
+)
+
+// ZIndex returns an entity's z-index, which is zero if it is not Layered.
+func ZIndex(entity Identifier) int {
+	layered, ok := entity.(Layered)
+	if !ok {
+		return 0
+	}
+	return layered.ZIndex()
+}
+
+// ZIndex draws mines below everything else, so players standing on them are
+// drawn.
+func (mine *Mine) ZIndex() int {
+	return zIndexMine
+}
+
+// ZIndex draws power-ups above mines.
+func (powerUp *PowerUp) ZIndex() int {
+	return zIndexPowerUp
+}
+
+// ZIndex draws health packs above power-ups.
+func (healthPack *HealthPack) ZIndex() int {
+	return zIndexHealthPack
+}
+
+// ZIndex draws lasers above power-ups and health packs.
+func (laser *Laser) ZIndex() int {
+	return zIndexLaser
+}
+
+// ZIndex draws players above lasers and pickups.
+func (p *Player) ZIndex() int {
+	return zIndexPlayer
+}
+
+// ZIndex draws walls above everything else.
+func (wall *Wall) ZIndex() int {
+	return zIndexWall
+}
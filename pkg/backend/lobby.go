@@ -0,0 +1,146 @@
+package backend
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const lobbyCheckFrequency = 100 * time.Millisecond
+
+// Phase is a stage of a match. Games start in PhasePlaying unless they are
+// set to start in a lobby.
+type Phase int
+
+// Contains phase constants.
+const (
+	PhasePlaying Phase = iota
+	PhaseLobby
+	PhaseEnded
+)
+
+// PhaseChange occurs when the match moves to another phase.
+type PhaseChange struct {
+	Change
+	Phase Phase
+}
+
+// ReadyChange occurs when a player readies up in the lobby.
+type ReadyChange struct {
+	Change
+	Player *Player
+}
+
+// ReadyAction is sent when a player is ready for the match to start.
+type ReadyAction struct {
+	PlayerID uuid.UUID
+}
+
+// Perform marks the player as ready, and starts the match if every player is
+// ready.
+func (action ReadyAction) Perform(game *Game) ActionResult {
+	if game.Phase != PhaseLobby {
+		return ActionRejectedInvalid
+	}
+	player, ok := game.actingEntity(action.PlayerID).(*Player)
+	if !ok || player.Ready {
+		return ActionRejectedInvalid
+	}
+	player.Ready = true
+	game.sendChange(ReadyChange{
+		Player: player,
+	})
+	if game.IsAuthoritative {
+		game.checkLobby(time.Now())
+	}
+	return ActionAccepted
+}
+
+// canFight checks if combat is allowed, which it isn't in the lobby or once
+// the match has ended.
+func (game *Game) canFight() bool {
+	return game.Phase == PhasePlaying
+}
+
+// setPhase moves the match to a phase. The game should be locked by the
+// caller.
+func (game *Game) setPhase(phase Phase) {
+	if game.Phase == phase {
+		return
+	}
+	game.Phase = phase
+	// Rounds are timed from when combat starts.
+	if phase == PhasePlaying {
+		game.roundStarted = time.Now()
+		game.wakeCollisions()
+	}
+	game.sendChange(PhaseChange{
+		Phase: phase,
+	})
+}
+
+// EndMatch ends the match, after which combat is disabled. The game should be
+// locked by the caller.
+func (game *Game) EndMatch() {
+	game.setPhase(PhaseEnded)
+}
+
+// watchLobby starts the match once the lobby times out.
+func (game *Game) watchLobby() {
+	game.Mu.Lock()
+	game.lobbyStarted = time.Now()
+	game.Mu.Unlock()
+	for {
+		time.Sleep(lobbyCheckFrequency)
+		game.Mu.Lock()
+		game.checkLobby(time.Now())
+		playing := game.Phase != PhaseLobby
+		game.Mu.Unlock()
+		if playing {
+			return
+		}
+	}
+}
+
+// checkLobby starts the match if every human player is ready, or if the lobby
+// has timed out. Bots are always ready. The game should be locked by the
+// caller.
+func (game *Game) checkLobby(now time.Time) {
+	if game.Phase != PhaseLobby {
+		return
+	}
+	if game.LobbyTimeout > 0 && !game.lobbyStarted.IsZero() && now.Sub(game.lobbyStarted) >= game.LobbyTimeout {
+		game.setPhase(PhasePlaying)
+		return
+	}
+	humans := 0
+	for _, player := range game.Players() {
+		if player.Bot {
+			continue
+		}
+		if !player.Ready {
+			return
+		}
+		humans++
+	}
+	if humans > 0 {
+		game.setPhase(PhasePlaying)
+	}
+}
+
+// ReadyCount returns how many human players are ready, and how many there
+// are in total.
+func (game *Game) ReadyCount() (int, int) {
+	ready := 0
+	total := 0
+	for _, player := range game.Players() {
+		if player.Bot {
+			continue
+		}
+		total++
+		if player.Ready {
+			ready++
+		}
+	}
+	return ready, total
+}
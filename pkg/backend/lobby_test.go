@@ -0,0 +1,158 @@
+package backend_test
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/mortenson/grpc-game-example/pkg/backend"
+	"github.com/mortenson/grpc-game-example/pkg/backend/testutil"
+)
+
+// phaseChanges returns the phases the game announced moving to.
+func phaseChanges(game *backend.Game) []backend.Phase {
+	phases := []backend.Phase{}
+	for _, change := range testutil.DrainChanges(game) {
+		if change, ok := change.(backend.PhaseChange); ok {
+			phases = append(phases, change.Phase)
+		}
+	}
+	return phases
+}
+
+func TestReadyUp(t *testing.T) {
+	tests := []struct {
+		name  string
+		ready []string
+		// results are the results of each ready action.
+		results []backend.ActionResult
+		phase   backend.Phase
+	}{
+		{
+			name:    "nobody is ready",
+			ready:   []string{},
+			results: []backend.ActionResult{},
+			phase:   backend.PhaseLobby,
+		},
+		{
+			name:    "some players are ready",
+			ready:   []string{"alice"},
+			results: []backend.ActionResult{backend.ActionAccepted},
+			phase:   backend.PhaseLobby,
+		},
+		{
+			name:    "every player is ready",
+			ready:   []string{"alice", "bob"},
+			results: []backend.ActionResult{backend.ActionAccepted, backend.ActionAccepted},
+			phase:   backend.PhasePlaying,
+		},
+		{
+			name:    "players can only ready once",
+			ready:   []string{"alice", "alice"},
+			results: []backend.ActionResult{backend.ActionAccepted, backend.ActionRejectedInvalid},
+			phase:   backend.PhaseLobby,
+		},
+		{
+			name:    "bots can't ready outside the lobby",
+			ready:   []string{"alice", "bob", "bot"},
+			results: []backend.ActionResult{backend.ActionAccepted, backend.ActionAccepted, backend.ActionRejectedInvalid},
+			phase:   backend.PhasePlaying,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			game := testutil.NewGame(
+				testutil.WithPlayerAt("alice", 0, 0),
+				testutil.WithPlayerAt("bob", 2, 0),
+				testutil.WithPlayerAt("bot", -2, 0),
+			)
+			// Bots are always ready.
+			testutil.Player(game, "bot").Bot = true
+			game.Phase = backend.PhaseLobby
+			results := []backend.ActionResult{}
+			for _, name := range test.ready {
+				results = append(results, game.PerformAction(backend.ReadyAction{
+					PlayerID: testutil.Player(game, name).ID(),
+				}))
+			}
+			if !reflect.DeepEqual(results, test.results) {
+				t.Errorf("got results %v, want %v", results, test.results)
+			}
+			if game.Phase != test.phase {
+				t.Errorf("got phase %v, want %v", game.Phase, test.phase)
+			}
+			wantChanges := []backend.Phase{}
+			if test.phase == backend.PhasePlaying {
+				wantChanges = append(wantChanges, backend.PhasePlaying)
+			}
+			if changes := phaseChanges(game); !reflect.DeepEqual(changes, wantChanges) {
+				t.Errorf("got phase changes %v, want %v", changes, wantChanges)
+			}
+		})
+	}
+}
+
+func TestLobbyTimeout(t *testing.T) {
+	tests := []struct {
+		name    string
+		timeout time.Duration
+		elapsed time.Duration
+		phase   backend.Phase
+	}{
+		{name: "before the timeout", timeout: time.Minute, elapsed: 30 * time.Second, phase: backend.PhaseLobby},
+		{name: "after the timeout", timeout: time.Minute, elapsed: time.Minute, phase: backend.PhasePlaying},
+		{name: "without a timeout", timeout: 0, elapsed: time.Hour, phase: backend.PhaseLobby},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			game := testutil.NewGame(testutil.WithPlayerAt("alice", 0, 0))
+			game.Phase = backend.PhaseLobby
+			game.LobbyTimeout = test.timeout
+			now := time.Now()
+			game.CheckLobby(now.Add(-test.elapsed), now)
+			if game.Phase != test.phase {
+				t.Errorf("got phase %v, want %v", game.Phase, test.phase)
+			}
+		})
+	}
+}
+
+func TestLobbyCombat(t *testing.T) {
+	tests := []struct {
+		name   string
+		phase  backend.Phase
+		combat bool
+	}{
+		{name: "lobby", phase: backend.PhaseLobby, combat: false},
+		{name: "playing", phase: backend.PhasePlaying, combat: true},
+		{name: "ended", phase: backend.PhaseEnded, combat: false},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			config := testutil.MapConfig(
+				"S    ",
+				"  ~  ",
+				"    S",
+			)
+			game := testutil.NewGameFromConfig(t, config,
+				testutil.WithPlayerAt("alice", 0, 0),
+				testutil.WithPlayerAt("bob", 2, 1),
+			)
+			game.Phase = test.phase
+			alice := testutil.Player(game, "alice")
+			bob := testutil.Player(game, "bob")
+			result := game.PerformAction(backend.FireAction{PlayerID: bob.ID(), Direction: backend.DirectionUp})
+			if fired := result == backend.ActionAccepted; fired != test.combat {
+				t.Errorf("fired is %v, want %v", fired, test.combat)
+			}
+			game.ApplyHazardDamage()
+			if damaged := alice.Damage > 0; damaged != test.combat {
+				t.Errorf("damaged by hazards is %v, want %v", damaged, test.combat)
+			}
+			laserKill(game, alice, bob)
+			if scored := game.Score[bob.ID()] > 0; scored != test.combat {
+				t.Errorf("scored is %v, want %v", scored, test.combat)
+			}
+		})
+	}
+}
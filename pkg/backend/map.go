@@ -7,6 +7,7 @@ const (
 	MapTypeNone MapType = iota
 	MapTypeWall
 	MapTypeSpawn
+	MapTypeHazard
 )
 
 // GetMapByType returns a map of map types to sets to coordinates.
@@ -17,13 +18,7 @@ func (game *Game) GetMapByType() map[MapType][]Coordinate {
 	symbols := make(map[MapType][]Coordinate, 0)
 	for mapY, row := range game.gameMap {
 		for mapX, col := range row {
-			mapType := MapTypeNone
-			switch col {
-			case '█':
-				mapType = MapTypeWall
-			case 'S':
-				mapType = MapTypeSpawn
-			}
+			mapType := getMapType(col)
 			symbols[mapType] = append(symbols[mapType], Coordinate{
 				X: mapX - mapCenterX,
 				Y: mapY - mapCenterY,
@@ -33,11 +28,56 @@ func (game *Game) GetMapByType() map[MapType][]Coordinate {
 	return symbols
 }
 
+// mapTypeAt returns the map type of a cell, which is MapTypeNone outside of
+// the map.
+func (game *Game) mapTypeAt(cell Coordinate) MapType {
+	min, max := game.Bounds()
+	if cell.X < min.X || cell.X > max.X || cell.Y < min.Y || cell.Y > max.Y {
+		return MapTypeNone
+	}
+	return getMapType(game.gameMap[cell.Y-min.Y][cell.X-min.X])
+}
+
+// getMapType returns the map type of a map symbol.
+func getMapType(symbol rune) MapType {
+	switch symbol {
+	case '█':
+		return MapTypeWall
+	case 'S':
+		return MapTypeSpawn
+	case '~':
+		return MapTypeHazard
+	}
+	return MapTypeNone
+}
+
 // GetMapDimensions returns the dimensions of the map.
 func (game *Game) GetMapDimensions() (int, int) {
 	return len(game.gameMap[0]), len(game.gameMap)
 }
 
+// Bounds returns the top left and bottom right coordinates of the map.
+func (game *Game) Bounds() (Coordinate, Coordinate) {
+	width, height := game.GetMapDimensions()
+	min := Coordinate{
+		X: -(width / 2),
+		Y: -(height / 2),
+	}
+	max := Coordinate{
+		X: min.X + width - 1,
+		Y: min.Y + height - 1,
+	}
+	return min, max
+}
+
+// Contains the default limits on the size of maps in configs, so that huge
+// maps can't exhaust the server's memory.
+const (
+	defaultMaxMapWidth  = 500
+	defaultMaxMapHeight = 500
+	defaultMaxMapCells  = 100000
+)
+
 // MapDefault is the default map used by the game.
 var MapDefault = [][]rune{
 	{'█', '█', '█', '█', '█', '█', '█', '█', '█', '█', '█', '█', '█', '█', '█', '█', '█', '█', '█', '█', '█', '█', '█', '█', '█', '█', '█', '█', '█', '█', '█', '█', '█', '█', '█', '█', '█', '█', '█', '█'},
@@ -81,3 +121,96 @@ var MapDefault = [][]rune{
 	{'█', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', '█'},
 	{'█', '█', '█', '█', '█', '█', '█', '█', '█', '█', '█', '█', '█', '█', '█', '█', '█', '█', '█', '█', '█', '█', '█', '█', '█', '█', '█', '█', '█', '█', '█', '█', '█', '█', '█', '█', '█', '█', '█', '█'},
 }
+
+// MinimapCell describes what is shown in a cell of a minimap.
+type MinimapCell int
+
+// Contains minimap cell constants, in order of importance. When multiple
+// things are in the same cell, the most important one is shown.
+const (
+	MinimapCellEmpty MinimapCell = iota
+	MinimapCellWall
+	MinimapCellLaser
+	MinimapCellPlayer
+)
+
+// Minimap is a scaled down view of the map and entities.
+type Minimap struct {
+	Width  int
+	Height int
+	// Cells are indexed by row (y), then column (x).
+	Cells     [][]MinimapCell
+	boundsMin Coordinate
+	mapWidth  int
+	mapHeight int
+}
+
+// Locate returns the minimap cell a position falls in. Positions outside of
+// the map return cells outside of the minimap.
+func (minimap Minimap) Locate(position Coordinate) (int, int) {
+	return scaleOffset(position.X-minimap.boundsMin.X, minimap.Width, minimap.mapWidth),
+		scaleOffset(position.Y-minimap.boundsMin.Y, minimap.Height, minimap.mapHeight)
+}
+
+// scaleOffset scales an offset from the edge of the map to a minimap of the
+// given size, rounding down so that negative offsets stay negative.
+func scaleOffset(offset, size, mapSize int) int {
+	scaled := offset * size
+	if scaled < 0 {
+		return (scaled - mapSize + 1) / mapSize
+	}
+	return scaled / mapSize
+}
+
+// Minimap returns a minimap of the given dimensions.
+func (game *Game) Minimap(width, height int) Minimap {
+	return game.FoggedMinimap(width, height, nil)
+}
+
+// FoggedMinimap returns a minimap of the given dimensions that only shows
+// entities in visible cells, such as those from VisibleCells. Walls are always
+// shown. A nil set of visible cells shows every entity.
+func (game *Game) FoggedMinimap(width, height int, visible map[Coordinate]bool) Minimap {
+	mapWidth, mapHeight := game.GetMapDimensions()
+	boundsMin, _ := game.Bounds()
+	minimap := Minimap{
+		Width:     width,
+		Height:    height,
+		Cells:     make([][]MinimapCell, height),
+		boundsMin: boundsMin,
+		mapWidth:  mapWidth,
+		mapHeight: mapHeight,
+	}
+	for y := range minimap.Cells {
+		minimap.Cells[y] = make([]MinimapCell, width)
+	}
+	mark := func(position Coordinate, cell MinimapCell) {
+		x, y := minimap.Locate(position)
+		if x < 0 || x >= width || y < 0 || y >= height {
+			return
+		}
+		if cell > minimap.Cells[y][x] {
+			minimap.Cells[y][x] = cell
+		}
+	}
+	for _, wall := range game.GetMapByType()[MapTypeWall] {
+		mark(wall, MinimapCellWall)
+	}
+	for _, entity := range game.Entities {
+		switch entity.(type) {
+		case *Player:
+			position := entity.(*Player).Position()
+			if visible == nil || visible[position] {
+				mark(position, MinimapCellPlayer)
+			}
+		case *Laser:
+			position := entity.(*Laser).Position()
+			if visible == nil || visible[position] {
+				mark(position, MinimapCellLaser)
+			}
+		case *Wall:
+			mark(entity.(*Wall).Position(), MinimapCellWall)
+		}
+	}
+	return minimap
+}
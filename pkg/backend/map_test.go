@@ -0,0 +1,112 @@
+package backend_test
+
+import (
+	"testing"
+
+	"github.com/mortenson/grpc-game-example/pkg/backend"
+	"github.com/mortenson/grpc-game-example/pkg/backend/testutil"
+)
+
+func TestBounds(t *testing.T) {
+	tests := []struct {
+		name string
+		rows []string
+		min  backend.Coordinate
+		max  backend.Coordinate
+	}{
+		{name: "one cell", rows: []string{"S"}, min: backend.Coordinate{}, max: backend.Coordinate{}},
+		{
+			name: "even dimensions",
+			rows: []string{"S   ", "    "},
+			min:  backend.Coordinate{X: -2, Y: -1},
+			max:  backend.Coordinate{X: 1, Y: 0},
+		},
+		{
+			name: "odd dimensions",
+			rows: []string{"S  ", "   ", "   "},
+			min:  backend.Coordinate{X: -1, Y: -1},
+			max:  backend.Coordinate{X: 1, Y: 1},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			game := testutil.NewGameFromConfig(t, testutil.MapConfig(test.rows...))
+			min, max := game.Bounds()
+			if min != test.min || max != test.max {
+				t.Errorf("got bounds %+v to %+v, want %+v to %+v", min, max, test.min, test.max)
+			}
+		})
+	}
+}
+
+func TestMinimap(t *testing.T) {
+	// The map spans from (-2, -1) to (1, 0), so each minimap cell covers two
+	// columns and one row.
+	rows := []string{
+		"█  S",
+		"    ",
+	}
+	tests := []struct {
+		name     string
+		entities func(game *backend.Game)
+		want     [][]backend.MinimapCell
+	}{
+		{
+			name:     "map walls",
+			entities: func(game *backend.Game) {},
+			want: [][]backend.MinimapCell{
+				{backend.MinimapCellWall, backend.MinimapCellEmpty},
+				{backend.MinimapCellEmpty, backend.MinimapCellEmpty},
+			},
+		},
+		{
+			name: "players, lasers, and wall entities",
+			entities: func(game *backend.Game) {
+				testutil.WithPlayerAt("alice", 1, 0)(game)
+				addStillLaser(game, game.IDGenerator(), backend.Coordinate{X: 0, Y: -1})
+				testutil.WithWallAt(-2, 0)(game)
+			},
+			want: [][]backend.MinimapCell{
+				{backend.MinimapCellWall, backend.MinimapCellLaser},
+				{backend.MinimapCellWall, backend.MinimapCellPlayer},
+			},
+		},
+		{
+			name: "players are shown over walls and lasers",
+			entities: func(game *backend.Game) {
+				testutil.WithPlayerAt("alice", -1, -1)(game)
+				addStillLaser(game, game.IDGenerator(), backend.Coordinate{X: 1, Y: -1})
+				testutil.WithPlayerAt("bob", 0, -1)(game)
+			},
+			want: [][]backend.MinimapCell{
+				{backend.MinimapCellPlayer, backend.MinimapCellPlayer},
+				{backend.MinimapCellEmpty, backend.MinimapCellEmpty},
+			},
+		},
+		{
+			name: "entities outside of the map are ignored",
+			entities: func(game *backend.Game) {
+				testutil.WithPlayerAt("alice", 5, 5)(game)
+				testutil.WithPlayerAt("bob", -3, 0)(game)
+			},
+			want: [][]backend.MinimapCell{
+				{backend.MinimapCellWall, backend.MinimapCellEmpty},
+				{backend.MinimapCellEmpty, backend.MinimapCellEmpty},
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			game := testutil.NewGameFromConfig(t, testutil.MapConfig(rows...))
+			test.entities(game)
+			minimap := game.Minimap(2, 2)
+			for y, row := range test.want {
+				for x, want := range row {
+					if cell := minimap.Cells[y][x]; cell != want {
+						t.Errorf("cell (%d, %d) is %v, want %v", x, y, cell, want)
+					}
+				}
+			}
+		})
+	}
+}
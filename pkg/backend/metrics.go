@@ -0,0 +1,52 @@
+package backend
+
+import (
+	"github.com/google/uuid"
+)
+
+// Metrics contains optional callbacks that report on what the engine is
+// doing, so that operators can monitor games. Callbacks are called with the
+// game locked.
+type Metrics struct {
+	// CollisionCheck is called after each collision check.
+	CollisionCheck func()
+	// UnknownEntityAction is called when an action is performed for an
+	// entity that doesn't exist, such as when clients act on stale entities.
+	UnknownEntityAction func()
+	// StatsStoreError is called when career stats can't be recorded.
+	StatsStoreError func(err error)
+}
+
+// collisionCheck reports a collision check, if there is a callback for it.
+func (metrics Metrics) collisionCheck() {
+	if metrics.CollisionCheck != nil {
+		metrics.CollisionCheck()
+	}
+}
+
+// unknownEntityAction reports an action for an unknown entity, if there is a
+// callback for it.
+func (metrics Metrics) unknownEntityAction() {
+	if metrics.UnknownEntityAction != nil {
+		metrics.UnknownEntityAction()
+	}
+}
+
+// statsStoreError reports an error recording career stats, if there is a
+// callback for it.
+func (metrics Metrics) statsStoreError(err error) {
+	if metrics.StatsStoreError != nil {
+		metrics.StatsStoreError(err)
+	}
+}
+
+// actingEntity gets the entity an action is performed for. If it doesn't
+// exist, nil is returned and it is reported to the game's metrics, so all
+// actions should use this to guard against unknown entities.
+func (game *Game) actingEntity(id uuid.UUID) Identifier {
+	entity := game.GetEntity(id)
+	if entity == nil {
+		game.Metrics.unknownEntityAction()
+	}
+	return entity
+}
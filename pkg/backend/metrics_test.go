@@ -0,0 +1,86 @@
+package backend_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mortenson/grpc-game-example/pkg/backend"
+	"github.com/mortenson/grpc-game-example/pkg/backend/testutil"
+)
+
+func TestUnknownEntityActions(t *testing.T) {
+	tests := []struct {
+		name  string
+		phase backend.Phase
+		// action returns an action for an entity that doesn't exist.
+		action func(id uuid.UUID) backend.Action
+	}{
+		{
+			name: "move",
+			action: func(id uuid.UUID) backend.Action {
+				return backend.MoveAction{ID: id, Direction: backend.DirectionUp, Created: time.Now()}
+			},
+		},
+		{
+			name: "dash",
+			action: func(id uuid.UUID) backend.Action {
+				return backend.DashAction{PlayerID: id, Created: time.Now()}
+			},
+		},
+		{
+			name: "laser",
+			action: func(id uuid.UUID) backend.Action {
+				return backend.LaserAction{ID: uuid.New(), OwnerID: id, Direction: backend.DirectionUp, Created: time.Now()}
+			},
+		},
+		{
+			name: "fire",
+			action: func(id uuid.UUID) backend.Action {
+				return backend.FireAction{PlayerID: id}
+			},
+		},
+		{
+			name: "switch weapon",
+			action: func(id uuid.UUID) backend.Action {
+				return backend.SwitchWeaponAction{PlayerID: id}
+			},
+		},
+		{
+			name: "deploy",
+			action: func(id uuid.UUID) backend.Action {
+				return backend.DeployAction{ID: uuid.New(), PlayerID: id}
+			},
+		},
+		{
+			name:  "ready",
+			phase: backend.PhaseLobby,
+			action: func(id uuid.UUID) backend.Action {
+				return backend.ReadyAction{PlayerID: id}
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			game := testutil.NewGame(testutil.WithPlayerAt("alice", 0, 0))
+			game.Phase = test.phase
+			unknown := 0
+			game.Metrics.UnknownEntityAction = func() {
+				unknown++
+			}
+			for i := 0; i < 5; i++ {
+				if result := game.PerformAction(test.action(uuid.New())); result != backend.ActionRejectedInvalid {
+					t.Errorf("got result %v, want the action to be rejected", result)
+				}
+			}
+			if unknown != 5 {
+				t.Errorf("the metric fired %d times, want 5", unknown)
+			}
+			// Actions for known entities aren't reported.
+			game.PerformAction(test.action(testutil.Player(game, "alice").ID()))
+			if unknown != 5 {
+				t.Errorf("the metric fired for a known entity")
+			}
+		})
+	}
+}
@@ -0,0 +1,179 @@
+package backend
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	defaultMaxMinesPerPlayer = 3
+	mineDamage               = 50
+	// mineRadius is how close, in cells, an enemy has to be to trigger a
+	// mine, and how far its explosion reaches. Diagonal cells are adjacent.
+	mineRadius = 1
+)
+
+// Mine is a deployable that players place where they stand. It explodes when
+// an enemy steps next to it, damaging every player nearby except its owner and
+// their teammates.
+type Mine struct {
+	IdentifierBase
+	Positioner
+	CurrentPosition Coordinate
+	OwnerID         uuid.UUID
+}
+
+// Position determines the mine position.
+func (mine *Mine) Position() Coordinate {
+	return mine.CurrentPosition
+}
+
+// Explosion is a mine going off, and is kept so that frontends can animate
+// it.
+type Explosion struct {
+	Position Coordinate
+	Radius   int
+	Time     time.Time
+}
+
+// ExplosionChange occurs when a mine explodes.
+type ExplosionChange struct {
+	Change
+	Explosion Explosion
+}
+
+// DeployAction is sent when a player places a mine at their position. If ID
+// is uuid.Nil, the mine's ID is chosen by the engine.
+type DeployAction struct {
+	ID       uuid.UUID
+	PlayerID uuid.UUID
+}
+
+// Perform places a mine under the player.
+func (action DeployAction) Perform(game *Game) ActionResult {
+	if !game.canFight() {
+		return ActionRejectedInvalid
+	}
+	player, ok := game.actingEntity(action.PlayerID).(*Player)
+	if !ok || player.Frozen {
+		return ActionRejectedInvalid
+	}
+	position := player.Position()
+	for _, entity := range game.getCollisionMap()[position] {
+		if _, ok := entity.(*Mine); ok {
+			return ActionRejectedInvalid
+		}
+	}
+	if game.MaxMinesPerPlayer > 0 && game.countMines(action.PlayerID) >= game.MaxMinesPerPlayer {
+		return ActionRejectedThrottled
+	}
+	id := action.ID
+	if id == uuid.Nil {
+		id = game.IDGenerator()
+	}
+	mine := &Mine{
+		IdentifierBase:  IdentifierBase{id},
+		CurrentPosition: position,
+		OwnerID:         action.PlayerID,
+	}
+	if game.AddEntity(mine) != nil {
+		return ActionRejectedInvalid
+	}
+	game.sendChange(AddEntityChange{
+		Entity: mine,
+	})
+	return ActionAccepted
+}
+
+// countMines counts the mines in play that were placed by a player.
+func (game *Game) countMines(ownerID uuid.UUID) int {
+	count := 0
+	for _, entity := range game.Entities {
+		mine, ok := entity.(*Mine)
+		if ok && mine.OwnerID == ownerID {
+			count++
+		}
+	}
+	return count
+}
+
+// AddExplosion records an explosion for frontends to animate, forgetting the
+// oldest explosions once there are more than recentKillLimit.
+func (game *Game) AddExplosion(explosion Explosion) {
+	game.RecentExplosions = append(game.RecentExplosions, explosion)
+	if len(game.RecentExplosions) > recentKillLimit {
+		game.RecentExplosions = game.RecentExplosions[len(game.RecentExplosions)-recentKillLimit:]
+	}
+}
+
+// triggerMines explodes mines that an enemy is next to. Players with spawn
+// protection don't trigger mines. Mines are decided by authoritative games.
+// The game should be locked by the caller.
+func (game *Game) triggerMines(now time.Time) {
+	if !game.IsAuthoritative || !game.canFight() {
+		return
+	}
+	mines := []*Mine{}
+	for _, entity := range game.Entities {
+		if mine, ok := entity.(*Mine); ok {
+			mines = append(mines, mine)
+		}
+	}
+	for _, mine := range mines {
+		if !game.isLive(mine) {
+			continue
+		}
+		for _, player := range game.Players() {
+			if game.inMineRange(mine, player, now) {
+				game.explodeMine(mine, now)
+				break
+			}
+		}
+	}
+}
+
+// inMineRange checks if a player is close enough to be hurt by a mine.
+// Owners and their teammates are never hurt by their mines.
+func (game *Game) inMineRange(mine *Mine, player *Player, now time.Time) bool {
+	if player.ID() == mine.OwnerID || player.Protected(now) {
+		return false
+	}
+	if owner, ok := game.GetEntity(mine.OwnerID).(*Player); ok && areTeammates(owner, player) {
+		return false
+	}
+	return mine.Position().ChebyshevDistance(player.Position()) <= mineRadius
+}
+
+// explodeMine removes a mine and damages the players around it. Players
+// killed by the explosion are credited to the mine's owner.
+func (game *Game) explodeMine(mine *Mine, now time.Time) {
+	game.sendChange(RemoveEntityChange{
+		Entity: mine,
+	})
+	game.RemoveEntity(mine.ID())
+	explosion := Explosion{
+		Position: mine.CurrentPosition,
+		Radius:   mineRadius,
+		Time:     now,
+	}
+	game.AddExplosion(explosion)
+	game.sendChange(ExplosionChange{
+		Explosion: explosion,
+	})
+	for _, player := range game.Players() {
+		if !game.inMineRange(mine, player, now) {
+			continue
+		}
+		player.Damage += game.mineDamage
+		player.LastHit = now
+		game.sendChange(DamageChange{
+			Player: player,
+			Amount: game.mineDamage,
+		})
+		if player.Health() > 0 {
+			continue
+		}
+		game.kill(player, mine.OwnerID)
+	}
+}
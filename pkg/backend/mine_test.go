@@ -0,0 +1,195 @@
+package backend_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/mortenson/grpc-game-example/pkg/backend"
+	"github.com/mortenson/grpc-game-example/pkg/backend/testutil"
+)
+
+// addMine places a mine owned by a player at a position.
+func addMine(game *backend.Game, owner *backend.Player, position backend.Coordinate) *backend.Mine {
+	mine := &backend.Mine{
+		IdentifierBase:  backend.IdentifierBase{UUID: game.IDGenerator()},
+		CurrentPosition: position,
+		OwnerID:         owner.ID(),
+	}
+	game.AddEntity(mine)
+	return mine
+}
+
+func TestDeployAction(t *testing.T) {
+	tests := []struct {
+		name     string
+		maxMines int
+		// placed are positions of mines alice already placed.
+		placed []backend.Coordinate
+		result backend.ActionResult
+	}{
+		{name: "placed under the player", maxMines: 3, result: backend.ActionAccepted},
+		{
+			name:     "under the limit",
+			maxMines: 3,
+			placed:   []backend.Coordinate{{X: 5}, {X: 6}},
+			result:   backend.ActionAccepted,
+		},
+		{
+			name:     "at the limit",
+			maxMines: 3,
+			placed:   []backend.Coordinate{{X: 5}, {X: 6}, {X: 7}},
+			result:   backend.ActionRejectedThrottled,
+		},
+		{
+			name:     "no limit",
+			maxMines: 0,
+			placed:   []backend.Coordinate{{X: 5}, {X: 6}, {X: 7}},
+			result:   backend.ActionAccepted,
+		},
+		{
+			name:     "one mine per cell",
+			maxMines: 3,
+			placed:   []backend.Coordinate{{}},
+			result:   backend.ActionRejectedInvalid,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			game := testutil.NewGame(testutil.WithPlayerAt("alice", 0, 0))
+			game.MaxMinesPerPlayer = test.maxMines
+			alice := testutil.Player(game, "alice")
+			for _, position := range test.placed {
+				addMine(game, alice, position)
+			}
+			testutil.DrainChanges(game)
+			if result := game.PerformAction(backend.DeployAction{PlayerID: alice.ID()}); result != test.result {
+				t.Fatalf("got result %v, want %v", result, test.result)
+			}
+			changes := testutil.DrainChanges(game)
+			if test.result != backend.ActionAccepted {
+				if len(testutil.Entities(game, &backend.Mine{})) != len(test.placed) || len(changes) != 0 {
+					t.Errorf("got mines %+v and changes %+v, want none to be placed", testutil.Entities(game, &backend.Mine{}), changes)
+				}
+				return
+			}
+			if len(testutil.Entities(game, &backend.Mine{})) != len(test.placed)+1 {
+				t.Fatalf("got %d mines, want a mine to be placed", len(testutil.Entities(game, &backend.Mine{})))
+			}
+			if len(changes) != 1 {
+				t.Fatalf("got changes %+v, want the mine to be added", changes)
+			}
+			change, ok := changes[0].(backend.AddEntityChange)
+			if !ok {
+				t.Fatalf("got change %+v, want the mine to be added", changes[0])
+			}
+			mine, ok := change.Entity.(*backend.Mine)
+			if !ok || mine.Position() != alice.Position() || mine.OwnerID != alice.ID() {
+				t.Errorf("got %+v, want a mine owned by alice at the player's position", change.Entity)
+			}
+		})
+	}
+}
+
+func TestMineTriggers(t *testing.T) {
+	tests := []struct {
+		name string
+		// bob is where bob is, with alice's mine at the origin.
+		bob           backend.Coordinate
+		bobDamage     int
+		protected     bool
+		teammates     bool
+		modifier      string
+		authoritative bool
+		exploded      bool
+		killed        bool
+	}{
+		{name: "enemies next to mines", bob: backend.Coordinate{X: 1}, authoritative: true, exploded: true},
+		{name: "diagonals are next to mines", bob: backend.Coordinate{X: -1, Y: 1}, authoritative: true, exploded: true},
+		{name: "enemies on mines", bob: backend.Coordinate{}, authoritative: true, exploded: true},
+		{name: "enemies further away", bob: backend.Coordinate{X: 2}, authoritative: true, exploded: false},
+		{name: "protected enemies", bob: backend.Coordinate{X: 1}, protected: true, authoritative: true, exploded: false},
+		{name: "left for the server", bob: backend.Coordinate{X: 1}, authoritative: false, exploded: false},
+		{name: "teammates next to mines", bob: backend.Coordinate{X: 1}, teammates: true, authoritative: true, exploded: false},
+		{name: "double damage kills outright", bob: backend.Coordinate{X: 1}, modifier: "double damage", authoritative: true, exploded: true, killed: true},
+		{
+			name:          "enemies killed by explosions",
+			bob:           backend.Coordinate{X: 1},
+			bobDamage:     backend.MaxHealth - 1,
+			authoritative: true,
+			exploded:      true,
+			killed:        true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			game := testutil.NewGame(
+				testutil.WithPlayerAt("alice", 0, -1),
+				testutil.WithPlayerAt("bob", test.bob.X, test.bob.Y),
+			)
+			game.IsAuthoritative = test.authoritative
+			alice := testutil.Player(game, "alice")
+			bob := testutil.Player(game, "bob")
+			bob.Damage = test.bobDamage
+			if test.protected {
+				bob.ProtectedUntil = time.Now().Add(time.Minute)
+			}
+			if test.teammates {
+				alice.Team = 1
+				bob.Team = 1
+			}
+			damage := 50
+			if test.modifier != "" {
+				game.ApplyModifier(test.modifier)
+				damage *= 2
+			}
+			mine := addMine(game, alice, backend.Coordinate{})
+			testutil.DrainChanges(game)
+			game.Step()
+			if exploded := game.GetEntity(mine.ID()) == nil; exploded != test.exploded {
+				t.Fatalf("exploded is %v, want %v", exploded, test.exploded)
+			}
+			if alice.Damage != 0 {
+				t.Errorf("alice has %d damage, want owners to be immune", alice.Damage)
+			}
+			explosions := 0
+			for _, change := range testutil.DrainChanges(game) {
+				if change, ok := change.(backend.ExplosionChange); ok && change.Explosion.Position == mine.Position() {
+					explosions++
+				}
+			}
+			if !test.exploded {
+				if bob.Damage != test.bobDamage || explosions != 0 {
+					t.Errorf("bob has %d damage and got %d explosions, want neither", bob.Damage, explosions)
+				}
+				return
+			}
+			if explosions != 1 || len(game.RecentExplosions) != 1 {
+				t.Errorf("got %d ExplosionChanges and %d explosions, want one", explosions, len(game.RecentExplosions))
+			}
+			killed := len(game.RecentKills) == 1 && game.RecentKills[0].KillerID == alice.ID()
+			if killed != test.killed {
+				t.Fatalf("got kills %+v, want killed by alice %v", game.RecentKills, test.killed)
+			}
+			if !test.killed && bob.Damage != test.bobDamage+damage {
+				t.Errorf("bob has %d damage, want the explosion to deal %d", bob.Damage, damage)
+			}
+		})
+	}
+}
+
+func TestOwnersDontTriggerMines(t *testing.T) {
+	game := testutil.NewGame(
+		testutil.WithPlayerAt("alice", 0, 0),
+		testutil.WithPlayerAt("bob", 5, 5),
+	)
+	alice := testutil.Player(game, "alice")
+	if result := game.PerformAction(backend.DeployAction{PlayerID: alice.ID()}); result != backend.ActionAccepted {
+		t.Fatalf("got result %v, want the mine to be placed", result)
+	}
+	game.Step()
+	game.MoveEntity(alice, backend.Coordinate{X: 1})
+	game.Step()
+	if len(testutil.Entities(game, &backend.Mine{})) != 1 || alice.Damage != 0 {
+		t.Errorf("got mines %+v and damage %d, want alice's mine to stay", testutil.Entities(game, &backend.Mine{}), alice.Damage)
+	}
+}
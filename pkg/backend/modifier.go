@@ -0,0 +1,69 @@
+package backend
+
+// Modifier changes a match's tunables for variety, such as making lasers fire
+// faster.
+type Modifier struct {
+	Name  string
+	Apply func(game *Game)
+}
+
+// DefaultModifiers are the modifiers matches can start with by default.
+var DefaultModifiers = []Modifier{
+	{
+		Name: "rapid fire",
+		Apply: func(game *Game) {
+			game.laserThrottle /= 2
+		},
+	},
+	{
+		Name: "fast movement",
+		Apply: func(game *Game) {
+			game.moveThrottle /= 2
+		},
+	},
+	{
+		Name: "double damage",
+		Apply: func(game *Game) {
+			game.hazardDamage *= 2
+			game.mineDamage *= 2
+		},
+	},
+}
+
+// ModifierChange occurs when a modifier is applied to the match.
+type ModifierChange struct {
+	Change
+	Name string
+}
+
+// ApplyModifier applies a modifier from the game's registry by name, and
+// returns false if there is no such modifier. A modifier that is already
+// active is not applied again, so that its effect isn't doubled. The game
+// should be locked by the caller.
+func (game *Game) ApplyModifier(name string) bool {
+	for _, modifier := range game.Modifiers {
+		if modifier.Name != name {
+			continue
+		}
+		if game.Modifier == name {
+			return true
+		}
+		modifier.Apply(game)
+		game.Modifier = name
+		game.sendChange(ModifierChange{
+			Name: name,
+		})
+		return true
+	}
+	return false
+}
+
+// applyRandomModifier applies a modifier chosen from the game's registry
+// using the game's random source. The game should be locked by the caller.
+func (game *Game) applyRandomModifier() {
+	if len(game.Modifiers) == 0 {
+		return
+	}
+	modifier := game.Modifiers[game.Rand.Intn(len(game.Modifiers))]
+	game.ApplyModifier(modifier.Name)
+}
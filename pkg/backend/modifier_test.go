@@ -0,0 +1,110 @@
+package backend_test
+
+import (
+	"math/rand"
+	"reflect"
+	"testing"
+
+	"github.com/mortenson/grpc-game-example/pkg/backend"
+	"github.com/mortenson/grpc-game-example/pkg/backend/testutil"
+)
+
+// modifierChanges returns the names of the modifiers announced by the game.
+func modifierChanges(game *backend.Game) []string {
+	names := []string{}
+	for _, change := range testutil.DrainChanges(game) {
+		if change, ok := change.(backend.ModifierChange); ok {
+			names = append(names, change.Name)
+		}
+	}
+	return names
+}
+
+func TestRandomModifier(t *testing.T) {
+	tests := []struct {
+		name    string
+		random  bool
+		seed    int64
+		applied bool
+	}{
+		{name: "seed 1", random: true, seed: 1, applied: true},
+		{name: "seed 2", random: true, seed: 2, applied: true},
+		{name: "seed 3", random: true, seed: 3, applied: true},
+		{name: "disabled", random: false, seed: 1, applied: false},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			modifiers := []string{}
+			for i := 0; i < 2; i++ {
+				game := testutil.NewGame()
+				game.Rand = rand.New(rand.NewSource(test.seed))
+				game.RandomModifier = test.random
+				game.Start()
+				changes := modifierChanges(game)
+				if !test.applied {
+					if game.Modifier != "" || len(changes) != 0 {
+						t.Fatalf("got modifier %q and changes %v, want none", game.Modifier, changes)
+					}
+					return
+				}
+				if len(changes) != 1 || changes[0] != game.Modifier {
+					t.Fatalf("got changes %v, want %q to be announced", changes, game.Modifier)
+				}
+				modifiers = append(modifiers, game.Modifier)
+			}
+			// Games with the same seed select the same modifier.
+			if modifiers[0] != modifiers[1] {
+				t.Errorf("got modifiers %q and %q for the same seed", modifiers[0], modifiers[1])
+			}
+		})
+	}
+}
+
+func TestApplyModifier(t *testing.T) {
+	tests := []struct {
+		name      string
+		modifiers []string
+		ok        bool
+		// damage is the hazard damage taken, relative to an unmodified game.
+		damage  int
+		changes []string
+	}{
+		{name: "double damage", modifiers: []string{"double damage"}, ok: true, damage: 2, changes: []string{"double damage"}},
+		{name: "applied once", modifiers: []string{"double damage", "double damage"}, ok: true, damage: 2, changes: []string{"double damage"}},
+		{name: "other modifiers", modifiers: []string{"rapid fire"}, ok: true, damage: 1, changes: []string{"rapid fire"}},
+		{name: "unknown modifiers", modifiers: []string{"low gravity"}, ok: false, damage: 1, changes: []string{}},
+	}
+	hazardDamage := func(modifiers []string) (int, []string, bool) {
+		config := testutil.MapConfig(
+			"S    ",
+			"  ~  ",
+			"     ",
+		)
+		game := testutil.NewGameFromConfig(t, config, testutil.WithPlayerAt("alice", 0, 0))
+		ok := true
+		for _, name := range modifiers {
+			ok = game.ApplyModifier(name) && ok
+		}
+		changes := modifierChanges(game)
+		game.ApplyHazardDamage()
+		return testutil.Player(game, "alice").Damage, changes, ok
+	}
+	base, _, _ := hazardDamage(nil)
+	if base == 0 {
+		t.Fatal("hazards did no damage")
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			damage, changes, ok := hazardDamage(test.modifiers)
+			if ok != test.ok {
+				t.Errorf("got ok %v, want %v", ok, test.ok)
+			}
+			if damage != base*test.damage {
+				t.Errorf("got damage %d, want %d", damage, base*test.damage)
+			}
+			if !reflect.DeepEqual(changes, test.changes) {
+				t.Errorf("got changes %v, want %v", changes, test.changes)
+			}
+		})
+	}
+}
@@ -0,0 +1,104 @@
+package backend
+
+import (
+	"github.com/google/uuid"
+)
+
+// MutationKind is a kind of change to the game's state.
+type MutationKind int
+
+// Contains mutation kind constants.
+const (
+	MutationAdd MutationKind = iota
+	MutationUpdate
+	MutationRemove
+	MutationMove
+	MutationScore
+)
+
+// Mutation describes a change to the game's state, with the values from
+// before and after it.
+type Mutation struct {
+	Kind     MutationKind
+	EntityID uuid.UUID
+	// Entity is the entity that was added, updated, removed, or moved, and is
+	// nil for score changes. Previous is the entity replaced by an add or
+	// update, if any.
+	Entity   Identifier
+	Previous Identifier
+	// From and To are an entity's positions before and after a move.
+	From Coordinate
+	To   Coordinate
+	// FromScore and ToScore are an entity's scores before and after a score
+	// change.
+	FromScore int
+	ToScore   int
+}
+
+// Observer is told about every change to the game's state as it happens, for
+// example to debug the engine. Unlike changes, which are what clients need to
+// know about, every mutation is observed, and observers are called
+// synchronously with the game locked, so they must not lock the game or block.
+// Restoring a snapshot replaces the whole state, and isn't observed.
+type Observer interface {
+	Observe(mutation Mutation)
+}
+
+// NopObserver ignores every mutation, and is the default observer.
+type NopObserver struct{}
+
+// Observe does nothing.
+func (NopObserver) Observe(mutation Mutation) {}
+
+// observe reports a mutation to the game's observer, if it has one.
+func (game *Game) observe(mutation Mutation) {
+	if game.Observer != nil {
+		game.Observer.Observe(mutation)
+	}
+}
+
+// MoveEntity moves an entity to a position without sending a change, and
+// reports the move to the game's observer. The game should be locked by the
+// caller.
+func (game *Game) MoveEntity(entity Identifier, position Coordinate) {
+	mover, ok := entity.(Mover)
+	if !ok {
+		return
+	}
+	from := position
+	if positioner, ok := entity.(Positioner); ok {
+		from = positioner.Position()
+	}
+	mover.Move(position)
+	game.observe(Mutation{
+		Kind:     MutationMove,
+		EntityID: entity.ID(),
+		Entity:   entity,
+		From:     from,
+		To:       position,
+	})
+}
+
+// SetScore sets an entity's score without sending a change, and reports it to
+// the game's observer. The game should be locked by the caller.
+func (game *Game) SetScore(id uuid.UUID, score int) {
+	from := game.Score[id]
+	game.Score[id] = score
+	game.observe(Mutation{
+		Kind:      MutationScore,
+		EntityID:  id,
+		FromScore: from,
+		ToScore:   score,
+	})
+}
+
+// ResetScores sets every entity's score to zero, reporting the scores that
+// changed to the game's observer. The game should be locked by the caller.
+func (game *Game) ResetScores() {
+	for id, score := range game.Score {
+		if score != 0 {
+			game.SetScore(id, 0)
+		}
+	}
+	game.Score = map[uuid.UUID]int{}
+}
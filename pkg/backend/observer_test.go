@@ -0,0 +1,133 @@
+package backend_test
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mortenson/grpc-game-example/pkg/backend"
+	"github.com/mortenson/grpc-game-example/pkg/backend/testutil"
+)
+
+// recordingObserver records the mutations it observes.
+type recordingObserver struct {
+	mutations []backend.Mutation
+}
+
+func (observer *recordingObserver) Observe(mutation backend.Mutation) {
+	observer.mutations = append(observer.mutations, mutation)
+}
+
+func TestObserver(t *testing.T) {
+	bobID := uuid.New()
+	tests := []struct {
+		name          string
+		startingScore int
+		mutate        func(game *backend.Game, alice *backend.Player)
+		// want describes the mutations, with entities and scores by name.
+		want []string
+	}{
+		{
+			name: "add",
+			mutate: func(game *backend.Game, alice *backend.Player) {
+				game.AddEntity(&backend.Player{IdentifierBase: backend.IdentifierBase{UUID: bobID}, Name: "bob"})
+			},
+			want: []string{"add bob replacing <nil>"},
+		},
+		{
+			name:          "add with a starting score",
+			startingScore: 5,
+			mutate: func(game *backend.Game, alice *backend.Player) {
+				game.AddEntity(&backend.Player{IdentifierBase: backend.IdentifierBase{UUID: bobID}, Name: "bob"})
+			},
+			want: []string{"add bob replacing <nil>", "score bob 0 to 5"},
+		},
+		{
+			name: "update",
+			mutate: func(game *backend.Game, alice *backend.Player) {
+				game.UpdateEntity(&backend.Player{IdentifierBase: backend.IdentifierBase{UUID: alice.ID()}, Name: "alicia"})
+			},
+			want: []string{"update alicia replacing alice"},
+		},
+		{
+			name: "remove",
+			mutate: func(game *backend.Game, alice *backend.Player) {
+				game.RemoveEntity(alice.ID())
+				game.RemoveEntity(bobID)
+			},
+			want: []string{"remove alice"},
+		},
+		{
+			name: "move",
+			mutate: func(game *backend.Game, alice *backend.Player) {
+				game.PerformAction(backend.MoveAction{ID: alice.ID(), Direction: backend.DirectionRight, Created: time.Now()})
+			},
+			want: []string{"move alice {0 0} to {1 0}"},
+		},
+		{
+			name: "score",
+			mutate: func(game *backend.Game, alice *backend.Player) {
+				game.AddScore(alice.ID())
+				game.AddScore(alice.ID())
+				game.RemoveScore(alice.ID())
+			},
+			want: []string{"score alice 0 to 1", "score alice 1 to 2", "score alice 2 to 1"},
+		},
+		{
+			name: "reset scores",
+			mutate: func(game *backend.Game, alice *backend.Player) {
+				game.SetScore(bobID, 0)
+				game.SetScore(alice.ID(), 3)
+				game.ResetScores()
+			},
+			want: []string{"score bob 0 to 0", "score alice 0 to 3", "score alice 3 to 0"},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			game := testutil.NewGame(testutil.WithPlayerAt("alice", 0, 0))
+			game.StartingScore = test.startingScore
+			alice := testutil.Player(game, "alice")
+			names := map[uuid.UUID]string{alice.ID(): "alice", bobID: "bob"}
+			observer := &recordingObserver{}
+			game.Observer = observer
+			test.mutate(game, alice)
+			got := []string{}
+			for _, mutation := range observer.mutations {
+				name := func(entity backend.Identifier) string {
+					if player, ok := entity.(*backend.Player); ok {
+						return player.Name
+					}
+					return fmt.Sprint(entity)
+				}
+				switch mutation.Kind {
+				case backend.MutationAdd:
+					got = append(got, fmt.Sprintf("add %s replacing %s", name(mutation.Entity), name(mutation.Previous)))
+				case backend.MutationUpdate:
+					got = append(got, fmt.Sprintf("update %s replacing %s", name(mutation.Entity), name(mutation.Previous)))
+				case backend.MutationRemove:
+					got = append(got, fmt.Sprintf("remove %s", name(mutation.Entity)))
+				case backend.MutationMove:
+					got = append(got, fmt.Sprintf("move %s %v to %v", name(mutation.Entity), mutation.From, mutation.To))
+				case backend.MutationScore:
+					got = append(got, fmt.Sprintf("score %s %d to %d", names[mutation.EntityID], mutation.FromScore, mutation.ToScore))
+				}
+			}
+			if !reflect.DeepEqual(got, test.want) {
+				t.Errorf("observed %v, want %v", got, test.want)
+			}
+		})
+	}
+}
+
+func TestNopObserver(t *testing.T) {
+	game := testutil.NewGame(testutil.WithPlayerAt("alice", 0, 0))
+	if _, ok := game.Observer.(backend.NopObserver); !ok {
+		t.Errorf("got observer %T, want a NopObserver by default", game.Observer)
+	}
+	// Games without an observer still work.
+	game.Observer = nil
+	game.AddScore(testutil.Player(game, "alice").ID())
+}
@@ -0,0 +1,78 @@
+package backend
+
+import (
+	"github.com/beefsack/go-astar"
+)
+
+// pathGrid is the map used to find paths, where map walls and wall entities
+// block movement.
+type pathGrid struct {
+	game  *Game
+	walls map[Coordinate]bool
+}
+
+// pathCell is a cell in a pathGrid, and is used by beefsack/astar to traverse.
+type pathCell struct {
+	position Coordinate
+	grid     *pathGrid
+}
+
+// FindPath finds the shortest path between two cells that doesn't go through
+// walls, using the A* algorithm. The path starts with from and ends with to,
+// and is nil if there is no such path. Paths only use cells inside the map.
+// The game should be locked by the caller.
+func FindPath(game *Game, from Coordinate, to Coordinate) []Coordinate {
+	grid := &pathGrid{
+		game:  game,
+		walls: make(map[Coordinate]bool),
+	}
+	for _, entity := range game.Entities {
+		if wall, ok := entity.(*Wall); ok {
+			grid.walls[wall.Position()] = true
+		}
+	}
+	if !grid.open(from) || !grid.open(to) {
+		return nil
+	}
+	// Paths are returned from the end, so they are found backwards.
+	cells, _, found := astar.Path(pathCell{to, grid}, pathCell{from, grid})
+	if !found {
+		return nil
+	}
+	path := make([]Coordinate, 0, len(cells))
+	for _, cell := range cells {
+		path = append(path, cell.(pathCell).position)
+	}
+	return path
+}
+
+// open checks if a path can go through a cell.
+func (grid *pathGrid) open(position Coordinate) bool {
+	min, max := grid.game.Bounds()
+	if position.X < min.X || position.X > max.X || position.Y < min.Y || position.Y > max.Y {
+		return false
+	}
+	return grid.game.mapTypeAt(position) != MapTypeWall && !grid.walls[position]
+}
+
+// PathNeighbors is used by beefsack/astar to traverse.
+func (cell pathCell) PathNeighbors() []astar.Pather {
+	neighbors := []astar.Pather{}
+	for _, direction := range []Direction{DirectionLeft, DirectionRight, DirectionUp, DirectionDown} {
+		position := cell.position.Add(direction.offset())
+		if cell.grid.open(position) {
+			neighbors = append(neighbors, pathCell{position, cell.grid})
+		}
+	}
+	return neighbors
+}
+
+// PathNeighborCost is used by beefsack/astar to determine the cost of a move.
+func (cell pathCell) PathNeighborCost(to astar.Pather) float64 {
+	return 1
+}
+
+// PathEstimatedCost estimates the cost of moving between two cells.
+func (cell pathCell) PathEstimatedCost(to astar.Pather) float64 {
+	return float64(cell.position.Distance(to.(pathCell).position))
+}
@@ -0,0 +1,68 @@
+package backend_test
+
+import (
+	"testing"
+
+	"github.com/mortenson/grpc-game-example/pkg/backend"
+	"github.com/mortenson/grpc-game-example/pkg/backend/testutil"
+)
+
+func TestFindPath(t *testing.T) {
+	tests := []struct {
+		name     string
+		from, to backend.Coordinate
+		// walls are wall entities added to the maze.
+		walls []backend.Coordinate
+		// length is the number of cells in the shortest path, or zero if
+		// there is no path.
+		length int
+	}{
+		{name: "through the maze", from: backend.Coordinate{X: -2, Y: -1}, to: backend.Coordinate{X: 2, Y: 1}, length: 11},
+		{name: "same cell", from: backend.Coordinate{X: 0, Y: 0}, to: backend.Coordinate{X: 0, Y: 0}, length: 1},
+		{
+			name:  "blocked by wall entities",
+			from:  backend.Coordinate{X: -2, Y: -1},
+			to:    backend.Coordinate{X: 2, Y: 1},
+			walls: []backend.Coordinate{{X: 2, Y: 0}},
+		},
+		{name: "to a wall", from: backend.Coordinate{X: -2, Y: -1}, to: backend.Coordinate{X: 1, Y: 0}},
+		{name: "outside the map", from: backend.Coordinate{X: -2, Y: -1}, to: backend.Coordinate{X: 3, Y: 0}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			config := testutil.MapConfig(
+				"S█   ",
+				" █ █ ",
+				"   █S",
+			)
+			game := testutil.NewGameFromConfig(t, config)
+			for _, wall := range test.walls {
+				testutil.WithWallAt(wall.X, wall.Y)(game)
+			}
+			path := backend.FindPath(game, test.from, test.to)
+			if test.length == 0 {
+				if path != nil {
+					t.Errorf("got path %v, want none", path)
+				}
+				return
+			}
+			if len(path) != test.length {
+				t.Fatalf("got path %v, want %d cells", path, test.length)
+			}
+			if path[0] != test.from || path[len(path)-1] != test.to {
+				t.Errorf("path %v doesn't go from %v to %v", path, test.from, test.to)
+			}
+			walls := game.GetMapByType()[backend.MapTypeWall]
+			for i, cell := range path {
+				for _, wall := range walls {
+					if cell == wall {
+						t.Errorf("path %v goes through the wall at %v", path, wall)
+					}
+				}
+				if i > 0 && cell.Distance(path[i-1]) != 1 {
+					t.Errorf("path %v jumps from %v to %v", path, path[i-1], cell)
+				}
+			}
+		})
+	}
+}
@@ -1,5 +1,14 @@
 package backend
 
+import (
+	"bytes"
+	"strings"
+	"time"
+)
+
+// MaxHealth is the health players have when they spawn.
+const MaxHealth = 100
+
 // Player contains information unique to local and remote players.
 type Player struct {
 	IdentifierBase
@@ -8,6 +17,86 @@ type Player struct {
 	CurrentPosition Coordinate
 	Name            string
 	Icon            rune
+	// Color is the name of the color used to render the player, which should
+	// be one of PlayerColors. If empty, the default color is used.
+	Color string
+	// Facing is the direction the player last tried to move in, and is only
+	// set if HasFacing is true.
+	Facing    Direction
+	HasFacing bool
+	// LastMoved is when the player last moved or dashed.
+	LastMoved time.Time
+	// RapidFireUntil is when the player's rapid fire power-up wears off.
+	RapidFireUntil time.Time
+	// Bot is true for players controlled by the game's bots.
+	Bot bool
+	// Damage is how much health the player has lost since they spawned.
+	Damage int
+	// LastHit is when the player was last hit, which delays regeneration.
+	LastHit time.Time
+	// SafePosition is the last position the player moved to that wasn't next
+	// to another player, and is only set if HasSafePosition is true.
+	SafePosition    Coordinate
+	HasSafePosition bool
+	// Team is the player's team in team modes like freeze tag, and zero if
+	// the player isn't on a team.
+	Team int
+	// Frozen players can't move or fire until a teammate unfreezes them.
+	Frozen bool
+	// ProtectedUntil is when the player's spawn protection wears off. While
+	// protected, Blinking is toggled every blink interval.
+	ProtectedUntil time.Time
+	Blinking       bool
+	nextBlink      time.Time
+	// Loadout is the weapons the player carries, and ActiveWeapon is the
+	// index of the one they fire.
+	Loadout      []WeaponKind
+	ActiveWeapon int
+	// Ready is true once the player is ready for the match to start.
+	Ready bool
+	// SpeedMultiplier scales how often the player can move, so that a value
+	// of two halves their move throttle. It is ignored unless it's positive.
+	SpeedMultiplier float64
+}
+
+// PlayerColors contains the colors players can choose from.
+var PlayerColors = []string{"white", "red", "green", "yellow", "blue", "fuchsia", "aqua"}
+
+// IsPlayerColor checks if a color can be chosen by players.
+func IsPlayerColor(color string) bool {
+	for _, playerColor := range PlayerColors {
+		if color == playerColor {
+			return true
+		}
+	}
+	return false
+}
+
+// PlayerByName finds a player by name, ignoring case, and returns nil if
+// there is no such player. If several players share the name, the one with
+// the lowest ID is returned so that the result is stable. The game should be
+// locked by the caller.
+func (game *Game) PlayerByName(name string) *Player {
+	var found *Player
+	for _, player := range game.Players() {
+		if !strings.EqualFold(player.Name, name) {
+			continue
+		}
+		if found == nil {
+			found = player
+			continue
+		}
+		id, foundID := player.ID(), found.ID()
+		if bytes.Compare(id[:], foundID[:]) < 0 {
+			found = player
+		}
+	}
+	return found
+}
+
+// Health returns how much health the player has left.
+func (p *Player) Health() int {
+	return MaxHealth - p.Damage
 }
 
 // Position determines the player position.
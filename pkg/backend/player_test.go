@@ -0,0 +1,60 @@
+package backend_test
+
+import (
+	"testing"
+
+	"github.com/mortenson/grpc-game-example/pkg/backend/testutil"
+)
+
+func TestPlayerByName(t *testing.T) {
+	tests := []struct {
+		name   string
+		lookup string
+		// want is the ID of the player found, counting from one, or zero
+		// if none is found.
+		want byte
+	}{
+		{name: "exact name", lookup: "alice", want: 1},
+		{name: "ignores case", lookup: "ALICE", want: 1},
+		{name: "unknown names", lookup: "dave", want: 0},
+		{name: "empty names", lookup: "", want: 0},
+		{name: "duplicate names", lookup: "bob", want: 2},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			game := testutil.NewGame(
+				testutil.WithSequentialIDs(),
+				testutil.WithPlayerAt("alice", 0, 0),
+				testutil.WithPlayerAt("bob", 1, 0),
+				testutil.WithPlayerAt("Bob", 2, 0),
+			)
+			player := game.PlayerByName(test.lookup)
+			if test.want == 0 {
+				if player != nil {
+					t.Errorf("found %q, want nil", player.Name)
+				}
+				return
+			}
+			if player == nil {
+				t.Fatal("no player was found")
+			}
+			if id := player.ID(); id[15] != test.want {
+				t.Errorf("found player %s, want ID %d", id, test.want)
+			}
+		})
+	}
+}
+
+// Lookups are stable for duplicate names, even though entities are stored in
+// a map.
+func TestPlayerByNameStable(t *testing.T) {
+	game := testutil.NewGame(testutil.WithSequentialIDs())
+	for i := 0; i < 5; i++ {
+		testutil.WithPlayerAt("bob", i, 0)(game)
+	}
+	for i := 0; i < 20; i++ {
+		if id := game.PlayerByName("bob").ID(); id[15] != 1 {
+			t.Fatalf("found player %s, want the lowest ID", id)
+		}
+	}
+}
@@ -0,0 +1,66 @@
+package backend
+
+import (
+	"time"
+)
+
+const (
+	defaultPowerUpDropChance = 0.2
+	rapidFireDuration        = 10 * time.Second
+)
+
+// PowerUp is sometimes dropped where a player is killed. Players who pick one
+// up can fire twice as fast for a while.
+type PowerUp struct {
+	IdentifierBase
+	Positioner
+	CurrentPosition Coordinate
+}
+
+// Position determines the power-up position.
+func (powerUp *PowerUp) Position() Coordinate {
+	return powerUp.CurrentPosition
+}
+
+// dropPowerUp randomly drops a power-up at a position, based on the game's
+// drop chance.
+func (game *Game) dropPowerUp(position Coordinate) {
+	if game.PowerUpDropChance <= 0 || game.Rand.Float64() >= game.PowerUpDropChance {
+		return
+	}
+	powerUp := &PowerUp{
+		IdentifierBase:  IdentifierBase{game.IDGenerator()},
+		CurrentPosition: position,
+	}
+	if game.AddEntity(powerUp) != nil {
+		return
+	}
+	game.sendChange(AddEntityChange{
+		Entity: powerUp,
+	})
+}
+
+// collectPowerUp gives a power-up to the first player that collided with it
+// and is still in its cell, as players may have respawned elsewhere.
+func (game *Game) collectPowerUp(powerUp *PowerUp, entities []Identifier, position Coordinate) {
+	var player *Player
+	for _, entity := range entities {
+		entityPlayer, ok := entity.(*Player)
+		if ok && game.isLive(entityPlayer) && entityPlayer.Position() == position {
+			player = entityPlayer
+			break
+		}
+	}
+	if player == nil {
+		return
+	}
+	player.RapidFireUntil = time.Now().Add(rapidFireDuration)
+	game.sendChange(RemoveEntityChange{
+		Entity: powerUp,
+	})
+	game.RemoveEntity(powerUp.ID())
+	game.Events.Publish(PowerUpCollectedEvent{
+		PlayerID:  player.ID(),
+		PowerUpID: powerUp.ID(),
+	})
+}
@@ -0,0 +1,133 @@
+package backend_test
+
+import (
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/mortenson/grpc-game-example/pkg/backend"
+	"github.com/mortenson/grpc-game-example/pkg/backend/testutil"
+)
+
+// killRepeatedly has bob kill alice a number of times, returning where she
+// died each time.
+func killRepeatedly(game *backend.Game, kills int) []backend.Coordinate {
+	alice := testutil.Player(game, "alice")
+	bob := testutil.Player(game, "bob")
+	deaths := []backend.Coordinate{}
+	for i := 0; i < kills; i++ {
+		deaths = append(deaths, alice.Position())
+		laserKill(game, alice, bob)
+	}
+	return deaths
+}
+
+func TestPowerUpDrops(t *testing.T) {
+	tests := []struct {
+		name       string
+		dropChance float64
+		kills      int
+		drops      int
+	}{
+		{name: "never dropped", dropChance: 0, kills: 5, drops: 0},
+		{name: "always dropped", dropChance: 1, kills: 5, drops: 5},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			game := testutil.NewGame(
+				testutil.WithPlayerAt("alice", 0, 0),
+				testutil.WithPlayerAt("bob", 3, 3),
+			)
+			game.PowerUpDropChance = test.dropChance
+			deaths := killRepeatedly(game, test.kills)
+			if len(game.RecentKills) != test.kills {
+				t.Fatalf("got %d kills, want %d", len(game.RecentKills), test.kills)
+			}
+			dropped := testutil.Entities(game, &backend.PowerUp{})
+			if len(dropped) != test.drops {
+				t.Fatalf("got %d power-ups, want %d", len(dropped), test.drops)
+			}
+			died := make(map[backend.Coordinate]bool)
+			for _, position := range deaths {
+				died[position] = true
+			}
+			for _, entity := range dropped {
+				powerUp := entity.(*backend.PowerUp)
+				if !died[powerUp.Position()] {
+					t.Errorf("power-up dropped at %+v, where no one died", powerUp.Position())
+				}
+			}
+			added := 0
+			for _, change := range testutil.DrainChanges(game) {
+				if change, ok := change.(backend.AddEntityChange); ok {
+					if _, ok := change.Entity.(*backend.PowerUp); ok {
+						added++
+					}
+				}
+			}
+			if added != test.drops {
+				t.Errorf("got %d AddEntityChanges for power-ups, want %d", added, test.drops)
+			}
+		})
+	}
+}
+
+func TestPowerUpDropsAreSeeded(t *testing.T) {
+	// drops returns where power-ups were dropped, in the order they were.
+	drops := func(seed int64) []backend.Coordinate {
+		game := testutil.NewGame(
+			testutil.WithPlayerAt("alice", 0, 0),
+			testutil.WithPlayerAt("bob", 3, 3),
+		)
+		game.Rand = rand.New(rand.NewSource(seed))
+		game.PowerUpDropChance = 0.5
+		kills := 9
+		killRepeatedly(game, kills)
+		dropped := []backend.Coordinate{}
+		for _, change := range testutil.DrainChanges(game) {
+			if change, ok := change.(backend.AddEntityChange); ok {
+				if powerUp, ok := change.Entity.(*backend.PowerUp); ok {
+					dropped = append(dropped, powerUp.Position())
+				}
+			}
+		}
+		if len(dropped) == 0 || len(dropped) == kills {
+			t.Errorf("seed %d dropped %d power-ups for %d kills, want some", seed, len(dropped), kills)
+		}
+		return dropped
+	}
+	first := drops(1)
+	second := drops(1)
+	if len(first) != len(second) {
+		t.Fatalf("got %d and %d drops for the same seed", len(first), len(second))
+	}
+	for i := range first {
+		if first[i] != second[i] {
+			t.Errorf("drop %d was at %+v and %+v for the same seed", i, first[i], second[i])
+		}
+	}
+}
+
+func TestCollectPowerUp(t *testing.T) {
+	game := testutil.NewGame(testutil.WithPlayerAt("alice", 0, 0))
+	alice := testutil.Player(game, "alice")
+	collected := false
+	game.Events.Subscribe(backend.PowerUpCollectedEvent{}, func(event backend.Event) {
+		collected = true
+	})
+	powerUp := &backend.PowerUp{
+		IdentifierBase:  backend.IdentifierBase{UUID: game.IDGenerator()},
+		CurrentPosition: alice.Position(),
+	}
+	game.AddEntity(powerUp)
+	game.Step()
+	if game.GetEntity(powerUp.ID()) != nil {
+		t.Error("the power-up was not removed")
+	}
+	if !alice.RapidFireUntil.After(time.Now()) {
+		t.Error("the player can not fire rapidly")
+	}
+	if !collected {
+		t.Error("no PowerUpCollectedEvent was published")
+	}
+}
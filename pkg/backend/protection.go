@@ -0,0 +1,68 @@
+package backend
+
+import (
+	"time"
+)
+
+const (
+	spawnBlinkInterval       = 200 * time.Millisecond
+	protectionCheckFrequency = 20 * time.Millisecond
+)
+
+// Protected checks if the player can't be hurt because they just respawned.
+func (p *Player) Protected(now time.Time) bool {
+	return now.Before(p.ProtectedUntil)
+}
+
+// protect makes a player invulnerable for the game's spawn protection, if
+// any. The game should be locked by the caller.
+func (game *Game) protect(player *Player, now time.Time) {
+	if game.SpawnProtection <= 0 {
+		return
+	}
+	player.ProtectedUntil = now.Add(game.scaleDuration(game.SpawnProtection))
+	player.Blinking = true
+	player.nextBlink = now.Add(game.scaleDuration(game.BlinkInterval))
+	game.sendChange(StatusChange{
+		Player: player,
+	})
+}
+
+// watchProtection blinks protected players and ends their protection.
+func (game *Game) watchProtection() {
+	for {
+		time.Sleep(game.scaleDuration(protectionCheckFrequency))
+		game.Mu.Lock()
+		game.updateProtection(time.Now())
+		game.Mu.Unlock()
+	}
+}
+
+// updateProtection toggles whether protected players are blinking every blink
+// interval, so that frontends can blink them without timing it themselves.
+// When protection ends, a final change is sent with the player no longer
+// blinking. The game should be locked by the caller.
+func (game *Game) updateProtection(now time.Time) {
+	for _, entity := range game.Entities {
+		player, ok := entity.(*Player)
+		if !ok || player.ProtectedUntil.IsZero() {
+			continue
+		}
+		if !player.Protected(now) {
+			player.ProtectedUntil = time.Time{}
+			player.Blinking = false
+			game.sendChange(StatusChange{
+				Player: player,
+			})
+			continue
+		}
+		if game.BlinkInterval <= 0 || now.Before(player.nextBlink) {
+			continue
+		}
+		player.Blinking = !player.Blinking
+		player.nextBlink = player.nextBlink.Add(game.scaleDuration(game.BlinkInterval))
+		game.sendChange(StatusChange{
+			Player: player,
+		})
+	}
+}
@@ -0,0 +1,78 @@
+package backend_test
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/mortenson/grpc-game-example/pkg/backend"
+	"github.com/mortenson/grpc-game-example/pkg/backend/testutil"
+)
+
+func TestProtectionBlinks(t *testing.T) {
+	tests := []struct {
+		name            string
+		spawnProtection time.Duration
+		blinkInterval   time.Duration
+		// blinks are the Blinking values of the status changes sent while
+		// protection is updated every 100ms for 1.5s.
+		blinks []bool
+	}{
+		{
+			name:            "blinks every interval",
+			spawnProtection: time.Second,
+			blinkInterval:   200 * time.Millisecond,
+			blinks:          []bool{true, false, true, false, true, false},
+		},
+		{
+			name:            "blinks at a slower cadence",
+			spawnProtection: time.Second,
+			blinkInterval:   400 * time.Millisecond,
+			blinks:          []bool{true, false, true, false},
+		},
+		{
+			name:            "without blinking",
+			spawnProtection: time.Second,
+			blinks:          []bool{true, false},
+		},
+		{
+			name:          "without protection",
+			blinkInterval: 200 * time.Millisecond,
+			blinks:        []bool{},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			game := testutil.NewGame(testutil.WithPlayerAt("alice", 0, 0))
+			game.SpawnProtection = test.spawnProtection
+			game.BlinkInterval = test.blinkInterval
+			alice := testutil.Player(game, "alice")
+			start := time.Now()
+			testutil.DrainChanges(game)
+			game.Protect(alice, start)
+			blinks := []bool{}
+			collect := func() {
+				for _, change := range testutil.DrainChanges(game) {
+					if change, ok := change.(backend.StatusChange); ok {
+						blinks = append(blinks, change.Player.Blinking)
+					}
+				}
+			}
+			collect()
+			for elapsed := 100 * time.Millisecond; elapsed <= 1500*time.Millisecond; elapsed += 100 * time.Millisecond {
+				now := start.Add(elapsed)
+				if protected := elapsed < test.spawnProtection; alice.Protected(now) != protected {
+					t.Errorf("protected is %v after %s, want %v", alice.Protected(now), elapsed, protected)
+				}
+				game.UpdateProtection(now)
+				collect()
+			}
+			if !reflect.DeepEqual(blinks, test.blinks) {
+				t.Errorf("got blinks %v, want %v", blinks, test.blinks)
+			}
+			if alice.Blinking {
+				t.Error("alice is still blinking after protection ended")
+			}
+		})
+	}
+}
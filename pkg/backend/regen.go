@@ -0,0 +1,43 @@
+package backend
+
+import (
+	"time"
+)
+
+const (
+	regenAmount   = 5
+	regenInterval = time.Second
+)
+
+// watchRegen heals players who haven't been hit recently.
+func (game *Game) watchRegen() {
+	for {
+		time.Sleep(game.scaleDuration(regenInterval))
+		game.Mu.Lock()
+		game.applyRegen(time.Now())
+		game.Mu.Unlock()
+	}
+}
+
+// applyRegen heals every damaged player who hasn't been hit for the game's
+// regen delay, up to full health. Regeneration is disabled if the delay is
+// zero. The game should be locked by the caller.
+func (game *Game) applyRegen(now time.Time) {
+	if game.RegenDelay <= 0 || game.WaitForRound {
+		return
+	}
+	for _, player := range game.Players() {
+		if player.Damage <= 0 || now.Sub(player.LastHit) < game.scaleDuration(game.RegenDelay) {
+			continue
+		}
+		heal := regenAmount
+		if heal > player.Damage {
+			heal = player.Damage
+		}
+		player.Damage -= heal
+		game.sendChange(DamageChange{
+			Player: player,
+			Amount: -heal,
+		})
+	}
+}
@@ -0,0 +1,80 @@
+package backend_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/mortenson/grpc-game-example/pkg/backend"
+	"github.com/mortenson/grpc-game-example/pkg/backend/testutil"
+)
+
+func TestRegen(t *testing.T) {
+	tests := []struct {
+		name    string
+		delay   time.Duration
+		damage  int
+		lastHit time.Duration
+		waiting bool
+		// hazard has alice hit by a hazard just before regenerating.
+		hazard bool
+		want   int
+	}{
+		{name: "undamaged players", delay: time.Second, damage: 0, lastHit: time.Minute, want: 0},
+		{name: "regenerates", delay: time.Second, damage: 20, lastHit: time.Minute, want: 15},
+		{name: "up to full health", delay: time.Second, damage: 3, lastHit: time.Minute, want: 0},
+		{name: "recently hit", delay: time.Second, damage: 20, lastHit: 500 * time.Millisecond, want: 20},
+		{name: "disabled", delay: 0, damage: 20, lastHit: time.Minute, want: 20},
+		{name: "between rounds", delay: time.Second, damage: 20, lastHit: time.Minute, waiting: true, want: 20},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			game := testutil.NewGame(testutil.WithPlayerAt("alice", 0, 0))
+			game.RegenDelay = test.delay
+			game.WaitForRound = test.waiting
+			alice := testutil.Player(game, "alice")
+			now := time.Now()
+			alice.Damage = test.damage
+			alice.LastHit = now.Add(-test.lastHit)
+			testutil.DrainChanges(game)
+			game.ApplyRegen(now)
+			if alice.Damage != test.want {
+				t.Errorf("alice has %d damage, want %d", alice.Damage, test.want)
+			}
+			changes := testutil.DrainChanges(game)
+			if healed := test.damage - test.want; healed > 0 {
+				if len(changes) != 1 {
+					t.Fatalf("got changes %+v, want a heal of %d", changes, healed)
+				}
+				if change, ok := changes[0].(backend.DamageChange); !ok || change.Amount != -healed {
+					t.Errorf("got change %+v, want a heal of %d", changes[0], healed)
+				}
+			} else if len(changes) != 0 {
+				t.Errorf("got changes %+v, want none", changes)
+			}
+		})
+	}
+}
+
+func TestRegenAfterHazards(t *testing.T) {
+	config := testutil.MapConfig(
+		"S    ",
+		"  ~  ",
+		"     ",
+	)
+	game := testutil.NewGameFromConfig(t, config, testutil.WithPlayerAt("alice", 0, 0))
+	game.RegenDelay = time.Second
+	alice := testutil.Player(game, "alice")
+	alice.Damage = 20
+	alice.LastHit = time.Now().Add(-time.Minute)
+	// Being hit resets the regen delay.
+	game.ApplyHazardDamage()
+	damage := alice.Damage
+	game.ApplyRegen(time.Now())
+	if alice.Damage != damage {
+		t.Errorf("alice regenerated to %d damage right after being hit", alice.Damage)
+	}
+	game.ApplyRegen(time.Now().Add(2 * time.Second))
+	if alice.Damage >= damage {
+		t.Errorf("alice has %d damage, want to regenerate after the delay", alice.Damage)
+	}
+}
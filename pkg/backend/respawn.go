@@ -0,0 +1,92 @@
+package backend
+
+// RespawnMode decides where killed players respawn.
+type RespawnMode int
+
+// Contains respawn mode constants. RespawnModeLastSafe respawns players at the
+// last position they moved to that wasn't next to another player, falling back
+// to spawn points if there is no such position or it is blocked.
+const (
+	RespawnModeSpawnPoints RespawnMode = iota
+	RespawnModeOrigin
+	RespawnModeLastSafe
+)
+
+// respawnPosition returns where a player should respawn, and false if the
+// player should stay where they are. Players never respawn in walls or on
+// hazards.
+func (game *Game) respawnPosition(player *Player) (Coordinate, bool) {
+	switch game.RespawnMode {
+	case RespawnModeOrigin:
+		if game.isSafeSpawn(Coordinate{}) {
+			return Coordinate{}, true
+		}
+	case RespawnModeLastSafe:
+		if player.HasSafePosition && !game.isBlocked(player.SafePosition) && game.isSafeSpawn(player.SafePosition) {
+			return player.SafePosition, true
+		}
+	}
+	spawnPoints := game.SafeSpawnCells()
+	if len(spawnPoints) == 0 {
+		return Coordinate{}, false
+	}
+	spawnPoint := spawnPoints[game.spawnPointIndex%len(spawnPoints)]
+	game.spawnPointIndex++
+	return spawnPoint, true
+}
+
+// SafeSpawnCells returns the spawn points that aren't blocked by walls or on
+// hazards. If none are safe, every free cell that isn't a hazard is returned
+// instead. The game should be locked by the caller.
+func (game *Game) SafeSpawnCells() []Coordinate {
+	mapByType := game.GetMapByType()
+	cells := []Coordinate{}
+	for _, spawnPoint := range mapByType[MapTypeSpawn] {
+		if game.isSafeSpawn(spawnPoint) {
+			cells = append(cells, spawnPoint)
+		}
+	}
+	if len(cells) > 0 {
+		return cells
+	}
+	collisionMap := game.getCollisionMap()
+	for _, cell := range mapByType[MapTypeNone] {
+		if len(collisionMap[cell]) == 0 {
+			cells = append(cells, cell)
+		}
+	}
+	return cells
+}
+
+// isSafeSpawn checks if a cell is not a wall or hazard, and has no wall
+// entities in it.
+func (game *Game) isSafeSpawn(cell Coordinate) bool {
+	switch game.mapTypeAt(cell) {
+	case MapTypeWall, MapTypeHazard:
+		return false
+	}
+	for _, entity := range game.Entities {
+		wall, ok := entity.(*Wall)
+		if ok && wall.Position() == cell {
+			return false
+		}
+	}
+	return true
+}
+
+// recordSafePosition remembers the player's position if no other player is
+// next to it.
+func (game *Game) recordSafePosition(player *Player) {
+	position := player.Position()
+	for _, entity := range game.Entities {
+		other, ok := entity.(*Player)
+		if !ok || other == player {
+			continue
+		}
+		if position.ChebyshevDistance(other.Position()) <= 1 {
+			return
+		}
+	}
+	player.SafePosition = position
+	player.HasSafePosition = true
+}
@@ -0,0 +1,193 @@
+package backend_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/mortenson/grpc-game-example/pkg/backend"
+	"github.com/mortenson/grpc-game-example/pkg/backend/testutil"
+)
+
+func TestRespawnMode(t *testing.T) {
+	tests := []struct {
+		name string
+		mode backend.RespawnMode
+		// moved is whether alice moves to (1, 1), which is safe, then back
+		// to (2, 1) next to bob before she is killed.
+		moved bool
+		// blocked puts carol on alice's safe position.
+		blocked bool
+		want    backend.Coordinate
+	}{
+		{name: "spawn points", mode: backend.RespawnModeSpawnPoints, moved: true, want: backend.Coordinate{X: -3, Y: -1}},
+		{name: "origin", mode: backend.RespawnModeOrigin, moved: true, want: backend.Coordinate{}},
+		{name: "last safe position", mode: backend.RespawnModeLastSafe, moved: true, want: backend.Coordinate{X: 1, Y: 1}},
+		{name: "no safe position", mode: backend.RespawnModeLastSafe, moved: false, want: backend.Coordinate{X: -3, Y: -1}},
+		{name: "safe position blocked", mode: backend.RespawnModeLastSafe, moved: true, blocked: true, want: backend.Coordinate{X: -3, Y: -1}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			config := testutil.MapConfig(
+				"S      ",
+				"       ",
+				"       ",
+			)
+			config.RespawnMode = test.mode
+			game := testutil.NewGameFromConfig(t, config,
+				testutil.WithPlayerAt("alice", 2, 1),
+				testutil.WithPlayerAt("bob", -3, 1),
+			)
+			alice := testutil.Player(game, "alice")
+			bob := testutil.Player(game, "bob")
+			if test.moved {
+				start := time.Now()
+				if result := game.PerformAction(moveAt(game, "alice", backend.DirectionLeft, start, 0)); result != backend.ActionAccepted {
+					t.Fatalf("first move got %v", result)
+				}
+				game.MoveEntity(bob, backend.Coordinate{X: 2, Y: 0})
+				if result := game.PerformAction(moveAt(game, "alice", backend.DirectionRight, start, 1)); result != backend.ActionAccepted {
+					t.Fatalf("second move got %v", result)
+				}
+			}
+			if test.blocked {
+				testutil.WithPlayerAt("carol", 1, 1)(game)
+			}
+			laserKill(game, alice, bob)
+			if len(game.RecentKills) != 1 {
+				t.Fatalf("got %d kills, want 1", len(game.RecentKills))
+			}
+			if position := alice.Position(); position != test.want {
+				t.Errorf("player respawned at %+v, want %+v", position, test.want)
+			}
+		})
+	}
+}
+
+func TestSafeSpawnCells(t *testing.T) {
+	tests := []struct {
+		name string
+		// walls are wall entities added to the map.
+		walls []backend.Coordinate
+		want  []backend.Coordinate
+	}{
+		{
+			name: "spawn points",
+			want: []backend.Coordinate{{X: -2, Y: -1}, {X: 2, Y: 1}},
+		},
+		{
+			name:  "spawn points with walls",
+			walls: []backend.Coordinate{{X: -2, Y: -1}},
+			want:  []backend.Coordinate{{X: 2, Y: 1}},
+		},
+		{
+			// Cells that are hazards, walls, or taken by entities are left
+			// out.
+			name:  "free cells when every spawn point is blocked",
+			walls: []backend.Coordinate{{X: -2, Y: -1}, {X: 2, Y: 1}},
+			want: []backend.Coordinate{
+				{X: 0, Y: -1}, {X: 1, Y: -1}, {X: 2, Y: -1},
+				{X: -2, Y: 0}, {X: 2, Y: 0},
+				{X: -2, Y: 1}, {X: -1, Y: 1}, {X: 1, Y: 1},
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			config := testutil.MapConfig(
+				"S█   ",
+				" ~ ~ ",
+				"  █ S",
+			)
+			game := testutil.NewGameFromConfig(t, config, testutil.WithPlayerAt("alice", 0, 0))
+			for _, wall := range test.walls {
+				testutil.WithWallAt(wall.X, wall.Y)(game)
+			}
+			cells := game.SafeSpawnCells()
+			if len(cells) != len(test.want) {
+				t.Fatalf("got cells %v, want %v", cells, test.want)
+			}
+			for _, want := range test.want {
+				found := false
+				for _, cell := range cells {
+					found = found || cell == want
+				}
+				if !found {
+					t.Errorf("got cells %v, want %v", cells, test.want)
+					break
+				}
+			}
+		})
+	}
+}
+
+func TestRespawnAvoidsWalls(t *testing.T) {
+	config := testutil.MapConfig(
+		"S    ",
+		"     ",
+		"    S",
+	)
+	game := testutil.NewGameFromConfig(t, config,
+		testutil.WithPlayerAt("alice", 0, 0),
+		testutil.WithPlayerAt("bob", 1, 0),
+		testutil.WithWallAt(-2, -1),
+	)
+	alice := testutil.Player(game, "alice")
+	bob := testutil.Player(game, "bob")
+	// Both kills respawn alice at the only spawn point without a wall.
+	for i := 0; i < 2; i++ {
+		laserKill(game, alice, bob)
+		if position := alice.Position(); position != (backend.Coordinate{X: 2, Y: 1}) {
+			t.Errorf("alice respawned at %+v, want the spawn point without a wall", position)
+		}
+		game.MoveEntity(alice, backend.Coordinate{})
+	}
+}
+
+func TestRespawnClearsThrottles(t *testing.T) {
+	tests := []struct {
+		name   string
+		killed bool
+		// actor is the player who acts before and after alice is killed.
+		actor string
+		want  backend.ActionResult
+	}{
+		{name: "after respawning", killed: true, actor: "alice", want: backend.ActionAccepted},
+		{name: "without respawning", killed: false, actor: "alice", want: backend.ActionRejectedThrottled},
+		{name: "other players", killed: true, actor: "bob", want: backend.ActionRejectedThrottled},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			config := testutil.MapConfig(
+				"S    ",
+				"     ",
+				"    S",
+			)
+			game := testutil.NewGameFromConfig(t, config,
+				testutil.WithPlayerAt("alice", 0, 0),
+				testutil.WithPlayerAt("bob", 1, 0),
+			)
+			alice := testutil.Player(game, "alice")
+			bob := testutil.Player(game, "bob")
+			now := time.Now()
+			move := func(direction backend.Direction) backend.MoveAction {
+				return backend.MoveAction{ID: testutil.Player(game, test.actor).ID(), Direction: direction, Created: now}
+			}
+			fire := fireAt(game, test.actor, now, 0, 10*time.Millisecond)
+			if result := game.PerformAction(move(backend.DirectionDown)); result != backend.ActionAccepted {
+				t.Fatalf("first move got %v", result)
+			}
+			if result := game.PerformAction(fire[0]); result != backend.ActionAccepted {
+				t.Fatalf("first shot got %v", result)
+			}
+			if test.killed {
+				laserKill(game, alice, bob)
+			}
+			if result := game.PerformAction(move(backend.DirectionRight)); result != test.want {
+				t.Errorf("second move got %v, want %v", result, test.want)
+			}
+			if result := game.PerformAction(fire[1]); result != test.want {
+				t.Errorf("second shot got %v, want %v", result, test.want)
+			}
+		})
+	}
+}
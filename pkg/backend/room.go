@@ -0,0 +1,75 @@
+package backend
+
+import (
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/google/uuid"
+)
+
+// Room is a named game that players can choose to join.
+type Room struct {
+	ID   uuid.UUID
+	Name string
+	Game *Game
+}
+
+// RoomManager keeps track of rooms, allowing multiple games to be played at
+// once.
+type RoomManager struct {
+	rooms map[uuid.UUID]*Room
+	mu    sync.RWMutex
+}
+
+// NewRoomManager constructs a new RoomManager struct.
+func NewRoomManager() *RoomManager {
+	return &RoomManager{
+		rooms: make(map[uuid.UUID]*Room),
+	}
+}
+
+// AddRoom adds a room for a game.
+func (manager *RoomManager) AddRoom(name string, game *Game) *Room {
+	manager.mu.Lock()
+	defer manager.mu.Unlock()
+	room := &Room{
+		ID:   uuid.New(),
+		Name: name,
+		Game: game,
+	}
+	manager.rooms[room.ID] = room
+	return room
+}
+
+// GetRoom gets a room, returning nil if it does not exist.
+func (manager *RoomManager) GetRoom(id uuid.UUID) *Room {
+	manager.mu.RLock()
+	defer manager.mu.RUnlock()
+	return manager.rooms[id]
+}
+
+// Rooms returns all rooms, sorted by name.
+func (manager *RoomManager) Rooms() []*Room {
+	manager.mu.RLock()
+	defer manager.mu.RUnlock()
+	rooms := make([]*Room, 0, len(manager.rooms))
+	for _, room := range manager.rooms {
+		rooms = append(rooms, room)
+	}
+	sort.Slice(rooms, func(i, j int) bool {
+		return strings.ToLower(rooms[i].Name) < strings.ToLower(rooms[j].Name)
+	})
+	return rooms
+}
+
+// CountPlayers counts the players in a game.
+func (game *Game) CountPlayers() int {
+	count := 0
+	for _, entity := range game.Entities {
+		if _, ok := entity.(*Player); ok {
+			count++
+		}
+	}
+	return count
+}
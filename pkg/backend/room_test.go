@@ -0,0 +1,46 @@
+package backend_test
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/mortenson/grpc-game-example/pkg/backend"
+	"github.com/mortenson/grpc-game-example/pkg/backend/testutil"
+)
+
+func TestRoomManager(t *testing.T) {
+	manager := backend.NewRoomManager()
+	if rooms := manager.Rooms(); len(rooms) != 0 {
+		t.Errorf("got %d rooms, want none", len(rooms))
+	}
+	red := manager.AddRoom("red", testutil.NewGame())
+	blue := manager.AddRoom("Blue", testutil.NewGame())
+	green := manager.AddRoom("green", testutil.NewGame())
+	rooms := manager.Rooms()
+	want := []*backend.Room{blue, green, red}
+	if len(rooms) != len(want) {
+		t.Fatalf("got %d rooms, want %d", len(rooms), len(want))
+	}
+	for i, room := range rooms {
+		if room != want[i] {
+			t.Errorf("room %d is %s, want %s", i, room.Name, want[i].Name)
+		}
+	}
+	if manager.GetRoom(green.ID) != green {
+		t.Error("room was not found by ID")
+	}
+	if manager.GetRoom(uuid.New()) != nil {
+		t.Error("unknown room was found")
+	}
+}
+
+func TestCountPlayers(t *testing.T) {
+	game := testutil.NewGame(
+		testutil.WithPlayerAt("alice", 0, 0),
+		testutil.WithPlayerAt("bob", 1, 0),
+	)
+	addStillLaser(game, testutil.Player(game, "alice").ID(), backend.Coordinate{X: 3})
+	if count := game.CountPlayers(); count != 2 {
+		t.Errorf("got %d players, want 2", count)
+	}
+}
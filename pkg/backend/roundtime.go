@@ -0,0 +1,55 @@
+package backend
+
+import (
+	"bytes"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// checkRoundTime ends the round once the game's round duration has passed
+// since it started, if it has one. If lasers are still in flight when time is
+// up, the round goes on for up to GoldenGoalGrace so that they can land, and
+// ends as soon as none are left. The player with the highest score wins the
+// round. The game should be locked by the caller.
+func (game *Game) checkRoundTime(now time.Time) {
+	if game.RoundDuration <= 0 || game.WaitForRound || !game.IsAuthoritative || !game.canFight() {
+		return
+	}
+	end := game.roundStarted.Add(game.scaleDuration(game.RoundDuration))
+	if now.Before(end) {
+		return
+	}
+	grace := game.scaleDuration(game.GoldenGoalGrace)
+	if now.Before(end.Add(grace)) && game.hasLasersInFlight() {
+		return
+	}
+	game.queueNewRound(game.scoreLeader())
+}
+
+// hasLasersInFlight checks if any lasers are in play.
+func (game *Game) hasLasersInFlight() bool {
+	for _, entity := range game.Entities {
+		if _, ok := entity.(*Laser); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// scoreLeader returns the player with the highest score, preferring the lowest
+// ID when scores are tied. uuid.Nil is returned if there are no players.
+func (game *Game) scoreLeader() uuid.UUID {
+	leader := uuid.Nil
+	for _, player := range game.Players() {
+		id := player.ID()
+		if leader != uuid.Nil {
+			score, best := game.Score[id], game.Score[leader]
+			if score < best || (score == best && bytes.Compare(id[:], leader[:]) >= 0) {
+				continue
+			}
+		}
+		leader = id
+	}
+	return leader
+}
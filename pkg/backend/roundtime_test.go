@@ -0,0 +1,82 @@
+package backend_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/mortenson/grpc-game-example/pkg/backend"
+	"github.com/mortenson/grpc-game-example/pkg/backend/testutil"
+)
+
+func TestRoundTime(t *testing.T) {
+	tests := []struct {
+		name     string
+		duration time.Duration
+		grace    time.Duration
+		// after is how long after the round's duration the time is checked.
+		after  time.Duration
+		lasers bool
+		over   bool
+	}{
+		{name: "no time limit", duration: 0, after: time.Hour, over: false},
+		{name: "time left", duration: time.Minute, after: -time.Second, over: false},
+		{name: "time up", duration: time.Minute, after: time.Second, over: true},
+		{name: "lasers in flight", duration: time.Minute, grace: 5 * time.Second, after: time.Second, lasers: true, over: false},
+		{name: "no grace", duration: time.Minute, grace: 0, after: time.Second, lasers: true, over: true},
+		{name: "grace is over", duration: time.Minute, grace: 5 * time.Second, after: 5 * time.Second, lasers: true, over: true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			game := testutil.NewGame(
+				testutil.WithSequentialIDs(),
+				testutil.WithPlayerAt("alice", 0, 0),
+				testutil.WithPlayerAt("bob", 3, 0),
+			)
+			start := time.Now()
+			game.RoundDuration = test.duration
+			game.GoldenGoalGrace = test.grace
+			bob := testutil.Player(game, "bob")
+			game.Score[bob.ID()] = 2
+			if test.lasers {
+				addStillLaser(game, bob.ID(), backend.Coordinate{X: 5, Y: 5})
+			}
+			game.CheckRoundTime(start.Add(test.duration + test.after))
+			if game.WaitForRound != test.over {
+				t.Fatalf("round over is %v, want %v", game.WaitForRound, test.over)
+			}
+			// The player with the highest score wins.
+			if test.over && game.RoundWinner != bob.ID() {
+				t.Errorf("round winner is %s, want bob", game.RoundWinner)
+			}
+		})
+	}
+}
+
+func TestGoldenGoal(t *testing.T) {
+	game := testutil.NewGame(
+		testutil.WithSequentialIDs(),
+		testutil.WithPlayerAt("alice", 0, 0),
+		testutil.WithPlayerAt("bob", 3, 0),
+		testutil.WithPlayerAt("carol", 3, 3),
+	)
+	start := time.Now()
+	game.RoundDuration = time.Minute
+	game.GoldenGoalGrace = 5 * time.Second
+	bob := testutil.Player(game, "bob")
+	carol := testutil.Player(game, "carol")
+	addStillLaser(game, bob.ID(), carol.Position())
+	timeUp := start.Add(game.RoundDuration + time.Second)
+	game.CheckRoundTime(timeUp)
+	if game.WaitForRound {
+		t.Fatal("the round ended with a laser in flight")
+	}
+	// The laser lands, which gives bob the lead.
+	game.Step()
+	if len(game.RecentKills) != 1 || game.RecentKills[0].KillerID != bob.ID() {
+		t.Fatalf("got kills %+v, want bob to kill carol", game.RecentKills)
+	}
+	game.CheckRoundTime(timeUp.Add(time.Second))
+	if !game.WaitForRound || game.RoundWinner != bob.ID() {
+		t.Errorf("round winner is %s, want bob once the laser landed", game.RoundWinner)
+	}
+}
@@ -0,0 +1,100 @@
+package backend_test
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mortenson/grpc-game-example/pkg/backend"
+	"github.com/mortenson/grpc-game-example/pkg/backend/testutil"
+)
+
+func TestScoreAccessors(t *testing.T) {
+	game := testutil.NewGame(
+		testutil.WithPlayerAt("alice", 0, 0),
+		testutil.WithPlayerAt("bob", 1, 0),
+	)
+	alice := testutil.Player(game, "alice")
+	bob := testutil.Player(game, "bob")
+	game.Score[alice.ID()] = 3
+	tests := []struct {
+		name string
+		id   uuid.UUID
+		want int
+	}{
+		{name: "scored", id: alice.ID(), want: 3},
+		{name: "not scored", id: bob.ID(), want: 0},
+		{name: "unknown entities", id: uuid.New(), want: 0},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if score := game.GetScore(test.id); score != test.want {
+				t.Errorf("got score %d, want %d", score, test.want)
+			}
+		})
+	}
+	scores := game.AllScores()
+	if scores[alice.ID()] != 3 {
+		t.Errorf("got scores %v, want alice to have 3", scores)
+	}
+	scores[alice.ID()] = 10
+	if score := game.GetScore(alice.ID()); score != 3 {
+		t.Errorf("changing the copy changed the score to %d", score)
+	}
+}
+
+// TestConcurrentScores is meant to be run with -race, and checks that scores
+// can be read while actions are submitted and the game scores players.
+func TestConcurrentScores(t *testing.T) {
+	game := testutil.NewGame(
+		testutil.WithPlayerAt("alice", 0, 0),
+		testutil.WithPlayerAt("bob", 5, 5),
+	)
+	alice := testutil.Player(game, "alice")
+	aliceID := alice.ID()
+	start := time.Now()
+	const rounds = 200
+	var wg sync.WaitGroup
+	wg.Add(4)
+	// Actions are submitted by several clients at once.
+	for _, direction := range []backend.Direction{backend.DirectionLeft, backend.DirectionRight} {
+		direction := direction
+		go func() {
+			defer wg.Done()
+			for i := 0; i < rounds; i++ {
+				game.SubmitActions([]backend.Action{backend.MoveAction{
+					ID:        aliceID,
+					Direction: direction,
+					Created:   start.Add(time.Duration(i) * time.Second),
+				}})
+			}
+		}()
+	}
+	// The game performs them, and scores alice.
+	go func() {
+		defer wg.Done()
+		for i := 0; i < rounds; i++ {
+			game.Step()
+			game.Mu.Lock()
+			game.AddScore(aliceID)
+			game.Mu.Unlock()
+		}
+	}()
+	go func() {
+		defer wg.Done()
+		for i := 0; i < rounds; i++ {
+			score := game.GetScore(aliceID)
+			scores := game.AllScores()
+			if scores[aliceID] < score {
+				t.Errorf("score went from %d to %d", score, scores[aliceID])
+				return
+			}
+			scores[aliceID] = -1
+		}
+	}()
+	wg.Wait()
+	if score := game.GetScore(aliceID); score != rounds {
+		t.Errorf("got score %d, want %d", score, rounds)
+	}
+}
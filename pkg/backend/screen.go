@@ -0,0 +1,57 @@
+package backend
+
+// ScreenTransform maps game coordinates to screen coordinates, so frontends
+// don't need their own mapping. Origin is where the game's origin is drawn,
+// and each cell is Scale screen units wide and tall. A Scale of zero is
+// treated as one.
+type ScreenTransform struct {
+	Origin Coordinate
+	Scale  int
+}
+
+// scale returns the transform's scale, defaulting to one.
+func (transform ScreenTransform) scale() int {
+	if transform.Scale <= 0 {
+		return 1
+	}
+	return transform.Scale
+}
+
+// ToScreen returns the screen position of the top left of a cell.
+func (transform ScreenTransform) ToScreen(c Coordinate) Coordinate {
+	scale := transform.scale()
+	return Coordinate{
+		X: transform.Origin.X + c.X*scale,
+		Y: transform.Origin.Y + c.Y*scale,
+	}
+}
+
+// FromScreen returns the cell that contains a screen position.
+func (transform ScreenTransform) FromScreen(c Coordinate) Coordinate {
+	scale := transform.scale()
+	return Coordinate{
+		X: floorDiv(c.X-transform.Origin.X, scale),
+		Y: floorDiv(c.Y-transform.Origin.Y, scale),
+	}
+}
+
+// floorDiv divides, rounding towards negative infinity so that screen
+// positions left of or above the origin map to negative cells.
+func floorDiv(a, b int) int {
+	quotient := a / b
+	if a%b != 0 && (a < 0) != (b < 0) {
+		quotient--
+	}
+	return quotient
+}
+
+// ToScreen maps a game coordinate to the screen using the game's transform.
+func (game *Game) ToScreen(c Coordinate) Coordinate {
+	return game.Screen.ToScreen(c)
+}
+
+// FromScreen maps a screen position to a game coordinate using the game's
+// transform.
+func (game *Game) FromScreen(c Coordinate) Coordinate {
+	return game.Screen.FromScreen(c)
+}
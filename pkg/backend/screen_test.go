@@ -0,0 +1,74 @@
+package backend_test
+
+import (
+	"testing"
+
+	"github.com/mortenson/grpc-game-example/pkg/backend"
+)
+
+func TestScreenTransform(t *testing.T) {
+	tests := []struct {
+		name      string
+		transform backend.ScreenTransform
+		cell      backend.Coordinate
+		screen    backend.Coordinate
+	}{
+		{name: "identity", transform: backend.ScreenTransform{}, cell: backend.Coordinate{X: 3, Y: -2}, screen: backend.Coordinate{X: 3, Y: -2}},
+		{
+			name:      "origin",
+			transform: backend.ScreenTransform{Origin: backend.Coordinate{X: 40, Y: 12}},
+			cell:      backend.Coordinate{X: -5, Y: 3},
+			screen:    backend.Coordinate{X: 35, Y: 15},
+		},
+		{
+			name:      "scale",
+			transform: backend.ScreenTransform{Scale: 2},
+			cell:      backend.Coordinate{X: 3, Y: 4},
+			screen:    backend.Coordinate{X: 6, Y: 8},
+		},
+		{
+			name:      "negative coordinates with a scale",
+			transform: backend.ScreenTransform{Origin: backend.Coordinate{X: 10, Y: 10}, Scale: 3},
+			cell:      backend.Coordinate{X: -4, Y: -1},
+			screen:    backend.Coordinate{X: -2, Y: 7},
+		},
+		{
+			name:      "negative scales are one",
+			transform: backend.ScreenTransform{Scale: -2},
+			cell:      backend.Coordinate{X: -1, Y: 1},
+			screen:    backend.Coordinate{X: -1, Y: 1},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if screen := test.transform.ToScreen(test.cell); screen != test.screen {
+				t.Errorf("cell %+v is drawn at %+v, want %+v", test.cell, screen, test.screen)
+			}
+			if cell := test.transform.FromScreen(test.screen); cell != test.cell {
+				t.Errorf("screen position %+v is in cell %+v, want %+v", test.screen, cell, test.cell)
+			}
+		})
+	}
+}
+
+func TestFromScreen(t *testing.T) {
+	transform := backend.ScreenTransform{Origin: backend.Coordinate{X: 10, Y: 10}, Scale: 3}
+	tests := []struct {
+		name   string
+		screen backend.Coordinate
+		cell   backend.Coordinate
+	}{
+		{name: "origin", screen: backend.Coordinate{X: 10, Y: 10}, cell: backend.Coordinate{}},
+		{name: "inside the origin cell", screen: backend.Coordinate{X: 12, Y: 11}, cell: backend.Coordinate{}},
+		{name: "just left of the origin", screen: backend.Coordinate{X: 9, Y: 10}, cell: backend.Coordinate{X: -1}},
+		{name: "inside a negative cell", screen: backend.Coordinate{X: 7, Y: 5}, cell: backend.Coordinate{X: -1, Y: -2}},
+		{name: "negative screen positions", screen: backend.Coordinate{X: -1, Y: -3}, cell: backend.Coordinate{X: -4, Y: -5}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if cell := transform.FromScreen(test.screen); cell != test.cell {
+				t.Errorf("got cell %+v, want %+v", cell, test.cell)
+			}
+		})
+	}
+}
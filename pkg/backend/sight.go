@@ -0,0 +1,66 @@
+package backend
+
+// HasLineOfSight checks if a straight line between two cells is clear of map
+// walls and wall entities. The cells at either end never block the line, so
+// walls can be seen. The game should be locked by the caller.
+func (game *Game) HasLineOfSight(from Coordinate, to Coordinate) bool {
+	return game.lineOfSight(from, to, game.sightBlockers())
+}
+
+// VisibleCells returns the cells within a radius of a position that it has a
+// line of sight to. The game should be locked by the caller.
+func (game *Game) VisibleCells(from Coordinate, radius int) map[Coordinate]bool {
+	blockers := game.sightBlockers()
+	visible := make(map[Coordinate]bool)
+	for x := from.X - radius; x <= from.X+radius; x++ {
+		for y := from.Y - radius; y <= from.Y+radius; y++ {
+			cell := Coordinate{X: x, Y: y}
+			if from.Distance(cell) <= radius && game.lineOfSight(from, cell, blockers) {
+				visible[cell] = true
+			}
+		}
+	}
+	return visible
+}
+
+// sightBlockers returns the wall entities' positions, as map walls are looked
+// up separately.
+func (game *Game) sightBlockers() map[Coordinate]bool {
+	blockers := make(map[Coordinate]bool)
+	for _, entity := range game.Entities {
+		if wall, ok := entity.(*Wall); ok {
+			blockers[wall.Position()] = true
+		}
+	}
+	return blockers
+}
+
+// lineOfSight walks the cells between two cells using Bresenham's line
+// algorithm, and checks that none of them are walls.
+func (game *Game) lineOfSight(from Coordinate, to Coordinate, blockers map[Coordinate]bool) bool {
+	dx, dy := to.X-from.X, to.Y-from.Y
+	stepX, stepY := 1, 1
+	if dx < 0 {
+		dx, stepX = -dx, -1
+	}
+	if dy < 0 {
+		dy, stepY = -dy, -1
+	}
+	err := dx - dy
+	position := from
+	for position != to {
+		if position != from && (blockers[position] || game.mapTypeAt(position) == MapTypeWall) {
+			return false
+		}
+		doubled := err * 2
+		if doubled > -dy {
+			err -= dy
+			position.X += stepX
+		}
+		if doubled < dx {
+			err += dx
+			position.Y += stepY
+		}
+	}
+	return true
+}
@@ -0,0 +1,79 @@
+package backend_test
+
+import (
+	"testing"
+
+	"github.com/mortenson/grpc-game-example/pkg/backend"
+	"github.com/mortenson/grpc-game-example/pkg/backend/testutil"
+)
+
+// newSightGame returns a game with a map wall at the origin, and optionally
+// wall entities.
+func newSightGame(t *testing.T, walls ...backend.Coordinate) *backend.Game {
+	config := testutil.MapConfig(
+		"S        ",
+		"         ",
+		"    █    ",
+		"         ",
+		"        S",
+	)
+	game := testutil.NewGameFromConfig(t, config)
+	for _, position := range walls {
+		testutil.WithWallAt(position.X, position.Y)(game)
+	}
+	return game
+}
+
+func TestHasLineOfSight(t *testing.T) {
+	tests := []struct {
+		name     string
+		from, to backend.Coordinate
+		walls    []backend.Coordinate
+		want     bool
+	}{
+		{name: "same cell", from: backend.Coordinate{X: -3}, to: backend.Coordinate{X: -3}, want: true},
+		{name: "clear lines", from: backend.Coordinate{X: -3, Y: -2}, to: backend.Coordinate{X: 3, Y: -2}, want: true},
+		{name: "map walls block lines", from: backend.Coordinate{X: -3}, to: backend.Coordinate{X: 3}, want: false},
+		{name: "lines are blocked both ways", from: backend.Coordinate{X: 3}, to: backend.Coordinate{X: -3}, want: false},
+		{name: "diagonal lines", from: backend.Coordinate{X: -2, Y: -2}, to: backend.Coordinate{X: 2, Y: 2}, want: false},
+		{name: "walls can be seen", from: backend.Coordinate{X: -3}, to: backend.Coordinate{}, want: true},
+		{
+			name:  "wall entities block lines",
+			from:  backend.Coordinate{X: -3, Y: -2},
+			to:    backend.Coordinate{X: 3, Y: -2},
+			walls: []backend.Coordinate{{Y: -2}},
+			want:  false,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			game := newSightGame(t, test.walls...)
+			if got := game.HasLineOfSight(test.from, test.to); got != test.want {
+				t.Errorf("got line of sight %v, want %v", got, test.want)
+			}
+		})
+	}
+}
+
+func TestVisibleCells(t *testing.T) {
+	game := newSightGame(t)
+	visible := game.VisibleCells(backend.Coordinate{X: -2}, 3)
+	tests := []struct {
+		name    string
+		cell    backend.Coordinate
+		visible bool
+	}{
+		{name: "own cell", cell: backend.Coordinate{X: -2}, visible: true},
+		{name: "walls", cell: backend.Coordinate{}, visible: true},
+		{name: "at the radius", cell: backend.Coordinate{X: -2, Y: 3}, visible: true},
+		{name: "behind walls", cell: backend.Coordinate{X: 1}, visible: false},
+		{name: "outside the radius", cell: backend.Coordinate{X: 2, Y: 2}, visible: false},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if visible[test.cell] != test.visible {
+				t.Errorf("visible is %v, want %v", visible[test.cell], test.visible)
+			}
+		})
+	}
+}
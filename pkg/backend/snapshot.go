@@ -0,0 +1,200 @@
+package backend
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// snapshotVersion is the current snapshot format. When the format changes,
+// increment it and add a migration from the previous version so that old
+// snapshots can still be restored.
+const snapshotVersion = 1
+
+// snapshotMigrations upgrade decoded snapshots by one version, keyed by the
+// version they upgrade from. Snapshots without a version are version 0.
+var snapshotMigrations = map[int]func(snapshot map[string]json.RawMessage) error{
+	0: migrateSnapshotV0,
+}
+
+// migrateSnapshotV0 upgrades snapshots saved before snapshots were versioned,
+// which only differ from version 1 by not having a version.
+func migrateSnapshotV0(snapshot map[string]json.RawMessage) error {
+	snapshot["version"] = json.RawMessage("1")
+	return nil
+}
+
+// snapshot is the persisted form of a game's state.
+type snapshot struct {
+	Version int               `json:"version"`
+	Players []snapshotPlayer  `json:"players"`
+	Lasers  []snapshotLaser   `json:"lasers"`
+	Walls   []snapshotWall    `json:"walls"`
+	Score   map[uuid.UUID]int `json:"score"`
+}
+
+type snapshotPlayer struct {
+	ID       uuid.UUID  `json:"id"`
+	Name     string     `json:"name"`
+	Icon     string     `json:"icon"`
+	Color    string     `json:"color"`
+	Position Coordinate `json:"position"`
+}
+
+type snapshotLaser struct {
+	ID              uuid.UUID  `json:"id"`
+	OwnerID         uuid.UUID  `json:"ownerId"`
+	InitialPosition Coordinate `json:"initialPosition"`
+	Direction       Direction  `json:"direction"`
+	StartTime       time.Time  `json:"startTime"`
+	SpeedMultiplier float64    `json:"speedMultiplier"`
+}
+
+type snapshotWall struct {
+	ID           uuid.UUID  `json:"id"`
+	Position     Coordinate `json:"position"`
+	Destructible bool       `json:"destructible"`
+	Health       int        `json:"health"`
+}
+
+// Snapshot encodes the game's entities and scores so that they can be
+// persisted and restored later. The game should be locked by the caller.
+func (game *Game) Snapshot() ([]byte, error) {
+	s := snapshot{
+		Version: snapshotVersion,
+		Players: []snapshotPlayer{},
+		Lasers:  []snapshotLaser{},
+		Walls:   []snapshotWall{},
+		Score:   game.Score,
+	}
+	for _, entity := range game.Entities {
+		switch entity := entity.(type) {
+		case *Player:
+			s.Players = append(s.Players, snapshotPlayer{
+				ID:       entity.ID(),
+				Name:     entity.Name,
+				Icon:     string(entity.Icon),
+				Color:    entity.Color,
+				Position: entity.Position(),
+			})
+		case *Laser:
+			s.Lasers = append(s.Lasers, snapshotLaser{
+				ID:              entity.ID(),
+				OwnerID:         entity.OwnerID,
+				InitialPosition: entity.InitialPosition,
+				Direction:       entity.Direction,
+				StartTime:       entity.StartTime,
+				SpeedMultiplier: entity.SpeedMultiplier,
+			})
+		case *Wall:
+			s.Walls = append(s.Walls, snapshotWall{
+				ID:           entity.ID(),
+				Position:     entity.Position(),
+				Destructible: entity.Destructible,
+				Health:       entity.Health,
+			})
+		}
+	}
+	return json.Marshal(s)
+}
+
+// DynamicSnapshot returns the entities that change during a game, such as
+// players, lasers, power-ups, and destructible walls, which can be damaged and
+// destroyed. Static entities, like the map's cells and indestructible walls,
+// are left out. The game should be locked by the caller.
+func (game *Game) DynamicSnapshot() []Identifier {
+	entities := make([]Identifier, 0, len(game.Entities))
+	for _, entity := range game.Entities {
+		if isStatic(entity) {
+			continue
+		}
+		entities = append(entities, entity)
+	}
+	return entities
+}
+
+// isStatic returns true if an entity never changes once it is added.
+func isStatic(entity Identifier) bool {
+	wall, ok := entity.(*Wall)
+	return ok && !wall.Destructible
+}
+
+// Restore replaces the game's entities and scores with those in a snapshot.
+// Snapshots from older versions are migrated, but snapshots from newer
+// versions can not be read. The game should be locked by the caller.
+func (game *Game) Restore(data []byte) error {
+	raw := map[string]json.RawMessage{}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return newError(ErrorCodeInvalidSnapshot, err, "invalid snapshot: %v", err)
+	}
+	version := 0
+	if rawVersion, ok := raw["version"]; ok {
+		if err := json.Unmarshal(rawVersion, &version); err != nil {
+			return newError(ErrorCodeInvalidSnapshot, err, "invalid snapshot version: %v", err)
+		}
+	}
+	if version > snapshotVersion {
+		return newError(ErrorCodeInvalidSnapshot, nil, "snapshot version %d is newer than the supported version %d", version, snapshotVersion)
+	}
+	for ; version < snapshotVersion; version++ {
+		migrate, ok := snapshotMigrations[version]
+		if !ok {
+			return newError(ErrorCodeInvalidSnapshot, nil, "snapshot version %d is not supported", version)
+		}
+		if err := migrate(raw); err != nil {
+			return newError(ErrorCodeInvalidSnapshot, err, "can not migrate snapshot from version %d: %v", version, err)
+		}
+	}
+	migrated, err := json.Marshal(raw)
+	if err != nil {
+		return err
+	}
+	s := snapshot{}
+	if err := json.Unmarshal(migrated, &s); err != nil {
+		return newError(ErrorCodeInvalidSnapshot, err, "invalid snapshot: %v", err)
+	}
+
+	// Build the new state first, so that the game is unchanged if the
+	// snapshot is invalid.
+	entities := make(map[uuid.UUID]Identifier)
+	for _, player := range s.Players {
+		icon := []rune(player.Icon)
+		if len(icon) != 1 {
+			return newError(ErrorCodeInvalidSnapshot, nil, "invalid icon for player %s", player.ID)
+		}
+		entities[player.ID] = &Player{
+			IdentifierBase:  IdentifierBase{player.ID},
+			Name:            player.Name,
+			Icon:            icon[0],
+			Color:           player.Color,
+			CurrentPosition: player.Position,
+		}
+	}
+	for _, laser := range s.Lasers {
+		entities[laser.ID] = &Laser{
+			IdentifierBase:  IdentifierBase{laser.ID},
+			OwnerID:         laser.OwnerID,
+			InitialPosition: laser.InitialPosition,
+			Direction:       laser.Direction,
+			StartTime:       laser.StartTime,
+			SpeedMultiplier: laser.SpeedMultiplier,
+		}
+	}
+	for _, wall := range s.Walls {
+		entities[wall.ID] = &Wall{
+			IdentifierBase:  IdentifierBase{wall.ID},
+			CurrentPosition: wall.Position,
+			Destructible:    wall.Destructible,
+			Health:          wall.Health,
+		}
+	}
+	score := make(map[uuid.UUID]int)
+	for id, value := range s.Score {
+		score[id] = value
+	}
+	game.Entities = entities
+	game.Score = score
+	game.wakeCollisions()
+	return nil
+}
@@ -0,0 +1,241 @@
+package backend_test
+
+import (
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mortenson/grpc-game-example/pkg/backend"
+	"github.com/mortenson/grpc-game-example/pkg/backend/testutil"
+)
+
+func TestSnapshotRoundTrip(t *testing.T) {
+	game := testutil.NewGame(
+		testutil.WithPlayerAt("alice", 1, 2),
+		testutil.WithPlayerAt("bob", -3, 0),
+	)
+	alice := testutil.Player(game, "alice")
+	alice.Color = "fuchsia"
+	laser := addStillLaser(game, alice.ID(), backend.Coordinate{X: 4, Y: 4})
+	wall := &backend.Wall{
+		IdentifierBase:  backend.IdentifierBase{UUID: uuid.New()},
+		CurrentPosition: backend.Coordinate{X: -1, Y: -1},
+		Destructible:    true,
+		Health:          2,
+	}
+	game.AddEntity(wall)
+	game.SetScore(alice.ID(), 3)
+	data, err := game.Snapshot()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	restored := testutil.NewGame(testutil.WithPlayerAt("carol", 0, 0))
+	if err := restored.Restore(data); err != nil {
+		t.Fatal(err)
+	}
+	if len(restored.Entities) != 4 || testutil.Player(restored, "carol") != nil {
+		t.Errorf("got %d entities, want only the snapshot's 4", len(restored.Entities))
+	}
+	restoredAlice := testutil.Player(restored, "alice")
+	if restoredAlice == nil || restoredAlice.ID() != alice.ID() || restoredAlice.Icon != 'A' ||
+		restoredAlice.Color != "fuchsia" || restoredAlice.Position() != alice.Position() {
+		t.Errorf("got player %+v, want %+v", restoredAlice, alice)
+	}
+	restoredLaser, ok := restored.GetEntity(laser.ID()).(*backend.Laser)
+	if !ok || restoredLaser.OwnerID != alice.ID() || restoredLaser.InitialPosition != laser.InitialPosition ||
+		!restoredLaser.StartTime.Equal(laser.StartTime) {
+		t.Errorf("got laser %+v, want %+v", restoredLaser, laser)
+	}
+	restoredWall, ok := restored.GetEntity(wall.ID()).(*backend.Wall)
+	if !ok || restoredWall.Position() != wall.Position() || !restoredWall.Destructible || restoredWall.Health != 2 {
+		t.Errorf("got wall %+v, want %+v", restoredWall, wall)
+	}
+	if score := restored.Score[alice.ID()]; score != 3 {
+		t.Errorf("alice has score %d, want 3", score)
+	}
+}
+
+func TestRestore(t *testing.T) {
+	playerID := uuid.MustParse("00000000-0000-0000-0000-000000000001")
+	tests := []struct {
+		name     string
+		snapshot string
+		// migrate replaces the migration from version 0 while restoring,
+		// and removeMigration removes it.
+		migrate         func(snapshot map[string]json.RawMessage) error
+		removeMigration bool
+		ok              bool
+	}{
+		{
+			name:     "current version",
+			snapshot: `{"version": 1, "players": [{"id": "` + playerID.String() + `", "name": "alice", "icon": "A"}]}`,
+			ok:       true,
+		},
+		{
+			name:     "future version",
+			snapshot: `{"version": 2, "players": []}`,
+			ok:       false,
+		},
+		{
+			name:     "unversioned",
+			snapshot: `{"players": [{"id": "` + playerID.String() + `", "name": "alice", "icon": "A"}]}`,
+			ok:       true,
+		},
+		{
+			name:            "old version without a migration",
+			snapshot:        `{"players": [{"id": "` + playerID.String() + `", "name": "alice", "icon": "A"}]}`,
+			removeMigration: true,
+			ok:              false,
+		},
+		{
+			name:     "old version with a migration",
+			snapshot: `{"people": [{"id": "` + playerID.String() + `", "name": "alice", "icon": "A"}]}`,
+			migrate: func(snapshot map[string]json.RawMessage) error {
+				snapshot["players"] = snapshot["people"]
+				delete(snapshot, "people")
+				return nil
+			},
+			ok: true,
+		},
+		{
+			name:     "failed migration",
+			snapshot: `{"people": []}`,
+			migrate: func(snapshot map[string]json.RawMessage) error {
+				return errors.New("no people")
+			},
+			ok: false,
+		},
+		{name: "invalid JSON", snapshot: `{"version": `, ok: false},
+		{name: "invalid version", snapshot: `{"version": "one"}`, ok: false},
+		{
+			name:     "invalid icon",
+			snapshot: `{"version": 1, "players": [{"id": "` + playerID.String() + `", "name": "alice", "icon": "AB"}]}`,
+			ok:       false,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			migrate := backend.SnapshotMigrations[0]
+			defer func() {
+				backend.SnapshotMigrations[0] = migrate
+			}()
+			if test.migrate != nil {
+				backend.SnapshotMigrations[0] = test.migrate
+			}
+			if test.removeMigration {
+				delete(backend.SnapshotMigrations, 0)
+			}
+			game := testutil.NewGame(testutil.WithPlayerAt("bob", 0, 0))
+			bob := testutil.Player(game, "bob")
+			err := game.Restore([]byte(test.snapshot))
+			if (err == nil) != test.ok {
+				t.Fatalf("got error %v, want ok %v", err, test.ok)
+			}
+			if err != nil {
+				if !errors.Is(err, backend.ErrInvalidSnapshot) {
+					t.Errorf("got error %v, want an invalid snapshot error", err)
+				}
+				if game.GetEntity(bob.ID()) != bob {
+					t.Error("the game was changed by an invalid snapshot")
+				}
+				return
+			}
+			alice, ok := game.GetEntity(playerID).(*backend.Player)
+			if !ok || alice.Name != "alice" || game.GetEntity(bob.ID()) != nil {
+				t.Errorf("got entities %v, want only alice", game.Entities)
+			}
+		})
+	}
+}
+
+func TestRestoreUnversioned(t *testing.T) {
+	// The snapshot was saved before snapshots were versioned.
+	data, err := ioutil.ReadFile(filepath.Join("testdata", "snapshot_v0.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	game := testutil.NewGame()
+	if err := game.Restore(data); err != nil {
+		t.Fatal(err)
+	}
+	aliceID := uuid.MustParse("00000000-0000-0000-0000-000000000001")
+	alice, ok := game.GetEntity(aliceID).(*backend.Player)
+	if !ok || alice.Name != "alice" || alice.Icon != 'A' || alice.Color != "fuchsia" ||
+		alice.Position() != (backend.Coordinate{X: 1, Y: 2}) {
+		t.Errorf("got player %+v, want alice", alice)
+	}
+	laser, ok := game.GetEntity(uuid.MustParse("00000000-0000-0000-0000-000000000002")).(*backend.Laser)
+	if !ok || laser.OwnerID != aliceID || laser.InitialPosition != (backend.Coordinate{X: 4, Y: 4}) ||
+		laser.Direction != backend.DirectionDown || !laser.StartTime.Equal(time.Date(2020, 4, 1, 12, 0, 0, 0, time.UTC)) {
+		t.Errorf("got laser %+v, want alice's laser", laser)
+	}
+	wall, ok := game.GetEntity(uuid.MustParse("00000000-0000-0000-0000-000000000003")).(*backend.Wall)
+	if !ok || wall.Position() != (backend.Coordinate{X: -1, Y: -1}) || !wall.Destructible || wall.Health != 2 {
+		t.Errorf("got wall %+v, want a destructible wall", wall)
+	}
+	if score := game.Score[aliceID]; score != 3 {
+		t.Errorf("alice has score %d, want 3", score)
+	}
+}
+
+func TestDynamicSnapshot(t *testing.T) {
+	config := testutil.MapConfig(
+		"S █  ",
+		"  █  ",
+		"    S",
+	)
+	game := testutil.NewGameFromConfig(t, config, testutil.WithPlayerAt("alice", -1, 0))
+	alice := testutil.Player(game, "alice")
+	laser := addStillLaser(game, alice.ID(), backend.Coordinate{X: 1, Y: 1})
+	powerUp := &backend.PowerUp{
+		IdentifierBase:  backend.IdentifierBase{UUID: game.IDGenerator()},
+		CurrentPosition: backend.Coordinate{X: 2, Y: 1},
+	}
+	game.AddEntity(powerUp)
+	destructibleWall := &backend.Wall{
+		IdentifierBase:  backend.IdentifierBase{UUID: game.IDGenerator()},
+		CurrentPosition: backend.Coordinate{X: 1, Y: -1},
+		Destructible:    true,
+		Health:          2,
+	}
+	game.AddEntity(destructibleWall)
+	wall := &backend.Wall{
+		IdentifierBase:  backend.IdentifierBase{UUID: game.IDGenerator()},
+		CurrentPosition: backend.Coordinate{X: 2, Y: -1},
+	}
+	game.AddEntity(wall)
+	tests := []struct {
+		name     string
+		entity   backend.Identifier
+		included bool
+	}{
+		{name: "players", entity: alice, included: true},
+		{name: "lasers", entity: laser, included: true},
+		{name: "power-ups", entity: powerUp, included: true},
+		{name: "destructible walls", entity: destructibleWall, included: true},
+		{name: "indestructible walls", entity: wall, included: false},
+	}
+	snapshot := game.DynamicSnapshot()
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			included := false
+			for _, entity := range snapshot {
+				if entity == test.entity {
+					included = true
+				}
+			}
+			if included != test.included {
+				t.Errorf("%s are included %v, want %v", test.name, included, test.included)
+			}
+		})
+	}
+	// The map's walls are static, and clients load them from the map.
+	if len(snapshot) != 4 {
+		t.Errorf("got %d entities, want only the 4 dynamic ones", len(snapshot))
+	}
+}
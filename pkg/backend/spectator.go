@@ -0,0 +1,35 @@
+package backend
+
+// SpectatorCountChange occurs when a spectator starts or stops watching.
+type SpectatorCountChange struct {
+	Change
+	Count int
+}
+
+// SpectatorCount returns how many spectators are watching the game.
+func (game *Game) SpectatorCount() int {
+	return game.spectators
+}
+
+// AddSpectator records that a spectator started watching.
+func (game *Game) AddSpectator() {
+	game.SetSpectatorCount(game.spectators + 1)
+}
+
+// RemoveSpectator records that a spectator stopped watching.
+func (game *Game) RemoveSpectator() {
+	if game.spectators == 0 {
+		return
+	}
+	game.SetSpectatorCount(game.spectators - 1)
+}
+
+// SetSpectatorCount sets the spectator count, which clients use to mirror the
+// server's count.
+func (game *Game) SetSpectatorCount(count int) {
+	if count == game.spectators {
+		return
+	}
+	game.spectators = count
+	game.sendChange(SpectatorCountChange{Count: count})
+}
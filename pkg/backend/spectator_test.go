@@ -0,0 +1,66 @@
+package backend_test
+
+import (
+	"testing"
+
+	"github.com/mortenson/grpc-game-example/pkg/backend"
+	"github.com/mortenson/grpc-game-example/pkg/backend/testutil"
+)
+
+func TestSpectatorCount(t *testing.T) {
+	tests := []struct {
+		name string
+		// update changes the count of a game with one spectator.
+		update  func(game *backend.Game)
+		want    int
+		changed bool
+	}{
+		{name: "subscribing", update: (*backend.Game).AddSpectator, want: 2, changed: true},
+		{name: "cancelling", update: (*backend.Game).RemoveSpectator, want: 0, changed: true},
+		{
+			name: "cancelling without spectators",
+			update: func(game *backend.Game) {
+				game.RemoveSpectator()
+				testutil.DrainChanges(game)
+				game.RemoveSpectator()
+			},
+			want:    0,
+			changed: false,
+		},
+		{
+			name:    "setting the count",
+			update:  func(game *backend.Game) { game.SetSpectatorCount(5) },
+			want:    5,
+			changed: true,
+		},
+		{
+			name:    "setting the same count",
+			update:  func(game *backend.Game) { game.SetSpectatorCount(1) },
+			want:    1,
+			changed: false,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			game := testutil.NewGame()
+			game.AddSpectator()
+			testutil.DrainChanges(game)
+			test.update(game)
+			if count := game.SpectatorCount(); count != test.want {
+				t.Errorf("got %d spectators, want %d", count, test.want)
+			}
+			changes := []backend.SpectatorCountChange{}
+			for _, change := range testutil.DrainChanges(game) {
+				if change, ok := change.(backend.SpectatorCountChange); ok {
+					changes = append(changes, change)
+				}
+			}
+			if changed := len(changes) > 0; changed != test.changed {
+				t.Fatalf("got changes %+v, want a change %v", changes, test.changed)
+			}
+			if test.changed && (len(changes) != 1 || changes[0].Count != test.want) {
+				t.Errorf("got changes %+v, want one to %d", changes, test.want)
+			}
+		})
+	}
+}
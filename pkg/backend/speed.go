@@ -0,0 +1,51 @@
+package backend
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// scaleDuration scales a duration by the game's speed multiplier, so that
+// doubling the speed halves durations. The multiplier is ignored unless it's
+// positive.
+func (game *Game) scaleDuration(duration time.Duration) time.Duration {
+	if game.SpeedMultiplier <= 0 {
+		return duration
+	}
+	return time.Duration(float64(duration) / game.SpeedMultiplier)
+}
+
+// entityMoveThrottle returns how long an entity has to wait between moves,
+// which is scaled by the entity's speed multiplier if it's a player.
+func (game *Game) entityMoveThrottle(entity Identifier) time.Duration {
+	player, ok := entity.(*Player)
+	if !ok || player.SpeedMultiplier <= 0 {
+		return game.moveThrottle
+	}
+	return time.Duration(float64(game.moveThrottle) / player.SpeedMultiplier)
+}
+
+// MoveThrottle returns how long players have to wait between moves, including
+// the active modifier, before speed multipliers are applied. The game should
+// be read locked by the caller.
+func (game *Game) MoveThrottle() time.Duration {
+	return game.moveThrottle
+}
+
+// SetMoveThrottle sets how long players have to wait between moves, such as
+// to match the server's throttle. The game should be locked by the caller.
+func (game *Game) SetMoveThrottle(throttle time.Duration) {
+	game.moveThrottle = throttle
+}
+
+// PlayerMoveThrottle returns how long a player has to wait between moves,
+// after the game's and the player's speed multipliers are applied. The game
+// should be read locked by the caller.
+func (game *Game) PlayerMoveThrottle(playerID uuid.UUID) time.Duration {
+	entity := game.GetEntity(playerID)
+	if entity == nil {
+		return game.scaleDuration(game.moveThrottle)
+	}
+	return game.scaleDuration(game.entityMoveThrottle(entity))
+}
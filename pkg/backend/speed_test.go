@@ -0,0 +1,196 @@
+package backend_test
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/mortenson/grpc-game-example/pkg/backend"
+	"github.com/mortenson/grpc-game-example/pkg/backend/testutil"
+)
+
+func TestPlayerMoveThrottle(t *testing.T) {
+	tests := []struct {
+		name       string
+		throttle   time.Duration
+		modifier   string
+		multiplier float64
+		// playerMultiplier is alice's speed multiplier.
+		playerMultiplier float64
+		want             time.Duration
+	}{
+		{name: "default throttle", throttle: 100 * time.Millisecond, want: 100 * time.Millisecond},
+		{name: "configured throttle", throttle: 60 * time.Millisecond, want: 60 * time.Millisecond},
+		{name: "fast movement", throttle: 100 * time.Millisecond, modifier: "fast movement", want: 50 * time.Millisecond},
+		{name: "double speed game", throttle: 100 * time.Millisecond, multiplier: 2, want: 50 * time.Millisecond},
+		{name: "double speed player", throttle: 100 * time.Millisecond, playerMultiplier: 2, want: 50 * time.Millisecond},
+		{
+			name:             "everything at once",
+			throttle:         80 * time.Millisecond,
+			modifier:         "fast movement",
+			multiplier:       2,
+			playerMultiplier: 0.5,
+			want:             40 * time.Millisecond,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			config := backend.DefaultConfig()
+			config.MoveThrottle = test.throttle
+			config.SpeedMultiplier = 1
+			if test.multiplier > 0 {
+				config.SpeedMultiplier = test.multiplier
+			}
+			game := testutil.NewGameFromConfig(t, config, testutil.WithPlayerAt("alice", 0, 0))
+			if test.modifier != "" {
+				game.ApplyModifier(test.modifier)
+			}
+			alice := testutil.Player(game, "alice")
+			alice.SpeedMultiplier = test.playerMultiplier
+			if throttle := game.PlayerMoveThrottle(alice.ID()); throttle != test.want {
+				t.Errorf("got move throttle %s, want %s", throttle, test.want)
+			}
+		})
+	}
+}
+
+func TestSpeedMultiplier(t *testing.T) {
+	tests := []struct {
+		name       string
+		multiplier float64
+		// scale is how much faster cooldowns run out.
+		scale float64
+	}{
+		{name: "normal speed", multiplier: 1, scale: 1},
+		{name: "double speed", multiplier: 2, scale: 2},
+		{name: "half speed", multiplier: 0.5, scale: 0.5},
+		{name: "no multiplier", multiplier: 0, scale: 1},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			game := testutil.NewGame(testutil.WithPlayerAt("alice", 0, 0))
+			game.SpeedMultiplier = test.multiplier
+			game.RegenDelay = time.Second
+			game.SpawnProtection = time.Second
+			alice := testutil.Player(game, "alice")
+			now := time.Now()
+			scaled := func(duration time.Duration) time.Duration {
+				return time.Duration(float64(duration) / test.scale)
+			}
+			accepted := []backend.ActionResult{backend.ActionAccepted, backend.ActionRejectedThrottled, backend.ActionAccepted}
+
+			// Shots are throttled for 500ms.
+			laserThrottle := scaled(500 * time.Millisecond)
+			results := []backend.ActionResult{}
+			for _, action := range fireAt(game, "alice", now, 0, laserThrottle-time.Millisecond, laserThrottle+time.Millisecond) {
+				results = append(results, game.PerformAction(action))
+			}
+			if !reflect.DeepEqual(results, accepted) {
+				t.Errorf("got shot results %v, want %v", results, accepted)
+			}
+
+			// Lasers move a cell every 50ms.
+			shot := fireAt(game, "alice", now.Add(3*laserThrottle), 0)[0]
+			if result := game.PerformAction(shot); result != backend.ActionAccepted {
+				t.Fatalf("got shot result %v, want it accepted", result)
+			}
+			laser := game.GetEntity(shot.(backend.LaserAction).ID).(*backend.Laser)
+			laserSpeed := scaled(50 * time.Millisecond)
+			if trail := laser.Trail(laser.StartTime, laser.StartTime.Add(laserSpeed-time.Millisecond)); len(trail) != 0 {
+				t.Errorf("the laser moved to %v before it could move", trail)
+			}
+			if trail := laser.Trail(laser.StartTime, laser.StartTime.Add(laserSpeed)); len(trail) != 1 {
+				t.Errorf("the laser moved to %v, want one cell", trail)
+			}
+
+			// Moves are throttled for 100ms.
+			moveThrottle := scaled(100 * time.Millisecond)
+			results = []backend.ActionResult{}
+			for i, offset := range []time.Duration{0, moveThrottle - time.Millisecond, moveThrottle + time.Millisecond} {
+				direction := backend.DirectionDown
+				if i%2 == 1 {
+					direction = backend.DirectionUp
+				}
+				results = append(results, game.PerformAction(backend.MoveAction{
+					ID:        alice.ID(),
+					Direction: direction,
+					Created:   now.Add(offset),
+				}))
+			}
+			if !reflect.DeepEqual(results, accepted) {
+				t.Errorf("got move results %v, want %v", results, accepted)
+			}
+
+			// Players regenerate a second after being hit.
+			regenDelay := scaled(time.Second)
+			alice.Damage = 10
+			alice.LastHit = now
+			game.ApplyRegen(now.Add(regenDelay - time.Millisecond))
+			if alice.Damage != 10 {
+				t.Error("alice regenerated before the regen delay")
+			}
+			game.ApplyRegen(now.Add(regenDelay))
+			if alice.Damage == 10 {
+				t.Error("alice didn't regenerate after the regen delay")
+			}
+
+			// Spawn protection lasts a second.
+			game.Protect(alice, now)
+			if protection := alice.ProtectedUntil.Sub(now); protection != scaled(time.Second) {
+				t.Errorf("protected for %s, want %s", protection, scaled(time.Second))
+			}
+		})
+	}
+}
+
+func TestPlayerSpeedMultiplier(t *testing.T) {
+	tests := []struct {
+		name       string
+		multiplier float64
+		// throttle is how long alice has to wait between moves, while bob
+		// always waits 100ms.
+		throttle time.Duration
+	}{
+		{name: "normal speed", multiplier: 1, throttle: 100 * time.Millisecond},
+		{name: "boosted", multiplier: 2, throttle: 50 * time.Millisecond},
+		{name: "slowed", multiplier: 0.5, throttle: 200 * time.Millisecond},
+		{name: "no multiplier", multiplier: 0, throttle: 100 * time.Millisecond},
+		{name: "negative multipliers are ignored", multiplier: -2, throttle: 100 * time.Millisecond},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			game := testutil.NewGame(
+				testutil.WithPlayerAt("alice", 0, 0),
+				testutil.WithPlayerAt("bob", 5, 0),
+			)
+			testutil.Player(game, "alice").SpeedMultiplier = test.multiplier
+			now := time.Now()
+			// moves returns the results of a player moving down and back up
+			// after the given offsets.
+			moves := func(name string, offsets ...time.Duration) []backend.ActionResult {
+				results := []backend.ActionResult{}
+				for i, offset := range offsets {
+					direction := backend.DirectionDown
+					if i%2 == 1 {
+						direction = backend.DirectionUp
+					}
+					results = append(results, game.PerformAction(backend.MoveAction{
+						ID:        testutil.Player(game, name).ID(),
+						Direction: direction,
+						Created:   now.Add(offset),
+					}))
+				}
+				return results
+			}
+			want := []backend.ActionResult{backend.ActionAccepted, backend.ActionRejectedThrottled, backend.ActionAccepted}
+			offsets := []time.Duration{0, test.throttle - time.Millisecond, test.throttle + time.Millisecond}
+			if results := moves("alice", offsets...); !reflect.DeepEqual(results, want) {
+				t.Errorf("alice got move results %v, want %v", results, want)
+			}
+			// Other players move at normal speed.
+			if results := moves("bob", 0, 99*time.Millisecond, 101*time.Millisecond); !reflect.DeepEqual(results, want) {
+				t.Errorf("bob got move results %v, want %v", results, want)
+			}
+		})
+	}
+}
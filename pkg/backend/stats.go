@@ -0,0 +1,194 @@
+package backend
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/google/uuid"
+)
+
+// CareerStats are a player's totals across every match they finished.
+type CareerStats struct {
+	Kills  int `json:"kills"`
+	Deaths int `json:"deaths"`
+	Wins   int `json:"wins"`
+}
+
+// add adds other stats to the stats.
+func (stats *CareerStats) add(other CareerStats) {
+	stats.Kills += other.Kills
+	stats.Deaths += other.Deaths
+	stats.Wins += other.Wins
+}
+
+// StatsStore persists career stats. Stats are keyed by player name, ignoring
+// case, since players don't have accounts.
+type StatsStore interface {
+	// Load returns a player's career stats, which are zero for players who
+	// have never finished a match.
+	Load(name string) (CareerStats, error)
+	// Record adds the stats for a match to each player's career stats. The
+	// stats are keyed by player name, and are recorded in one batch.
+	Record(stats map[string]CareerStats) error
+}
+
+// statsKey returns the key a player's stats are stored under.
+func statsKey(name string) string {
+	return strings.ToLower(name)
+}
+
+// MemoryStatsStore keeps career stats in memory, so they last until the
+// process exits.
+type MemoryStatsStore struct {
+	mu    sync.Mutex
+	stats map[string]CareerStats
+}
+
+// NewMemoryStatsStore constructs a new MemoryStatsStore struct.
+func NewMemoryStatsStore() *MemoryStatsStore {
+	return &MemoryStatsStore{
+		stats: make(map[string]CareerStats),
+	}
+}
+
+// Load returns a player's career stats.
+func (store *MemoryStatsStore) Load(name string) (CareerStats, error) {
+	store.mu.Lock()
+	defer store.mu.Unlock()
+	return store.stats[statsKey(name)], nil
+}
+
+// Record adds the stats for a match to each player's career stats.
+func (store *MemoryStatsStore) Record(stats map[string]CareerStats) error {
+	store.mu.Lock()
+	defer store.mu.Unlock()
+	addCareerStats(store.stats, stats)
+	return nil
+}
+
+// addCareerStats adds the stats for a match to each player's career stats.
+func addCareerStats(careers map[string]CareerStats, stats map[string]CareerStats) {
+	for name, matchStats := range stats {
+		career := careers[statsKey(name)]
+		career.add(matchStats)
+		careers[statsKey(name)] = career
+	}
+}
+
+// FileStatsStore keeps career stats in a JSON file, which is created when
+// stats are first recorded.
+type FileStatsStore struct {
+	mu   sync.Mutex
+	path string
+}
+
+// NewFileStatsStore constructs a new FileStatsStore struct.
+func NewFileStatsStore(path string) *FileStatsStore {
+	return &FileStatsStore{
+		path: path,
+	}
+}
+
+// Load returns a player's career stats.
+func (store *FileStatsStore) Load(name string) (CareerStats, error) {
+	store.mu.Lock()
+	defer store.mu.Unlock()
+	stats, err := store.read()
+	if err != nil {
+		return CareerStats{}, err
+	}
+	return stats[statsKey(name)], nil
+}
+
+// Record adds the stats for a match to each player's career stats, rewriting
+// the file once.
+func (store *FileStatsStore) Record(stats map[string]CareerStats) error {
+	store.mu.Lock()
+	defer store.mu.Unlock()
+	allStats, err := store.read()
+	if err != nil {
+		return err
+	}
+	addCareerStats(allStats, stats)
+	data, err := json.Marshal(allStats)
+	if err != nil {
+		return err
+	}
+	return store.write(data)
+}
+
+// write replaces the file's contents. The data is written to a temporary file
+// that is renamed over the file, so that the stats aren't lost if the process
+// exits while writing.
+func (store *FileStatsStore) write(data []byte) error {
+	file, err := ioutil.TempFile(filepath.Dir(store.path), filepath.Base(store.path)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(file.Name())
+	if _, err := file.Write(data); err != nil {
+		file.Close()
+		return err
+	}
+	if err := file.Close(); err != nil {
+		return err
+	}
+	if err := os.Chmod(file.Name(), 0644); err != nil {
+		return err
+	}
+	return os.Rename(file.Name(), store.path)
+}
+
+// read reads every player's stats from the file, which has no stats if it
+// doesn't exist yet.
+func (store *FileStatsStore) read() (map[string]CareerStats, error) {
+	stats := make(map[string]CareerStats)
+	data, err := ioutil.ReadFile(store.path)
+	if os.IsNotExist(err) {
+		return stats, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(data, &stats); err != nil {
+		return nil, err
+	}
+	return stats, nil
+}
+
+// roundCareerStats returns each player's stats for the round, keyed by name,
+// to be added to their career stats. Bots don't have career stats. The game
+// should be locked by the caller.
+func (game *Game) roundCareerStats(winnerID uuid.UUID) map[string]CareerStats {
+	stats := make(map[string]CareerStats)
+	for _, player := range game.Players() {
+		if player.Bot {
+			continue
+		}
+		id := player.ID()
+		playerStats := CareerStats{
+			Kills:  game.stats.kills[id],
+			Deaths: game.stats.deaths[id],
+		}
+		if id == winnerID {
+			playerStats.Wins = 1
+		}
+		// Players can share a name, in which case their stats are combined.
+		career := stats[player.Name]
+		career.add(playerStats)
+		stats[player.Name] = career
+	}
+	return stats
+}
+
+// recordCareerStats adds a round's stats to the players' career stats. It's
+// called without the game locked, so that a slow store doesn't stall the game.
+func (game *Game) recordCareerStats(store StatsStore, stats map[string]CareerStats) {
+	if err := store.Record(stats); err != nil {
+		game.Metrics.statsStoreError(err)
+	}
+}
@@ -0,0 +1,166 @@
+package backend_test
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/mortenson/grpc-game-example/pkg/backend"
+	"github.com/mortenson/grpc-game-example/pkg/backend/testutil"
+)
+
+func TestFileStatsStore(t *testing.T) {
+	dir, err := ioutil.TempDir("", "stats")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	tests := []struct {
+		name string
+		// batches are recorded in order.
+		batches []map[string]backend.CareerStats
+		load    string
+		want    backend.CareerStats
+	}{
+		{name: "no file", load: "alice", want: backend.CareerStats{}},
+		{
+			name:    "unknown players",
+			batches: []map[string]backend.CareerStats{{"bob": {Kills: 1}}},
+			load:    "alice",
+			want:    backend.CareerStats{},
+		},
+		{
+			name:    "one match",
+			batches: []map[string]backend.CareerStats{{"alice": {Kills: 3, Deaths: 1, Wins: 1}}},
+			load:    "alice",
+			want:    backend.CareerStats{Kills: 3, Deaths: 1, Wins: 1},
+		},
+		{
+			name: "matches add up",
+			batches: []map[string]backend.CareerStats{
+				{"alice": {Kills: 3, Deaths: 1, Wins: 1}, "bob": {Kills: 1, Deaths: 3}},
+				{"alice": {Kills: 2, Deaths: 2}},
+			},
+			load: "alice",
+			want: backend.CareerStats{Kills: 5, Deaths: 3, Wins: 1},
+		},
+		{
+			name: "names ignore case",
+			batches: []map[string]backend.CareerStats{
+				{"Alice": {Kills: 1}, "alice": {Deaths: 1}},
+			},
+			load: "ALICE",
+			want: backend.CareerStats{Kills: 1, Deaths: 1},
+		},
+	}
+	for i, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			path := filepath.Join(dir, fmt.Sprintf("stats%d.json", i))
+			store := backend.NewFileStatsStore(path)
+			for _, batch := range test.batches {
+				if err := store.Record(batch); err != nil {
+					t.Fatal(err)
+				}
+			}
+			// Stats are read back from the file by a new store.
+			got, err := backend.NewFileStatsStore(path).Load(test.load)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != test.want {
+				t.Errorf("got stats %+v, want %+v", got, test.want)
+			}
+		})
+	}
+}
+
+func TestFileStatsStoreReplacesFile(t *testing.T) {
+	dir, err := ioutil.TempDir("", "stats")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	store := backend.NewFileStatsStore(filepath.Join(dir, "stats.json"))
+	for i := 0; i < 2; i++ {
+		if err := store.Record(map[string]backend.CareerStats{"alice": {Kills: 1}}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// Stats are written to a temporary file that replaces the stats file.
+	files, err := ioutil.ReadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(files) != 1 || files[0].Name() != "stats.json" {
+		names := []string{}
+		for _, file := range files {
+			names = append(names, file.Name())
+		}
+		t.Errorf("got files %v, want only the stats file", names)
+	}
+}
+
+func TestFileStatsStoreInvalidFile(t *testing.T) {
+	dir, err := ioutil.TempDir("", "stats")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "stats.json")
+	if err := ioutil.WriteFile(path, []byte("not json"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	store := backend.NewFileStatsStore(path)
+	if _, err := store.Load("alice"); err == nil {
+		t.Error("loaded stats from an invalid file, want an error")
+	}
+	if err := store.Record(map[string]backend.CareerStats{"alice": {Kills: 1}}); err == nil {
+		t.Error("recorded stats to an invalid file, want an error")
+	}
+}
+
+func TestRecordCareerStats(t *testing.T) {
+	config := testutil.MapConfig(
+		"S    ",
+		"     ",
+		"    S",
+	)
+	config.RoundOverScore = 1
+	game := testutil.NewGameFromConfig(t, config,
+		testutil.WithPlayerAt("alice", 0, 0),
+		testutil.WithPlayerAt("bob", 2, 1),
+	)
+	store := backend.NewMemoryStatsStore()
+	game.StatsStore = store
+	laserKill(game, testutil.Player(game, "alice"), testutil.Player(game, "bob"))
+	if !game.WaitForRound {
+		t.Fatal("the round isn't over")
+	}
+	// Stats are recorded in the background, outside of the game lock.
+	want := map[string]backend.CareerStats{
+		"alice": {Deaths: 1},
+		"bob":   {Kills: 1, Wins: 1},
+	}
+	deadline := time.Now().Add(time.Second)
+	for {
+		got := map[string]backend.CareerStats{}
+		for name := range want {
+			stats, err := store.Load(name)
+			if err != nil {
+				t.Fatal(err)
+			}
+			got[name] = stats
+		}
+		if reflect.DeepEqual(got, want) {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("got stats %+v, want %+v", got, want)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
@@ -0,0 +1,97 @@
+package backend
+
+import (
+	"bytes"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Summary describes how a round went, for showing when it is over.
+type Summary struct {
+	// Standings contains every player's final score, highest first.
+	Standings []LeaderboardEntry
+	// TopFragger is the player with the most kills.
+	TopFragger SummaryStat
+	// LongestStreak is the player with the most kills without dying.
+	LongestStreak SummaryStat
+	Duration      time.Duration
+}
+
+// SummaryStat is a player's value for a stat in a summary. If no player has
+// any kills, the ID is uuid.Nil.
+type SummaryStat struct {
+	PlayerID uuid.UUID
+	Name     string
+	Value    int
+}
+
+// matchStats collects stats during a round for its summary.
+type matchStats struct {
+	started time.Time
+	kills   map[uuid.UUID]int
+	// streaks are current kill streaks, and longestStreaks are the best
+	// streaks in the round.
+	streaks        map[uuid.UUID]int
+	longestStreaks map[uuid.UUID]int
+	deaths         map[uuid.UUID]int
+}
+
+// newMatchStats constructs stats for a round that started at a given time.
+func newMatchStats(started time.Time) matchStats {
+	return matchStats{
+		started:        started,
+		kills:          make(map[uuid.UUID]int),
+		streaks:        make(map[uuid.UUID]int),
+		longestStreaks: make(map[uuid.UUID]int),
+		deaths:         make(map[uuid.UUID]int),
+	}
+}
+
+// recordKill updates kill and death counts, and streaks. Kills no one is
+// credited for still count as deaths, and end the victim's streak.
+func (stats *matchStats) recordKill(killerID uuid.UUID, victimID uuid.UUID) {
+	stats.deaths[victimID]++
+	stats.streaks[victimID] = 0
+	if killerID == uuid.Nil {
+		return
+	}
+	stats.kills[killerID]++
+	stats.streaks[killerID]++
+	if stats.streaks[killerID] > stats.longestStreaks[killerID] {
+		stats.longestStreaks[killerID] = stats.streaks[killerID]
+	}
+}
+
+// MatchSummary summarizes the current round, which is also stored in
+// RoundSummary when a round is over. The game should be read locked by the
+// caller.
+func (game *Game) MatchSummary() Summary {
+	return Summary{
+		Standings:     game.Leaderboard(),
+		TopFragger:    game.bestStat(game.stats.kills),
+		LongestStreak: game.bestStat(game.stats.longestStreaks),
+		Duration:      time.Now().Sub(game.stats.started),
+	}
+}
+
+// bestStat finds the player in the game with the highest value, breaking ties
+// by ID so that summaries are consistent.
+func (game *Game) bestStat(values map[uuid.UUID]int) SummaryStat {
+	best := SummaryStat{}
+	for id, value := range values {
+		player, ok := game.GetEntity(id).(*Player)
+		if !ok || value <= 0 || value < best.Value {
+			continue
+		}
+		if value == best.Value && bytes.Compare(id[:], best.PlayerID[:]) > 0 {
+			continue
+		}
+		best = SummaryStat{
+			PlayerID: id,
+			Name:     player.Name,
+			Value:    value,
+		}
+	}
+	return best
+}
@@ -0,0 +1,115 @@
+package backend_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mortenson/grpc-game-example/pkg/backend"
+	"github.com/mortenson/grpc-game-example/pkg/backend/testutil"
+)
+
+func TestMatchSummary(t *testing.T) {
+	// kills are pairs of killer and victim names, where an empty killer is a
+	// hazard.
+	type kill struct {
+		killer, victim string
+	}
+	tests := []struct {
+		name          string
+		kills         []kill
+		leader        string
+		topFragger    string
+		topKills      int
+		longestStreak string
+		streak        int
+	}{
+		{name: "no kills", kills: nil},
+		{
+			name: "deaths end streaks",
+			kills: []kill{
+				{"bob", "alice"}, {"bob", "alice"}, {"alice", "bob"}, {"bob", "carol"},
+			},
+			leader:        "bob",
+			topFragger:    "bob",
+			topKills:      3,
+			longestStreak: "bob",
+			streak:        2,
+		},
+		{
+			name: "most kills and longest streak",
+			kills: []kill{
+				{"bob", "alice"}, {"alice", "bob"}, {"bob", "alice"}, {"alice", "bob"},
+				{"bob", "alice"}, {"carol", "alice"}, {"carol", "alice"},
+			},
+			leader:        "bob",
+			topFragger:    "bob",
+			topKills:      3,
+			longestStreak: "carol",
+			streak:        2,
+		},
+		{
+			name:          "hazard deaths end streaks",
+			kills:         []kill{{"bob", "alice"}, {"", "bob"}, {"bob", "alice"}},
+			leader:        "bob",
+			topFragger:    "bob",
+			topKills:      2,
+			longestStreak: "bob",
+			streak:        1,
+		},
+		{
+			name:          "ties go to the lowest ID",
+			kills:         []kill{{"carol", "alice"}, {"bob", "alice"}},
+			topFragger:    "bob",
+			topKills:      1,
+			longestStreak: "bob",
+			streak:        1,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			game := testutil.NewGame(
+				testutil.WithSequentialIDs(),
+				testutil.WithPlayerAt("alice", 0, 0),
+				testutil.WithPlayerAt("bob", 1, 0),
+				testutil.WithPlayerAt("carol", 2, 0),
+			)
+			id := func(name string) uuid.UUID {
+				if name == "" {
+					return uuid.Nil
+				}
+				return testutil.Player(game, name).ID()
+			}
+			for _, kill := range test.kills {
+				game.AddKill(id(kill.killer), id(kill.victim), backend.Coordinate{})
+				if kill.killer != "" {
+					game.AddScore(id(kill.killer))
+				}
+			}
+			summary := game.MatchSummary()
+			if len(summary.Standings) != 3 {
+				t.Fatalf("got %d standings, want every player", len(summary.Standings))
+			}
+			if test.leader != "" && summary.Standings[0].Name != test.leader {
+				t.Errorf("%s leads the standings, want %s", summary.Standings[0].Name, test.leader)
+			}
+			want := backend.SummaryStat{}
+			if test.topFragger != "" {
+				want = backend.SummaryStat{PlayerID: id(test.topFragger), Name: test.topFragger, Value: test.topKills}
+			}
+			if summary.TopFragger != want {
+				t.Errorf("top fragger is %+v, want %+v", summary.TopFragger, want)
+			}
+			want = backend.SummaryStat{}
+			if test.longestStreak != "" {
+				want = backend.SummaryStat{PlayerID: id(test.longestStreak), Name: test.longestStreak, Value: test.streak}
+			}
+			if summary.LongestStreak != want {
+				t.Errorf("longest streak is %+v, want %+v", summary.LongestStreak, want)
+			}
+			if summary.Duration < 0 || summary.Duration > time.Minute {
+				t.Errorf("got duration %v, want the time since the round started", summary.Duration)
+			}
+		})
+	}
+}
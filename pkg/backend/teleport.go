@@ -0,0 +1,33 @@
+package backend
+
+import (
+	"github.com/google/uuid"
+)
+
+// SetPosition moves an entity to a position without an action, bypassing
+// throttles and validation. It is meant for server operators and game modes,
+// for example teleporters. The game should be locked by the caller.
+func (game *Game) SetPosition(id uuid.UUID, position Coordinate) error {
+	entity := game.GetEntity(id)
+	if entity == nil {
+		return newError(ErrorCodeEntityNotFound, nil, "entity %s does not exist", id)
+	}
+	if _, ok := entity.(Mover); !ok {
+		return newError(ErrorCodeInvalidMove, nil, "entity %s can not be moved", id)
+	}
+	positioner, ok := entity.(Positioner)
+	if !ok {
+		return newError(ErrorCodeInvalidMove, nil, "entity %s has no position", id)
+	}
+	from := positioner.Position()
+	game.MoveEntity(entity, position)
+	game.sendChange(MoveChange{
+		Entity:       entity,
+		Direction:    DirectionStop,
+		Position:     position,
+		From:         from,
+		Teleport:     true,
+		MoveSequence: game.nextMoveSequence(id),
+	})
+	return nil
+}
@@ -0,0 +1,84 @@
+package backend_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mortenson/grpc-game-example/pkg/backend"
+	"github.com/mortenson/grpc-game-example/pkg/backend/testutil"
+)
+
+func TestSetPosition(t *testing.T) {
+	wallID := uuid.New()
+	tests := []struct {
+		name string
+		// id returns the entity to move.
+		id       func(game *backend.Game) uuid.UUID
+		position backend.Coordinate
+		err      error
+	}{
+		{
+			name:     "moves players",
+			id:       func(game *backend.Game) uuid.UUID { return testutil.Player(game, "alice").ID() },
+			position: backend.Coordinate{X: -2, Y: 3},
+		},
+		{
+			name: "ignores throttles",
+			id: func(game *backend.Game) uuid.UUID {
+				game.PerformAction(moveAt(game, "alice", backend.DirectionUp, time.Now(), 0))
+				return testutil.Player(game, "alice").ID()
+			},
+			position: backend.Coordinate{X: 1, Y: 0},
+		},
+		{
+			name:     "fails for missing entities",
+			id:       func(game *backend.Game) uuid.UUID { return uuid.New() },
+			position: backend.Coordinate{X: 1, Y: 0},
+			err:      backend.ErrEntityNotFound,
+		},
+		{
+			name:     "fails for entities that can't move",
+			id:       func(game *backend.Game) uuid.UUID { return wallID },
+			position: backend.Coordinate{X: 1, Y: 0},
+			err:      backend.ErrInvalidMove,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			game := testutil.NewGame(testutil.WithPlayerAt("alice", 0, 0))
+			game.AddEntity(&backend.Wall{
+				IdentifierBase:  backend.IdentifierBase{UUID: wallID},
+				CurrentPosition: backend.Coordinate{X: 3, Y: 3},
+			})
+			id := test.id(game)
+			from := testutil.Player(game, "alice").Position()
+			testutil.DrainChanges(game)
+			err := game.SetPosition(id, test.position)
+			changes := testutil.DrainChanges(game)
+			if test.err != nil {
+				if !errors.Is(err, test.err) {
+					t.Errorf("got error %v, want %v", err, test.err)
+				}
+				if len(changes) != 0 {
+					t.Errorf("got changes %+v, want none", changes)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("got error %v", err)
+			}
+			if position := testutil.Player(game, "alice").Position(); position != test.position {
+				t.Errorf("alice is at %v, want %v", position, test.position)
+			}
+			if len(changes) != 1 {
+				t.Fatalf("got changes %+v, want one move", changes)
+			}
+			change, ok := changes[0].(backend.MoveChange)
+			if !ok || !change.Teleport || change.Position != test.position || change.From != from {
+				t.Errorf("got change %+v, want a teleport from %v to %v", changes[0], from, test.position)
+			}
+		})
+	}
+}
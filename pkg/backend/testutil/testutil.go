@@ -0,0 +1,150 @@
+// Package testutil builds games for use in tests, without needing to start the
+// game loop.
+package testutil
+
+import (
+	"encoding/binary"
+	"reflect"
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+	"github.com/mortenson/grpc-game-example/pkg/backend"
+)
+
+// changeBufferSize is large enough that changes are not dropped while actions
+// are run, as the game engine does not block when sending changes.
+const changeBufferSize = 1024
+
+// Option changes a game while it is being built.
+type Option func(game *backend.Game)
+
+// NewGame constructs a game that has not been started, applying options in
+// order.
+func NewGame(options ...Option) *backend.Game {
+	game := backend.NewGame()
+	game.ChangeChannel = make(chan backend.Change, changeBufferSize)
+	for _, option := range options {
+		option(game)
+	}
+	return game
+}
+
+// NewGameFromConfig constructs a game from a config, like
+// backend.NewGameFromConfig, but like NewGame it is not started and keeps its
+// changes. Invalid configs fail the test.
+func NewGameFromConfig(t testing.TB, config backend.Config, options ...Option) *backend.Game {
+	t.Helper()
+	game, err := backend.NewGameFromConfig(config)
+	if err != nil {
+		t.Fatalf("invalid config: %v", err)
+	}
+	game.ChangeChannel = make(chan backend.Change, changeBufferSize)
+	for _, option := range options {
+		option(game)
+	}
+	return game
+}
+
+// MapConfig returns the default config with a map given as one string per row.
+// The center of the map is at the origin.
+func MapConfig(rows ...string) backend.Config {
+	config := backend.DefaultConfig()
+	config.Map = rows
+	return config
+}
+
+// WithPlayerAt adds a player with the given name at a position.
+func WithPlayerAt(name string, x, y int) Option {
+	return func(game *backend.Game) {
+		icon, _ := utf8.DecodeRuneInString(strings.ToUpper(name))
+		game.AddEntity(&backend.Player{
+			IdentifierBase:  backend.IdentifierBase{UUID: game.IDGenerator()},
+			Name:            name,
+			Icon:            icon,
+			CurrentPosition: backend.Coordinate{X: x, Y: y},
+		})
+	}
+}
+
+// WithWallAt adds an indestructible wall entity at a position.
+func WithWallAt(x, y int) Option {
+	return func(game *backend.Game) {
+		game.AddEntity(&backend.Wall{
+			IdentifierBase:  backend.IdentifierBase{UUID: game.IDGenerator()},
+			CurrentPosition: backend.Coordinate{X: x, Y: y},
+		})
+	}
+}
+
+// WithSequentialIDs makes the game create predictable IDs, see SequentialIDs.
+// It should come before options that add entities.
+func WithSequentialIDs() Option {
+	return func(game *backend.Game) {
+		game.IDGenerator = SequentialIDs()
+	}
+}
+
+// SequentialIDs returns an ID generator that counts up from one, so the first
+// ID is 00000000-0000-0000-0000-000000000001.
+func SequentialIDs() func() uuid.UUID {
+	var next uint64
+	return func() uuid.UUID {
+		next++
+		var id uuid.UUID
+		binary.BigEndian.PutUint64(id[8:], next)
+		return id
+	}
+}
+
+// WithMaxLasersPerPlayer limits how many lasers each player can have in play.
+func WithMaxLasersPerPlayer(max int) Option {
+	return func(game *backend.Game) {
+		game.MaxLasersPerPlayer = max
+	}
+}
+
+// Player finds a player by name, returning nil if there is no such player.
+func Player(game *backend.Game, name string) *backend.Player {
+	for _, entity := range game.Entities {
+		player, ok := entity.(*backend.Player)
+		if ok && player.Name == name {
+			return player
+		}
+	}
+	return nil
+}
+
+// Entities finds the entities with the same type as kind, such as
+// &backend.Laser{} for every laser in the game.
+func Entities(game *backend.Game, kind backend.Identifier) []backend.Identifier {
+	kindType := reflect.TypeOf(kind)
+	return game.FindEntities(func(entity backend.Identifier) bool {
+		return reflect.TypeOf(entity) == kindType
+	})
+}
+
+// RunActions performs actions in order, as the game loop would, and returns
+// the changes they caused.
+func RunActions(game *backend.Game, actions ...backend.Action) []backend.Change {
+	game.Mu.Lock()
+	for _, action := range actions {
+		action.Perform(game)
+	}
+	game.Mu.Unlock()
+	return DrainChanges(game)
+}
+
+// DrainChanges returns all changes waiting in the change channel.
+func DrainChanges(game *backend.Game) []backend.Change {
+	changes := []backend.Change{}
+	for {
+		select {
+		case change := <-game.ChangeChannel:
+			changes = append(changes, change)
+		default:
+			return changes
+		}
+	}
+}
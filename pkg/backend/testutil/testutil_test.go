@@ -0,0 +1,197 @@
+package testutil_test
+
+import (
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mortenson/grpc-game-example/pkg/backend"
+	"github.com/mortenson/grpc-game-example/pkg/backend/testutil"
+)
+
+// fatalRecorder records if a test failed, instead of failing the real test.
+type fatalRecorder struct {
+	testing.TB
+	failed bool
+}
+
+func (recorder *fatalRecorder) Helper() {}
+
+func (recorder *fatalRecorder) Fatalf(format string, args ...interface{}) {
+	recorder.failed = true
+	runtime.Goexit()
+}
+
+func TestWithPlayerAt(t *testing.T) {
+	game := testutil.NewGame(
+		testutil.WithPlayerAt("alice", 1, 2),
+		testutil.WithPlayerAt("bob", -3, 0),
+	)
+	tests := []struct {
+		name     string
+		icon     rune
+		position backend.Coordinate
+	}{
+		{name: "alice", icon: 'A', position: backend.Coordinate{X: 1, Y: 2}},
+		{name: "bob", icon: 'B', position: backend.Coordinate{X: -3, Y: 0}},
+	}
+	for _, test := range tests {
+		player := testutil.Player(game, test.name)
+		if player == nil {
+			t.Errorf("%s was not added", test.name)
+			continue
+		}
+		if player.Icon != test.icon || player.Position() != test.position {
+			t.Errorf("%s has icon %q at %+v, want %q at %+v", test.name, player.Icon, player.Position(), test.icon, test.position)
+		}
+	}
+	if player := testutil.Player(game, "carol"); player != nil {
+		t.Errorf("got %+v for an unknown player, want nil", player)
+	}
+}
+
+func TestWithWallAt(t *testing.T) {
+	game := testutil.NewGame(testutil.WithWallAt(1, 2))
+	position := backend.Coordinate{X: 1, Y: 2}
+	walls := 0
+	for _, entity := range game.Entities {
+		wall, ok := entity.(*backend.Wall)
+		if !ok {
+			continue
+		}
+		walls++
+		if wall.Position() != position || wall.Destructible {
+			t.Errorf("got wall %+v, want an indestructible wall at %+v", wall, position)
+		}
+	}
+	if walls != 1 {
+		t.Errorf("got %d walls, want 1", walls)
+	}
+}
+
+func TestSequentialIDs(t *testing.T) {
+	generate := testutil.SequentialIDs()
+	want := []string{
+		"00000000-0000-0000-0000-000000000001",
+		"00000000-0000-0000-0000-000000000002",
+		"00000000-0000-0000-0000-000000000003",
+	}
+	for _, id := range want {
+		if got := generate(); got.String() != id {
+			t.Errorf("got ID %s, want %s", got, id)
+		}
+	}
+	if got := testutil.SequentialIDs()(); got.String() != want[0] {
+		t.Errorf("a new generator started at %s, want %s", got, want[0])
+	}
+
+	game := testutil.NewGame(
+		testutil.WithSequentialIDs(),
+		testutil.WithPlayerAt("alice", 0, 0),
+		testutil.WithPlayerAt("bob", 1, 0),
+	)
+	if id := testutil.Player(game, "alice").ID(); id != uuid.MustParse(want[0]) {
+		t.Errorf("alice has ID %s, want %s", id, want[0])
+	}
+	if id := testutil.Player(game, "bob").ID(); id != uuid.MustParse(want[1]) {
+		t.Errorf("bob has ID %s, want %s", id, want[1])
+	}
+}
+
+func TestWithMaxLasersPerPlayer(t *testing.T) {
+	game := testutil.NewGame(testutil.WithMaxLasersPerPlayer(3))
+	if game.MaxLasersPerPlayer != 3 {
+		t.Errorf("got max lasers %d, want 3", game.MaxLasersPerPlayer)
+	}
+}
+
+func TestEntities(t *testing.T) {
+	game := testutil.NewGame(
+		testutil.WithPlayerAt("alice", 0, 0),
+		testutil.WithPlayerAt("bob", 1, 0),
+	)
+	if err := game.AddEntity(&backend.Laser{IdentifierBase: backend.IdentifierBase{UUID: uuid.New()}}); err != nil {
+		t.Fatal(err)
+	}
+	if players := testutil.Entities(game, &backend.Player{}); len(players) != 2 {
+		t.Errorf("got %d players, want 2", len(players))
+	}
+	lasers := testutil.Entities(game, &backend.Laser{})
+	if len(lasers) != 1 {
+		t.Fatalf("got %d lasers, want 1", len(lasers))
+	}
+	if _, ok := lasers[0].(*backend.Laser); !ok {
+		t.Errorf("got %+v, want a laser", lasers[0])
+	}
+	if mines := testutil.Entities(game, &backend.Mine{}); len(mines) != 0 {
+		t.Errorf("got %d mines, want none", len(mines))
+	}
+}
+
+func TestRunActions(t *testing.T) {
+	game := testutil.NewGame(testutil.WithPlayerAt("alice", 0, 0))
+	alice := testutil.Player(game, "alice")
+	testutil.DrainChanges(game)
+	start := time.Now()
+	changes := testutil.RunActions(game,
+		backend.MoveAction{ID: alice.ID(), Direction: backend.DirectionRight, Created: start},
+		backend.MoveAction{ID: alice.ID(), Direction: backend.DirectionDown, Created: start.Add(time.Second)},
+	)
+	if len(changes) != 2 {
+		t.Fatalf("got %d changes, want 2", len(changes))
+	}
+	for i, direction := range []backend.Direction{backend.DirectionRight, backend.DirectionDown} {
+		move, ok := changes[i].(backend.MoveChange)
+		if !ok || move.Direction != direction {
+			t.Errorf("change %d is %+v, want a move %v", i, changes[i], direction)
+		}
+	}
+	if changes := testutil.DrainChanges(game); len(changes) != 0 {
+		t.Errorf("got %d changes after running actions, want them drained", len(changes))
+	}
+}
+
+func TestNewGameFromConfig(t *testing.T) {
+	tests := []struct {
+		name   string
+		config backend.Config
+		failed bool
+	}{
+		{name: "valid", config: testutil.MapConfig("S  ", "   "), failed: false},
+		{name: "invalid", config: testutil.MapConfig("   ", "   "), failed: true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			recorder := &fatalRecorder{TB: t}
+			var game *backend.Game
+			done := make(chan struct{})
+			go func() {
+				defer close(done)
+				game = testutil.NewGameFromConfig(recorder, test.config, testutil.WithPlayerAt("alice", 0, 0))
+			}()
+			<-done
+			if recorder.failed != test.failed {
+				t.Fatalf("failed is %v, want %v", recorder.failed, test.failed)
+			}
+			if test.failed {
+				return
+			}
+			if width, height := game.GetMapDimensions(); width != 3 || height != 2 {
+				t.Errorf("map is %dx%d, want 3x2", width, height)
+			}
+			if testutil.Player(game, "alice") == nil {
+				t.Fatal("options were not applied")
+			}
+			// Changes are kept, unlike games from backend.NewGameFromConfig.
+			alice := testutil.Player(game, "alice")
+			testutil.DrainChanges(game)
+			changes := testutil.RunActions(game,
+				backend.MoveAction{ID: alice.ID(), Direction: backend.DirectionRight, Created: time.Now()},
+			)
+			if len(changes) != 1 {
+				t.Errorf("got %d changes, want 1", len(changes))
+			}
+		})
+	}
+}
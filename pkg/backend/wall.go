@@ -0,0 +1,26 @@
+package backend
+
+// Wall is an entity that blocks movement and lasers. Unlike walls that are part
+// of the map, destructible walls can be removed by shooting them.
+type Wall struct {
+	IdentifierBase
+	Positioner
+	CurrentPosition Coordinate
+	Destructible    bool
+	Health          int
+}
+
+// Position determines the wall position.
+func (wall *Wall) Position() Coordinate {
+	return wall.CurrentPosition
+}
+
+// damage reduces the wall's health, returning true if the wall was destroyed.
+// Indestructible walls are never damaged.
+func (wall *Wall) damage() bool {
+	if !wall.Destructible {
+		return false
+	}
+	wall.Health--
+	return wall.Health <= 0
+}
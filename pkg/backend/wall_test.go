@@ -0,0 +1,105 @@
+package backend_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mortenson/grpc-game-example/pkg/backend"
+	"github.com/mortenson/grpc-game-example/pkg/backend/testutil"
+)
+
+// addStillLaser adds a laser that stays where it is, so that collisions can be
+// checked without waiting for it to move.
+func addStillLaser(game *backend.Game, ownerID uuid.UUID, position backend.Coordinate) *backend.Laser {
+	laser := &backend.Laser{
+		IdentifierBase:  backend.IdentifierBase{UUID: game.IDGenerator()},
+		InitialPosition: position,
+		Direction:       backend.DirectionStop,
+		StartTime:       time.Now(),
+		OwnerID:         ownerID,
+	}
+	game.AddEntity(laser)
+	return laser
+}
+
+func TestLasersDamageWalls(t *testing.T) {
+	tests := []struct {
+		name          string
+		destructible  bool
+		authoritative bool
+		health        int
+		shots         int
+		destroyed     bool
+		wantHealth    int
+	}{
+		{
+			name:          "destroyed once health runs out",
+			destructible:  true,
+			authoritative: true,
+			health:        3,
+			shots:         3,
+			destroyed:     true,
+		},
+		{
+			name:          "damaged by each hit",
+			destructible:  true,
+			authoritative: true,
+			health:        3,
+			shots:         2,
+			wantHealth:    1,
+		},
+		{
+			name:          "indestructible walls absorb lasers",
+			destructible:  false,
+			authoritative: true,
+			health:        1,
+			shots:         5,
+			wantHealth:    1,
+		},
+		{
+			name:          "non-authoritative games leave walls to the server",
+			destructible:  true,
+			authoritative: false,
+			health:        1,
+			shots:         2,
+			wantHealth:    1,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			game := testutil.NewGame()
+			game.IsAuthoritative = test.authoritative
+			position := backend.Coordinate{X: 2, Y: 2}
+			wall := &backend.Wall{
+				IdentifierBase:  backend.IdentifierBase{UUID: uuid.New()},
+				CurrentPosition: position,
+				Destructible:    test.destructible,
+				Health:          test.health,
+			}
+			game.AddEntity(wall)
+			removed := false
+			for i := 0; i < test.shots; i++ {
+				laser := addStillLaser(game, uuid.New(), position)
+				game.Step()
+				if game.GetEntity(laser.ID()) != nil {
+					t.Fatalf("laser %d wasn't removed by the wall", i)
+				}
+				for _, change := range testutil.DrainChanges(game) {
+					if change, ok := change.(backend.RemoveEntityChange); ok && change.Entity == wall {
+						removed = true
+					}
+				}
+			}
+			if destroyed := game.GetEntity(wall.ID()) == nil; destroyed != test.destroyed {
+				t.Errorf("wall destroyed is %v, want %v", destroyed, test.destroyed)
+			}
+			if removed != test.destroyed {
+				t.Errorf("wall removal change sent is %v, want %v", removed, test.destroyed)
+			}
+			if !test.destroyed && wall.Health != test.wantHealth {
+				t.Errorf("wall has %d health, want %d", wall.Health, test.wantHealth)
+			}
+		})
+	}
+}
@@ -0,0 +1,53 @@
+package backend
+
+import (
+	"github.com/google/uuid"
+)
+
+// WeaponKind is a kind of weapon that players can carry.
+type WeaponKind int
+
+// Contains weapon kind constants.
+const (
+	WeaponLaser WeaponKind = iota
+	WeaponPiercingLaser
+	WeaponSwapLaser
+)
+
+// DefaultLoadout is the loadout players are given when none is set: a laser
+// as their primary weapon, followed by a piercing laser and a swap laser.
+var DefaultLoadout = []WeaponKind{WeaponLaser, WeaponPiercingLaser, WeaponSwapLaser}
+
+// Weapon returns the player's active weapon, which is a laser if the player
+// has no loadout.
+func (p *Player) Weapon() WeaponKind {
+	if p.ActiveWeapon < 0 || p.ActiveWeapon >= len(p.Loadout) {
+		return WeaponLaser
+	}
+	return p.Loadout[p.ActiveWeapon]
+}
+
+// WeaponChange occurs when a player switches weapons.
+type WeaponChange struct {
+	Change
+	Player *Player
+}
+
+// SwitchWeaponAction is sent when a player switches to the next weapon in
+// their loadout.
+type SwitchWeaponAction struct {
+	PlayerID uuid.UUID
+}
+
+// Perform switches the player's active weapon.
+func (action SwitchWeaponAction) Perform(game *Game) ActionResult {
+	player, ok := game.actingEntity(action.PlayerID).(*Player)
+	if !ok || len(player.Loadout) < 2 {
+		return ActionRejectedInvalid
+	}
+	player.ActiveWeapon = (player.ActiveWeapon + 1) % len(player.Loadout)
+	game.sendChange(WeaponChange{
+		Player: player,
+	})
+	return ActionAccepted
+}
@@ -0,0 +1,103 @@
+package backend_test
+
+import (
+	"testing"
+
+	"github.com/mortenson/grpc-game-example/pkg/backend"
+	"github.com/mortenson/grpc-game-example/pkg/backend/testutil"
+)
+
+func TestSwitchWeapon(t *testing.T) {
+	tests := []struct {
+		name     string
+		loadout  []backend.WeaponKind
+		switches int
+		result   backend.ActionResult
+		active   int
+		piercing bool
+	}{
+		{
+			name:    "fires the primary weapon",
+			loadout: []backend.WeaponKind{backend.WeaponLaser, backend.WeaponPiercingLaser},
+			result:  backend.ActionAccepted,
+		},
+		{
+			name:     "fires the secondary weapon",
+			loadout:  []backend.WeaponKind{backend.WeaponLaser, backend.WeaponPiercingLaser},
+			switches: 1,
+			result:   backend.ActionAccepted,
+			active:   1,
+			piercing: true,
+		},
+		{
+			name:     "wraps around to the primary weapon",
+			loadout:  []backend.WeaponKind{backend.WeaponLaser, backend.WeaponPiercingLaser},
+			switches: 2,
+			result:   backend.ActionAccepted,
+		},
+		{
+			name:     "a single weapon can't be switched",
+			loadout:  []backend.WeaponKind{backend.WeaponPiercingLaser},
+			switches: 1,
+			result:   backend.ActionRejectedInvalid,
+			piercing: true,
+		},
+		{
+			name:     "an empty loadout fires lasers",
+			loadout:  []backend.WeaponKind{},
+			switches: 1,
+			result:   backend.ActionRejectedInvalid,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			game := testutil.NewGame(testutil.WithPlayerAt("alice", 0, 0))
+			alice := testutil.Player(game, "alice")
+			alice.Loadout = test.loadout
+			testutil.DrainChanges(game)
+			for i := 0; i < test.switches; i++ {
+				if result := game.PerformAction(backend.SwitchWeaponAction{PlayerID: alice.ID()}); result != test.result {
+					t.Fatalf("got result %v, want %v", result, test.result)
+				}
+			}
+			switched := 0
+			for _, change := range testutil.DrainChanges(game) {
+				if change, ok := change.(backend.WeaponChange); ok && change.Player == alice {
+					switched++
+				}
+			}
+			if test.result == backend.ActionAccepted && switched != test.switches {
+				t.Errorf("got %d weapon changes, want %d", switched, test.switches)
+			}
+			if alice.ActiveWeapon != test.active {
+				t.Errorf("active weapon is %d, want %d", alice.ActiveWeapon, test.active)
+			}
+			game.PerformAction(backend.FireAction{
+				PlayerID:  alice.ID(),
+				Direction: backend.DirectionRight,
+			})
+			fired := testutil.Entities(game, &backend.Laser{})
+			if len(fired) != 1 {
+				t.Fatalf("got %d lasers, want 1", len(fired))
+			}
+			if laser := fired[0].(*backend.Laser); laser.Piercing != test.piercing {
+				t.Errorf("piercing is %v, want %v", laser.Piercing, test.piercing)
+			}
+		})
+	}
+}
+
+func TestDefaultLoadout(t *testing.T) {
+	game := testutil.NewGame(testutil.WithPlayerAt("alice", 0, 0))
+	game.Loadout = []backend.WeaponKind{backend.WeaponLaser, backend.WeaponPiercingLaser}
+	testutil.WithPlayerAt("bob", 2, 2)(game)
+	bob := testutil.Player(game, "bob")
+	if len(bob.Loadout) != 2 || bob.Loadout[1] != backend.WeaponPiercingLaser {
+		t.Fatalf("bob has loadout %v, want the game's loadout", bob.Loadout)
+	}
+	// Players have their own copy of the loadout.
+	bob.Loadout[0] = backend.WeaponSwapLaser
+	if game.Loadout[0] != backend.WeaponLaser {
+		t.Error("changing a player's loadout changed the game's loadout")
+	}
+}
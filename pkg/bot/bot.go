@@ -1,10 +1,11 @@
 package bot
 
 import (
+	"log"
 	"math/rand"
+	"sync"
 	"time"
 
-	"github.com/beefsack/go-astar"
 	"github.com/google/uuid"
 	"github.com/mortenson/grpc-game-example/pkg/backend"
 )
@@ -16,6 +17,8 @@ type bot struct {
 
 // Bots controls all bots added to a game.
 type Bots struct {
+	// mu protects bots, which are added while bots are running in horde mode.
+	mu   sync.Mutex
 	bots []*bot
 	game *backend.Game
 }
@@ -28,69 +31,37 @@ func NewBots(game *backend.Game) *Bots {
 	}
 }
 
-// AddBot adds a new bot to the game.
+// AddBot adds a new bot to the game, returning nil if it could not be added.
 func (bots *Bots) AddBot(name string) *backend.Player {
-	playerID := uuid.New()
+	bots.game.Mu.Lock()
+	defer bots.game.Mu.Unlock()
+	return bots.addBot(name, backend.Coordinate{X: -1, Y: 9})
+}
+
+// addBot adds a new bot at a position, returning nil if the game refused the
+// bot's player. The game should be locked by the caller.
+func (bots *Bots) addBot(name string, position backend.Coordinate) *backend.Player {
+	playerID := bots.game.IDGenerator()
 	player := &backend.Player{
 		Name:            name,
 		Icon:            'b',
-		IdentifierBase:  backend.IdentifierBase{playerID},
-		CurrentPosition: backend.Coordinate{X: -1, Y: 9},
+		IdentifierBase:  backend.IdentifierBase{UUID: playerID},
+		CurrentPosition: position,
+		Bot:             true,
 	}
-	bots.game.Mu.Lock()
-	bots.game.AddEntity(player)
-	bots.game.Mu.Unlock()
+	if err := bots.game.AddEntity(player); err != nil {
+		log.Printf("can not add bot %s: %v", name, err)
+		return nil
+	}
+	bots.mu.Lock()
 	bots.bots = append(bots.bots, &bot{playerID: playerID})
+	bots.mu.Unlock()
 	return player
 }
 
-// world tracks all game tiles and is used for astar traversal.
+// world tracks the map's walls, which bots can't shoot through.
 type world struct {
-	tiles map[backend.Coordinate]*tile
-}
-
-// tileKind differentiates walls from normal tiles.
-type tileKind int
-
-const (
-	tileWall tileKind = iota
-	tileNone
-)
-
-// tile represents a point on the map.
-type tile struct {
-	position backend.Coordinate
-	world    *world
-	kind     tileKind
-}
-
-// PathNeighbors is used by beefsack/astar to traverse.
-func (t *tile) PathNeighbors() []astar.Pather {
-	neighbors := []astar.Pather{}
-	for _, difference := range []backend.Coordinate{
-		{X: -1, Y: 0},
-		{X: 1, Y: 0},
-		{X: 0, Y: -1},
-		{X: 0, Y: 1},
-	} {
-		position := t.position.Add(difference)
-		neighbor, ok := t.world.tiles[position]
-		if ok && neighbor.kind == tileNone {
-			neighbors = append(neighbors, neighbor)
-		}
-	}
-	return neighbors
-}
-
-// PathNeighborCost is used by beefsack/astar to determine the cost of a move.
-func (t *tile) PathNeighborCost(to astar.Pather) float64 {
-	return 1
-}
-
-// PathEstimatedCost estimates the cost of moving between two points.
-func (t *tile) PathEstimatedCost(to astar.Pather) float64 {
-	toT := to.(*tile)
-	return float64(t.position.Distance(toT.position))
+	walls map[backend.Coordinate]bool
 }
 
 // getShootDirection determines if a straight path between c1 and c2 is not
@@ -126,8 +97,7 @@ func getShootDirection(world *world, c1 backend.Coordinate, c2 backend.Coordinat
 		if newPosition == c2 {
 			break
 		}
-		tile, ok := world.tiles[newPosition]
-		if ok && tile.kind == tileWall {
+		if world.walls[newPosition] {
 			return backend.DirectionStop
 		}
 		newPosition = newPosition.Add(diffCoordinate)
@@ -139,41 +109,40 @@ func getShootDirection(world *world, c1 backend.Coordinate, c2 backend.Coordinat
 func (bots *Bots) Start() {
 	go func() {
 		world := &world{
-			tiles: make(map[backend.Coordinate]*tile),
+			walls: make(map[backend.Coordinate]bool),
 		}
-		for symbol, positions := range bots.game.GetMapByType() {
-			for _, position := range positions {
-				if symbol == backend.MapTypeWall {
-					world.tiles[position] = &tile{
-						position: position,
-						world:    world,
-						kind:     tileWall,
-					}
-				} else {
-					world.tiles[position] = &tile{
-						position: position,
-						world:    world,
-						kind:     tileNone,
-					}
-				}
-			}
+		for _, position := range bots.game.GetMapByType()[backend.MapTypeWall] {
+			world.walls[position] = true
 		}
 		for {
 			bots.game.Mu.RLock()
-			// Get all player positions.
+			// Get all player positions. In horde mode, bots only go after
+			// human players.
 			playerPositions := make(map[uuid.UUID]backend.Coordinate, 0)
 			for _, entity := range bots.game.Entities {
 				switch entity.(type) {
 				case *backend.Player:
 					player := entity.(*backend.Player)
+					if bots.game.HordeMode && player.Bot {
+						continue
+					}
 					playerPositions[entity.ID()] = player.Position()
 				}
 			}
 			bots.game.Mu.RUnlock()
-			for _, bot := range bots.bots {
+			bots.mu.Lock()
+			currentBots := append([]*bot{}, bots.bots...)
+			bots.mu.Unlock()
+			// Every bot's action is submitted at once, after deciding them.
+			actions := make([]backend.Action, 0, len(currentBots))
+			for _, bot := range currentBots {
 				bots.game.Mu.RLock()
-				player := bots.game.GetEntity(bot.playerID).(*backend.Player)
+				player, ok := bots.game.GetEntity(bot.playerID).(*backend.Player)
 				bots.game.Mu.RUnlock()
+				// Bots are removed from the game when killed in horde mode.
+				if !ok {
+					continue
+				}
 				playerPosition := player.Position()
 				// Find the closest position.
 				closestPosition := backend.Coordinate{}
@@ -218,37 +187,27 @@ func (bots *Bots) Start() {
 				}
 				// Shooting takes priority over moving.
 				if shoot {
-					bots.game.ActionChannel <- backend.LaserAction{
-						ID:        uuid.New(),
-						OwnerID:   player.ID(),
+					actions = append(actions, backend.FireAction{
+						PlayerID:  player.ID(),
 						Direction: shootDirection,
-						Created:   time.Now(),
-					}
+					})
 					continue
 				}
 				if !move {
 					continue
 				}
-				// Ensure that the tiles we're moving from/to exist.
-				fromTile, ok := world.tiles[playerPosition]
-				if !ok {
-					continue
-				}
-				toTile, ok := world.tiles[closestPosition]
-				if !ok {
-					continue
-				}
-				// Find a path using the astar algorithm.
-				path, _, found := astar.Path(toTile, fromTile)
-				if !found {
+				// Find a path around walls. Paths are found again every
+				// tick, since targets move and walls can be destroyed.
+				bots.game.Mu.RLock()
+				path := backend.FindPath(bots.game, playerPosition, closestPosition)
+				bots.game.Mu.RUnlock()
+				if path == nil {
 					continue
 				}
 				// Move on the path.
-				var moveTowards backend.Coordinate
+				moveTowards := path[0]
 				if len(path) > 1 {
-					moveTowards = path[1].(*tile).position
-				} else {
-					moveTowards = path[0].(*tile).position
+					moveTowards = path[1]
 				}
 				// Determine the direction to move to reach the point.
 				xDiff := moveTowards.X - playerPosition.X
@@ -266,12 +225,13 @@ func (bots *Bots) Start() {
 				if direction == backend.DirectionStop {
 					continue
 				}
-				bots.game.ActionChannel <- backend.MoveAction{
+				actions = append(actions, backend.MoveAction{
 					ID:        player.ID(),
 					Direction: direction,
 					Created:   time.Now(),
-				}
+				})
 			}
+			bots.game.SubmitActions(actions)
 			time.Sleep(time.Millisecond * 200)
 		}
 	}()
@@ -0,0 +1,61 @@
+package bot
+
+import (
+	"fmt"
+	"time"
+)
+
+const (
+	hordeWaveDelay     = 5 * time.Second
+	hordeCheckInterval = 500 * time.Millisecond
+)
+
+// StartHorde spawns waves of bots for the game's horde mode. Once every bot
+// in a wave has been killed, a larger wave is spawned after a delay.
+func (bots *Bots) StartHorde() {
+	go func() {
+		var clearedAt time.Time
+		for {
+			time.Sleep(hordeCheckInterval)
+			bots.game.Mu.Lock()
+			if bots.game.CountBots() > 0 || bots.game.WaitForRound {
+				clearedAt = time.Time{}
+				bots.game.Mu.Unlock()
+				continue
+			}
+			if clearedAt.IsZero() {
+				clearedAt = time.Now()
+			}
+			// The first wave starts right away.
+			if bots.game.Wave > 0 && time.Now().Sub(clearedAt) < hordeWaveDelay {
+				bots.game.Mu.Unlock()
+				continue
+			}
+			bots.forgetRemoved()
+			size := bots.game.StartWave()
+			spawnPoints := bots.game.SafeSpawnCells()
+			for i := 0; i < size && len(spawnPoints) > 0; i++ {
+				name := fmt.Sprintf("Wave %d Bot %d", bots.game.Wave, i+1)
+				if bots.addBot(name, spawnPoints[i%len(spawnPoints)]) == nil {
+					break
+				}
+			}
+			clearedAt = time.Time{}
+			bots.game.Mu.Unlock()
+		}
+	}()
+}
+
+// forgetRemoved stops controlling bots that are no longer in the game. The
+// game should be locked by the caller.
+func (bots *Bots) forgetRemoved() {
+	bots.mu.Lock()
+	defer bots.mu.Unlock()
+	remaining := bots.bots[:0]
+	for _, bot := range bots.bots {
+		if bots.game.GetEntity(bot.playerID) != nil {
+			remaining = append(remaining, bot)
+		}
+	}
+	bots.bots = remaining
+}
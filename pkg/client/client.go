@@ -2,8 +2,11 @@ package client
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log"
+	"sync"
+	"time"
 
 	"github.com/golang/protobuf/ptypes"
 	"github.com/google/uuid"
@@ -14,70 +17,340 @@ import (
 )
 
 const (
-	positionHistoryLimit = 5
+	connectAttempts     = 3
+	reconnectAttempts   = 6
+	reconnectMinBackoff = 500 * time.Millisecond
+	reconnectMaxBackoff = 8 * time.Second
 )
 
 // GameClient is used to stream game information to a server and update the
-// game state as needed.
+// game state as needed. Set Spectator before connecting to watch the game
+// without joining as a player, and RoomID to join a room other than the
+// server's default. ReconnectToken lets the server reattach the client to its
+// player after losing the connection. It is set when connecting, and can be
+// set beforehand to resume a player from an earlier session.
+// HeartbeatInterval is how often heartbeats are sent once started.
 type GameClient struct {
-	CurrentPlayer   uuid.UUID
-	Stream          proto.Game_StreamClient
-	Game            *backend.Game
-	View            *frontend.View
-	positionHistory []backend.Coordinate
+	CurrentPlayer     uuid.UUID
+	Stream            proto.Game_StreamClient
+	Game              *backend.Game
+	View              *frontend.View
+	Done              chan error
+	OnDisconnect      func(err error)
+	OnReconnect       func()
+	Spectator         bool
+	RoomID            string
+	ReconnectToken    string
+	HeartbeatInterval time.Duration
+	interpolation     *InterpolationBuffer
+	latency           *LatencyTracker
+	grpcClient        proto.GameClient
+	playerName        string
+	password          string
+	color             string
+	streamMu          sync.RWMutex
+	cancelStream      context.CancelFunc
+	lastHeartbeat     time.Time
+	heartbeatMu       sync.Mutex
+	sendMu            sync.Mutex
+	disconnected      bool
+	stop              chan struct{}
+	stopOnce          sync.Once
+	sequence          uint32
+	predictedMoves    []predictedMove
+	predictionMu      sync.Mutex
+	// deltaBases and stateSyncPending are only used while the game is locked.
+	deltaBases       map[uuid.UUID]deltaBase
+	stateSyncPending bool
+	// recorder is only used while sendMu is locked.
+	recorder *recorder
 }
 
-// NewGameClient constructs a new game client struct.
+// NewGameClient constructs a new game client struct. The view may be nil if
+// the client is not rendering the game.
 func NewGameClient(game *backend.Game, view *frontend.View) *GameClient {
+	interpolation := NewInterpolationBuffer()
+	latency := &LatencyTracker{}
+	if view != nil {
+		view.Interpolator = interpolation
+		view.Latency = latency
+	}
 	return &GameClient{
-		Game:            game,
-		View:            view,
-		positionHistory: make([]backend.Coordinate, positionHistoryLimit),
+		Game:              game,
+		View:              view,
+		Done:              make(chan error, 1),
+		stop:              make(chan struct{}),
+		HeartbeatInterval: defaultHeartbeatInterval,
+		interpolation:     interpolation,
+		latency:           latency,
+		deltaBases:        make(map[uuid.UUID]deltaBase),
+	}
+}
+
+// Connect connects a new player to the server. The color is optional, and
+// should be one of backend.PlayerColors.
+func (c *GameClient) Connect(grpcClient proto.GameClient, playerID uuid.UUID, playerName string, password string, color string) error {
+	c.grpcClient = grpcClient
+	c.CurrentPlayer = playerID
+	if c.View != nil {
+		c.View.CurrentPlayer = playerID
+	}
+	c.playerName = playerName
+	c.password = password
+	c.color = color
+	return c.connect()
+}
+
+// Dialer opens a connection to a server, returning a client for it and a
+// function that closes the connection.
+type Dialer func() (proto.GameClient, func() error, error)
+
+// DialAndConnect dials the server and connects a new player like Connect,
+// retrying with exponential backoff if dialing or connecting fails. The
+// connection is closed after each failed attempt, and the last error is
+// returned if every attempt fails.
+func (c *GameClient) DialAndConnect(dial Dialer, playerID uuid.UUID, playerName string, password string, color string) error {
+	var err error
+	backoff := reconnectMinBackoff
+	for attempt := 0; attempt < connectAttempts; attempt++ {
+		if attempt > 0 {
+			time.Sleep(backoff)
+			backoff *= 2
+			if backoff > reconnectMaxBackoff {
+				backoff = reconnectMaxBackoff
+			}
+		}
+		var grpcClient proto.GameClient
+		var closeConn func() error
+		grpcClient, closeConn, err = dial()
+		if err != nil {
+			continue
+		}
+		err = c.Connect(grpcClient, playerID, playerName, password, color)
+		if err == nil {
+			return nil
+		}
+		closeConn()
 	}
+	return err
 }
 
-// Connect connects a new player to the server.
-func (c *GameClient) Connect(grpcClient proto.GameClient, playerID uuid.UUID, playerName string, password string) error {
+// connect sends a connect request to the server, replaces the local entity
+// state with the server's, and initializes a new stream.
+func (c *GameClient) connect() error {
 	// Connect to server.
 	req := proto.ConnectRequest{
-		Id:       playerID.String(),
-		Name:     playerName,
-		Password: password,
+		Id:             c.CurrentPlayer.String(),
+		Name:           c.playerName,
+		Password:       c.password,
+		Color:          c.color,
+		Spectate:       c.Spectator,
+		ReconnectToken: c.ReconnectToken,
+	}
+	var resp *proto.ConnectResponse
+	var err error
+	if c.RoomID != "" {
+		resp, err = c.grpcClient.JoinRoom(context.Background(), &proto.JoinRoomRequest{
+			RoomId:  c.RoomID,
+			Connect: &req,
+		})
+	} else {
+		resp, err = c.grpcClient.Connect(context.Background(), &req)
 	}
-	resp, err := grpcClient.Connect(context.Background(), &req)
 	if err != nil {
 		return err
 	}
 
-	// Add initial entity state.
+	entities := make([]backend.Identifier, 0, len(resp.Entities))
 	for _, entity := range resp.Entities {
 		backendEntity := proto.GetBackendEntity(entity)
 		if backendEntity == nil {
 			return fmt.Errorf("can not get backend entity from %+v", entity)
 		}
-		c.Game.AddEntity(backendEntity)
+		entities = append(entities, backendEntity)
 	}
 
 	// Initialize stream with token.
 	header := metadata.New(map[string]string{"authorization": resp.Token})
 	ctx := metadata.NewOutgoingContext(context.Background(), header)
-	stream, err := grpcClient.Stream(ctx)
+	// The stream is cancelled if the server stops responding to heartbeats.
+	ctx, cancel := context.WithCancel(ctx)
+	stream, err := c.grpcClient.Stream(ctx)
 	if err != nil {
+		cancel()
 		return err
 	}
 
-	c.CurrentPlayer = playerID
-	c.View.CurrentPlayer = playerID
+	// Replace entity state, which may be stale if we are reconnecting.
+	c.ReconnectToken = resp.ReconnectToken
+
+	c.Game.Mu.Lock()
+	c.replaceEntities(entities)
+	c.Game.DefaultDirection = proto.GetBackendDirection(resp.DefaultDirection)
+	if resp.Modifier != "" {
+		c.Game.ApplyModifier(resp.Modifier)
+	}
+	// The server's throttle already includes its modifier, so it's set
+	// after applying the modifier.
+	if resp.MoveThrottleMillis > 0 {
+		c.Game.SetMoveThrottle(time.Duration(resp.MoveThrottleMillis) * time.Millisecond)
+	}
+	c.Game.Phase = proto.GetBackendPhase(resp.Phase)
+	// Lasers fired locally should move as fast as the server's.
+	if resp.SpeedMultiplier > 0 {
+		c.Game.SpeedMultiplier = resp.SpeedMultiplier
+	}
+	if resp.TeamColors != nil {
+		c.Game.TeamColors = resp.TeamColors
+	}
+	if c.View != nil {
+		c.View.Career = proto.GetBackendCareerStats(resp.Career)
+	}
+	c.Game.Mu.Unlock()
+
+	c.streamMu.Lock()
+	if c.cancelStream != nil {
+		c.cancelStream()
+	}
 	c.Stream = stream
+	c.cancelStream = cancel
+	c.streamMu.Unlock()
+	c.heartbeatReceived()
 
 	return nil
 }
 
+// GetLeaderboard fetches the score of every player from the server, highest
+// first. This can be used to poll scores without relying on the stream.
+func (c *GameClient) GetLeaderboard() ([]backend.LeaderboardEntry, error) {
+	resp, err := c.grpcClient.GetLeaderboard(context.Background(), &proto.LeaderboardRequest{
+		RoomId: c.RoomID,
+	})
+	if err != nil {
+		return nil, err
+	}
+	leaderboard := make([]backend.LeaderboardEntry, 0, len(resp.Entries))
+	for _, protoEntry := range resp.Entries {
+		entry := proto.GetBackendLeaderboardEntry(protoEntry)
+		if entry == nil {
+			return nil, fmt.Errorf("can not get backend leaderboard entry from %+v", protoEntry)
+		}
+		leaderboard = append(leaderboard, *entry)
+	}
+	return leaderboard, nil
+}
+
+// replaceEntities replaces all local entities with the given ones. The game
+// should be locked by the caller.
+func (c *GameClient) replaceEntities(entities []backend.Identifier) {
+	for id := range c.Game.Entities {
+		c.interpolation.Remove(id)
+		c.Game.RemoveEntity(id)
+	}
+	c.deltaBases = make(map[uuid.UUID]deltaBase)
+	for _, entity := range entities {
+		if err := c.Game.AddEntity(entity); err != nil {
+			log.Printf("can not add entity %s: %v", entity.ID(), err)
+			continue
+		}
+		c.setDeltaBase(entity)
+	}
+	c.stateSyncPending = false
+	c.clearPredictions()
+}
+
+// reconnect tries to connect to the server again using exponential backoff.
+// True is returned if reconnecting succeeded.
+func (c *GameClient) reconnect(err error) bool {
+	if c.OnDisconnect != nil {
+		c.OnDisconnect(err)
+	}
+	backoff := reconnectMinBackoff
+	for attempt := 0; attempt < reconnectAttempts; attempt++ {
+		time.Sleep(backoff)
+		if err := c.connect(); err != nil {
+			backoff *= 2
+			if backoff > reconnectMaxBackoff {
+				backoff = reconnectMaxBackoff
+			}
+			continue
+		}
+		if c.OnReconnect != nil {
+			c.OnReconnect()
+		}
+		return true
+	}
+	return false
+}
+
+// getStream returns the current stream, which changes when reconnecting.
+func (c *GameClient) getStream() proto.Game_StreamClient {
+	c.streamMu.RLock()
+	defer c.streamMu.RUnlock()
+	return c.Stream
+}
+
+// send sends a request to the server. Streams do not support concurrent
+// sends, so this should be used instead of sending to the stream directly.
+func (c *GameClient) send(req *proto.Request) error {
+	c.sendMu.Lock()
+	defer c.sendMu.Unlock()
+	if c.recorder != nil {
+		if err := c.recorder.record(req); err != nil {
+			log.Printf("can not record request: %v", err)
+		}
+	}
+	return c.getStream().Send(req)
+}
+
+// Disconnect tells the server that the client is leaving, so that the player
+// is removed right away. The client will not reconnect afterwards.
+func (c *GameClient) Disconnect() error {
+	c.streamMu.Lock()
+	c.disconnected = true
+	c.streamMu.Unlock()
+	c.stopBackground()
+	req := proto.Request{
+		Action: &proto.Request_Disconnect{
+			Disconnect: &proto.Disconnect{},
+		},
+	}
+	if err := c.send(&req); err != nil {
+		return err
+	}
+	c.sendMu.Lock()
+	defer c.sendMu.Unlock()
+	return c.getStream().CloseSend()
+}
+
+// isDisconnected checks if Disconnect has been called.
+func (c *GameClient) isDisconnected() bool {
+	c.streamMu.RLock()
+	defer c.streamMu.RUnlock()
+	return c.disconnected
+}
+
 // Exit stops the tview application and prints a message.
 // This is needed as stdout is mangled while tview is running.
 func (c *GameClient) Exit(message string) {
-	c.View.App.Stop()
+	if c.View != nil {
+		c.View.App.Stop()
+	}
 	log.Println(message)
+	c.stopBackground()
+	select {
+	case c.Done <- errors.New(message):
+	default:
+	}
+}
+
+// stopBackground stops the goroutines that send requests on their own, like
+// pings, heartbeats, and headless actions, once the client exits or
+// disconnects.
+func (c *GameClient) stopBackground() {
+	c.stopOnce.Do(func() {
+		close(c.stop)
+	})
 }
 
 // Start begins the goroutines needed to recieve server changes and send game
@@ -94,14 +367,60 @@ func (c *GameClient) Start() {
 			case backend.AddEntityChange:
 				change := change.(backend.AddEntityChange)
 				c.handleAddEntityChange(change)
+			case backend.WeaponChange:
+				c.handleWeaponChange()
+			case backend.ReadyChange:
+				c.handleReadyChange()
 			}
 		}
 	}()
+	// Measure latency.
+	go func() {
+		pingTicker := time.NewTicker(pingFrequency)
+		defer pingTicker.Stop()
+		for {
+			select {
+			case <-pingTicker.C:
+			case <-c.stop:
+				return
+			}
+			c.sendPing()
+		}
+	}()
+	// Detect when the server stops responding.
+	go func() {
+		heartbeatTicker := time.NewTicker(c.HeartbeatInterval)
+		defer heartbeatTicker.Stop()
+		for {
+			select {
+			case <-heartbeatTicker.C:
+			case <-c.stop:
+				return
+			}
+			if c.heartbeatMissed() {
+				// Cancelling the stream makes receiving fail, which starts
+				// reconnecting.
+				c.streamMu.RLock()
+				if c.cancelStream != nil {
+					c.cancelStream()
+				}
+				c.streamMu.RUnlock()
+				continue
+			}
+			c.sendHeartbeat()
+		}
+	}()
 	// Handle stream messages.
 	go func() {
 		for {
-			resp, err := c.Stream.Recv()
+			resp, err := c.getStream().Recv()
 			if err != nil {
+				if c.isDisconnected() {
+					return
+				}
+				if c.reconnect(err) {
+					continue
+				}
 				c.Exit(fmt.Sprintf("can not receive, error: %v", err))
 				return
 			}
@@ -112,6 +431,8 @@ func (c *GameClient) Start() {
 				c.handleAddEntityResponse(resp)
 			case *proto.Response_UpdateEntity:
 				c.handleUpdateEntityResponse(resp)
+			case *proto.Response_MoveDelta:
+				c.handleMoveDeltaResponse(resp)
 			case *proto.Response_RemoveEntity:
 				c.handleRemoveEntityResponse(resp)
 			case *proto.Response_PlayerRespawn:
@@ -120,6 +441,30 @@ func (c *GameClient) Start() {
 				c.handleRoundOverResponse(resp)
 			case *proto.Response_RoundStart:
 				c.handleRoundStartResponse(resp)
+			case *proto.Response_Pong:
+				c.handlePongResponse(resp)
+			case *proto.Response_StateSync:
+				c.handleStateSyncResponse(resp)
+			case *proto.Response_Heartbeat:
+				c.heartbeatReceived()
+			case *proto.Response_Damage:
+				c.handleDamageResponse(resp)
+			case *proto.Response_Score:
+				c.handleScoreResponse(resp)
+			case *proto.Response_Status:
+				c.handleStatusResponse(resp)
+			case *proto.Response_Wave:
+				c.Game.Wave = int(resp.GetWave().Wave)
+			case *proto.Response_WeaponSwitch:
+				c.handleWeaponSwitchResponse(resp)
+			case *proto.Response_Modifier:
+				c.Game.ApplyModifier(resp.GetModifier().Name)
+			case *proto.Response_Phase:
+				c.Game.Phase = proto.GetBackendPhase(resp.GetPhase().Phase)
+			case *proto.Response_SpectatorCount:
+				c.Game.SetSpectatorCount(int(resp.GetSpectatorCount().Count))
+			case *proto.Response_Explosion:
+				c.handleExplosionResponse(resp)
 			}
 			c.Game.Mu.Unlock()
 		}
@@ -127,16 +472,51 @@ func (c *GameClient) Start() {
 }
 
 func (c *GameClient) handleMoveChange(change backend.MoveChange) {
+	// Teleports are decided by the server, not requested by the player.
+	if change.Teleport {
+		return
+	}
+	// The move has already been applied locally, so track it until the server
+	// confirms or corrects it.
+	sequence := c.predictMove(change.Position)
+	if change.Dash {
+		req := proto.Request{
+			Action: &proto.Request_Dash{
+				Dash: &proto.Dash{
+					Sequence: sequence,
+				},
+			},
+		}
+		c.send(&req)
+		return
+	}
 	req := proto.Request{
 		Action: &proto.Request_Move{
 			Move: &proto.Move{
 				Direction: proto.GetProtoDirection(change.Direction),
+				Sequence:  sequence,
 			},
 		},
 	}
-	c.Stream.Send(&req)
-	// Store position history to help with stuttering.
-	c.positionHistory = append([]backend.Coordinate{change.Position}, c.positionHistory[:positionHistoryLimit]...)
+	c.send(&req)
+}
+
+func (c *GameClient) handleWeaponChange() {
+	req := proto.Request{
+		Action: &proto.Request_SwitchWeapon{
+			SwitchWeapon: &proto.SwitchWeapon{},
+		},
+	}
+	c.send(&req)
+}
+
+func (c *GameClient) handleReadyChange() {
+	req := proto.Request{
+		Action: &proto.Request_Ready{
+			Ready: &proto.Ready{},
+		},
+	}
+	c.send(&req)
 }
 
 func (c *GameClient) handleAddEntityChange(change backend.AddEntityChange) {
@@ -152,10 +532,33 @@ func (c *GameClient) handleAddEntityChange(change backend.AddEntityChange) {
 				Laser: proto.GetProtoLaser(laser),
 			},
 		}
-		c.Stream.Send(&req)
+		c.send(&req)
+	case *backend.Mine:
+		req := proto.Request{
+			Action: &proto.Request_Deploy{
+				Deploy: &proto.Deploy{
+					Id: change.Entity.ID().String(),
+				},
+			},
+		}
+		c.send(&req)
 	}
 }
 
+func (c *GameClient) handleStateSyncResponse(resp *proto.Response) {
+	stateSync := resp.GetStateSync()
+	entities := make([]backend.Identifier, 0, len(stateSync.Entities))
+	for _, entity := range stateSync.Entities {
+		backendEntity := proto.GetBackendEntity(entity)
+		if backendEntity == nil {
+			c.Exit(fmt.Sprintf("can not get backend entity from %+v", entity))
+			return
+		}
+		entities = append(entities, backendEntity)
+	}
+	c.replaceEntities(entities)
+}
+
 func (c *GameClient) handleAddEntityResponse(resp *proto.Response) {
 	add := resp.GetAddEntity()
 	entity := proto.GetBackendEntity(add.Entity)
@@ -168,7 +571,11 @@ func (c *GameClient) handleAddEntityResponse(resp *proto.Response) {
 	if ok && laser.OwnerID == c.CurrentPlayer {
 		return
 	}
-	c.Game.AddEntity(entity)
+	if err := c.Game.AddEntity(entity); err != nil {
+		log.Printf("can not add entity %s: %v", entity.ID(), err)
+		return
+	}
+	c.setDeltaBase(entity)
 }
 
 func (c *GameClient) handleUpdateEntityResponse(resp *proto.Response) {
@@ -178,18 +585,16 @@ func (c *GameClient) handleUpdateEntityResponse(resp *proto.Response) {
 		c.Exit(fmt.Sprintf("can not get backend entity from %+v", entity))
 		return
 	}
-	// To prevent jittering, ignore updates for recent positions.
-	// Note: This feels OK, but isn't perfect. I think if I refactored the
-	// networking to use more targeted responses, i.e. "move confirmed" sent
-	// after a player moves, you could compare recent moves instead of
-	// positions and do something like rollback networking.
-	player, ok := entity.(*backend.Player)
-	if ok && player.ID() == c.CurrentPlayer {
-		for _, position := range c.positionHistory {
-			if player.Position() == position {
-				return
-			}
-		}
+	// Our own moves are reconciled using move deltas, so an update for the
+	// current player is authoritative and replaces any predictions.
+	c.setDeltaBase(entity)
+	if entity.ID() == c.CurrentPlayer {
+		c.clearPredictions()
+	}
+	// Buffer positions of remote entities so they can be rendered smoothly.
+	positioner, ok := entity.(backend.Positioner)
+	if ok && entity.ID() != c.CurrentPlayer {
+		c.interpolation.Add(entity.ID(), positioner.Position(), time.Now())
 	}
 	c.Game.UpdateEntity(entity)
 }
@@ -201,6 +606,8 @@ func (c *GameClient) handleRemoveEntityResponse(resp *proto.Response) {
 		c.Exit(fmt.Sprintf("error when parsing UUID: %v", err))
 		return
 	}
+	c.interpolation.Remove(id)
+	delete(c.deltaBases, id)
 	c.Game.RemoveEntity(id)
 }
 
@@ -216,10 +623,82 @@ func (c *GameClient) handlePlayerRespawnResponse(resp *proto.Response) {
 		c.Exit(fmt.Sprintf("can not get backend player from %+v", respawn.Player))
 		return
 	}
-	c.Game.AddScore(killedByID)
+	// Hazard deaths are not credited to anyone.
+	if killedByID != uuid.Nil {
+		c.Game.SetScore(killedByID, int(respawn.KillerScore))
+	}
+	c.Game.SetScore(player.ID(), int(respawn.VictimScore))
+	// Record where the player was killed, before they are moved.
+	if victim, ok := c.Game.GetEntity(player.ID()).(*backend.Player); ok {
+		c.Game.AddKill(killedByID, player.ID(), victim.Position())
+	}
+	// Respawning players should not be interpolated from where they died.
+	c.interpolation.Remove(player.ID())
+	if player.ID() == c.CurrentPlayer {
+		c.clearPredictions()
+	}
+	c.setDeltaBase(player)
 	c.Game.UpdateEntity(player)
 }
 
+func (c *GameClient) handleDamageResponse(resp *proto.Response) {
+	damage := resp.GetDamage()
+	playerID, err := uuid.Parse(damage.PlayerId)
+	if err != nil {
+		c.Exit(fmt.Sprintf("error when parsing UUID: %v", err))
+		return
+	}
+	if player, ok := c.Game.GetEntity(playerID).(*backend.Player); ok {
+		player.Damage = int(damage.Damage)
+	}
+}
+
+func (c *GameClient) handleExplosionResponse(resp *proto.Response) {
+	explosion := resp.GetExplosion()
+	c.Game.AddExplosion(backend.Explosion{
+		Position: proto.GetBackendCoordinate(explosion.Position),
+		Radius:   int(explosion.Radius),
+		Time:     time.Now(),
+	})
+}
+
+func (c *GameClient) handleScoreResponse(resp *proto.Response) {
+	score := resp.GetScore()
+	playerID, err := uuid.Parse(score.PlayerId)
+	if err != nil {
+		c.Exit(fmt.Sprintf("error when parsing UUID: %v", err))
+		return
+	}
+	c.Game.SetScore(playerID, int(score.Score))
+}
+
+func (c *GameClient) handleStatusResponse(resp *proto.Response) {
+	status := resp.GetStatus()
+	playerID, err := uuid.Parse(status.PlayerId)
+	if err != nil {
+		c.Exit(fmt.Sprintf("error when parsing UUID: %v", err))
+		return
+	}
+	if player, ok := c.Game.GetEntity(playerID).(*backend.Player); ok {
+		player.Frozen = status.Frozen
+		player.Blinking = status.Blinking
+		player.Ready = status.Ready
+		player.ProtectedUntil = proto.GetBackendTime(status.ProtectedUntil)
+	}
+}
+
+func (c *GameClient) handleWeaponSwitchResponse(resp *proto.Response) {
+	weaponSwitch := resp.GetWeaponSwitch()
+	playerID, err := uuid.Parse(weaponSwitch.PlayerId)
+	if err != nil {
+		c.Exit(fmt.Sprintf("error when parsing UUID: %v", err))
+		return
+	}
+	if player, ok := c.Game.GetEntity(playerID).(*backend.Player); ok {
+		player.ActiveWeapon = int(weaponSwitch.ActiveWeapon)
+	}
+}
+
 func (c *GameClient) handleRoundOverResponse(resp *proto.Response) {
 	respawn := resp.GetRoundOver()
 	roundWinner, err := uuid.Parse(respawn.RoundWinnerId)
@@ -232,10 +711,19 @@ func (c *GameClient) handleRoundOverResponse(resp *proto.Response) {
 		c.Exit(fmt.Sprintf("error when parsing timestamp: %v", err))
 		return
 	}
+	// Older servers don't send a summary.
+	if respawn.Summary != nil {
+		summary := proto.GetBackendSummary(respawn.Summary)
+		if summary == nil {
+			c.Exit(fmt.Sprintf("can not get backend summary from %+v", respawn.Summary))
+			return
+		}
+		c.Game.RoundSummary = *summary
+	}
 	c.Game.RoundWinner = roundWinner
 	c.Game.NewRoundAt = newRoundAt
 	c.Game.WaitForRound = true
-	c.Game.Score = make(map[uuid.UUID]int)
+	c.Game.ResetScores()
 }
 
 func (c *GameClient) handleRoundStartResponse(resp *proto.Response) {
@@ -247,6 +735,37 @@ func (c *GameClient) handleRoundStartResponse(resp *proto.Response) {
 			c.Exit(fmt.Sprintf("can not get backend player from %+v", protoPlayer))
 			return
 		}
-		c.Game.AddEntity(player)
+		c.interpolation.Remove(player.ID())
+		if player.ID() == c.CurrentPlayer {
+			c.clearPredictions()
+		}
+		if err := c.Game.AddEntity(player); err != nil {
+			log.Printf("can not add player %s: %v", player.ID(), err)
+			continue
+		}
+		c.setDeltaBase(player)
+	}
+}
+
+func (c *GameClient) sendPing() {
+	sent, err := ptypes.TimestampProto(time.Now())
+	if err != nil {
+		return
+	}
+	req := proto.Request{
+		Action: &proto.Request_Ping{
+			Ping: &proto.Ping{
+				Sent: sent,
+			},
+		},
+	}
+	c.send(&req)
+}
+
+func (c *GameClient) handlePongResponse(resp *proto.Response) {
+	sent, err := ptypes.Timestamp(resp.GetPong().Sent)
+	if err != nil {
+		return
 	}
+	c.latency.Add(time.Now().Sub(sent))
 }
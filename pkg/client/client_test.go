@@ -0,0 +1,649 @@
+package client
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+	"github.com/google/uuid"
+	"github.com/mortenson/grpc-game-example/pkg/backend"
+	"github.com/mortenson/grpc-game-example/pkg/backend/testutil"
+	"github.com/mortenson/grpc-game-example/proto"
+)
+
+// newTestClient returns a client connected to a fake server as the given
+// player, with a game that hasn't been started.
+func newTestClient(t *testing.T, server *fakeServer, playerID uuid.UUID) *GameClient {
+	t.Helper()
+	c := NewGameClient(testutil.NewGame(), nil)
+	if err := c.Connect(server, playerID, "alice", "", ""); err != nil {
+		t.Fatalf("can not connect: %v", err)
+	}
+	return c
+}
+
+func TestReconnect(t *testing.T) {
+	playerID := uuid.New()
+	tests := []struct {
+		name     string
+		failures int
+	}{
+		{name: "first attempt succeeds", failures: 0},
+		{name: "recovers after a failure", failures: 1},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			server := &fakeServer{
+				entities: []*proto.Entity{proto.GetProtoEntity(&backend.Player{
+					IdentifierBase: backend.IdentifierBase{UUID: playerID},
+					Name:           "alice",
+				})},
+			}
+			c := newTestClient(t, server, playerID)
+			// While disconnected the server state changed, so the player
+			// moved and someone else joined.
+			bob := &backend.Player{
+				IdentifierBase:  backend.IdentifierBase{UUID: uuid.New()},
+				Name:            "bob",
+				CurrentPosition: backend.Coordinate{X: 3},
+			}
+			server.mu.Lock()
+			server.failures = test.failures
+			server.entities = []*proto.Entity{
+				proto.GetProtoEntity(&backend.Player{
+					IdentifierBase:  backend.IdentifierBase{UUID: playerID},
+					Name:            "alice",
+					CurrentPosition: backend.Coordinate{Y: 2},
+				}),
+				proto.GetProtoEntity(bob),
+			}
+			server.mu.Unlock()
+
+			dropped := errors.New("stream dropped")
+			var disconnectErr error
+			reconnected := false
+			c.OnDisconnect = func(err error) {
+				disconnectErr = err
+			}
+			c.OnReconnect = func() {
+				reconnected = true
+			}
+			if !c.reconnect(dropped) {
+				t.Fatal("client did not reconnect")
+			}
+			if disconnectErr != dropped {
+				t.Errorf("OnDisconnect got %v, want %v", disconnectErr, dropped)
+			}
+			if !reconnected {
+				t.Error("OnReconnect was not called")
+			}
+			if connects := len(server.connects); connects != test.failures+2 {
+				t.Errorf("got %d connect requests, want %d", connects, test.failures+2)
+			}
+			if last := server.connects[len(server.connects)-1]; last.ReconnectToken != "reconnect" {
+				t.Errorf("reconnected with token %q, want the one from the first connect", last.ReconnectToken)
+			}
+			if c.getStream() != server.streams[len(server.streams)-1] {
+				t.Error("client is not using the new stream")
+			}
+			player, ok := c.Game.GetEntity(playerID).(*backend.Player)
+			if !ok || player.Position() != (backend.Coordinate{Y: 2}) {
+				t.Errorf("player state was not synced, got %+v", player)
+			}
+			if c.Game.GetEntity(bob.ID()) == nil {
+				t.Error("new entities were not synced")
+			}
+		})
+	}
+}
+
+func TestDialAndConnect(t *testing.T) {
+	tests := []struct {
+		name string
+		// dialFailures and connectFailures are how many dials and connect
+		// requests fail before succeeding.
+		dialFailures    int
+		connectFailures int
+		// closes is how many connections are closed after failing to connect.
+		closes    int
+		connected bool
+	}{
+		{name: "connects", connected: true},
+		{name: "retries a failed dial", dialFailures: 1, connected: true},
+		{name: "retries a failed connect", connectFailures: 1, closes: 1, connected: true},
+		{name: "gives up after every attempt fails", dialFailures: 1, connectFailures: connectAttempts, closes: connectAttempts - 1, connected: false},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			server := &fakeServer{failures: test.connectFailures}
+			dialFailures := test.dialFailures
+			closes := 0
+			dial := func() (proto.GameClient, func() error, error) {
+				if dialFailures > 0 {
+					dialFailures--
+					return nil, nil, errors.New("connection refused")
+				}
+				return server, func() error {
+					closes++
+					return nil
+				}, nil
+			}
+			c := NewGameClient(testutil.NewGame(), nil)
+			err := c.DialAndConnect(dial, uuid.New(), "alice", "", "")
+			if connected := err == nil; connected != test.connected {
+				t.Fatalf("connected is %v, want %v (error %v)", connected, test.connected, err)
+			}
+			if closes != test.closes {
+				t.Errorf("closed %d connections, want %d", closes, test.closes)
+			}
+			if streams := len(server.streams); test.connected != (streams == 1) {
+				t.Errorf("got %d streams, want one only if connected", streams)
+			}
+		})
+	}
+}
+
+func TestPlayerRespawnResponse(t *testing.T) {
+	tests := []struct {
+		name   string
+		killer bool
+	}{
+		{name: "killed by a player", killer: true},
+		{name: "killed by a hazard", killer: false},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			game := testutil.NewGame(
+				testutil.WithPlayerAt("alice", 2, 3),
+				testutil.WithPlayerAt("bob", 0, 0),
+			)
+			alice := testutil.Player(game, "alice")
+			killerID := uuid.Nil
+			if test.killer {
+				killerID = testutil.Player(game, "bob").ID()
+			}
+			c := NewGameClient(game, nil)
+			respawned := *alice
+			respawned.CurrentPosition = backend.Coordinate{X: -5, Y: -5}
+			c.handlePlayerRespawnResponse(&proto.Response{
+				Action: &proto.Response_PlayerRespawn{
+					PlayerRespawn: &proto.PlayerRespawn{
+						Player:      proto.GetProtoPlayer(&respawned),
+						KilledById:  killerID.String(),
+						KillerScore: 4,
+						VictimScore: 2,
+					},
+				},
+			})
+			if len(game.RecentKills) != 1 {
+				t.Fatalf("got %d kills in the feed, want 1", len(game.RecentKills))
+			}
+			kill := game.RecentKills[0]
+			if kill.KillerID != killerID || kill.VictimID != alice.ID() {
+				t.Errorf("got kill of %s by %s, want %s by %s", kill.VictimID, kill.KillerID, alice.ID(), killerID)
+			}
+			if kill.Position != (backend.Coordinate{X: 2, Y: 3}) {
+				t.Errorf("kill is at %+v, want where the player died", kill.Position)
+			}
+			if score := game.Score[alice.ID()]; score != 2 {
+				t.Errorf("victim has score %d, want 2", score)
+			}
+			if score, ok := game.Score[killerID]; test.killer != ok || (ok && score != 4) {
+				t.Errorf("killer has score %d, want 4 only if there was a killer", score)
+			}
+			player := game.GetEntity(alice.ID()).(*backend.Player)
+			if player.Position() != respawned.CurrentPosition {
+				t.Errorf("player is at %+v, want the respawn position", player.Position())
+			}
+		})
+	}
+}
+
+func TestStateSyncResponse(t *testing.T) {
+	game := testutil.NewGame(
+		testutil.WithPlayerAt("alice", 0, 0),
+		testutil.WithPlayerAt("stale", 1, 0),
+	)
+	alice := testutil.Player(game, "alice")
+	stale := testutil.Player(game, "stale")
+	c := NewGameClient(game, nil)
+	moved := *alice
+	moved.CurrentPosition = backend.Coordinate{X: 4, Y: 4}
+	bob := &backend.Player{
+		IdentifierBase:  backend.IdentifierBase{UUID: uuid.New()},
+		Name:            "bob",
+		CurrentPosition: backend.Coordinate{X: -3},
+	}
+	c.handleStateSyncResponse(&proto.Response{
+		Action: &proto.Response_StateSync{
+			StateSync: &proto.StateSync{
+				Entities: []*proto.Entity{
+					proto.GetProtoEntity(&moved),
+					proto.GetProtoEntity(bob),
+				},
+			},
+		},
+	})
+	if len(game.Entities) != 2 {
+		t.Errorf("got %d entities, want 2", len(game.Entities))
+	}
+	if game.GetEntity(stale.ID()) != nil {
+		t.Error("entities missing from the sync were not removed")
+	}
+	if player, ok := game.GetEntity(alice.ID()).(*backend.Player); !ok || player.Position() != moved.Position() {
+		t.Errorf("existing entities were not updated, got %+v", player)
+	}
+	if game.GetEntity(bob.ID()) == nil {
+		t.Error("new entities were not added")
+	}
+}
+
+func TestGetLeaderboard(t *testing.T) {
+	aliceID := uuid.New()
+	bobID := uuid.New()
+	tests := []struct {
+		name    string
+		entries []*proto.LeaderboardEntry
+		want    []backend.LeaderboardEntry
+		ok      bool
+	}{
+		{name: "empty", entries: nil, want: []backend.LeaderboardEntry{}, ok: true},
+		{
+			name: "entries are kept in order",
+			entries: []*proto.LeaderboardEntry{
+				{PlayerId: bobID.String(), Name: "bob", Score: 5},
+				{PlayerId: aliceID.String(), Name: "alice", Score: 2},
+			},
+			want: []backend.LeaderboardEntry{
+				{PlayerID: bobID, Name: "bob", Score: 5},
+				{PlayerID: aliceID, Name: "alice", Score: 2},
+			},
+			ok: true,
+		},
+		{
+			name:    "invalid player ID",
+			entries: []*proto.LeaderboardEntry{{PlayerId: "bob", Name: "bob", Score: 5}},
+			ok:      false,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			server := &fakeServer{leaderboard: test.entries}
+			c := newTestClient(t, server, aliceID)
+			leaderboard, err := c.GetLeaderboard()
+			if (err == nil) != test.ok {
+				t.Fatalf("got error %v, want ok %v", err, test.ok)
+			}
+			if len(leaderboard) != len(test.want) {
+				t.Fatalf("got %d entries, want %d", len(leaderboard), len(test.want))
+			}
+			for i, entry := range leaderboard {
+				if entry != test.want[i] {
+					t.Errorf("entry %d is %+v, want %+v", i, entry, test.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestDisconnect(t *testing.T) {
+	server := &fakeServer{}
+	c := newTestClient(t, server, uuid.New())
+	if err := c.Disconnect(); err != nil {
+		t.Fatal(err)
+	}
+	stream := server.streams[0]
+	requests := stream.requests()
+	if len(requests) != 1 || requests[0].GetDisconnect() == nil {
+		t.Errorf("sent %v, want a disconnect message", requests)
+	}
+	stream.mu.Lock()
+	closed := stream.closed
+	stream.mu.Unlock()
+	if !closed {
+		t.Error("the stream was not closed")
+	}
+	if !c.isDisconnected() {
+		t.Error("the client does not know it disconnected")
+	}
+}
+
+func TestMoveChangeRequests(t *testing.T) {
+	tests := []struct {
+		name   string
+		change backend.MoveChange
+		match  func(req *proto.Request) bool
+	}{
+		{
+			name:   "moves",
+			change: backend.MoveChange{Direction: backend.DirectionLeft, Position: backend.Coordinate{X: -1}, Distance: 1},
+			match: func(req *proto.Request) bool {
+				return req.GetMove() != nil && req.GetMove().Direction == proto.Direction_LEFT && req.GetMove().Sequence == 1
+			},
+		},
+		{
+			name:   "dashes",
+			change: backend.MoveChange{Direction: backend.DirectionLeft, Position: backend.Coordinate{X: -4}, Distance: 4, Dash: true},
+			match: func(req *proto.Request) bool {
+				return req.GetDash() != nil && req.GetDash().Sequence == 1
+			},
+		},
+		{
+			name:   "teleports are not sent",
+			change: backend.MoveChange{Teleport: true, Position: backend.Coordinate{X: 3}},
+			match:  nil,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			server := &fakeServer{}
+			c := newTestClient(t, server, uuid.New())
+			c.handleMoveChange(test.change)
+			requests := server.streams[0].requests()
+			if test.match == nil {
+				if len(requests) != 0 {
+					t.Errorf("sent %v, want nothing", requests)
+				}
+				return
+			}
+			if len(requests) != 1 || !test.match(requests[0]) {
+				t.Errorf("sent %v", requests)
+			}
+		})
+	}
+}
+
+func TestDamageResponse(t *testing.T) {
+	game := testutil.NewGame(testutil.WithPlayerAt("alice", 0, 0))
+	alice := testutil.Player(game, "alice")
+	c := NewGameClient(game, nil)
+	c.handleDamageResponse(&proto.Response{
+		Action: &proto.Response_Damage{
+			Damage: &proto.Damage{
+				PlayerId: alice.ID().String(),
+				Damage:   30,
+			},
+		},
+	})
+	if health := alice.Health(); health != backend.MaxHealth-30 {
+		t.Errorf("player has %d health, want %d", health, backend.MaxHealth-30)
+	}
+}
+
+func TestConnectDefaultDirection(t *testing.T) {
+	server := &fakeServer{defaultDirection: proto.Direction_LEFT}
+	c := newTestClient(t, server, uuid.New())
+	if direction := c.Game.DefaultDirection; direction != backend.DirectionLeft {
+		t.Errorf("got default direction %v, want the server's", direction)
+	}
+}
+
+func TestConnectSpeedMultiplier(t *testing.T) {
+	server := &fakeServer{speedMultiplier: 2}
+	c := newTestClient(t, server, uuid.New())
+	if multiplier := c.Game.SpeedMultiplier; multiplier != 2 {
+		t.Errorf("got speed multiplier %v, want the server's", multiplier)
+	}
+}
+
+func TestConnectMoveThrottle(t *testing.T) {
+	// The server's throttle already includes the fast movement modifier.
+	server := &fakeServer{moveThrottleMillis: 25, modifier: "fast movement"}
+	c := newTestClient(t, server, uuid.New())
+	c.Game.Mu.RLock()
+	defer c.Game.Mu.RUnlock()
+	if throttle := c.Game.MoveThrottle(); throttle != 25*time.Millisecond {
+		t.Errorf("got move throttle %s, want the server's", throttle)
+	}
+}
+
+func TestConnectModifier(t *testing.T) {
+	tests := []struct {
+		name     string
+		modifier string
+	}{
+		{name: "modifier", modifier: "rapid fire"},
+		{name: "no modifier", modifier: ""},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			server := &fakeServer{modifier: test.modifier}
+			c := newTestClient(t, server, uuid.New())
+			c.Game.Mu.RLock()
+			defer c.Game.Mu.RUnlock()
+			if modifier := c.Game.Modifier; modifier != test.modifier {
+				t.Errorf("got modifier %q, want the server's", modifier)
+			}
+		})
+	}
+}
+
+func TestConnectTeamColors(t *testing.T) {
+	tests := []struct {
+		name       string
+		teamColors []string
+		want       []string
+	}{
+		{name: "server colors", teamColors: []string{"green", "yellow"}, want: []string{"green", "yellow"}},
+		{name: "no colors", teamColors: nil, want: backend.DefaultTeamColors},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			server := &fakeServer{teamColors: test.teamColors}
+			c := newTestClient(t, server, uuid.New())
+			c.Game.Mu.RLock()
+			defer c.Game.Mu.RUnlock()
+			if !reflect.DeepEqual(c.Game.TeamColors, test.want) {
+				t.Errorf("got team colors %v, want %v", c.Game.TeamColors, test.want)
+			}
+		})
+	}
+}
+
+func TestConnectPhase(t *testing.T) {
+	tests := []struct {
+		name  string
+		phase proto.GamePhase
+		want  backend.Phase
+	}{
+		{name: "lobby", phase: proto.GamePhase_LOBBY, want: backend.PhaseLobby},
+		{name: "playing", phase: proto.GamePhase_PLAYING, want: backend.PhasePlaying},
+		{name: "ended", phase: proto.GamePhase_ENDED, want: backend.PhaseEnded},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			server := &fakeServer{phase: test.phase}
+			c := newTestClient(t, server, uuid.New())
+			c.Game.Mu.RLock()
+			defer c.Game.Mu.RUnlock()
+			if c.Game.Phase != test.want {
+				t.Errorf("got phase %v, want %v", c.Game.Phase, test.want)
+			}
+		})
+	}
+}
+
+func TestReadyChangeRequest(t *testing.T) {
+	server := &fakeServer{}
+	c := newTestClient(t, server, uuid.New())
+	c.handleReadyChange()
+	requests := server.streams[0].requests()
+	if len(requests) != 1 || requests[0].GetReady() == nil {
+		t.Errorf("sent %v, want a ready request", requests)
+	}
+}
+
+func TestScoreResponse(t *testing.T) {
+	game := testutil.NewGame(testutil.WithPlayerAt("alice", 0, 0))
+	alice := testutil.Player(game, "alice")
+	game.Score[alice.ID()] = 5
+	c := NewGameClient(game, nil)
+	c.handleScoreResponse(&proto.Response{
+		Action: &proto.Response_Score{
+			Score: &proto.Score{
+				PlayerId: alice.ID().String(),
+				Score:    3,
+			},
+		},
+	})
+	if score := game.Score[alice.ID()]; score != 3 {
+		t.Errorf("player has score %d, want the server's", score)
+	}
+}
+
+func TestStatusResponse(t *testing.T) {
+	protectedUntil := time.Now().Add(time.Second).Round(0)
+	tests := []struct {
+		name   string
+		status *proto.Status
+	}{
+		{name: "frozen", status: &proto.Status{Frozen: true}},
+		{name: "unfrozen", status: &proto.Status{Frozen: false}},
+		{name: "blinking", status: &proto.Status{Protected: true, Blinking: true, ProtectedUntil: proto.GetProtoTime(protectedUntil)}},
+		{name: "not blinking", status: &proto.Status{Protected: true, Blinking: false}},
+		{name: "ready", status: &proto.Status{Ready: true}},
+		{name: "not ready", status: &proto.Status{Ready: false}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			game := testutil.NewGame(testutil.WithPlayerAt("alice", 0, 0))
+			alice := testutil.Player(game, "alice")
+			alice.Frozen = !test.status.Frozen
+			alice.Blinking = !test.status.Blinking
+			alice.Ready = !test.status.Ready
+			c := NewGameClient(game, nil)
+			test.status.PlayerId = alice.ID().String()
+			c.handleStatusResponse(&proto.Response{
+				Action: &proto.Response_Status{Status: test.status},
+			})
+			if alice.Frozen != test.status.Frozen {
+				t.Errorf("frozen is %v, want %v", alice.Frozen, test.status.Frozen)
+			}
+			if alice.Blinking != test.status.Blinking {
+				t.Errorf("blinking is %v, want %v", alice.Blinking, test.status.Blinking)
+			}
+			if alice.Ready != test.status.Ready {
+				t.Errorf("ready is %v, want %v", alice.Ready, test.status.Ready)
+			}
+			if want := proto.GetBackendTime(test.status.ProtectedUntil); !alice.ProtectedUntil.Equal(want) {
+				t.Errorf("protected until %v, want %v", alice.ProtectedUntil, want)
+			}
+		})
+	}
+}
+
+func TestRoundOverResponse(t *testing.T) {
+	aliceID := uuid.New()
+	summary := backend.Summary{
+		Standings:     []backend.LeaderboardEntry{{PlayerID: aliceID, Name: "alice", Score: 10}},
+		TopFragger:    backend.SummaryStat{PlayerID: aliceID, Name: "alice", Value: 10},
+		LongestStreak: backend.SummaryStat{PlayerID: aliceID, Name: "alice", Value: 4},
+		Duration:      90 * time.Second,
+	}
+	tests := []struct {
+		name    string
+		summary *proto.Summary
+		want    backend.Summary
+	}{
+		{name: "summary", summary: proto.GetProtoSummary(summary), want: summary},
+		{name: "older servers", summary: nil, want: backend.Summary{Duration: time.Minute}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			game := testutil.NewGame()
+			game.RoundSummary = backend.Summary{Duration: time.Minute}
+			c := NewGameClient(game, nil)
+			newRoundAt, err := ptypes.TimestampProto(time.Now().Add(time.Second))
+			if err != nil {
+				t.Fatal(err)
+			}
+			c.handleRoundOverResponse(&proto.Response{
+				Action: &proto.Response_RoundOver{
+					RoundOver: &proto.RoundOver{
+						RoundWinnerId: aliceID.String(),
+						NewRoundAt:    newRoundAt,
+						Summary:       test.summary,
+					},
+				},
+			})
+			if !game.WaitForRound || game.RoundWinner != aliceID {
+				t.Errorf("round winner is %s, want alice", game.RoundWinner)
+			}
+			if !reflect.DeepEqual(game.RoundSummary, test.want) {
+				t.Errorf("got summary %+v, want %+v", game.RoundSummary, test.want)
+			}
+		})
+	}
+}
+
+func TestWeaponChangeRequest(t *testing.T) {
+	server := &fakeServer{}
+	c := newTestClient(t, server, uuid.New())
+	c.handleWeaponChange()
+	requests := server.streams[0].requests()
+	if len(requests) != 1 || requests[0].GetSwitchWeapon() == nil {
+		t.Errorf("sent %v, want a weapon switch", requests)
+	}
+}
+
+func TestWeaponSwitchResponse(t *testing.T) {
+	tests := []struct {
+		name   string
+		active int32
+	}{
+		{name: "secondary weapon", active: 1},
+		{name: "primary weapon", active: 0},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			game := testutil.NewGame(testutil.WithPlayerAt("alice", 0, 0))
+			alice := testutil.Player(game, "alice")
+			alice.ActiveWeapon = 2
+			c := NewGameClient(game, nil)
+			c.handleWeaponSwitchResponse(&proto.Response{
+				Action: &proto.Response_WeaponSwitch{
+					WeaponSwitch: &proto.WeaponSwitch{
+						PlayerId:     alice.ID().String(),
+						ActiveWeapon: test.active,
+					},
+				},
+			})
+			if alice.ActiveWeapon != int(test.active) {
+				t.Errorf("active weapon is %d, want %d", alice.ActiveWeapon, test.active)
+			}
+		})
+	}
+}
+
+func TestDeployRequest(t *testing.T) {
+	server := &fakeServer{}
+	c := newTestClient(t, server, uuid.New())
+	mine := &backend.Mine{IdentifierBase: backend.IdentifierBase{UUID: uuid.New()}}
+	c.handleAddEntityChange(backend.AddEntityChange{Entity: mine})
+	requests := server.streams[0].requests()
+	if len(requests) != 1 || requests[0].GetDeploy() == nil || requests[0].GetDeploy().Id != mine.ID().String() {
+		t.Errorf("sent %v, want the mine to be deployed", requests)
+	}
+}
+
+func TestExplosionResponse(t *testing.T) {
+	game := testutil.NewGame()
+	c := NewGameClient(game, nil)
+	c.handleExplosionResponse(&proto.Response{
+		Action: &proto.Response_Explosion{
+			Explosion: &proto.Explosion{
+				Position: &proto.Coordinate{X: 2, Y: 3},
+				Radius:   1,
+			},
+		},
+	})
+	if len(game.RecentExplosions) != 1 {
+		t.Fatalf("got explosions %+v, want one", game.RecentExplosions)
+	}
+	explosion := game.RecentExplosions[0]
+	if explosion.Position != (backend.Coordinate{X: 2, Y: 3}) || explosion.Radius != 1 {
+		t.Errorf("got explosion %+v, want it at 2, 3 with radius 1", explosion)
+	}
+}
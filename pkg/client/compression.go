@@ -0,0 +1,13 @@
+package client
+
+import (
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/encoding/gzip"
+)
+
+// CompressionDialOption returns an option that gzip compresses all messages
+// sent to the server. Servers reply using the same compression, which reduces
+// bandwidth in busy games at the cost of some CPU.
+func CompressionDialOption() grpc.DialOption {
+	return grpc.WithDefaultCallOptions(grpc.UseCompressor(gzip.Name))
+}
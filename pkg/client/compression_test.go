@@ -0,0 +1,154 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net"
+	"sync"
+	"testing"
+
+	protobuf "github.com/golang/protobuf/proto"
+	"github.com/google/uuid"
+	"github.com/mortenson/grpc-game-example/pkg/backend"
+	"github.com/mortenson/grpc-game-example/pkg/backend/testutil"
+	"github.com/mortenson/grpc-game-example/pkg/server"
+	"github.com/mortenson/grpc-game-example/proto"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/encoding"
+	"google.golang.org/grpc/encoding/gzip"
+	"google.golang.org/grpc/stats"
+)
+
+// payloadRecorder records the size of payloads received by a client.
+type payloadRecorder struct {
+	mu       sync.Mutex
+	payloads []*stats.InPayload
+}
+
+func (recorder *payloadRecorder) TagRPC(ctx context.Context, info *stats.RPCTagInfo) context.Context {
+	return ctx
+}
+
+func (recorder *payloadRecorder) HandleRPC(ctx context.Context, s stats.RPCStats) {
+	if payload, ok := s.(*stats.InPayload); ok {
+		recorder.mu.Lock()
+		defer recorder.mu.Unlock()
+		recorder.payloads = append(recorder.payloads, payload)
+	}
+}
+
+func (recorder *payloadRecorder) TagConn(ctx context.Context, info *stats.ConnTagInfo) context.Context {
+	return ctx
+}
+
+func (recorder *payloadRecorder) HandleConn(ctx context.Context, s stats.ConnStats) {}
+
+// startServer starts a game server without TLS, returning its address.
+func startServer(t *testing.T, game *backend.Game) string {
+	t.Helper()
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	grpcServer := grpc.NewServer()
+	proto.RegisterGameServer(grpcServer, server.NewGameServer(game, "", 0))
+	go grpcServer.Serve(listener)
+	t.Cleanup(grpcServer.Stop)
+	return listener.Addr().String()
+}
+
+// busyGame returns a game with many players.
+func busyGame(players int) *backend.Game {
+	options := make([]testutil.Option, 0, players)
+	for i := 0; i < players; i++ {
+		options = append(options, testutil.WithPlayerAt(fmt.Sprintf("player%d", i), i%10-5, i/10-5))
+	}
+	return testutil.NewGame(options...)
+}
+
+func TestCompressionDialOption(t *testing.T) {
+	tests := []struct {
+		name     string
+		compress bool
+	}{
+		{name: "uncompressed", compress: false},
+		{name: "compressed", compress: true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			game := busyGame(50)
+			address := startServer(t, game)
+			recorder := &payloadRecorder{}
+			options := []grpc.DialOption{grpc.WithInsecure(), grpc.WithStatsHandler(recorder)}
+			if test.compress {
+				options = append(options, CompressionDialOption())
+			}
+			conn, err := grpc.Dial(address, options...)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer conn.Close()
+			resp, err := proto.NewGameClient(conn).Connect(context.Background(), &proto.ConnectRequest{
+				Id:   uuid.New().String(),
+				Name: "alice",
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+			// The response should be the same either way.
+			game.Mu.RLock()
+			want := len(game.Entities)
+			game.Mu.RUnlock()
+			if len(resp.Entities) != want {
+				t.Fatalf("got %d entities, want %d", len(resp.Entities), want)
+			}
+			for _, entity := range resp.Entities {
+				player := entity.GetPlayer()
+				if player == nil || game.GetEntity(uuid.MustParse(player.Id)) == nil {
+					t.Errorf("got unknown entity %+v", entity)
+				}
+			}
+			recorder.mu.Lock()
+			defer recorder.mu.Unlock()
+			if len(recorder.payloads) != 1 {
+				t.Fatalf("got %d payloads, want 1", len(recorder.payloads))
+			}
+			payload := recorder.payloads[0]
+			if compressed := payload.WireLength < payload.Length; compressed != test.compress {
+				t.Errorf("sent %d bytes for a %d byte response, want compressed %v", payload.WireLength, payload.Length, test.compress)
+			}
+		})
+	}
+}
+
+func BenchmarkCompression(b *testing.B) {
+	game := busyGame(100)
+	entities := make([]*proto.Entity, 0)
+	for _, entity := range game.Entities {
+		entities = append(entities, proto.GetProtoEntity(entity))
+	}
+	message, err := protobuf.Marshal(&proto.Response{
+		Action: &proto.Response_StateSync{StateSync: &proto.StateSync{Entities: entities}},
+	})
+	if err != nil {
+		b.Fatal(err)
+	}
+	b.Run("uncompressed", func(b *testing.B) {
+		b.ReportMetric(float64(len(message)), "wirebytes/op")
+	})
+	b.Run("gzip", func(b *testing.B) {
+		compressor := encoding.GetCompressor(gzip.Name)
+		var buffer bytes.Buffer
+		for i := 0; i < b.N; i++ {
+			buffer.Reset()
+			writer, err := compressor.Compress(&buffer)
+			if err != nil {
+				b.Fatal(err)
+			}
+			writer.Write(message)
+			writer.Close()
+		}
+		b.ReportMetric(float64(buffer.Len()), "wirebytes/op")
+	})
+}
@@ -0,0 +1,102 @@
+package client
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mortenson/grpc-game-example/pkg/backend"
+	"github.com/mortenson/grpc-game-example/proto"
+)
+
+// deltaBase is the last position of an entity sent by the server, which move
+// deltas are applied to. This differs from the local position of the current
+// player, which is predicted.
+type deltaBase struct {
+	position backend.Coordinate
+	sequence uint32
+	// When a new base position is set, the next delta sequence is unknown.
+	hasSequence bool
+}
+
+// setDeltaBase records a position sent by the server. The game should be
+// locked by the caller.
+func (c *GameClient) setDeltaBase(entity backend.Identifier) {
+	positioner, ok := entity.(backend.Positioner)
+	if !ok {
+		return
+	}
+	c.deltaBases[entity.ID()] = deltaBase{
+		position: positioner.Position(),
+	}
+}
+
+// applyMoveDelta applies a move delta to an entity's base position, returning
+// the new position. A delta may combine several moves, which take up that many
+// sequence numbers. False is returned if the entity is unknown or a move was
+// missed, in which case state needs to be synced again. The game should be
+// locked by the caller.
+func (c *GameClient) applyMoveDelta(id uuid.UUID, delta backend.Coordinate, sequence uint32, moves uint32) (backend.Coordinate, bool) {
+	// Older servers don't say how many moves were combined.
+	if moves == 0 {
+		moves = 1
+	}
+	base, ok := c.deltaBases[id]
+	if !ok || (base.hasSequence && sequence != base.sequence+moves) {
+		return backend.Coordinate{}, false
+	}
+	base.position = base.position.Add(delta)
+	base.sequence = sequence
+	base.hasSequence = true
+	c.deltaBases[id] = base
+	return base.position, true
+}
+
+// requestStateSync asks the server for the full game state, unless a request
+// is already pending. The game should be locked by the caller.
+func (c *GameClient) requestStateSync() {
+	if c.stateSyncPending {
+		return
+	}
+	c.stateSyncPending = true
+	req := proto.Request{
+		Action: &proto.Request_StateSyncRequest{
+			StateSyncRequest: &proto.StateSyncRequest{},
+		},
+	}
+	c.send(&req)
+}
+
+func (c *GameClient) handleMoveDeltaResponse(resp *proto.Response) {
+	delta := resp.GetMoveDelta()
+	id, err := uuid.Parse(delta.Id)
+	if err != nil {
+		c.Exit(fmt.Sprintf("error when parsing UUID: %v", err))
+		return
+	}
+	if c.stateSyncPending {
+		return
+	}
+	position, ok := c.applyMoveDelta(id, backend.Coordinate{X: int(delta.Dx), Y: int(delta.Dy)}, delta.Sequence, delta.Moves)
+	if !ok {
+		c.requestStateSync()
+		return
+	}
+	entity := c.Game.GetEntity(id)
+	if _, ok := entity.(backend.Mover); !ok {
+		return
+	}
+	// As with entity updates, our own moves are only applied when a
+	// prediction was wrong. Teleports weren't predicted, so they replace any
+	// pending predictions.
+	if id == c.CurrentPlayer {
+		if delta.Teleport {
+			c.clearPredictions()
+		} else if c.reconcile(delta.ClientSequence, position) {
+			return
+		}
+	} else {
+		c.interpolation.Add(id, position, time.Now())
+	}
+	c.Game.MoveEntity(entity, position)
+}
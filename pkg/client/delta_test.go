@@ -0,0 +1,175 @@
+package client
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/mortenson/grpc-game-example/pkg/backend"
+	"github.com/mortenson/grpc-game-example/proto"
+)
+
+// combinedMoveDelta returns a move delta that combines several moves.
+func combinedMoveDelta(id uuid.UUID, dx int, sequence uint32, moves uint32) *proto.Response {
+	resp := moveDelta(id, dx, 0, sequence, 0)
+	resp.GetMoveDelta().Moves = moves
+	return resp
+}
+
+// countStateSyncRequests returns how many state syncs were requested.
+func countStateSyncRequests(stream *fakeStream) int {
+	count := 0
+	for _, req := range stream.requests() {
+		if req.GetStateSyncRequest() != nil {
+			count++
+		}
+	}
+	return count
+}
+
+func TestMoveDeltas(t *testing.T) {
+	bobID := uuid.New()
+	tests := []struct {
+		name     string
+		deltas   []*proto.Response
+		position backend.Coordinate
+		syncs    int
+	}{
+		{
+			name:     "first delta",
+			deltas:   []*proto.Response{moveDelta(bobID, 1, 0, 5, 0)},
+			position: backend.Coordinate{X: 4},
+		},
+		{
+			name: "consecutive deltas",
+			deltas: []*proto.Response{
+				moveDelta(bobID, 1, 0, 5, 0),
+				moveDelta(bobID, 0, -1, 6, 0),
+			},
+			position: backend.Coordinate{X: 4, Y: -1},
+		},
+		{
+			name: "combined moves",
+			deltas: []*proto.Response{
+				moveDelta(bobID, 1, 0, 5, 0),
+				combinedMoveDelta(bobID, 3, 8, 3),
+			},
+			position: backend.Coordinate{X: 7},
+		},
+		{
+			name: "deltas without a move count are one move",
+			deltas: []*proto.Response{
+				moveDelta(bobID, 1, 0, 5, 0),
+				combinedMoveDelta(bobID, 1, 6, 0),
+			},
+			position: backend.Coordinate{X: 5},
+		},
+		{
+			name: "gap",
+			deltas: []*proto.Response{
+				moveDelta(bobID, 1, 0, 5, 0),
+				moveDelta(bobID, 1, 0, 7, 0),
+			},
+			position: backend.Coordinate{X: 4},
+			syncs:    1,
+		},
+		{
+			name: "fewer moves than combined",
+			deltas: []*proto.Response{
+				moveDelta(bobID, 1, 0, 5, 0),
+				combinedMoveDelta(bobID, 2, 7, 1),
+			},
+			position: backend.Coordinate{X: 4},
+			syncs:    1,
+		},
+		{
+			name: "out of order",
+			deltas: []*proto.Response{
+				moveDelta(bobID, 1, 0, 6, 0),
+				moveDelta(bobID, 1, 0, 5, 0),
+			},
+			position: backend.Coordinate{X: 4},
+			syncs:    1,
+		},
+		{
+			name: "deltas are ignored until synced",
+			deltas: []*proto.Response{
+				moveDelta(bobID, 1, 0, 5, 0),
+				moveDelta(bobID, 1, 0, 7, 0),
+				moveDelta(bobID, 1, 0, 8, 0),
+				moveDelta(bobID, 1, 0, 10, 0),
+			},
+			position: backend.Coordinate{X: 4},
+			syncs:    1,
+		},
+		{
+			name:     "unknown entity",
+			deltas:   []*proto.Response{moveDelta(uuid.New(), 1, 0, 1, 0)},
+			position: backend.Coordinate{X: 3},
+			syncs:    1,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			playerID := uuid.New()
+			server := &fakeServer{
+				entities: []*proto.Entity{
+					proto.GetProtoEntity(&backend.Player{
+						IdentifierBase: backend.IdentifierBase{UUID: playerID},
+						Name:           "alice",
+					}),
+					proto.GetProtoEntity(&backend.Player{
+						IdentifierBase:  backend.IdentifierBase{UUID: bobID},
+						Name:            "bob",
+						CurrentPosition: backend.Coordinate{X: 3},
+					}),
+				},
+			}
+			c := newTestClient(t, server, playerID)
+			for _, delta := range test.deltas {
+				c.handleMoveDeltaResponse(delta)
+			}
+			bob := c.Game.GetEntity(bobID).(*backend.Player)
+			if bob.Position() != test.position {
+				t.Errorf("bob is at %+v, want %+v", bob.Position(), test.position)
+			}
+			if syncs := countStateSyncRequests(server.streams[0]); syncs != test.syncs {
+				t.Errorf("requested %d state syncs, want %d", syncs, test.syncs)
+			}
+		})
+	}
+}
+
+func TestMoveDeltasAfterStateSync(t *testing.T) {
+	playerID := uuid.New()
+	bob := &backend.Player{
+		IdentifierBase:  backend.IdentifierBase{UUID: uuid.New()},
+		Name:            "bob",
+		CurrentPosition: backend.Coordinate{X: 3},
+	}
+	server := &fakeServer{
+		entities: []*proto.Entity{proto.GetProtoEntity(bob)},
+	}
+	c := newTestClient(t, server, playerID)
+	c.handleMoveDeltaResponse(moveDelta(bob.ID(), 1, 0, 5, 0))
+	c.handleMoveDeltaResponse(moveDelta(bob.ID(), 1, 0, 9, 0))
+	// The server sends where bob is after the missed moves.
+	synced := *bob
+	synced.CurrentPosition = backend.Coordinate{X: 10}
+	c.handleStateSyncResponse(&proto.Response{
+		Action: &proto.Response_StateSync{
+			StateSync: &proto.StateSync{
+				Entities: []*proto.Entity{proto.GetProtoEntity(&synced)},
+			},
+		},
+	})
+	// Any sequence follows a sync, but the next must follow on from it.
+	c.handleMoveDeltaResponse(moveDelta(bob.ID(), 1, 0, 20, 0))
+	c.handleMoveDeltaResponse(moveDelta(bob.ID(), 1, 0, 21, 0))
+	if position := c.Game.GetEntity(bob.ID()).(*backend.Player).Position(); position != (backend.Coordinate{X: 12}) {
+		t.Errorf("bob is at %+v, want %+v", position, backend.Coordinate{X: 12})
+	}
+	c.handleMoveDeltaResponse(moveDelta(bob.ID(), 1, 0, 23, 0))
+	if syncs := countStateSyncRequests(server.streams[0]); syncs != 2 {
+		t.Errorf("requested %d state syncs, want 2", syncs)
+	}
+}
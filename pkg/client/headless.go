@@ -0,0 +1,62 @@
+package client
+
+import (
+	"math/rand"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mortenson/grpc-game-example/pkg/backend"
+)
+
+// ActionGenerator decides what a headless client should do next. Returning nil
+// skips a turn.
+type ActionGenerator func(game *backend.Game, playerID uuid.UUID) backend.Action
+
+// NewHeadlessGameClient constructs a game client with no frontend, which is
+// useful for load testing servers.
+func NewHeadlessGameClient(game *backend.Game) *GameClient {
+	return NewGameClient(game, nil)
+}
+
+// RandomActions is an ActionGenerator that moves or fires in a random
+// direction.
+func RandomActions(game *backend.Game, playerID uuid.UUID) backend.Action {
+	directions := []backend.Direction{
+		backend.DirectionUp,
+		backend.DirectionDown,
+		backend.DirectionLeft,
+		backend.DirectionRight,
+	}
+	direction := directions[rand.Intn(len(directions))]
+	if rand.Intn(2) == 0 {
+		return backend.FireAction{
+			PlayerID:  playerID,
+			Direction: direction,
+		}
+	}
+	return backend.MoveAction{
+		ID:        playerID,
+		Direction: direction,
+		Created:   time.Now(),
+	}
+}
+
+// RunActions sends actions from a generator to the local game at the given
+// interval, until the client exits or disconnects. The client's change handling
+// sends the resulting moves and lasers to the server.
+func (c *GameClient) RunActions(generator ActionGenerator, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-c.stop:
+			return
+		case <-ticker.C:
+		}
+		action := generator(c.Game, c.CurrentPlayer)
+		if action == nil {
+			continue
+		}
+		c.Game.SubmitActions([]backend.Action{action})
+	}
+}
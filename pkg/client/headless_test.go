@@ -0,0 +1,85 @@
+package client
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mortenson/grpc-game-example/pkg/backend"
+	"github.com/mortenson/grpc-game-example/pkg/backend/testutil"
+	"github.com/mortenson/grpc-game-example/proto"
+)
+
+// waitForRequest waits for a request matching a predicate to be sent to a
+// stream, returning false if none was sent in time.
+func waitForRequest(stream *fakeStream, match func(req *proto.Request) bool) bool {
+	deadline := time.Now().Add(2 * time.Second)
+	for time.Now().Before(deadline) {
+		for _, req := range stream.requests() {
+			if match(req) {
+				return true
+			}
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	return false
+}
+
+func TestHeadlessClientSendsActions(t *testing.T) {
+	tests := []struct {
+		name      string
+		generator ActionGenerator
+		match     func(req *proto.Request) bool
+	}{
+		{
+			name: "moves",
+			generator: func(game *backend.Game, playerID uuid.UUID) backend.Action {
+				return backend.MoveAction{
+					ID:        playerID,
+					Direction: backend.DirectionRight,
+					Created:   time.Now(),
+				}
+			},
+			match: func(req *proto.Request) bool {
+				return req.GetMove() != nil && req.GetMove().Direction == proto.Direction_RIGHT
+			},
+		},
+		{
+			name: "fires",
+			generator: func(game *backend.Game, playerID uuid.UUID) backend.Action {
+				return backend.FireAction{
+					PlayerID:  playerID,
+					Direction: backend.DirectionDown,
+				}
+			},
+			match: func(req *proto.Request) bool {
+				return req.GetLaser() != nil && req.GetLaser().Direction == proto.Direction_DOWN
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			playerID := uuid.New()
+			server := &fakeServer{
+				entities: []*proto.Entity{proto.GetProtoEntity(&backend.Player{
+					IdentifierBase: backend.IdentifierBase{UUID: playerID},
+					Name:           "bot",
+				})},
+			}
+			game := testutil.NewGame()
+			game.IsAuthoritative = false
+			game.ResolveCollisions = false
+			c := NewHeadlessGameClient(game)
+			if err := c.Connect(server, playerID, "bot", "", ""); err != nil {
+				t.Fatalf("can not connect: %v", err)
+			}
+			game.Start()
+			c.Start()
+			go c.RunActions(test.generator, 10*time.Millisecond)
+			defer c.Exit("test over")
+			if !waitForRequest(server.streams[0], test.match) {
+				t.Error("the action was not sent to the server")
+			}
+		})
+	}
+}
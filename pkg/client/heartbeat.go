@@ -0,0 +1,39 @@
+package client
+
+import (
+	"time"
+
+	"github.com/mortenson/grpc-game-example/proto"
+)
+
+const (
+	defaultHeartbeatInterval = 5 * time.Second
+	// If this many heartbeats in a row aren't echoed by the server, the stream
+	// is treated as disconnected.
+	missedHeartbeats = 3
+)
+
+// sendHeartbeat sends a heartbeat, which the server echoes back. Heartbeats
+// also stop idle streams from being closed by proxies.
+func (c *GameClient) sendHeartbeat() {
+	req := proto.Request{
+		Action: &proto.Request_Heartbeat{
+			Heartbeat: &proto.Heartbeat{},
+		},
+	}
+	c.send(&req)
+}
+
+// heartbeatReceived records that the stream is alive.
+func (c *GameClient) heartbeatReceived() {
+	c.heartbeatMu.Lock()
+	defer c.heartbeatMu.Unlock()
+	c.lastHeartbeat = time.Now()
+}
+
+// heartbeatMissed checks if the server has stopped echoing heartbeats.
+func (c *GameClient) heartbeatMissed() bool {
+	c.heartbeatMu.Lock()
+	defer c.heartbeatMu.Unlock()
+	return time.Now().Sub(c.lastHeartbeat) > missedHeartbeats*c.HeartbeatInterval
+}
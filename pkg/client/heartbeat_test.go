@@ -0,0 +1,127 @@
+package client
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mortenson/grpc-game-example/pkg/backend"
+	"github.com/mortenson/grpc-game-example/pkg/backend/testutil"
+	"github.com/mortenson/grpc-game-example/proto"
+)
+
+// countHeartbeats returns how many heartbeats were sent to a stream.
+func countHeartbeats(stream *fakeStream) int {
+	count := 0
+	for _, req := range stream.requests() {
+		if req.GetHeartbeat() != nil {
+			count++
+		}
+	}
+	return count
+}
+
+// echoHeartbeats responds to every heartbeat sent to a stream, like a server
+// would, until stop is closed.
+func echoHeartbeats(stream *fakeStream, stop chan struct{}) {
+	echoed := 0
+	ticker := time.NewTicker(5 * time.Millisecond)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+		case <-stop:
+			return
+		}
+		for sent := countHeartbeats(stream); echoed < sent; echoed++ {
+			stream.responses <- &proto.Response{
+				Action: &proto.Response_Heartbeat{Heartbeat: &proto.Heartbeat{}},
+			}
+		}
+	}
+}
+
+func TestHeartbeats(t *testing.T) {
+	interval := 20 * time.Millisecond
+	tests := []struct {
+		name       string
+		echo       bool
+		disconnect bool
+	}{
+		{name: "server echoes heartbeats", echo: true, disconnect: false},
+		{name: "server stops responding", echo: false, disconnect: true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			playerID := uuid.New()
+			server := &fakeServer{
+				entities: []*proto.Entity{proto.GetProtoEntity(&backend.Player{
+					IdentifierBase: backend.IdentifierBase{UUID: playerID},
+					Name:           "alice",
+				})},
+			}
+			game := testutil.NewGame()
+			game.IsAuthoritative = false
+			c := NewHeadlessGameClient(game)
+			c.HeartbeatInterval = interval
+			disconnected := make(chan error, 1)
+			c.OnDisconnect = func(err error) {
+				select {
+				case disconnected <- err:
+				default:
+				}
+			}
+			if err := c.Connect(server, playerID, "alice", "", ""); err != nil {
+				t.Fatalf("can not connect: %v", err)
+			}
+			stop := make(chan struct{})
+			defer close(stop)
+			if test.echo {
+				go echoHeartbeats(server.streams[0], stop)
+			}
+			game.Start()
+			c.Start()
+			defer c.Exit("test over")
+
+			select {
+			case err := <-disconnected:
+				if !test.disconnect {
+					t.Errorf("client disconnected with %v", err)
+				}
+			case <-time.After(20 * interval):
+				if test.disconnect {
+					t.Error("client did not disconnect")
+				}
+			}
+			// Heartbeats are sent every interval until they are missed.
+			sent := countHeartbeats(server.streams[0])
+			if test.disconnect && sent > missedHeartbeats+1 {
+				t.Errorf("sent %d heartbeats before disconnecting, want at most %d", sent, missedHeartbeats+1)
+			}
+			if !test.disconnect && (sent < 5 || sent > 20) {
+				t.Errorf("sent %d heartbeats in %v, want about 20", sent, 20*interval)
+			}
+		})
+	}
+}
+
+func TestHeartbeatsStop(t *testing.T) {
+	interval := 10 * time.Millisecond
+	c := newTestClient(t, &fakeServer{}, uuid.New())
+	c.HeartbeatInterval = interval
+	c.Start()
+	c.Exit("test over")
+	time.Sleep(5 * interval)
+	if sent := countHeartbeats(c.Stream.(*fakeStream)); sent != 0 {
+		t.Errorf("sent %d heartbeats after exiting, want 0", sent)
+	}
+	// Heartbeats don't take the error from anyone waiting on the client.
+	select {
+	case err := <-c.Done:
+		if err == nil || err.Error() != "test over" {
+			t.Errorf("client is done with %v, want the exit message", err)
+		}
+	default:
+		t.Error("client is not done")
+	}
+}
@@ -0,0 +1,92 @@
+package client
+
+import (
+	"math"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mortenson/grpc-game-example/pkg/backend"
+)
+
+const (
+	interpolationBufferSize = 4
+	interpolationDelay      = 100 * time.Millisecond
+)
+
+// positionUpdate is an entity position received at a point in time.
+type positionUpdate struct {
+	position backend.Coordinate
+	received time.Time
+}
+
+// InterpolationBuffer stores the last few position updates for each entity,
+// so that remote entities can be rendered smoothly slightly behind real time
+// instead of jumping whenever an update arrives.
+type InterpolationBuffer struct {
+	updates map[uuid.UUID][]positionUpdate
+	mu      sync.RWMutex
+}
+
+// NewInterpolationBuffer constructs a new interpolation buffer.
+func NewInterpolationBuffer() *InterpolationBuffer {
+	return &InterpolationBuffer{
+		updates: make(map[uuid.UUID][]positionUpdate),
+	}
+}
+
+// Add stores a position update for an entity.
+func (buffer *InterpolationBuffer) Add(id uuid.UUID, position backend.Coordinate, received time.Time) {
+	buffer.mu.Lock()
+	defer buffer.mu.Unlock()
+	updates := append(buffer.updates[id], positionUpdate{
+		position: position,
+		received: received,
+	})
+	if len(updates) > interpolationBufferSize {
+		updates = updates[len(updates)-interpolationBufferSize:]
+	}
+	buffer.updates[id] = updates
+}
+
+// Remove forgets all position updates for an entity. This should be called
+// when an entity is removed or teleports, i.e. when respawning.
+func (buffer *InterpolationBuffer) Remove(id uuid.UUID) {
+	buffer.mu.Lock()
+	defer buffer.mu.Unlock()
+	delete(buffer.updates, id)
+}
+
+// Position returns the interpolated position of an entity for a given render
+// time. False is returned if no updates have been stored for the entity.
+func (buffer *InterpolationBuffer) Position(id uuid.UUID, renderTime time.Time) (backend.Coordinate, bool) {
+	buffer.mu.RLock()
+	defer buffer.mu.RUnlock()
+	updates, ok := buffer.updates[id]
+	if !ok || len(updates) == 0 {
+		return backend.Coordinate{}, false
+	}
+	// Render slightly in the past so there is usually an update on either side
+	// of the render time.
+	renderTime = renderTime.Add(-interpolationDelay)
+	if !renderTime.After(updates[0].received) {
+		return updates[0].position, true
+	}
+	for i := 1; i < len(updates); i++ {
+		from := updates[i-1]
+		to := updates[i]
+		if renderTime.After(to.received) {
+			continue
+		}
+		total := to.received.Sub(from.received)
+		if total <= 0 {
+			return to.position, true
+		}
+		progress := float64(renderTime.Sub(from.received)) / float64(total)
+		return backend.Coordinate{
+			X: from.position.X + int(math.Round(float64(to.position.X-from.position.X)*progress)),
+			Y: from.position.Y + int(math.Round(float64(to.position.Y-from.position.Y)*progress)),
+		}, true
+	}
+	return updates[len(updates)-1].position, true
+}
@@ -0,0 +1,83 @@
+package client
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mortenson/grpc-game-example/pkg/backend"
+)
+
+func TestInterpolationBufferPosition(t *testing.T) {
+	start := time.Now()
+	id := uuid.New()
+	// The entity moves ten cells right every 100ms.
+	moves := []positionUpdate{
+		{position: backend.Coordinate{X: 0}, received: start},
+		{position: backend.Coordinate{X: 10}, received: start.Add(100 * time.Millisecond)},
+		{position: backend.Coordinate{X: 20}, received: start.Add(200 * time.Millisecond)},
+	}
+	tests := []struct {
+		name   string
+		render time.Duration
+		want   backend.Coordinate
+	}{
+		{name: "before the first update", render: 0, want: backend.Coordinate{X: 0}},
+		{name: "at the first update", render: interpolationDelay, want: backend.Coordinate{X: 0}},
+		{name: "between updates", render: interpolationDelay + 50*time.Millisecond, want: backend.Coordinate{X: 5}},
+		{name: "between later updates", render: interpolationDelay + 130*time.Millisecond, want: backend.Coordinate{X: 13}},
+		{name: "at the last update", render: interpolationDelay + 200*time.Millisecond, want: backend.Coordinate{X: 20}},
+		{name: "after the last update", render: time.Second, want: backend.Coordinate{X: 20}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			buffer := NewInterpolationBuffer()
+			for _, move := range moves {
+				buffer.Add(id, move.position, move.received)
+			}
+			position, ok := buffer.Position(id, start.Add(test.render))
+			if !ok {
+				t.Fatal("no position was returned")
+			}
+			if position != test.want {
+				t.Errorf("got %+v, want %+v", position, test.want)
+			}
+		})
+	}
+}
+
+func TestInterpolationBufferLimits(t *testing.T) {
+	start := time.Now()
+	id := uuid.New()
+	tests := []struct {
+		name    string
+		updates int
+		remove  bool
+		ok      bool
+		want    backend.Coordinate
+	}{
+		{name: "unknown entity", updates: 0, ok: false},
+		{name: "removed entity", updates: 2, remove: true, ok: false},
+		// Only the last few updates are kept, so rendering far in the past
+		// returns the oldest kept update rather than the first one.
+		{name: "old updates are dropped", updates: interpolationBufferSize + 2, ok: true, want: backend.Coordinate{Y: 2}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			buffer := NewInterpolationBuffer()
+			for i := 0; i < test.updates; i++ {
+				buffer.Add(id, backend.Coordinate{Y: i}, start.Add(time.Duration(i)*time.Second))
+			}
+			if test.remove {
+				buffer.Remove(id)
+			}
+			position, ok := buffer.Position(id, start)
+			if ok != test.ok {
+				t.Fatalf("got ok %v, want %v", ok, test.ok)
+			}
+			if ok && position != test.want {
+				t.Errorf("got %+v, want %+v", position, test.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,43 @@
+package client
+
+import (
+	"sync"
+	"time"
+)
+
+const (
+	latencySamples = 10
+	pingFrequency  = 1 * time.Second
+)
+
+// LatencyTracker keeps a rolling average of round trip times to the server.
+type LatencyTracker struct {
+	samples []time.Duration
+	mu      sync.RWMutex
+}
+
+// Add stores a round trip time, forgetting the oldest once there are more
+// than latencySamples.
+func (tracker *LatencyTracker) Add(sample time.Duration) {
+	tracker.mu.Lock()
+	defer tracker.mu.Unlock()
+	tracker.samples = append(tracker.samples, sample)
+	if len(tracker.samples) > latencySamples {
+		tracker.samples = tracker.samples[len(tracker.samples)-latencySamples:]
+	}
+}
+
+// Latency returns the average round trip time. False is returned if no round
+// trips have been measured yet.
+func (tracker *LatencyTracker) Latency() (time.Duration, bool) {
+	tracker.mu.RLock()
+	defer tracker.mu.RUnlock()
+	if len(tracker.samples) == 0 {
+		return 0, false
+	}
+	var total time.Duration
+	for _, sample := range tracker.samples {
+		total += sample
+	}
+	return total / time.Duration(len(tracker.samples)), true
+}
@@ -0,0 +1,61 @@
+package client
+
+import (
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+	"github.com/mortenson/grpc-game-example/pkg/backend/testutil"
+	"github.com/mortenson/grpc-game-example/proto"
+)
+
+func TestLatencyTracker(t *testing.T) {
+	ms := time.Millisecond
+	tests := []struct {
+		name    string
+		samples []time.Duration
+		latency time.Duration
+		ok      bool
+	}{
+		{name: "no samples", samples: nil, latency: 0, ok: false},
+		{name: "one sample", samples: []time.Duration{40 * ms}, latency: 40 * ms, ok: true},
+		{name: "average", samples: []time.Duration{10 * ms, 20 * ms, 60 * ms}, latency: 30 * ms, ok: true},
+		{
+			name: "oldest samples are forgotten",
+			samples: []time.Duration{
+				1000 * ms, 1000 * ms,
+				10 * ms, 10 * ms, 10 * ms, 10 * ms, 10 * ms,
+				20 * ms, 20 * ms, 20 * ms, 20 * ms, 20 * ms,
+			},
+			latency: 15 * ms,
+			ok:      true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			tracker := &LatencyTracker{}
+			for _, sample := range test.samples {
+				tracker.Add(sample)
+			}
+			latency, ok := tracker.Latency()
+			if latency != test.latency || ok != test.ok {
+				t.Errorf("got %v, %v, want %v, %v", latency, ok, test.latency, test.ok)
+			}
+		})
+	}
+}
+
+func TestPongResponse(t *testing.T) {
+	c := NewGameClient(testutil.NewGame(), nil)
+	sent, err := ptypes.TimestampProto(time.Now().Add(-50 * time.Millisecond))
+	if err != nil {
+		t.Fatal(err)
+	}
+	c.handlePongResponse(&proto.Response{
+		Action: &proto.Response_Pong{Pong: &proto.Pong{Sent: sent}},
+	})
+	latency, ok := c.latency.Latency()
+	if !ok || latency < 50*time.Millisecond || latency > time.Second {
+		t.Errorf("got latency %v, %v, want about 50ms", latency, ok)
+	}
+}
@@ -0,0 +1,122 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"io"
+	"sync"
+
+	"github.com/mortenson/grpc-game-example/proto"
+	"google.golang.org/grpc"
+)
+
+// fakeStream is a stream that records the requests sent to it and returns the
+// responses sent to its responses channel, until it is closed or its context
+// is cancelled.
+type fakeStream struct {
+	grpc.ClientStream
+	ctx       context.Context
+	mu        sync.Mutex
+	sent      []*proto.Request
+	responses chan *proto.Response
+	closed    bool
+}
+
+func (stream *fakeStream) Send(req *proto.Request) error {
+	stream.mu.Lock()
+	defer stream.mu.Unlock()
+	stream.sent = append(stream.sent, req)
+	return nil
+}
+
+func (stream *fakeStream) Recv() (*proto.Response, error) {
+	select {
+	case resp, ok := <-stream.responses:
+		if !ok {
+			return nil, io.EOF
+		}
+		return resp, nil
+	case <-stream.ctx.Done():
+		return nil, stream.ctx.Err()
+	}
+}
+
+func (stream *fakeStream) CloseSend() error {
+	stream.mu.Lock()
+	defer stream.mu.Unlock()
+	stream.closed = true
+	return nil
+}
+
+// requests returns the requests sent to the stream.
+func (stream *fakeStream) requests() []*proto.Request {
+	stream.mu.Lock()
+	defer stream.mu.Unlock()
+	return append([]*proto.Request{}, stream.sent...)
+}
+
+// fakeServer is a game server that fails the first few connect requests, and
+// then accepts them with the given entities.
+type fakeServer struct {
+	mu       sync.Mutex
+	failures int
+	entities []*proto.Entity
+	connects []*proto.ConnectRequest
+	streams  []*fakeStream
+	// leaderboard is returned by GetLeaderboard.
+	leaderboard []*proto.LeaderboardEntry
+	// defaultDirection, modifier, phase, teamColors, speedMultiplier, and
+	// moveThrottleMillis are returned when connecting.
+	defaultDirection   proto.Direction
+	modifier           string
+	phase              proto.GamePhase
+	teamColors         []string
+	speedMultiplier    float64
+	moveThrottleMillis uint32
+}
+
+func (server *fakeServer) Connect(ctx context.Context, in *proto.ConnectRequest, opts ...grpc.CallOption) (*proto.ConnectResponse, error) {
+	server.mu.Lock()
+	defer server.mu.Unlock()
+	server.connects = append(server.connects, in)
+	if server.failures > 0 {
+		server.failures--
+		return nil, errors.New("server unavailable")
+	}
+	return &proto.ConnectResponse{
+		Token:              "token",
+		ReconnectToken:     "reconnect",
+		Entities:           server.entities,
+		DefaultDirection:   server.defaultDirection,
+		Modifier:           server.modifier,
+		Phase:              server.phase,
+		TeamColors:         server.teamColors,
+		SpeedMultiplier:    server.speedMultiplier,
+		MoveThrottleMillis: server.moveThrottleMillis,
+	}, nil
+}
+
+func (server *fakeServer) Stream(ctx context.Context, opts ...grpc.CallOption) (proto.Game_StreamClient, error) {
+	server.mu.Lock()
+	defer server.mu.Unlock()
+	stream := &fakeStream{
+		ctx:       ctx,
+		responses: make(chan *proto.Response, 16),
+	}
+	server.streams = append(server.streams, stream)
+	return stream, nil
+}
+
+func (server *fakeServer) GetLeaderboard(ctx context.Context, in *proto.LeaderboardRequest, opts ...grpc.CallOption) (*proto.LeaderboardResponse, error) {
+	server.mu.Lock()
+	defer server.mu.Unlock()
+	return &proto.LeaderboardResponse{Entries: server.leaderboard}, nil
+}
+
+func (server *fakeServer) ListRooms(ctx context.Context, in *proto.ListRoomsRequest, opts ...grpc.CallOption) (*proto.ListRoomsResponse, error) {
+	return &proto.ListRoomsResponse{}, nil
+}
+
+func (server *fakeServer) JoinRoom(ctx context.Context, in *proto.JoinRoomRequest, opts ...grpc.CallOption) (*proto.ConnectResponse, error) {
+	return server.Connect(ctx, in.Connect, opts...)
+}
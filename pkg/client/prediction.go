@@ -0,0 +1,70 @@
+package client
+
+import (
+	"github.com/mortenson/grpc-game-example/pkg/backend"
+)
+
+const (
+	predictedMoveLimit = 32
+)
+
+// predictedMove is a move that was applied to the local game before being
+// confirmed by the server.
+type predictedMove struct {
+	sequence uint32
+	position backend.Coordinate
+}
+
+// predictMove records a move that was applied locally, returning the sequence
+// number that should be sent to the server with it.
+func (c *GameClient) predictMove(position backend.Coordinate) uint32 {
+	c.predictionMu.Lock()
+	defer c.predictionMu.Unlock()
+	c.sequence++
+	c.predictedMoves = append(c.predictedMoves, predictedMove{
+		sequence: c.sequence,
+		position: position,
+	})
+	if len(c.predictedMoves) > predictedMoveLimit {
+		c.predictedMoves = c.predictedMoves[len(c.predictedMoves)-predictedMoveLimit:]
+	}
+	return c.sequence
+}
+
+// reconcile compares an authoritative position for the current player with
+// the moves we predicted. Confirmed moves are forgotten. False is returned if
+// the prediction was wrong, in which case the server's position should be used
+// and all pending predictions are discarded.
+func (c *GameClient) reconcile(sequence uint32, position backend.Coordinate) bool {
+	c.predictionMu.Lock()
+	defer c.predictionMu.Unlock()
+	if len(c.predictedMoves) == 0 {
+		return false
+	}
+	// Ignore updates older than anything we're still waiting on.
+	if sequence < c.predictedMoves[0].sequence {
+		return true
+	}
+	for i, move := range c.predictedMoves {
+		if move.sequence != sequence {
+			continue
+		}
+		if move.position != position {
+			c.predictedMoves = nil
+			return false
+		}
+		c.predictedMoves = c.predictedMoves[i+1:]
+		return true
+	}
+	c.predictedMoves = nil
+	return false
+}
+
+// clearPredictions forgets all pending predictions, which is needed when the
+// server moves the player without us asking, i.e. when respawning or
+// teleporting.
+func (c *GameClient) clearPredictions() {
+	c.predictionMu.Lock()
+	defer c.predictionMu.Unlock()
+	c.predictedMoves = nil
+}
@@ -0,0 +1,134 @@
+package client
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/mortenson/grpc-game-example/pkg/backend"
+	"github.com/mortenson/grpc-game-example/pkg/backend/testutil"
+	"github.com/mortenson/grpc-game-example/proto"
+)
+
+// moveDelta builds a move delta response.
+func moveDelta(id uuid.UUID, dx int, dy int, sequence uint32, clientSequence uint32) *proto.Response {
+	return &proto.Response{
+		Action: &proto.Response_MoveDelta{
+			MoveDelta: &proto.MoveDelta{
+				Id:             id.String(),
+				Dx:             int32(dx),
+				Dy:             int32(dy),
+				Sequence:       sequence,
+				ClientSequence: clientSequence,
+				Moves:          1,
+			},
+		},
+	}
+}
+
+func TestPredictionReconciliation(t *testing.T) {
+	tests := []struct {
+		name string
+		// predicted are the positions the player moved to locally.
+		predicted []backend.Coordinate
+		// deltas are the server's moves, confirming each prediction in order.
+		deltas []backend.Coordinate
+		want   backend.Coordinate
+		// pending is how many predictions are still waiting to be confirmed.
+		pending int
+	}{
+		{
+			name:      "confirmed moves are kept",
+			predicted: []backend.Coordinate{{X: 1}, {X: 2}},
+			deltas:    []backend.Coordinate{{X: 1}},
+			want:      backend.Coordinate{X: 2},
+			pending:   1,
+		},
+		{
+			name:      "mispredicted moves are corrected",
+			predicted: []backend.Coordinate{{X: 1}, {X: 2}},
+			deltas:    []backend.Coordinate{{Y: 1}},
+			want:      backend.Coordinate{Y: 1},
+			pending:   0,
+		},
+		{
+			name:      "later moves are corrected too",
+			predicted: []backend.Coordinate{{X: 1}, {X: 2}},
+			deltas:    []backend.Coordinate{{X: 1}, {Y: 1}},
+			want:      backend.Coordinate{X: 1, Y: 1},
+			pending:   0,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			game := testutil.NewGame(testutil.WithPlayerAt("alice", 0, 0))
+			alice := testutil.Player(game, "alice")
+			c := NewGameClient(game, nil)
+			c.CurrentPlayer = alice.ID()
+			c.setDeltaBase(alice)
+			for _, position := range test.predicted {
+				game.MoveEntity(alice, position)
+				c.predictMove(position)
+			}
+			for i, delta := range test.deltas {
+				sequence := uint32(i + 1)
+				c.handleMoveDeltaResponse(moveDelta(alice.ID(), delta.X, delta.Y, sequence, sequence))
+			}
+			if position := alice.Position(); position != test.want {
+				t.Errorf("player is at %+v, want %+v", position, test.want)
+			}
+			if pending := len(c.predictedMoves); pending != test.pending {
+				t.Errorf("got %d pending predictions, want %d", pending, test.pending)
+			}
+		})
+	}
+}
+
+func TestOwnUpdatesAreAuthoritative(t *testing.T) {
+	game := testutil.NewGame(testutil.WithPlayerAt("alice", 0, 0))
+	alice := testutil.Player(game, "alice")
+	c := NewGameClient(game, nil)
+	c.CurrentPlayer = alice.ID()
+	c.setDeltaBase(alice)
+	game.MoveEntity(alice, backend.Coordinate{X: 1})
+	c.predictMove(backend.Coordinate{X: 1})
+	c.handleUpdateEntityResponse(&proto.Response{
+		Action: &proto.Response_UpdateEntity{
+			UpdateEntity: &proto.UpdateEntity{
+				Entity: proto.GetProtoEntity(&backend.Player{
+					IdentifierBase:  backend.IdentifierBase{UUID: alice.ID()},
+					Name:            "alice",
+					CurrentPosition: backend.Coordinate{X: 5, Y: 5},
+				}),
+			},
+		},
+	})
+	player := game.GetEntity(alice.ID()).(*backend.Player)
+	if position := player.Position(); position != (backend.Coordinate{X: 5, Y: 5}) {
+		t.Errorf("player is at %+v, want the server's position", position)
+	}
+	if len(c.predictedMoves) != 0 {
+		t.Errorf("got %d pending predictions, want none", len(c.predictedMoves))
+	}
+}
+
+func TestTeleportsOverridePredictions(t *testing.T) {
+	game := testutil.NewGame(testutil.WithPlayerAt("alice", 0, 0))
+	alice := testutil.Player(game, "alice")
+	c := NewGameClient(game, nil)
+	c.CurrentPlayer = alice.ID()
+	c.setDeltaBase(alice)
+	for _, position := range []backend.Coordinate{{X: 1}, {X: 2}} {
+		game.MoveEntity(alice, position)
+		c.predictMove(position)
+	}
+	// Teleports have no client sequence, as the player didn't ask for them.
+	teleport := moveDelta(alice.ID(), 3, 4, 1, 0)
+	teleport.GetMoveDelta().Teleport = true
+	c.handleMoveDeltaResponse(teleport)
+	if position := alice.Position(); position != (backend.Coordinate{X: 3, Y: 4}) {
+		t.Errorf("player is at %+v, want the teleport's position", position)
+	}
+	if len(c.predictedMoves) != 0 {
+		t.Errorf("got %d pending predictions, want none", len(c.predictedMoves))
+	}
+}
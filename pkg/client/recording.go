@@ -0,0 +1,141 @@
+package client
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/golang/protobuf/jsonpb"
+	"github.com/mortenson/grpc-game-example/pkg/backend"
+	"github.com/mortenson/grpc-game-example/proto"
+)
+
+// recordedRequest is one line of a recording.
+type recordedRequest struct {
+	// Offset is how long after recording started the request was sent.
+	Offset  time.Duration   `json:"offset"`
+	Request json.RawMessage `json:"request"`
+}
+
+// recorder writes outgoing player actions, so that they can be replayed.
+type recorder struct {
+	writer  io.Writer
+	started time.Time
+}
+
+// StartRecording writes every move, dash, and laser sent to the server to w,
+// one JSON object per line. Use ReplayFrom to perform the actions again.
+func (c *GameClient) StartRecording(w io.Writer) {
+	c.sendMu.Lock()
+	defer c.sendMu.Unlock()
+	c.recorder = &recorder{
+		writer:  w,
+		started: time.Now(),
+	}
+}
+
+// StopRecording stops writing sent actions.
+func (c *GameClient) StopRecording() {
+	c.sendMu.Lock()
+	defer c.sendMu.Unlock()
+	c.recorder = nil
+}
+
+// record writes a request if it is a player action.
+func (r *recorder) record(req *proto.Request) error {
+	switch req.GetAction().(type) {
+	case *proto.Request_Move, *proto.Request_Dash, *proto.Request_Laser:
+	default:
+		return nil
+	}
+	marshaler := jsonpb.Marshaler{}
+	request, err := marshaler.MarshalToString(req)
+	if err != nil {
+		return err
+	}
+	line, err := json.Marshal(recordedRequest{
+		Offset:  time.Now().Sub(r.started),
+		Request: json.RawMessage(request),
+	})
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(r.writer, "%s\n", line)
+	return err
+}
+
+// ReplayFrom submits recorded actions to the local game with the same timing
+// they were recorded with, blocking until the recording ends. The client's
+// change handling sends them to the server, like the player's own actions.
+// Actions recorded at the same time are submitted together.
+func (c *GameClient) ReplayFrom(r io.Reader) error {
+	started := time.Now()
+	var batch []backend.Action
+	var batchOffset time.Duration
+	submit := func() {
+		if len(batch) == 0 {
+			return
+		}
+		time.Sleep(time.Until(started.Add(batchOffset)))
+		c.Game.SubmitActions(batch)
+		batch = nil
+	}
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+		recorded := recordedRequest{}
+		if err := json.Unmarshal([]byte(line), &recorded); err != nil {
+			return fmt.Errorf("invalid recording line: %v", err)
+		}
+		req := proto.Request{}
+		if err := jsonpb.UnmarshalString(string(recorded.Request), &req); err != nil {
+			return fmt.Errorf("invalid recorded request: %v", err)
+		}
+		action := c.recordedAction(&req)
+		if action == nil {
+			continue
+		}
+		if recorded.Offset != batchOffset {
+			submit()
+			batchOffset = recorded.Offset
+		}
+		batch = append(batch, action)
+	}
+	if err := scanner.Err(); err != nil {
+		return err
+	}
+	submit()
+	return nil
+}
+
+// recordedAction returns the action the current player took to send a
+// recorded request. Lasers are fired again instead of reusing the recorded
+// ones, so they get new IDs and start where the player is.
+func (c *GameClient) recordedAction(req *proto.Request) backend.Action {
+	switch req.GetAction().(type) {
+	case *proto.Request_Move:
+		return backend.MoveAction{
+			ID:        c.CurrentPlayer,
+			Direction: proto.GetBackendDirection(req.GetMove().Direction),
+			Created:   time.Now(),
+		}
+	case *proto.Request_Dash:
+		return backend.DashAction{
+			PlayerID: c.CurrentPlayer,
+			Created:  time.Now(),
+		}
+	case *proto.Request_Laser:
+		return backend.FireAction{
+			PlayerID:  c.CurrentPlayer,
+			Direction: proto.GetBackendDirection(req.GetLaser().Direction),
+			Piercing:  req.GetLaser().Piercing,
+		}
+	}
+	return nil
+}
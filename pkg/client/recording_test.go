@@ -0,0 +1,100 @@
+package client
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/mortenson/grpc-game-example/pkg/backend"
+	"github.com/mortenson/grpc-game-example/pkg/backend/testutil"
+	"github.com/mortenson/grpc-game-example/proto"
+)
+
+func TestRecordAndReplay(t *testing.T) {
+	move := &proto.Request{Action: &proto.Request_Move{Move: &proto.Move{Direction: proto.Direction_LEFT, Sequence: 1}}}
+	dash := &proto.Request{Action: &proto.Request_Dash{Dash: &proto.Dash{Sequence: 2}}}
+	laser := &proto.Request{Action: &proto.Request_Laser{Laser: &proto.Laser{Id: uuid.New().String(), Direction: proto.Direction_UP}}}
+	heartbeat := &proto.Request{Action: &proto.Request_Heartbeat{Heartbeat: &proto.Heartbeat{}}}
+
+	server := &fakeServer{}
+	c := newTestClient(t, server, uuid.New())
+	recording := &bytes.Buffer{}
+	c.StartRecording(recording)
+	for _, req := range []*proto.Request{move, heartbeat, dash, laser} {
+		if err := c.send(req); err != nil {
+			t.Fatal(err)
+		}
+	}
+	c.StopRecording()
+	if err := c.send(move); err != nil {
+		t.Fatal(err)
+	}
+	if lines := strings.Count(recording.String(), "\n"); lines != 3 {
+		t.Fatalf("recorded %d lines, want only the 3 actions sent while recording", lines)
+	}
+
+	// The replaying client performs the recorded actions itself, like a
+	// headless client, so it sends requests with its own sequences.
+	playerID := uuid.New()
+	replayServer := &fakeServer{
+		entities: []*proto.Entity{proto.GetProtoEntity(&backend.Player{
+			IdentifierBase: backend.IdentifierBase{UUID: playerID},
+			Name:           "bot",
+		})},
+	}
+	game := testutil.NewGame()
+	game.IsAuthoritative = false
+	game.ResolveCollisions = false
+	replay := NewHeadlessGameClient(game)
+	if err := replay.Connect(replayServer, playerID, "bot", "", ""); err != nil {
+		t.Fatalf("can not connect: %v", err)
+	}
+	game.Start()
+	replay.Start()
+	defer replay.Exit("test over")
+	if err := replay.ReplayFrom(recording); err != nil {
+		t.Fatal(err)
+	}
+	stream := replayServer.streams[0]
+	if !waitForRequest(stream, func(req *proto.Request) bool {
+		return req.GetMove() != nil && req.GetMove().Direction == proto.Direction_LEFT
+	}) {
+		t.Error("the move was not replayed")
+	}
+	if !waitForRequest(stream, func(req *proto.Request) bool { return req.GetDash() != nil }) {
+		t.Error("the dash was not replayed")
+	}
+	if !waitForRequest(stream, func(req *proto.Request) bool {
+		return req.GetLaser() != nil && req.GetLaser().Direction == proto.Direction_UP
+	}) {
+		t.Error("the laser was not replayed")
+	}
+	for _, req := range stream.requests() {
+		if req.GetLaser() != nil && req.GetLaser().Id == laser.GetLaser().Id {
+			t.Error("the replayed laser has the recorded ID")
+		}
+	}
+}
+
+func TestReplayFromInvalid(t *testing.T) {
+	tests := []struct {
+		name      string
+		recording string
+	}{
+		{name: "invalid line", recording: "not json\n"},
+		{name: "invalid request", recording: `{"offset":0,"request":{"move":"left"}}` + "\n"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			server := &fakeServer{}
+			c := newTestClient(t, server, uuid.New())
+			if err := c.ReplayFrom(strings.NewReader(test.recording)); err == nil {
+				t.Error("got no error")
+			}
+			if requests := server.streams[0].requests(); len(requests) != 0 {
+				t.Errorf("sent %v, want nothing", requests)
+			}
+		})
+	}
+}
@@ -0,0 +1,64 @@
+package client
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/google/uuid"
+)
+
+// Session is what a client needs to resume its player after restarting, as
+// long as the server still has the player.
+type Session struct {
+	Address        string    `json:"address"`
+	RoomID         string    `json:"roomId"`
+	PlayerID       uuid.UUID `json:"playerId"`
+	ReconnectToken string    `json:"reconnectToken"`
+}
+
+// Matches checks if a session was for the same server and room.
+func (session Session) Matches(address string, roomID string) bool {
+	return session.PlayerID != uuid.Nil && session.Address == address && session.RoomID == roomID
+}
+
+// DefaultSessionPath returns where sessions are kept by default, in the
+// user's config directory.
+func DefaultSessionPath() (string, error) {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "tshooter", "session.json"), nil
+}
+
+// LoadSession reads a session from a JSON file. If the file doesn't exist, an
+// empty session is returned.
+func LoadSession(path string) (Session, error) {
+	session := Session{}
+	data, err := ioutil.ReadFile(path)
+	if os.IsNotExist(err) {
+		return session, nil
+	}
+	if err != nil {
+		return session, err
+	}
+	if err := json.Unmarshal(data, &session); err != nil {
+		return Session{}, err
+	}
+	return session, nil
+}
+
+// SaveSession writes a session to a JSON file, creating its directory if
+// needed.
+func SaveSession(path string, session Session) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	data, err := json.Marshal(session)
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(path, data, 0600)
+}
@@ -0,0 +1,62 @@
+package client
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+func TestSessionRoundTrip(t *testing.T) {
+	dir, err := ioutil.TempDir("", "session")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	// Sessions are saved in directories that may not exist yet.
+	path := filepath.Join(dir, "tshooter", "session.json")
+	if session, err := LoadSession(path); err != nil || session != (Session{}) {
+		t.Fatalf("got session %+v and error %v, want an empty session", session, err)
+	}
+	want := Session{
+		Address:        "localhost:8888",
+		RoomID:         "red",
+		PlayerID:       uuid.New(),
+		ReconnectToken: uuid.New().String(),
+	}
+	if err := SaveSession(path, want); err != nil {
+		t.Fatal(err)
+	}
+	got, err := LoadSession(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got != want {
+		t.Errorf("got session %+v, want %+v", got, want)
+	}
+}
+
+func TestSessionMatches(t *testing.T) {
+	session := Session{Address: "localhost:8888", RoomID: "red", PlayerID: uuid.New()}
+	tests := []struct {
+		name    string
+		session Session
+		address string
+		roomID  string
+		want    bool
+	}{
+		{name: "same server and room", session: session, address: "localhost:8888", roomID: "red", want: true},
+		{name: "other servers", session: session, address: "example.com:8888", roomID: "red", want: false},
+		{name: "other rooms", session: session, address: "localhost:8888", roomID: "", want: false},
+		{name: "empty sessions", session: Session{}, address: "", roomID: "", want: false},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := test.session.Matches(test.address, test.roomID); got != test.want {
+				t.Errorf("got %v, want %v", got, test.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,51 @@
+package client
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"io/ioutil"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+)
+
+// TLSConfig configures how the connection to a server is secured.
+type TLSConfig struct {
+	// CAFile is a PEM encoded CA certificate used to verify the server. If
+	// empty, the system's root certificates are used.
+	CAFile string
+	// CertFile and KeyFile are an optional client certificate and key, used
+	// when the server requires mutual TLS.
+	CertFile string
+	KeyFile  string
+}
+
+// DialOption returns the option needed to dial a server with the given TLS
+// config. Connections are only made without TLS if the config is nil.
+func DialOption(config *TLSConfig) (grpc.DialOption, error) {
+	if config == nil {
+		return grpc.WithInsecure(), nil
+	}
+	tlsConfig := &tls.Config{}
+	if config.CAFile != "" {
+		pem, err := ioutil.ReadFile(config.CAFile)
+		if err != nil {
+			return nil, fmt.Errorf("could not read CA certificate: %v", err)
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, errors.New("could not parse CA certificate")
+		}
+		tlsConfig.RootCAs = pool
+	}
+	if config.CertFile != "" || config.KeyFile != "" {
+		certificate, err := tls.LoadX509KeyPair(config.CertFile, config.KeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("could not load client certificate: %v", err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{certificate}
+	}
+	return grpc.WithTransportCredentials(credentials.NewTLS(tlsConfig)), nil
+}
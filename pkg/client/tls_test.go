@@ -0,0 +1,168 @@
+package client
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"io/ioutil"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/mortenson/grpc-game-example/pkg/server"
+	"google.golang.org/grpc"
+)
+
+// writeCertificate writes a self-signed certificate for localhost and its key
+// to a directory, returning their paths. The certificate is its own CA, and
+// can be used by both servers and clients.
+func writeCertificate(t *testing.T, dir string) (string, string) {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "localhost"},
+		DNSNames:              []string{"localhost"},
+		IPAddresses:           []net.IP{net.ParseIP("127.0.0.1")},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, &template, &template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	certFile := filepath.Join(dir, "cert.pem")
+	keyFile := filepath.Join(dir, "key.pem")
+	if err := ioutil.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if err := ioutil.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}), 0600); err != nil {
+		t.Fatal(err)
+	}
+	return certFile, keyFile
+}
+
+// startTLSServer starts a gRPC server with the given TLS config, returning its
+// address.
+func startTLSServer(t *testing.T, config server.TLSConfig) string {
+	t.Helper()
+	option, err := config.ServerOption()
+	if err != nil {
+		t.Fatal(err)
+	}
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	grpcServer := grpc.NewServer(option)
+	go grpcServer.Serve(listener)
+	t.Cleanup(grpcServer.Stop)
+	return listener.Addr().String()
+}
+
+func TestDialOption(t *testing.T) {
+	dir, err := ioutil.TempDir("", "tls")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	certFile, keyFile := writeCertificate(t, dir)
+	invalidFile := filepath.Join(dir, "invalid.pem")
+	if err := ioutil.WriteFile(invalidFile, []byte("not a certificate"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name string
+		// mutual servers require clients to present a certificate.
+		mutual    bool
+		config    *TLSConfig
+		optionErr bool
+		connects  bool
+	}{
+		{
+			name:     "TLS",
+			config:   &TLSConfig{CAFile: certFile},
+			connects: true,
+		},
+		{
+			name:     "mutual TLS",
+			mutual:   true,
+			config:   &TLSConfig{CAFile: certFile, CertFile: certFile, KeyFile: keyFile},
+			connects: true,
+		},
+		{
+			name:     "mutual TLS without a client certificate",
+			mutual:   true,
+			config:   &TLSConfig{CAFile: certFile},
+			connects: false,
+		},
+		{
+			name:     "untrusted server",
+			config:   &TLSConfig{},
+			connects: false,
+		},
+		{
+			name:     "insecure connections to TLS servers",
+			config:   nil,
+			connects: false,
+		},
+		{
+			name:      "missing CA certificate",
+			config:    &TLSConfig{CAFile: filepath.Join(dir, "missing.pem")},
+			optionErr: true,
+		},
+		{
+			name:      "invalid CA certificate",
+			config:    &TLSConfig{CAFile: invalidFile},
+			optionErr: true,
+		},
+		{
+			name:      "client certificate without a key",
+			config:    &TLSConfig{CAFile: certFile, CertFile: certFile},
+			optionErr: true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			option, err := DialOption(test.config)
+			if (err != nil) != test.optionErr {
+				t.Fatalf("got error %v, want error %v", err, test.optionErr)
+			}
+			if test.optionErr {
+				return
+			}
+			serverConfig := server.TLSConfig{CertFile: certFile, KeyFile: keyFile}
+			if test.mutual {
+				serverConfig.ClientCAFile = certFile
+			}
+			address := startTLSServer(t, serverConfig)
+			ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+			defer cancel()
+			conn, err := grpc.DialContext(ctx, address, option, grpc.WithBlock())
+			if err == nil {
+				conn.Close()
+			}
+			if connects := err == nil; connects != test.connects {
+				t.Errorf("connected is %v, want %v (error %v)", connects, test.connects, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,45 @@
+package frontend
+
+import (
+	"time"
+
+	"github.com/gdamore/tcell"
+	"github.com/mortenson/grpc-game-example/pkg/backend"
+)
+
+const (
+	deathAnimationDuration        = 600 * time.Millisecond
+	deathAnimationFrameLength     = 150 * time.Millisecond
+	deathColor                    = tcell.ColorRed
+	explosionAnimationDuration    = 450 * time.Millisecond
+	explosionAnimationFrameLength = 150 * time.Millisecond
+	explosionColor                = tcell.ColorOrange
+)
+
+// deathAnimationFrames are drawn in order where a player was killed.
+var deathAnimationFrames = []rune{'X', '+'}
+
+// deathAnimationFrame returns the rune to draw for a kill at a given time.
+// False is returned if the animation is over.
+func deathAnimationFrame(kill backend.Kill, now time.Time) (rune, bool) {
+	age := now.Sub(kill.Time)
+	if age < 0 || age >= deathAnimationDuration {
+		return 0, false
+	}
+	frame := int(age/deathAnimationFrameLength) % len(deathAnimationFrames)
+	return deathAnimationFrames[frame], true
+}
+
+// explosionAnimationFrames are drawn in order around a mine that exploded.
+var explosionAnimationFrames = []rune{'#', '*', '.'}
+
+// explosionAnimationFrame returns the rune to draw for an explosion at a
+// given time. False is returned if the animation is over.
+func explosionAnimationFrame(explosion backend.Explosion, now time.Time) (rune, bool) {
+	age := now.Sub(explosion.Time)
+	if age < 0 || age >= explosionAnimationDuration {
+		return 0, false
+	}
+	frame := int(age/explosionAnimationFrameLength) % len(explosionAnimationFrames)
+	return explosionAnimationFrames[frame], true
+}
@@ -0,0 +1,35 @@
+package frontend
+
+import (
+	"testing"
+	"time"
+
+	"github.com/mortenson/grpc-game-example/pkg/backend"
+)
+
+func TestDeathAnimationFrame(t *testing.T) {
+	killed := time.Now()
+	ms := time.Millisecond
+	tests := []struct {
+		name  string
+		age   time.Duration
+		frame rune
+		ok    bool
+	}{
+		{name: "not killed yet", age: -ms, ok: false},
+		{name: "first frame", age: 0, frame: 'X', ok: true},
+		{name: "end of the first frame", age: 149 * ms, frame: 'X', ok: true},
+		{name: "second frame", age: 150 * ms, frame: '+', ok: true},
+		{name: "frames repeat", age: 300 * ms, frame: 'X', ok: true},
+		{name: "last frame", age: 599 * ms, frame: '+', ok: true},
+		{name: "over", age: 600 * ms, ok: false},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			frame, ok := deathAnimationFrame(backend.Kill{Time: killed}, killed.Add(test.age))
+			if ok != test.ok || (ok && frame != test.frame) {
+				t.Errorf("got %q, %v, want %q, %v", frame, ok, test.frame, test.ok)
+			}
+		})
+	}
+}
@@ -0,0 +1,32 @@
+package frontend
+
+import (
+	"github.com/mortenson/grpc-game-example/pkg/backend"
+)
+
+// cameraPosition returns the position the camera should be centered on for a
+// view of the given size. The camera follows the target, but stops scrolling
+// at the edge of the bounds. Bounds smaller than the view are centered.
+func cameraPosition(target backend.Coordinate, width, height int, boundsMin, boundsMax backend.Coordinate) backend.Coordinate {
+	return backend.Coordinate{
+		X: clampCamera(target.X, width, boundsMin.X, boundsMax.X),
+		Y: clampCamera(target.Y, height, boundsMin.Y, boundsMax.Y),
+	}
+}
+
+// clampCamera clamps the camera on one axis.
+func clampCamera(target, size, min, max int) int {
+	if max-min+1 <= size {
+		return min + (max-min)/2
+	}
+	// The view spans from camera - size/2 to camera + (size-1)/2.
+	low := min + size/2
+	high := max - (size-1)/2
+	if target < low {
+		return low
+	}
+	if target > high {
+		return high
+	}
+	return target
+}
@@ -0,0 +1,64 @@
+package frontend
+
+import (
+	"testing"
+
+	"github.com/mortenson/grpc-game-example/pkg/backend"
+)
+
+func TestCameraPosition(t *testing.T) {
+	// The map spans from -20 to 19 on both axes.
+	boundsMin := backend.Coordinate{X: -20, Y: -20}
+	boundsMax := backend.Coordinate{X: 19, Y: 19}
+	tests := []struct {
+		name   string
+		target backend.Coordinate
+		width  int
+		height int
+		want   backend.Coordinate
+	}{
+		{
+			name:   "follows the target",
+			target: backend.Coordinate{X: 3, Y: -4},
+			width:  10,
+			height: 10,
+			want:   backend.Coordinate{X: 3, Y: -4},
+		},
+		{
+			name:   "stops at the top left",
+			target: backend.Coordinate{X: -19, Y: -20},
+			width:  10,
+			height: 6,
+			want:   backend.Coordinate{X: -15, Y: -17},
+		},
+		{
+			name:   "stops at the bottom right",
+			target: backend.Coordinate{X: 19, Y: 18},
+			width:  10,
+			height: 6,
+			want:   backend.Coordinate{X: 15, Y: 17},
+		},
+		{
+			name:   "centers maps smaller than the view",
+			target: backend.Coordinate{X: 10, Y: 10},
+			width:  80,
+			height: 40,
+			want:   backend.Coordinate{X: -1, Y: -1},
+		},
+		{
+			name:   "odd view sizes",
+			target: backend.Coordinate{X: 19, Y: -20},
+			width:  9,
+			height: 9,
+			want:   backend.Coordinate{X: 15, Y: -16},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			camera := cameraPosition(test.target, test.width, test.height, boundsMin, boundsMax)
+			if camera != test.want {
+				t.Errorf("got %+v, want %+v", camera, test.want)
+			}
+		})
+	}
+}
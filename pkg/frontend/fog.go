@@ -0,0 +1,23 @@
+package frontend
+
+import (
+	"github.com/mortenson/grpc-game-example/pkg/backend"
+)
+
+// fog contains the cells the current player can see when the view has a fog
+// radius. A nil fog hides nothing.
+type fog map[backend.Coordinate]bool
+
+// fog returns what the current player can see from a position. Spectators
+// see everything. The game should be locked by the caller.
+func (view *View) fog(position backend.Coordinate) fog {
+	if view.FogRadius <= 0 || view.Spectating {
+		return nil
+	}
+	return view.Game.VisibleCells(position, view.FogRadius)
+}
+
+// hides checks if the fog hides a cell.
+func (f fog) hides(position backend.Coordinate) bool {
+	return f != nil && !f[position]
+}
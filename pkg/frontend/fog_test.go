@@ -0,0 +1,62 @@
+package frontend
+
+import (
+	"testing"
+
+	"github.com/mortenson/grpc-game-example/pkg/backend"
+	"github.com/mortenson/grpc-game-example/pkg/backend/testutil"
+)
+
+func TestFog(t *testing.T) {
+	config := testutil.MapConfig(
+		"S        ",
+		"         ",
+		"    █    ",
+		"         ",
+		"        S",
+	)
+	tests := []struct {
+		name       string
+		radius     int
+		spectating bool
+		hidden     []backend.Coordinate
+		shown      []backend.Coordinate
+	}{
+		{
+			name:   "no fog",
+			radius: 0,
+			shown:  []backend.Coordinate{{X: 1}, {X: 4, Y: 2}},
+		},
+		{
+			name:   "hides cells behind walls and far away",
+			radius: 3,
+			hidden: []backend.Coordinate{{X: 1}, {X: 4, Y: 2}},
+			shown:  []backend.Coordinate{{X: -2}, {}, {X: -2, Y: 3}},
+		},
+		{
+			name:       "spectators see everything",
+			radius:     3,
+			spectating: true,
+			shown:      []backend.Coordinate{{X: 1}, {X: 4, Y: 2}},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			game := testutil.NewGameFromConfig(t, config)
+			view := NewView(game, DefaultKeyMap())
+			view.FogRadius = test.radius
+			view.SetSpectating(test.spectating)
+			visible := view.fog(backend.Coordinate{X: -2})
+			for _, cell := range test.hidden {
+				if !visible.hides(cell) {
+					t.Errorf("%+v is shown, want it hidden", cell)
+				}
+			}
+			for _, cell := range test.shown {
+				if visible.hides(cell) {
+					t.Errorf("%+v is hidden, want it shown", cell)
+				}
+			}
+		})
+	}
+}
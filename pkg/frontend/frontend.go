@@ -2,9 +2,6 @@ package frontend
 
 import (
 	"fmt"
-	"math"
-	"sort"
-	"strings"
 	"time"
 
 	"github.com/gdamore/tcell"
@@ -16,21 +13,63 @@ import (
 const (
 	backgroundColor = tcell.Color234
 	textColor       = tcell.ColorWhite
+	fadedTextColor  = tcell.ColorGray
 	playerColor     = tcell.ColorWhite
 	wallColor       = tcell.Color24
 	laserColor      = tcell.ColorRed
+	powerUpColor    = tcell.ColorYellow
+	healthPackColor = tcell.ColorGreen
+	mineColor       = tcell.ColorOrange
+	hazardColor     = tcell.ColorOrangeRed
+	frozenColor     = tcell.ColorLightCyan
+	laserTrailColor = tcell.ColorDarkRed
+	laserTrail      = 100 * time.Millisecond
 	drawFrequency   = 17 * time.Millisecond
+	killFeedLength  = 4
+	killFeedFade    = 4 * time.Second
+	killFeedExpiry  = 6 * time.Second
+	playerHelpText  = "← → ↑ ↓ move - wasd/space shoot - e dash - q weapon - m mine - tab score - esc close - ctrl+q quit"
 )
 
+// weaponNames are shown for the player's active weapon.
+var weaponNames = map[backend.WeaponKind]string{
+	backend.WeaponLaser:         "laser",
+	backend.WeaponPiercingLaser: "piercing laser",
+	backend.WeaponSwapLaser:     "swap laser",
+}
+
+// Interpolator provides smoothed positions for entities that are updated
+// remotely. Returning false falls back to the entity's own position.
+type Interpolator interface {
+	Position(id uuid.UUID, renderTime time.Time) (backend.Coordinate, bool)
+}
+
 // View renders the game and handles user interaction.
 type View struct {
 	Game          *backend.Game
 	App           *tview.Application
 	CurrentPlayer uuid.UUID
+	Interpolator  Interpolator
+	Latency       LatencyReporter
+	KeyMap        KeyMap
+	Spectating    bool
 	pages         *tview.Pages
 	drawCallbacks []func()
 	viewPort      tview.Primitive
+	helpText      *tview.TextView
+	showStats     bool
 	Done          chan error
+	// findingPlayer is true while a spectator is typing a player's name, and
+	// shortcuts are ignored.
+	findingPlayer   bool
+	findPlayerInput *tview.InputField
+	// Career is the current player's career stats, if the server keeps them.
+	// It should be accessed with the game locked.
+	Career *backend.CareerStats
+	// FogRadius limits what the current player sees to entities within a
+	// radius, in cells, that they have a line of sight to. Zero shows every
+	// entity, as does spectating.
+	FogRadius int
 }
 
 func centeredModal(p tview.Primitive) tview.Primitive {
@@ -66,6 +105,7 @@ func setupRoundWaitModal(view *View) {
 			}
 			player := view.Game.GetEntity(view.Game.RoundWinner).(*backend.Player)
 			text := fmt.Sprintf("\nWinner: %s\n\n", player.Name)
+			text += formatSummary(view.Game.RoundSummary)
 			text += fmt.Sprintf("New round in %d seconds...", seconds)
 			textView.SetText(text)
 		} else {
@@ -77,6 +117,33 @@ func setupRoundWaitModal(view *View) {
 	view.pages.AddPage("roundwait", modal, true, false)
 }
 
+// formatSummary formats a round summary, leaving out stats no one has.
+func formatSummary(summary backend.Summary) string {
+	text := ""
+	if summary.TopFragger.Value > 0 {
+		text += fmt.Sprintf("Top fragger: %s (%d kills)\n", summary.TopFragger.Name, summary.TopFragger.Value)
+	}
+	if summary.LongestStreak.Value > 0 {
+		text += fmt.Sprintf("Longest streak: %s (%d kills)\n", summary.LongestStreak.Name, summary.LongestStreak.Value)
+	}
+	if summary.Duration > 0 {
+		text += fmt.Sprintf("Round length: %s\n", summary.Duration.Round(time.Second))
+	}
+	if text == "" {
+		return text
+	}
+	return text + "\n"
+}
+
+// formatLeaderboard formats leaderboard entries as one line per player.
+func formatLeaderboard(leaderboard []backend.LeaderboardEntry) string {
+	text := ""
+	for _, entry := range leaderboard {
+		text += fmt.Sprintf("%s - %d\n", entry.Name, entry.Score)
+	}
+	return text
+}
+
 func setupScoreModal(view *View) {
 	textView := tview.NewTextView()
 	textView.SetBorder(true).SetTitle("Score").SetBackgroundColor(backgroundColor)
@@ -85,38 +152,9 @@ func setupScoreModal(view *View) {
 	callback := func() {
 		view.Game.Mu.RLock()
 		defer view.Game.Mu.RUnlock()
-		text := ""
-		type PlayerScore struct {
-			Name  string
-			Score int
-		}
-		playerScore := make([]PlayerScore, 0)
-		for _, entity := range view.Game.Entities {
-			player, ok := entity.(*backend.Player)
-			if !ok {
-				continue
-			}
-			score, ok := view.Game.Score[player.ID()]
-			if !ok {
-				score = 0
-			}
-			playerScore = append(playerScore, PlayerScore{
-				Name:  player.Name,
-				Score: score,
-			})
-		}
-		sort.Slice(playerScore, func(i, j int) bool {
-			if playerScore[i].Score > playerScore[j].Score {
-				return true
-			} else if playerScore[i].Score < playerScore[j].Score {
-				return false
-			} else if strings.ToLower(playerScore[i].Name) < strings.ToLower(playerScore[j].Name) {
-				return true
-			}
-			return false
-		})
-		for _, playerScore := range playerScore {
-			text += fmt.Sprintf("%s - %d\n", playerScore.Name, playerScore.Score)
+		text := formatLeaderboard(view.Game.Leaderboard())
+		if view.Career != nil {
+			text += fmt.Sprintf("\nCareer: %d kills, %d deaths, %d wins\n", view.Career.Kills, view.Career.Deaths, view.Career.Wins)
 		}
 		textView.SetText(text)
 	}
@@ -124,8 +162,64 @@ func setupScoreModal(view *View) {
 	view.pages.AddPage("score", modal, true, false)
 }
 
-func withinDrawBounds(x, y, width, height int) bool {
-	return x < width && x > 0 && y < height && y > 0
+// getPlayerColor maps a player's color name to a terminal color.
+func getPlayerColor(player *backend.Player) tcell.Color {
+	if player.Frozen {
+		return frozenColor
+	}
+	if player.Color == "" {
+		return playerColor
+	}
+	color := tcell.GetColor(player.Color)
+	if color == tcell.ColorDefault {
+		return playerColor
+	}
+	return color
+}
+
+// killFeedLine is a line of text in the kill feed.
+type killFeedLine struct {
+	text   string
+	fading bool
+}
+
+// killFeed returns the lines of the kill feed for a given time, newest last.
+// Kills are faded, then removed from the feed as they get older.
+func killFeed(game *backend.Game, now time.Time) []killFeedLine {
+	lines := make([]killFeedLine, 0)
+	for _, kill := range game.RecentKills {
+		age := now.Sub(kill.Time)
+		if age > killFeedExpiry {
+			continue
+		}
+		killer, ok := game.GetEntity(kill.KillerID).(*backend.Player)
+		if !ok {
+			continue
+		}
+		victim, ok := game.GetEntity(kill.VictimID).(*backend.Player)
+		if !ok {
+			continue
+		}
+		lines = append(lines, killFeedLine{
+			text:   fmt.Sprintf("%s fragged %s", killer.Name, victim.Name),
+			fading: age > killFeedFade,
+		})
+	}
+	if len(lines) > killFeedLength {
+		lines = lines[len(lines)-killFeedLength:]
+	}
+	return lines
+}
+
+// blinkedOut checks if a player is hidden because the server is blinking them.
+// Players are only hidden until their protection ends, in case the change that
+// stops them blinking was missed.
+func blinkedOut(player *backend.Player, now time.Time) bool {
+	return player.Blinking && player.Protected(now)
+}
+
+func withinDrawBounds(drawX, drawY, x, y, width, height int) bool {
+	return drawX >= x && drawX < x+width && drawY >= y && drawY < y+height
 }
 
 func setupViewPort(view *View) {
@@ -133,135 +227,282 @@ func setupViewPort(view *View) {
 		SetBorder(true).
 		SetTitle("tshooter").
 		SetBackgroundColor(backgroundColor)
-	cameraX := 0
-	cameraY := 0
+	var minimap backend.Minimap
+	var minimapUpdated time.Time
+	fps := fpsCounter{}
 	box.SetDrawFunc(func(screen tcell.Screen, x int, y int, width int, height int) (int, int, int, int) {
 		view.Game.Mu.RLock()
 		defer view.Game.Mu.RUnlock()
 		style := tcell.StyleDefault.Background(backgroundColor)
-		// Move camera
+		// Spectators follow someone else if their player is gone.
+		if view.Spectating && view.Game.GetEntity(view.CurrentPlayer) == nil {
+			view.CurrentPlayer = view.nextPlayer(0)
+		}
 		currentEntity := view.Game.GetEntity(view.CurrentPlayer)
 		if currentEntity == nil {
 			return 0, 0, 0, 0
 		}
+		// Draw within the border. As the size is passed on every draw, the
+		// camera adjusts to terminal resizes automatically.
+		x, y, width, height = x+1, y+1, width-2, height-2
+		boundsMin, boundsMax := view.Game.Bounds()
 		currentPlayerPosition := currentEntity.(*backend.Player).Position()
-		cameraDiffX := float64(cameraX - currentPlayerPosition.X)
-		cameraDiffY := float64(cameraY - currentPlayerPosition.Y)
-		cameraDiffXMax := float64(width / 6)
-		cameraDiffYMax := float64(height / 6)
-		if math.Abs(cameraDiffX) > cameraDiffXMax {
-			if cameraDiffX <= 0 {
-				cameraX++
-			} else {
-				cameraX--
+		camera := cameraPosition(currentPlayerPosition, width, height, boundsMin, boundsMax)
+		grid := backend.ScreenTransform{
+			Origin: backend.Coordinate{
+				X: x + width/2 - camera.X,
+				Y: y + height/2 - camera.Y,
+			},
+		}
+		renderTime := time.Now()
+		visible := view.fog(currentPlayerPosition)
+		// Players who were just killed are hidden until their death animation
+		// is over, so they don't appear to teleport.
+		dying := make(map[uuid.UUID]bool)
+		for _, kill := range view.Game.RecentKills {
+			if _, ok := deathAnimationFrame(kill, renderTime); ok {
+				dying[kill.VictimID] = true
 			}
 		}
-		if math.Abs(cameraDiffY) > cameraDiffYMax {
-			if cameraDiffY <= 0 {
-				cameraY++
-			} else {
-				cameraY--
+		// Draw hazards below everything else, as players can stand on them.
+		for _, hazard := range view.Game.GetMapByType()[backend.MapTypeHazard] {
+			draw := grid.ToScreen(hazard)
+			if !withinDrawBounds(draw.X, draw.Y, x, y, width, height) {
+				continue
 			}
+			screen.SetContent(draw.X, draw.Y, '~', nil, style.Foreground(hazardColor))
 		}
-		width = width - 1
-		height = height - 1
-		mapWidth, mapHeight := view.Game.GetMapDimensions()
-		if width > mapWidth {
-			cameraX = -1
-		}
-		if height > mapHeight {
-			cameraY = -1
-		}
-		centerY := (y + height/2) - cameraY
-		centerX := (x + width/2) - cameraX
-		// Draw center point - useful for debugging
-		// if withinDrawBounds(centerX, centerY, width, height) {
-		// 	screen.SetContent(centerX, centerY, 'C', nil, style.Foreground(tcell.ColorWhite))
-		// }
-		// Draw entities
+		// Draw laser trails below entities, so lasers are drawn over the end
+		// of their trails.
 		for _, entity := range view.Game.Entities {
+			laser, ok := entity.(*backend.Laser)
+			if !ok {
+				continue
+			}
+			for _, cell := range laser.Trail(renderTime.Add(-laserTrail), renderTime) {
+				draw := grid.ToScreen(cell)
+				if !withinDrawBounds(draw.X, draw.Y, x, y, width, height) || visible.hides(cell) {
+					continue
+				}
+				screen.SetContent(draw.X, draw.Y, '·', nil, style.Foreground(laserTrailColor))
+			}
+		}
+		// Draw entities, with the highest z-index on top.
+		for _, entity := range sortByZIndex(view.Game.Entities) {
 			positioner, ok := entity.(backend.Positioner)
 			if !ok {
 				continue
 			}
 			position := positioner.Position()
-			drawX := centerX + position.X
-			drawY := centerY + position.Y
-			if !withinDrawBounds(drawX, drawY, width, height) {
+			if view.Interpolator != nil && (entity.ID() != view.CurrentPlayer || view.Spectating) {
+				interpolated, ok := view.Interpolator.Position(entity.ID(), renderTime)
+				if ok {
+					position = interpolated
+				}
+			}
+			draw := grid.ToScreen(position)
+			if !withinDrawBounds(draw.X, draw.Y, x, y, width, height) {
+				continue
+			}
+			// Walls are part of the map, so fog doesn't hide them.
+			if _, ok := entity.(*backend.Wall); !ok && visible.hides(position) {
 				continue
 			}
 			var icon rune
 			var color tcell.Color
 			switch entity.(type) {
 			case *backend.Player:
-				icon = entity.(*backend.Player).Icon
-				color = playerColor
+				player := entity.(*backend.Player)
+				if dying[player.ID()] || blinkedOut(player, renderTime) {
+					continue
+				}
+				icon = player.Icon
+				color = getPlayerColor(player)
+				// Make the current player stand out from others.
+				if player.ID() == view.CurrentPlayer {
+					screen.SetContent(draw.X, draw.Y, icon, nil, style.Foreground(color).Bold(true).Underline(true))
+					continue
+				}
 			case *backend.Laser:
 				icon = 'x'
 				color = laserColor
+				// Lasers fired by players on a team are drawn in its color.
+				if teamColor := view.Game.TeamColor(entity.(*backend.Laser).Team); teamColor != "" {
+					color = tcell.GetColor(teamColor)
+				}
+			case *backend.PowerUp:
+				icon = '*'
+				color = powerUpColor
+			case *backend.HealthPack:
+				icon = '+'
+				color = healthPackColor
+			case *backend.Mine:
+				icon = '^'
+				color = mineColor
+			case *backend.Wall:
+				icon = '█'
+				if entity.(*backend.Wall).Destructible {
+					icon = '▓'
+				}
+				color = wallColor
 			default:
 				continue
 			}
 			// See if player is far from center of viewport.
-			screen.SetContent(drawX, drawY, icon, nil, style.Foreground(color))
+			screen.SetContent(draw.X, draw.Y, icon, nil, style.Foreground(color))
 		}
 		// Draw map
 		for _, wall := range view.Game.GetMapByType()[backend.MapTypeWall] {
-			x := centerX + wall.X
-			y := centerY + wall.Y
-			if !withinDrawBounds(x, y, width, height) {
+			draw := grid.ToScreen(wall)
+			if !withinDrawBounds(draw.X, draw.Y, x, y, width, height) {
+				continue
+			}
+			screen.SetContent(draw.X, draw.Y, '█', nil, style.Foreground(wallColor))
+		}
+		// Draw death animations
+		for _, kill := range view.Game.RecentKills {
+			icon, ok := deathAnimationFrame(kill, renderTime)
+			if !ok {
+				continue
+			}
+			draw := grid.ToScreen(kill.Position)
+			if !withinDrawBounds(draw.X, draw.Y, x, y, width, height) || visible.hides(kill.Position) {
+				continue
+			}
+			screen.SetContent(draw.X, draw.Y, icon, nil, style.Foreground(deathColor))
+		}
+		// Draw explosions over every cell they reach.
+		for _, explosion := range view.Game.RecentExplosions {
+			icon, ok := explosionAnimationFrame(explosion, renderTime)
+			if !ok {
 				continue
 			}
-			screen.SetContent(x, y, '█', nil, style.Foreground(wallColor))
+			for dx := -explosion.Radius; dx <= explosion.Radius; dx++ {
+				for dy := -explosion.Radius; dy <= explosion.Radius; dy++ {
+					cell := explosion.Position.Add(backend.Coordinate{X: dx, Y: dy})
+					draw := grid.ToScreen(cell)
+					if !withinDrawBounds(draw.X, draw.Y, x, y, width, height) || visible.hides(cell) {
+						continue
+					}
+					screen.SetContent(draw.X, draw.Y, icon, nil, style.Foreground(explosionColor))
+				}
+			}
+		}
+		// Draw minimap, if there is room for it.
+		if width >= minimapWidth*2 && height >= minimapHeight*2 {
+			if renderTime.Sub(minimapUpdated) > minimapRefresh {
+				minimap = view.Game.FoggedMinimap(minimapWidth, minimapHeight, visible)
+				minimapUpdated = renderTime
+			}
+			drawMinimap(screen, minimap, currentPlayerPosition, x+width-minimapWidth, y+height-minimapHeight, renderTime)
+		}
+		// Draw stats, for debugging.
+		fps.frame(renderTime)
+		if view.showStats {
+			tview.Print(screen, statsText(fps.fps, view.Game.SpectatorCount(), view.Latency), x, y, width, tview.AlignLeft, textColor)
+		}
+		// Draw who is ready while in the lobby.
+		if view.Game.Phase == backend.PhaseLobby {
+			ready, total := view.Game.ReadyCount()
+			tview.Print(screen, fmt.Sprintf("lobby - press r to ready up (%d/%d ready)", ready, total), x, y+1, width, tview.AlignCenter, textColor)
+		}
+		// Draw the match's modifier, if any.
+		if view.Game.Modifier != "" {
+			tview.Print(screen, view.Game.Modifier, x, y, width, tview.AlignCenter, textColor)
+		}
+		// Draw the wave in horde mode.
+		if view.Game.Wave > 0 {
+			tview.Print(screen, fmt.Sprintf("wave %d", view.Game.Wave), x, y+height-1, width, tview.AlignCenter, textColor)
+		}
+		// Draw health once the player has been damaged.
+		if player := currentEntity.(*backend.Player); player.Damage > 0 {
+			tview.Print(screen, fmt.Sprintf("health: %d", player.Health()), x, y+height-1, width, tview.AlignLeft, hazardColor)
+		}
+		// Draw the active weapon if the player can switch weapons.
+		if player := currentEntity.(*backend.Player); len(player.Loadout) > 1 {
+			tview.Print(screen, weaponNames[player.Weapon()], x, y+height-1, width, tview.AlignRight, textColor)
+		}
+		// Draw kill feed
+		for i, line := range killFeed(view.Game, renderTime) {
+			color := textColor
+			if line.fading {
+				color = fadedTextColor
+			}
+			tview.Print(screen, line.text, x, y+i, width, tview.AlignRight, color)
 		}
 		return 0, 0, 0, 0
 	})
 	// Handle player movement input.
+	held := &heldMove{direction: backend.DirectionStop}
+	sendMove := func(direction backend.Direction) {
+		view.Game.ActionChannel <- backend.MoveAction{
+			ID:        view.CurrentPlayer,
+			Direction: direction,
+			Created:   time.Now(),
+		}
+	}
+	// Draw callbacks run in the same goroutine as input handling, so held keys
+	// can be checked without locking.
+	view.drawCallbacks = append(view.drawCallbacks, func() {
+		if view.Spectating {
+			return
+		}
+		view.Game.Mu.RLock()
+		interval := view.Game.PlayerMoveThrottle(view.CurrentPlayer)
+		view.Game.Mu.RUnlock()
+		if direction := held.tick(time.Now(), interval); direction != backend.DirectionStop {
+			sendMove(direction)
+		}
+	})
 	box.SetInputCapture(func(e *tcell.EventKey) *tcell.EventKey {
-		// Movement
-		direction := backend.DirectionStop
-		switch e.Key() {
-		case tcell.KeyUp:
-			direction = backend.DirectionUp
-		case tcell.KeyDown:
-			direction = backend.DirectionDown
-		case tcell.KeyLeft:
-			direction = backend.DirectionLeft
-		case tcell.KeyRight:
-			direction = backend.DirectionRight
+		if view.Spectating {
+			view.handleSpectatorInput(e)
+			return e
+		}
+		action, ok := view.KeyMap.Action(e)
+		if !ok {
+			return e
 		}
+		// Movement
+		direction := action.moveDirection()
 		if direction != backend.DirectionStop {
-			view.Game.ActionChannel <- backend.MoveAction{
-				ID:        view.CurrentPlayer,
-				Direction: direction,
-				Created:   time.Now(),
+			if held.press(direction, time.Now()) {
+				sendMove(direction)
+			}
+		}
+		if action == KeyActionDash {
+			view.Game.ActionChannel <- backend.DashAction{
+				PlayerID: view.CurrentPlayer,
+				Created:  time.Now(),
+			}
+		}
+		if action == KeyActionReady {
+			view.Game.ActionChannel <- backend.ReadyAction{
+				PlayerID: view.CurrentPlayer,
+			}
+		}
+		if action == KeyActionDeploy {
+			view.Game.ActionChannel <- backend.DeployAction{
+				PlayerID: view.CurrentPlayer,
+			}
+		}
+		if action == KeyActionSwitchWeapon {
+			view.Game.ActionChannel <- backend.SwitchWeaponAction{
+				PlayerID: view.CurrentPlayer,
 			}
 		}
 		// Lasers
-		laserDirection := backend.DirectionStop
-		switch e.Rune() {
-		case 'w':
-			laserDirection = backend.DirectionUp
-		case 's':
-			laserDirection = backend.DirectionDown
-		case 'a':
-			laserDirection = backend.DirectionLeft
-		case 'd':
-			laserDirection = backend.DirectionRight
-		}
-		if laserDirection != backend.DirectionStop {
-			view.Game.ActionChannel <- backend.LaserAction{
-				OwnerID:   view.CurrentPlayer,
-				ID:        uuid.New(),
+		if laserDirection, ok := action.fireDirection(); ok {
+			view.Game.ActionChannel <- backend.FireAction{
+				PlayerID:  view.CurrentPlayer,
 				Direction: laserDirection,
-				Created:   time.Now(),
 			}
 		}
 		return e
 	})
 	helpText := tview.NewTextView().
 		SetTextAlign(tview.AlignCenter).
-		SetText("← → ↑ ↓ move - wasd shoot - p score - esc close - ctrl+q quit").
+		SetText(playerHelpText).
 		SetTextColor(textColor)
 	helpText.SetBackgroundColor(backgroundColor)
 	flex := tview.NewFlex().
@@ -270,15 +511,18 @@ func setupViewPort(view *View) {
 		AddItem(helpText, 1, 1, false)
 	view.pages.AddPage("viewport", flex, true, true)
 	view.viewPort = box
+	view.helpText = helpText
 }
 
-// NewView construsts a new View struct.
-func NewView(game *backend.Game) *View {
+// NewView construsts a new View struct. Use DefaultKeyMap for the default
+// controls.
+func NewView(game *backend.Game, keyMap KeyMap) *View {
 	app := tview.NewApplication()
 	pages := tview.NewPages()
 	view := &View{
 		Game:          game,
 		App:           app,
+		KeyMap:        keyMap,
 		pages:         pages,
 		drawCallbacks: make([]func(), 0),
 		Done:          make(chan error),
@@ -286,13 +530,32 @@ func NewView(game *backend.Game) *View {
 	setupViewPort(view)
 	setupScoreModal(view)
 	setupRoundWaitModal(view)
+	setupFindPlayerModal(view)
+	scoreVisible := false
 	app.SetInputCapture(func(e *tcell.EventKey) *tcell.EventKey {
+		if view.findingPlayer && e.Key() != tcell.KeyCtrlQ && e.Key() != tcell.KeyCtrlC {
+			return e
+		}
 		if e.Rune() == 'p' {
 			pages.ShowPage("score")
+			scoreVisible = true
 		}
 		switch e.Key() {
+		case tcell.KeyF3:
+			// Toggle FPS and latency stats.
+			view.showStats = !view.showStats
+		case tcell.KeyTab:
+			// Toggle the scoreboard over the game.
+			if scoreVisible {
+				pages.HidePage("score")
+				app.SetFocus(view.viewPort)
+			} else {
+				pages.ShowPage("score")
+			}
+			scoreVisible = !scoreVisible
 		case tcell.KeyEsc:
 			pages.HidePage("score")
+			scoreVisible = false
 			app.SetFocus(view.viewPort)
 		case tcell.KeyCtrlQ:
 			fallthrough
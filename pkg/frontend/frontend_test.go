@@ -0,0 +1,31 @@
+package frontend
+
+import (
+	"testing"
+	"time"
+
+	"github.com/mortenson/grpc-game-example/pkg/backend"
+)
+
+func TestBlinkedOut(t *testing.T) {
+	now := time.Now()
+	tests := []struct {
+		name           string
+		blinking       bool
+		protectedUntil time.Time
+		want           bool
+	}{
+		{name: "blinking while protected", blinking: true, protectedUntil: now.Add(time.Second), want: true},
+		{name: "not blinking while protected", blinking: false, protectedUntil: now.Add(time.Second), want: false},
+		{name: "still blinking after protection", blinking: true, protectedUntil: now.Add(-time.Second), want: false},
+		{name: "never protected", blinking: true, want: false},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			player := &backend.Player{Blinking: test.blinking, ProtectedUntil: test.protectedUntil}
+			if got := blinkedOut(player, now); got != test.want {
+				t.Errorf("blinked out is %v, want %v", got, test.want)
+			}
+		})
+	}
+}
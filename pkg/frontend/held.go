@@ -0,0 +1,55 @@
+package frontend
+
+import (
+	"time"
+
+	"github.com/mortenson/grpc-game-example/pkg/backend"
+)
+
+// Terminals don't report key releases, so a held key is detected from
+// repeated key presses. If no repeat is seen within heldMoveRelease, the key
+// is considered released.
+const heldMoveRelease = 150 * time.Millisecond
+
+// heldMove tracks a direction key being held down, so that moves are sent at
+// a steady rate instead of the terminal's key repeat rate.
+type heldMove struct {
+	direction backend.Direction
+	held      bool
+	lastPress time.Time
+	lastMove  time.Time
+}
+
+// press records a direction key press. True is returned if a move should be
+// sent right away, which is the case for new presses. Repeated presses mark
+// the key as held, and moves are sent by tick instead.
+func (move *heldMove) press(direction backend.Direction, now time.Time) bool {
+	if move.direction == direction && now.Sub(move.lastPress) < heldMoveRelease {
+		move.held = true
+		move.lastPress = now
+		return false
+	}
+	move.direction = direction
+	move.held = false
+	move.lastPress = now
+	move.lastMove = now
+	return true
+}
+
+// tick returns the direction to move in if the key is held and a move is due,
+// otherwise DirectionStop is returned. Moves are due every interval, which
+// should be the player's move throttle so that they move as fast as allowed.
+func (move *heldMove) tick(now time.Time, interval time.Duration) backend.Direction {
+	if !move.held {
+		return backend.DirectionStop
+	}
+	if now.Sub(move.lastPress) > heldMoveRelease {
+		move.held = false
+		return backend.DirectionStop
+	}
+	if now.Sub(move.lastMove) < interval {
+		return backend.DirectionStop
+	}
+	move.lastMove = now
+	return move.direction
+}
@@ -0,0 +1,107 @@
+package frontend
+
+import (
+	"testing"
+	"time"
+
+	"github.com/mortenson/grpc-game-example/pkg/backend"
+)
+
+func TestHeldMove(t *testing.T) {
+	// heldEvent is a key press or, if press is false, a tick, at an offset
+	// from the start of the test.
+	type heldEvent struct {
+		at        time.Duration
+		press     bool
+		direction backend.Direction
+		// want is whether a press sends a move, or the direction a tick
+		// moves in.
+		want     bool
+		wantMove backend.Direction
+	}
+	up := backend.DirectionUp
+	left := backend.DirectionLeft
+	stop := backend.DirectionStop
+	tests := []struct {
+		name string
+		// interval is the player's move throttle.
+		interval time.Duration
+		events   []heldEvent
+	}{
+		{
+			name:     "single presses move right away",
+			interval: 100 * time.Millisecond,
+			events: []heldEvent{
+				{at: 0, press: true, direction: up, want: true},
+				{at: 50 * time.Millisecond, wantMove: stop},
+				{at: 500 * time.Millisecond, press: true, direction: up, want: true},
+			},
+		},
+		{
+			name:     "held keys move at the throttle interval",
+			interval: 100 * time.Millisecond,
+			events: []heldEvent{
+				{at: 0, press: true, direction: up, want: true},
+				{at: 30 * time.Millisecond, press: true, direction: up, want: false},
+				{at: 60 * time.Millisecond, wantMove: stop},
+				{at: 90 * time.Millisecond, press: true, direction: up, want: false},
+				{at: 100 * time.Millisecond, wantMove: up},
+				{at: 150 * time.Millisecond, press: true, direction: up, want: false},
+				{at: 160 * time.Millisecond, wantMove: stop},
+				{at: 200 * time.Millisecond, wantMove: up},
+			},
+		},
+		{
+			name:     "released keys stop moving",
+			interval: 100 * time.Millisecond,
+			events: []heldEvent{
+				{at: 0, press: true, direction: up, want: true},
+				{at: 30 * time.Millisecond, press: true, direction: up, want: false},
+				{at: 200 * time.Millisecond, wantMove: stop},
+				{at: 300 * time.Millisecond, wantMove: stop},
+			},
+		},
+		{
+			name:     "changing direction moves right away",
+			interval: 100 * time.Millisecond,
+			events: []heldEvent{
+				{at: 0, press: true, direction: up, want: true},
+				{at: 30 * time.Millisecond, press: true, direction: up, want: false},
+				{at: 60 * time.Millisecond, press: true, direction: left, want: true},
+				{at: 130 * time.Millisecond, wantMove: stop},
+				{at: 140 * time.Millisecond, press: true, direction: left, want: false},
+				{at: 160 * time.Millisecond, wantMove: left},
+			},
+		},
+		{
+			name:     "faster players move more often",
+			interval: 50 * time.Millisecond,
+			events: []heldEvent{
+				{at: 0, press: true, direction: up, want: true},
+				{at: 30 * time.Millisecond, press: true, direction: up, want: false},
+				{at: 40 * time.Millisecond, wantMove: stop},
+				{at: 50 * time.Millisecond, wantMove: up},
+				{at: 90 * time.Millisecond, press: true, direction: up, want: false},
+				{at: 100 * time.Millisecond, wantMove: up},
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			start := time.Now()
+			held := &heldMove{direction: backend.DirectionStop}
+			for i, event := range test.events {
+				now := start.Add(event.at)
+				if event.press {
+					if sent := held.press(event.direction, now); sent != event.want {
+						t.Errorf("press %d sent a move %v, want %v", i, sent, event.want)
+					}
+					continue
+				}
+				if direction := held.tick(now, test.interval); direction != event.wantMove {
+					t.Errorf("tick %d moved %v, want %v", i, direction, event.wantMove)
+				}
+			}
+		})
+	}
+}
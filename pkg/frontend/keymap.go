@@ -0,0 +1,106 @@
+package frontend
+
+import (
+	"github.com/gdamore/tcell"
+	"github.com/mortenson/grpc-game-example/pkg/backend"
+)
+
+// KeyAction is something the current player can do by pressing a key.
+type KeyAction int
+
+// Contains key action constants. KeyActionFire fires in the direction the
+// player last moved, and KeyActionDash dashes in that direction.
+// KeyActionSwitchWeapon switches to the next weapon in the player's loadout,
+// KeyActionReady readies the player up in the lobby, and KeyActionDeploy
+// places a mine under the player.
+const (
+	KeyActionMoveUp KeyAction = iota
+	KeyActionMoveDown
+	KeyActionMoveLeft
+	KeyActionMoveRight
+	KeyActionFireUp
+	KeyActionFireDown
+	KeyActionFireLeft
+	KeyActionFireRight
+	KeyActionFire
+	KeyActionDash
+	KeyActionSwitchWeapon
+	KeyActionReady
+	KeyActionDeploy
+)
+
+// KeyMap maps keys to actions. Keys is used for special keys like arrows, and
+// Runes for printable characters.
+type KeyMap struct {
+	Keys  map[tcell.Key]KeyAction
+	Runes map[rune]KeyAction
+}
+
+// DefaultKeyMap returns the default key map - arrows to move, wasd to fire in
+// a direction, space to fire in the direction the player is facing, e to dash,
+// q to switch weapons, r to ready up, and m to place a mine.
+func DefaultKeyMap() KeyMap {
+	return KeyMap{
+		Keys: map[tcell.Key]KeyAction{
+			tcell.KeyUp:    KeyActionMoveUp,
+			tcell.KeyDown:  KeyActionMoveDown,
+			tcell.KeyLeft:  KeyActionMoveLeft,
+			tcell.KeyRight: KeyActionMoveRight,
+		},
+		Runes: map[rune]KeyAction{
+			'w': KeyActionFireUp,
+			's': KeyActionFireDown,
+			'a': KeyActionFireLeft,
+			'd': KeyActionFireRight,
+			' ': KeyActionFire,
+			'e': KeyActionDash,
+			'q': KeyActionSwitchWeapon,
+			'r': KeyActionReady,
+			'm': KeyActionDeploy,
+		},
+	}
+}
+
+// Action returns the action bound to a key event, if any.
+func (keyMap KeyMap) Action(e *tcell.EventKey) (KeyAction, bool) {
+	if e.Key() == tcell.KeyRune {
+		action, ok := keyMap.Runes[e.Rune()]
+		return action, ok
+	}
+	action, ok := keyMap.Keys[e.Key()]
+	return action, ok
+}
+
+// moveDirection returns the direction to move in for an action.
+func (action KeyAction) moveDirection() backend.Direction {
+	switch action {
+	case KeyActionMoveUp:
+		return backend.DirectionUp
+	case KeyActionMoveDown:
+		return backend.DirectionDown
+	case KeyActionMoveLeft:
+		return backend.DirectionLeft
+	case KeyActionMoveRight:
+		return backend.DirectionRight
+	}
+	return backend.DirectionStop
+}
+
+// fireDirection returns the direction to fire in for an action, and false if
+// the action doesn't fire. KeyActionFire returns DirectionStop, which fires in
+// the direction the player is facing.
+func (action KeyAction) fireDirection() (backend.Direction, bool) {
+	switch action {
+	case KeyActionFireUp:
+		return backend.DirectionUp, true
+	case KeyActionFireDown:
+		return backend.DirectionDown, true
+	case KeyActionFireLeft:
+		return backend.DirectionLeft, true
+	case KeyActionFireRight:
+		return backend.DirectionRight, true
+	case KeyActionFire:
+		return backend.DirectionStop, true
+	}
+	return backend.DirectionStop, false
+}
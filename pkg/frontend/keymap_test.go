@@ -0,0 +1,102 @@
+package frontend
+
+import (
+	"testing"
+
+	"github.com/gdamore/tcell"
+	"github.com/mortenson/grpc-game-example/pkg/backend"
+)
+
+func TestKeyMapAction(t *testing.T) {
+	custom := KeyMap{
+		Keys: map[tcell.Key]KeyAction{
+			tcell.KeyEnter: KeyActionFire,
+		},
+		Runes: map[rune]KeyAction{
+			'k': KeyActionMoveUp,
+		},
+	}
+	tests := []struct {
+		name   string
+		keyMap KeyMap
+		event  *tcell.EventKey
+		action KeyAction
+		ok     bool
+	}{
+		{
+			name:   "default arrow",
+			keyMap: DefaultKeyMap(),
+			event:  tcell.NewEventKey(tcell.KeyLeft, 0, tcell.ModNone),
+			action: KeyActionMoveLeft,
+			ok:     true,
+		},
+		{
+			name:   "default rune",
+			keyMap: DefaultKeyMap(),
+			event:  tcell.NewEventKey(tcell.KeyRune, 'd', tcell.ModNone),
+			action: KeyActionFireRight,
+			ok:     true,
+		},
+		{
+			name:   "unbound rune",
+			keyMap: DefaultKeyMap(),
+			event:  tcell.NewEventKey(tcell.KeyRune, 'z', tcell.ModNone),
+			ok:     false,
+		},
+		{
+			name:   "custom key",
+			keyMap: custom,
+			event:  tcell.NewEventKey(tcell.KeyEnter, 0, tcell.ModNone),
+			action: KeyActionFire,
+			ok:     true,
+		},
+		{
+			name:   "custom rune",
+			keyMap: custom,
+			event:  tcell.NewEventKey(tcell.KeyRune, 'k', tcell.ModNone),
+			action: KeyActionMoveUp,
+			ok:     true,
+		},
+		{
+			name:   "defaults are replaced",
+			keyMap: custom,
+			event:  tcell.NewEventKey(tcell.KeyUp, 0, tcell.ModNone),
+			ok:     false,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			action, ok := test.keyMap.Action(test.event)
+			if ok != test.ok {
+				t.Fatalf("got ok %v, want %v", ok, test.ok)
+			}
+			if ok && action != test.action {
+				t.Errorf("got action %v, want %v", action, test.action)
+			}
+		})
+	}
+}
+
+func TestKeyActionDirections(t *testing.T) {
+	tests := []struct {
+		action KeyAction
+		move   backend.Direction
+		fire   backend.Direction
+		fires  bool
+	}{
+		{action: KeyActionMoveUp, move: backend.DirectionUp, fire: backend.DirectionStop},
+		{action: KeyActionMoveRight, move: backend.DirectionRight, fire: backend.DirectionStop},
+		{action: KeyActionFireLeft, move: backend.DirectionStop, fire: backend.DirectionLeft, fires: true},
+		{action: KeyActionFire, move: backend.DirectionStop, fire: backend.DirectionStop, fires: true},
+		{action: KeyActionDash, move: backend.DirectionStop, fire: backend.DirectionStop},
+	}
+	for _, test := range tests {
+		if move := test.action.moveDirection(); move != test.move {
+			t.Errorf("action %v moves %v, want %v", test.action, move, test.move)
+		}
+		fire, fires := test.action.fireDirection()
+		if fire != test.fire || fires != test.fires {
+			t.Errorf("action %v fires %v, %v, want %v, %v", test.action, fire, fires, test.fire, test.fires)
+		}
+	}
+}
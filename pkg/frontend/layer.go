@@ -0,0 +1,26 @@
+package frontend
+
+import (
+	"sort"
+
+	"github.com/google/uuid"
+	"github.com/mortenson/grpc-game-example/pkg/backend"
+)
+
+// sortByZIndex orders entities from the lowest z-index to the highest, so
+// that drawing them in order leaves the top entity visible in each cell.
+func sortByZIndex(entities map[uuid.UUID]backend.Identifier) []backend.Identifier {
+	sorted := make([]backend.Identifier, 0, len(entities))
+	for _, entity := range entities {
+		sorted = append(sorted, entity)
+	}
+	sort.Slice(sorted, func(i, j int) bool {
+		zIndexI := backend.ZIndex(sorted[i])
+		zIndexJ := backend.ZIndex(sorted[j])
+		if zIndexI != zIndexJ {
+			return zIndexI < zIndexJ
+		}
+		return sorted[i].ID().String() < sorted[j].ID().String()
+	})
+	return sorted
+}
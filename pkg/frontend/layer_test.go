@@ -0,0 +1,65 @@
+package frontend
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/mortenson/grpc-game-example/pkg/backend"
+)
+
+func TestSortByZIndex(t *testing.T) {
+	base := func() backend.IdentifierBase {
+		return backend.IdentifierBase{UUID: uuid.New()}
+	}
+	tests := []struct {
+		name     string
+		entities []backend.Identifier
+		// top is the type of the entity drawn last, and so seen.
+		top string
+	}{
+		{
+			name:     "players above lasers",
+			entities: []backend.Identifier{&backend.Player{IdentifierBase: base()}, &backend.Laser{IdentifierBase: base()}},
+			top:      "*backend.Player",
+		},
+		{
+			name:     "lasers above pickups",
+			entities: []backend.Identifier{&backend.Laser{IdentifierBase: base()}, &backend.PowerUp{IdentifierBase: base()}, &backend.HealthPack{IdentifierBase: base()}},
+			top:      "*backend.Laser",
+		},
+		{
+			name:     "health packs above power-ups",
+			entities: []backend.Identifier{&backend.PowerUp{IdentifierBase: base()}, &backend.HealthPack{IdentifierBase: base()}},
+			top:      "*backend.HealthPack",
+		},
+		{
+			name:     "players above mines",
+			entities: []backend.Identifier{&backend.Mine{IdentifierBase: base()}, &backend.Player{IdentifierBase: base()}},
+			top:      "*backend.Player",
+		},
+		{
+			name:     "walls above everything",
+			entities: []backend.Identifier{&backend.Wall{IdentifierBase: base()}, &backend.Player{IdentifierBase: base()}, &backend.Laser{IdentifierBase: base()}},
+			top:      "*backend.Wall",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			entities := make(map[uuid.UUID]backend.Identifier)
+			for _, entity := range test.entities {
+				entities[entity.ID()] = entity
+			}
+			// Map order is random, so sort a few times.
+			for i := 0; i < 10; i++ {
+				sorted := sortByZIndex(entities)
+				if len(sorted) != len(test.entities) {
+					t.Fatalf("got %d entities, want %d", len(sorted), len(test.entities))
+				}
+				if top := fmt.Sprintf("%T", sorted[len(sorted)-1]); top != test.top {
+					t.Fatalf("%s is drawn on top, want %s", top, test.top)
+				}
+			}
+		})
+	}
+}
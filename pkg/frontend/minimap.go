@@ -0,0 +1,52 @@
+package frontend
+
+import (
+	"time"
+
+	"github.com/gdamore/tcell"
+	"github.com/mortenson/grpc-game-example/pkg/backend"
+)
+
+const (
+	minimapWidth           = 20
+	minimapHeight          = 8
+	minimapRefresh         = 250 * time.Millisecond
+	minimapBlinkFrequency  = 500 * time.Millisecond
+	minimapBackgroundColor = tcell.Color236
+	minimapHighlightColor  = tcell.ColorYellow
+)
+
+// minimapRunes maps minimap cells to the runes used to draw them.
+var minimapRunes = map[backend.MinimapCell]rune{
+	backend.MinimapCellEmpty:  ' ',
+	backend.MinimapCellWall:   '▒',
+	backend.MinimapCellLaser:  '·',
+	backend.MinimapCellPlayer: '•',
+}
+
+// minimapColors maps minimap cells to the colors used to draw them.
+var minimapColors = map[backend.MinimapCell]tcell.Color{
+	backend.MinimapCellEmpty:  textColor,
+	backend.MinimapCellWall:   wallColor,
+	backend.MinimapCellLaser:  laserColor,
+	backend.MinimapCellPlayer: playerColor,
+}
+
+// drawMinimap draws a minimap with its top left corner at x, y. The current
+// player blinks so that they are easy to find.
+func drawMinimap(screen tcell.Screen, minimap backend.Minimap, currentPlayer backend.Coordinate, x, y int, now time.Time) {
+	style := tcell.StyleDefault.Background(minimapBackgroundColor)
+	playerX, playerY := minimap.Locate(currentPlayer)
+	highlight := (now.UnixNano()/int64(minimapBlinkFrequency))%2 == 0
+	for cellY, row := range minimap.Cells {
+		for cellX, cell := range row {
+			icon := minimapRunes[cell]
+			color := minimapColors[cell]
+			if highlight && cellX == playerX && cellY == playerY {
+				icon = minimapRunes[backend.MinimapCellPlayer]
+				color = minimapHighlightColor
+			}
+			screen.SetContent(x+cellX, y+cellY, icon, nil, style.Foreground(color))
+		}
+	}
+}
@@ -0,0 +1,66 @@
+package frontend
+
+import (
+	"testing"
+	"time"
+
+	"github.com/gdamore/tcell"
+	"github.com/mortenson/grpc-game-example/pkg/backend"
+	"github.com/mortenson/grpc-game-example/pkg/backend/testutil"
+)
+
+func TestDrawMinimap(t *testing.T) {
+	// The minimap is the same size as the map, so each cell is drawn once.
+	config := testutil.MapConfig(
+		"█  S",
+		"   █",
+		"S   ",
+	)
+	game := testutil.NewGameFromConfig(t, config,
+		testutil.WithPlayerAt("alice", 0, 0),
+		testutil.WithPlayerAt("bob", 1, 1),
+	)
+	minimap := game.Minimap(4, 3)
+	tests := []struct {
+		name string
+		now  time.Time
+		// color is the color of the current player's cell.
+		color tcell.Color
+	}{
+		{name: "current player highlighted", now: time.Unix(0, 0), color: minimapHighlightColor},
+		{name: "current player not highlighted", now: time.Unix(0, int64(minimapBlinkFrequency)), color: playerColor},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			screen := tcell.NewSimulationScreen("")
+			if err := screen.Init(); err != nil {
+				t.Fatal(err)
+			}
+			defer screen.Fini()
+			screen.SetSize(10, 5)
+			drawMinimap(screen, minimap, backend.Coordinate{X: 0, Y: 0}, 1, 1, test.now)
+			want := []string{
+				"▒   ",
+				"  •▒",
+				"   •",
+			}
+			for y, row := range want {
+				for x, icon := range []rune(row) {
+					got, _, _, _ := screen.GetContent(1+x, 1+y)
+					if got != icon {
+						t.Errorf("got %q at %d, %d, want %q", got, x, y, icon)
+					}
+				}
+			}
+			if _, _, style, _ := screen.GetContent(3, 2); !hasForeground(style, test.color) {
+				t.Errorf("current player has style %v, want color %v", style, test.color)
+			}
+		})
+	}
+}
+
+// hasForeground returns whether a style has the given foreground color.
+func hasForeground(style tcell.Style, color tcell.Color) bool {
+	foreground, _, _ := style.Decompose()
+	return foreground == color
+}
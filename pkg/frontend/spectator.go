@@ -0,0 +1,125 @@
+package frontend
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/gdamore/tcell"
+	"github.com/google/uuid"
+	"github.com/mortenson/grpc-game-example/pkg/backend"
+	"github.com/rivo/tview"
+)
+
+const spectatorHelpText = "← → ↑ ↓ follow another player - / find by name - tab score - esc close - ctrl+q quit"
+
+// SetSpectating switches the view between playing and spectating. Spectators
+// can't act, and instead use movement keys to choose which player to follow.
+func (view *View) SetSpectating(spectating bool) {
+	view.Spectating = spectating
+	if spectating {
+		view.helpText.SetText(spectatorHelpText)
+	} else {
+		view.helpText.SetText(playerHelpText)
+	}
+}
+
+// handleSpectatorInput switches the followed player when movement keys are
+// pressed, or opens the find player input when slash is pressed.
+func (view *View) handleSpectatorInput(e *tcell.EventKey) {
+	if e.Key() == tcell.KeyRune && e.Rune() == '/' {
+		view.showFindPlayer()
+		return
+	}
+	action, ok := view.KeyMap.Action(e)
+	if !ok {
+		return
+	}
+	offset := 0
+	switch action.moveDirection() {
+	case backend.DirectionRight, backend.DirectionDown:
+		offset = 1
+	case backend.DirectionLeft, backend.DirectionUp:
+		offset = -1
+	default:
+		return
+	}
+	view.Game.Mu.RLock()
+	defer view.Game.Mu.RUnlock()
+	view.CurrentPlayer = view.nextPlayer(offset)
+}
+
+// nextPlayer returns the ID of the player offset from the followed player,
+// with players sorted by name. If the followed player is gone, the first
+// player is returned. The game should be locked by the caller.
+func (view *View) nextPlayer(offset int) uuid.UUID {
+	players := make([]*backend.Player, 0)
+	for _, entity := range view.Game.Entities {
+		player, ok := entity.(*backend.Player)
+		if ok {
+			players = append(players, player)
+		}
+	}
+	if len(players) == 0 {
+		return view.CurrentPlayer
+	}
+	sort.Slice(players, func(i, j int) bool {
+		nameI := strings.ToLower(players[i].Name)
+		nameJ := strings.ToLower(players[j].Name)
+		if nameI != nameJ {
+			return nameI < nameJ
+		}
+		return players[i].ID().String() < players[j].ID().String()
+	})
+	for i, player := range players {
+		if player.ID() == view.CurrentPlayer {
+			index := (i + offset) % len(players)
+			if index < 0 {
+				index += len(players)
+			}
+			return players[index].ID()
+		}
+	}
+	return players[0].ID()
+}
+
+// setupFindPlayerModal adds an input spectators can type a player's name into
+// to follow them.
+func setupFindPlayerModal(view *View) {
+	input := tview.NewInputField().SetLabel("Name: ")
+	input.SetBorder(true).
+		SetBackgroundColor(backgroundColor).
+		SetTitle("Follow player")
+	input.SetDoneFunc(func(key tcell.Key) {
+		if key == tcell.KeyEnter {
+			name := input.GetText()
+			view.Game.Mu.RLock()
+			player := view.Game.PlayerByName(name)
+			view.Game.Mu.RUnlock()
+			if player == nil {
+				input.SetTitle(fmt.Sprintf("No player named %q", name))
+				return
+			}
+			view.CurrentPlayer = player.ID()
+		}
+		view.hideFindPlayer()
+	})
+	view.pages.AddPage("findplayer", centeredModal(input), true, false)
+	view.findPlayerInput = input
+}
+
+// showFindPlayer opens the find player input.
+func (view *View) showFindPlayer() {
+	view.findingPlayer = true
+	view.findPlayerInput.SetText("")
+	view.findPlayerInput.SetTitle("Follow player")
+	view.pages.ShowPage("findplayer")
+	view.App.SetFocus(view.findPlayerInput)
+}
+
+// hideFindPlayer closes the find player input.
+func (view *View) hideFindPlayer() {
+	view.findingPlayer = false
+	view.pages.HidePage("findplayer")
+	view.App.SetFocus(view.viewPort)
+}
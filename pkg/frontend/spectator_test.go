@@ -0,0 +1,119 @@
+package frontend
+
+import (
+	"testing"
+
+	"github.com/gdamore/tcell"
+	"github.com/google/uuid"
+	"github.com/mortenson/grpc-game-example/pkg/backend"
+	"github.com/mortenson/grpc-game-example/pkg/backend/testutil"
+	"github.com/rivo/tview"
+)
+
+func TestSpectatorInput(t *testing.T) {
+	tests := []struct {
+		name      string
+		event     *tcell.EventKey
+		following string
+		want      string
+	}{
+		{
+			name:      "next player",
+			event:     tcell.NewEventKey(tcell.KeyRight, 0, tcell.ModNone),
+			following: "alice",
+			want:      "bob",
+		},
+		{
+			name:      "previous player wraps around",
+			event:     tcell.NewEventKey(tcell.KeyUp, 0, tcell.ModNone),
+			following: "alice",
+			want:      "carol",
+		},
+		{
+			name:      "next player wraps around",
+			event:     tcell.NewEventKey(tcell.KeyDown, 0, tcell.ModNone),
+			following: "carol",
+			want:      "alice",
+		},
+		{
+			name:      "fire keys are ignored",
+			event:     tcell.NewEventKey(tcell.KeyRune, 'd', tcell.ModNone),
+			following: "bob",
+			want:      "bob",
+		},
+		{
+			name:      "followed player is gone",
+			event:     tcell.NewEventKey(tcell.KeyRight, 0, tcell.ModNone),
+			following: "",
+			want:      "alice",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			game := testutil.NewGame(
+				testutil.WithPlayerAt("carol", 0, 0),
+				testutil.WithPlayerAt("alice", 1, 0),
+				testutil.WithPlayerAt("bob", 2, 0),
+			)
+			view := NewView(game, DefaultKeyMap())
+			view.SetSpectating(true)
+			view.CurrentPlayer = uuid.New()
+			if test.following != "" {
+				view.CurrentPlayer = testutil.Player(game, test.following).ID()
+			}
+			view.viewPort.(*tview.Box).GetInputCapture()(test.event)
+			if want := testutil.Player(game, test.want).ID(); view.CurrentPlayer != want {
+				t.Errorf("following %v, want %s", followedName(game, view.CurrentPlayer), test.want)
+			}
+			if len(game.ActionChannel) != 0 {
+				t.Errorf("spectator input sent %v", <-game.ActionChannel)
+			}
+		})
+	}
+}
+
+// followedName returns the name of a player, for test failures.
+func followedName(game *backend.Game, id uuid.UUID) string {
+	if player, ok := game.GetEntity(id).(*backend.Player); ok {
+		return player.Name
+	}
+	return id.String()
+}
+
+func TestFindPlayer(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  string
+		found bool
+	}{
+		{name: "exact name", input: "bob", want: "bob", found: true},
+		{name: "ignores case", input: "CAROL", want: "carol", found: true},
+		{name: "unknown names", input: "dave", want: "alice", found: false},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			game := testutil.NewGame(
+				testutil.WithPlayerAt("carol", 0, 0),
+				testutil.WithPlayerAt("alice", 1, 0),
+				testutil.WithPlayerAt("bob", 2, 0),
+			)
+			view := NewView(game, DefaultKeyMap())
+			view.SetSpectating(true)
+			view.CurrentPlayer = testutil.Player(game, "alice").ID()
+			view.viewPort.(*tview.Box).GetInputCapture()(tcell.NewEventKey(tcell.KeyRune, '/', tcell.ModNone))
+			if !view.findingPlayer {
+				t.Fatal("the find player input was not opened")
+			}
+			view.findPlayerInput.SetText(test.input)
+			view.findPlayerInput.InputHandler()(tcell.NewEventKey(tcell.KeyEnter, 0, tcell.ModNone), func(tview.Primitive) {})
+			if want := testutil.Player(game, test.want).ID(); view.CurrentPlayer != want {
+				t.Errorf("following %v, want %s", followedName(game, view.CurrentPlayer), test.want)
+			}
+			// The input stays open to show that no player was found.
+			if view.findingPlayer == test.found {
+				t.Errorf("finding player is %v, want %v", view.findingPlayer, !test.found)
+			}
+		})
+	}
+}
@@ -0,0 +1,46 @@
+package frontend
+
+import (
+	"fmt"
+	"time"
+)
+
+// LatencyReporter reports the average round trip time to a server. Returning
+// false means the latency is unknown, i.e. when playing offline.
+type LatencyReporter interface {
+	Latency() (time.Duration, bool)
+}
+
+// fpsCounter counts how many frames are drawn each second.
+type fpsCounter struct {
+	frames int
+	start  time.Time
+	fps    int
+}
+
+// frame records that a frame was drawn.
+func (counter *fpsCounter) frame(now time.Time) {
+	counter.frames++
+	elapsed := now.Sub(counter.start)
+	if elapsed < time.Second {
+		return
+	}
+	counter.fps = int(float64(counter.frames) / elapsed.Seconds())
+	counter.frames = 0
+	counter.start = now
+}
+
+// statsText returns the text for the stats overlay.
+func statsText(fps int, spectators int, latency LatencyReporter) string {
+	text := fmt.Sprintf("%d fps", fps)
+	if spectators > 0 {
+		text += fmt.Sprintf(" - %d watching", spectators)
+	}
+	if latency == nil {
+		return text
+	}
+	if average, ok := latency.Latency(); ok {
+		text += fmt.Sprintf(" - %dms latency", average.Milliseconds())
+	}
+	return text
+}
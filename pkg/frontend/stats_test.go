@@ -0,0 +1,56 @@
+package frontend
+
+import (
+	"testing"
+	"time"
+)
+
+// fixedLatency reports a latency, or that it is unknown if negative.
+type fixedLatency time.Duration
+
+func (latency fixedLatency) Latency() (time.Duration, bool) {
+	return time.Duration(latency), latency >= 0
+}
+
+func TestFPSCounter(t *testing.T) {
+	start := time.Now()
+	counter := fpsCounter{start: start}
+	for i := 1; i <= 30; i++ {
+		counter.frame(start.Add(time.Duration(i) * time.Second / 60))
+	}
+	if counter.fps != 0 {
+		t.Errorf("got %d fps before a second passed, want 0", counter.fps)
+	}
+	for i := 31; i <= 60; i++ {
+		counter.frame(start.Add(time.Duration(i) * time.Second / 60))
+	}
+	if counter.fps != 60 {
+		t.Errorf("got %d fps, want 60", counter.fps)
+	}
+}
+
+func TestStatsText(t *testing.T) {
+	tests := []struct {
+		name       string
+		spectators int
+		latency    LatencyReporter
+		want       string
+	}{
+		{name: "offline", latency: nil, want: "60 fps"},
+		{name: "unknown latency", latency: fixedLatency(-1), want: "60 fps"},
+		{name: "latency", latency: fixedLatency(42 * time.Millisecond), want: "60 fps - 42ms latency"},
+		{
+			name:       "spectators",
+			spectators: 2,
+			latency:    fixedLatency(42 * time.Millisecond),
+			want:       "60 fps - 2 watching - 42ms latency",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if text := statsText(60, test.spectators, test.latency); text != test.want {
+				t.Errorf("got %q, want %q", text, test.want)
+			}
+		})
+	}
+}
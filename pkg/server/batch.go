@@ -0,0 +1,85 @@
+package server
+
+import (
+	"github.com/google/uuid"
+
+	"github.com/mortenson/grpc-game-example/pkg/backend"
+)
+
+// coalescedMove is one or more moves of an entity, combined into one delta.
+type coalescedMove struct {
+	// change is the most recent move, which has the entity's latest position
+	// and client sequence.
+	change backend.MoveChange
+	dx     int
+	dy     int
+	// moves is how many moves were combined.
+	moves int
+	// teleport is true if any of the moves was a teleport.
+	teleport bool
+}
+
+// changeBatch collects changes between broadcast ticks. Moves of an entity
+// are coalesced until another kind of change is added, so that the order
+// clients apply changes in is preserved.
+type changeBatch struct {
+	changes []backend.Change
+	moves   map[uuid.UUID]*coalescedMove
+}
+
+// newChangeBatch constructs a new changeBatch struct.
+func newChangeBatch() *changeBatch {
+	return &changeBatch{
+		changes: []backend.Change{},
+		moves:   make(map[uuid.UUID]*coalescedMove),
+	}
+}
+
+// add adds a change to the batch.
+func (batch *changeBatch) add(change backend.Change) {
+	moveChange, ok := change.(backend.MoveChange)
+	if !ok {
+		batch.moves = make(map[uuid.UUID]*coalescedMove)
+		batch.changes = append(batch.changes, change)
+		return
+	}
+	dx, dy := moveDelta(moveChange)
+	id := moveChange.Entity.ID()
+	move, ok := batch.moves[id]
+	if !ok {
+		move = &coalescedMove{}
+		batch.moves[id] = move
+		batch.changes = append(batch.changes, move)
+	}
+	move.change = moveChange
+	move.dx += dx
+	move.dy += dy
+	move.moves++
+	move.teleport = move.teleport || moveChange.Teleport
+}
+
+// flush returns the batched changes, emptying the batch.
+func (batch *changeBatch) flush() []backend.Change {
+	changes := batch.changes
+	batch.changes = []backend.Change{}
+	batch.moves = make(map[uuid.UUID]*coalescedMove)
+	return changes
+}
+
+// moveDelta returns how far a move changed an entity's position on each axis.
+func moveDelta(change backend.MoveChange) (int, int) {
+	if change.Teleport {
+		return change.Position.X - change.From.X, change.Position.Y - change.From.Y
+	}
+	switch change.Direction {
+	case backend.DirectionUp:
+		return 0, -change.Distance
+	case backend.DirectionDown:
+		return 0, change.Distance
+	case backend.DirectionLeft:
+		return -change.Distance, 0
+	case backend.DirectionRight:
+		return change.Distance, 0
+	}
+	return 0, 0
+}
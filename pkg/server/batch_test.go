@@ -0,0 +1,275 @@
+package server
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mortenson/grpc-game-example/pkg/backend"
+	"github.com/mortenson/grpc-game-example/pkg/backend/testutil"
+	"github.com/mortenson/grpc-game-example/proto"
+)
+
+func TestChangeBatch(t *testing.T) {
+	alice := &backend.Player{IdentifierBase: backend.IdentifierBase{UUID: uuid.New()}}
+	bob := &backend.Player{IdentifierBase: backend.IdentifierBase{UUID: uuid.New()}}
+	move := func(player *backend.Player, direction backend.Direction, sequence uint32) backend.MoveChange {
+		return backend.MoveChange{
+			Entity:       player,
+			Direction:    direction,
+			Distance:     1,
+			MoveSequence: sequence,
+		}
+	}
+	teleport := func(player *backend.Player, dx int, sequence uint32) backend.MoveChange {
+		return backend.MoveChange{
+			Entity:       player,
+			Teleport:     true,
+			Position:     backend.Coordinate{X: dx},
+			MoveSequence: sequence,
+		}
+	}
+	add := backend.AddEntityChange{Entity: bob}
+	// coalesced describes a coalesced move in a flushed batch.
+	type coalesced struct {
+		player   *backend.Player
+		dx, dy   int
+		moves    int
+		sequence uint32
+		teleport bool
+	}
+	tests := []struct {
+		name    string
+		changes []backend.Change
+		// want has a coalesced move, or nil for another kind of change.
+		want []*coalesced
+	}{
+		{
+			name:    "empty",
+			changes: []backend.Change{},
+			want:    []*coalesced{},
+		},
+		{
+			name: "moves of one entity",
+			changes: []backend.Change{
+				move(alice, backend.DirectionRight, 1),
+				move(alice, backend.DirectionRight, 2),
+				move(alice, backend.DirectionDown, 3),
+			},
+			want: []*coalesced{{player: alice, dx: 2, dy: 1, moves: 3, sequence: 3}},
+		},
+		{
+			name: "moves back and forth",
+			changes: []backend.Change{
+				move(alice, backend.DirectionRight, 1),
+				move(alice, backend.DirectionLeft, 2),
+			},
+			want: []*coalesced{{player: alice, dx: 0, dy: 0, moves: 2, sequence: 2}},
+		},
+		{
+			name: "moves of several entities",
+			changes: []backend.Change{
+				move(alice, backend.DirectionRight, 1),
+				move(bob, backend.DirectionUp, 1),
+				move(alice, backend.DirectionRight, 2),
+			},
+			want: []*coalesced{
+				{player: alice, dx: 2, moves: 2, sequence: 2},
+				{player: bob, dy: -1, moves: 1, sequence: 1},
+			},
+		},
+		{
+			name: "other changes keep their order",
+			changes: []backend.Change{
+				move(alice, backend.DirectionRight, 1),
+				add,
+				move(alice, backend.DirectionRight, 2),
+				move(alice, backend.DirectionRight, 3),
+			},
+			want: []*coalesced{
+				{player: alice, dx: 1, moves: 1, sequence: 1},
+				nil,
+				{player: alice, dx: 2, moves: 2, sequence: 3},
+			},
+		},
+		{
+			name: "teleports",
+			changes: []backend.Change{
+				teleport(alice, 5, 1),
+				move(alice, backend.DirectionRight, 2),
+			},
+			want: []*coalesced{{player: alice, dx: 6, moves: 2, sequence: 2, teleport: true}},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			batch := newChangeBatch()
+			for _, change := range test.changes {
+				batch.add(change)
+			}
+			changes := batch.flush()
+			if len(changes) != len(test.want) {
+				t.Fatalf("got %d changes, want %d", len(changes), len(test.want))
+			}
+			for i, change := range changes {
+				want := test.want[i]
+				move, ok := change.(*coalescedMove)
+				if ok != (want != nil) {
+					t.Errorf("change %d is %T", i, change)
+					continue
+				}
+				if !ok {
+					continue
+				}
+				if move.change.Entity != want.player || move.dx != want.dx || move.dy != want.dy ||
+					move.moves != want.moves || move.change.MoveSequence != want.sequence || move.teleport != want.teleport {
+					t.Errorf("change %d moved %v by (%d, %d) in %d moves ending at %d with teleport %v, want %v by (%d, %d) in %d moves ending at %d with teleport %v",
+						i, move.change.Entity.ID(), move.dx, move.dy, move.moves, move.change.MoveSequence, move.teleport,
+						want.player.ID(), want.dx, want.dy, want.moves, want.sequence, want.teleport)
+				}
+			}
+			if len(batch.flush()) != 0 {
+				t.Error("flushing did not empty the batch")
+			}
+		})
+	}
+}
+
+func TestMoveDelta(t *testing.T) {
+	tests := []struct {
+		name   string
+		change backend.MoveChange
+		dx, dy int
+	}{
+		{name: "up", change: backend.MoveChange{Direction: backend.DirectionUp, Distance: 1}, dy: -1},
+		{name: "dash", change: backend.MoveChange{Direction: backend.DirectionLeft, Distance: 3}, dx: -3},
+		{name: "stop", change: backend.MoveChange{Direction: backend.DirectionStop, Distance: 1}},
+		{
+			name: "teleport",
+			change: backend.MoveChange{
+				Teleport: true,
+				From:     backend.Coordinate{X: 2, Y: 2},
+				Position: backend.Coordinate{X: -1, Y: 5},
+			},
+			dx: -3,
+			dy: 3,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if dx, dy := moveDelta(test.change); dx != test.dx || dy != test.dy {
+				t.Errorf("got (%d, %d), want (%d, %d)", dx, dy, test.dx, test.dy)
+			}
+		})
+	}
+}
+
+func TestTeleportDelta(t *testing.T) {
+	tests := []struct {
+		name string
+		// teleport moves alice without her asking.
+		teleport func(t *testing.T, game *backend.Game, aliceID uuid.UUID, bobID uuid.UUID)
+	}{
+		{
+			name: "set position",
+			teleport: func(t *testing.T, game *backend.Game, aliceID uuid.UUID, bobID uuid.UUID) {
+				game.Mu.Lock()
+				defer game.Mu.Unlock()
+				if err := game.SetPosition(aliceID, backend.Coordinate{X: 3, Y: 3}); err != nil {
+					t.Fatal(err)
+				}
+			},
+		},
+		{
+			name: "swap laser",
+			teleport: func(t *testing.T, game *backend.Game, aliceID uuid.UUID, bobID uuid.UUID) {
+				game.Mu.Lock()
+				game.MoveEntity(game.GetEntity(aliceID), backend.Coordinate{X: 0, Y: 0})
+				game.MoveEntity(game.GetEntity(bobID), backend.Coordinate{X: 3, Y: 0})
+				game.AddEntity(&backend.Laser{
+					IdentifierBase: backend.IdentifierBase{UUID: uuid.New()},
+					Direction:      backend.DirectionStop,
+					StartTime:      time.Now(),
+					OwnerID:        bobID,
+					Swap:           true,
+				})
+				game.Mu.Unlock()
+				game.Step()
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			game := testutil.NewGame()
+			s := NewGameServer(game, "", 0)
+			req := connectRequest("alice", "")
+			resp, err := s.Connect(context.Background(), req)
+			if err != nil {
+				t.Fatal(err)
+			}
+			bobReq := connectRequest("bob", "")
+			if _, err := s.Connect(context.Background(), bobReq); err != nil {
+				t.Fatal(err)
+			}
+			stream := startStream(t, s, resp.Token)
+			test.teleport(t, game, uuid.MustParse(req.Id), uuid.MustParse(bobReq.Id))
+			delta := stream.waitForResponse(func(resp *proto.Response) bool {
+				delta := resp.GetMoveDelta()
+				return delta != nil && delta.Id == req.Id && (delta.Dx != 0 || delta.Dy != 0)
+			})
+			if delta == nil {
+				t.Fatal("no move delta was sent for alice")
+			}
+			if !delta.GetMoveDelta().Teleport {
+				t.Error("the move delta is not marked as a teleport")
+			}
+		})
+	}
+}
+
+func TestBroadcastInterval(t *testing.T) {
+	game := testutil.NewGame()
+	s := NewGameServer(game, "", 200*time.Millisecond)
+	req := connectRequest("alice", "")
+	if _, err := s.Connect(context.Background(), req); err != nil {
+		t.Fatal(err)
+	}
+	bobReq := connectRequest("bob", "")
+	bob, err := s.Connect(context.Background(), bobReq)
+	if err != nil {
+		t.Fatal(err)
+	}
+	stream := startStream(t, s, bob.Token)
+	playerID := uuid.MustParse(req.Id)
+	start := time.Now()
+	game.Mu.Lock()
+	// Players spawn anywhere, so move them where alice has room to move.
+	game.MoveEntity(game.GetEntity(playerID), backend.Coordinate{X: 0, Y: 0})
+	game.MoveEntity(game.GetEntity(uuid.MustParse(bobReq.Id)), backend.Coordinate{X: -5, Y: -5})
+	for i := 0; i < 3; i++ {
+		backend.MoveAction{
+			ID:        playerID,
+			Direction: backend.DirectionRight,
+			Created:   start.Add(time.Duration(i) * time.Second),
+		}.Perform(game)
+	}
+	game.Mu.Unlock()
+
+	// The moves may straddle a tick, but should still be coalesced.
+	deltas, moves, dx := 0, 0, 0
+	stream.waitForResponse(func(resp *proto.Response) bool {
+		if delta := resp.GetMoveDelta(); delta != nil && delta.Id == req.Id {
+			deltas++
+			moves += int(delta.Moves)
+			dx += int(delta.Dx)
+		}
+		return moves >= 3
+	})
+	if moves != 3 || dx != 3 {
+		t.Errorf("got %d moves moving %d cells, want 3 moves moving 3 cells", moves, dx)
+	}
+	if deltas >= 3 {
+		t.Errorf("got %d deltas, want the moves to be coalesced", deltas)
+	}
+}
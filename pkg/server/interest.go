@@ -0,0 +1,218 @@
+package server
+
+import (
+	"errors"
+	"log"
+
+	"github.com/google/uuid"
+	"github.com/mortenson/grpc-game-example/pkg/backend"
+	"github.com/mortenson/grpc-game-example/proto"
+)
+
+// SetInterestRadius limits what each client is sent about entities to those
+// within a radius of their player, in cells, to save bandwidth on large maps.
+// Moves, added and removed entities, respawns, damage, and statuses are
+// filtered, and lasers are sent if their path comes into range. Clients are
+// sent an entity again once it comes back into range. Spectators are sent
+// everything. A radius of zero disables the limit. This should be called
+// before serving.
+func (s *GameServer) SetInterestRadius(radius int) {
+	s.interestRadius = radius
+}
+
+// interestedIn checks if a position is in a client's area of interest, which
+// is centered on their player. The game should be read locked by the caller.
+func (s *GameServer) interestedIn(currentClient *client, position backend.Coordinate) bool {
+	if s.interestRadius <= 0 || currentClient.spectator {
+		return true
+	}
+	player, ok := currentClient.game.GetEntity(currentClient.playerID).(*backend.Player)
+	if !ok {
+		return true
+	}
+	return player.Position().Distance(position) <= s.interestRadius
+}
+
+// interestedInEntity checks if an entity is in a client's area of interest.
+// Clients are always interested in their own player, and in lasers that will
+// pass through their area of interest. The game should be read locked by the
+// caller.
+func (s *GameServer) interestedInEntity(currentClient *client, entity backend.Identifier) bool {
+	if s.interestRadius <= 0 || currentClient.spectator || entity.ID() == currentClient.playerID {
+		return true
+	}
+	player, ok := currentClient.game.GetEntity(currentClient.playerID).(*backend.Player)
+	if !ok {
+		return true
+	}
+	positioner, ok := entity.(backend.Positioner)
+	if !ok {
+		return true
+	}
+	position := positioner.Position()
+	if laser, ok := entity.(*backend.Laser); ok {
+		position = closestOnPath(laser, player.Position())
+	}
+	return player.Position().Distance(position) <= s.interestRadius
+}
+
+// closestOnPath returns the cell a laser has yet to pass through that is
+// closest to a position.
+func closestOnPath(laser *backend.Laser, position backend.Coordinate) backend.Coordinate {
+	closest := laser.Position()
+	switch laser.Direction {
+	case backend.DirectionUp:
+		if position.Y < closest.Y {
+			closest.Y = position.Y
+		}
+	case backend.DirectionDown:
+		if position.Y > closest.Y {
+			closest.Y = position.Y
+		}
+	case backend.DirectionLeft:
+		if position.X < closest.X {
+			closest.X = position.X
+		}
+	case backend.DirectionRight:
+		if position.X > closest.X {
+			closest.X = position.X
+		}
+	}
+	return closest
+}
+
+// gameClients returns the clients in a game that have a stream.
+func (s *GameServer) gameClients(game *backend.Game) []*client {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	clients := make([]*client, 0)
+	for _, currentClient := range s.clients {
+		if currentClient.streamServer != nil && currentClient.game == game {
+			clients = append(clients, currentClient)
+		}
+	}
+	return clients
+}
+
+// sendResponses sends each client their responses, in order.
+func (s *GameServer) sendResponses(responses map[*client][]*proto.Response) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for currentClient, clientResponses := range responses {
+		for _, clientResp := range clientResponses {
+			if err := currentClient.streamServer.Send(clientResp); err != nil {
+				log.Printf("%s - broadcast error %v", currentClient.id, err)
+				currentClient.stop(errors.New("failed to broadcast message"))
+				break
+			}
+		}
+	}
+}
+
+// broadcastNear sends a response about an entity to each client in a game
+// that is interested in the entity. Other clients are sent the fallback
+// responses instead, if any, and are sent the entity once it comes into their
+// area of interest.
+func (s *GameServer) broadcastNear(game *backend.Game, entity backend.Identifier, resp *proto.Response, fallback ...*proto.Response) {
+	if s.interestRadius <= 0 {
+		s.broadcast(game, resp)
+		return
+	}
+	id := entity.ID()
+	clients := s.gameClients(game)
+
+	// Missed entities are only tracked in this game's change goroutine, so
+	// the clients' missed entities don't need to be locked.
+	responses := make(map[*client][]*proto.Response, len(clients))
+	game.Mu.RLock()
+	for _, currentClient := range clients {
+		if !s.interestedInEntity(currentClient, entity) {
+			currentClient.missed[id] = true
+			responses[currentClient] = fallback
+			continue
+		}
+		responses[currentClient] = []*proto.Response{resp}
+	}
+	game.Mu.RUnlock()
+	s.sendResponses(responses)
+}
+
+// broadcastMove sends a move delta to each client in a game that is
+// interested in the move, which is when the entity moved from or to a cell
+// in the client's area of interest. Clients that missed the entity are sent
+// it instead. When a client's player moves, they are also sent the missed
+// entities that are now in range.
+func (s *GameServer) broadcastMove(game *backend.Game, change backend.MoveChange, dx int, dy int, resp *proto.Response) {
+	if s.interestRadius <= 0 {
+		s.broadcast(game, resp)
+		return
+	}
+	id := change.Entity.ID()
+	from := change.Position.Add(backend.Coordinate{X: -dx, Y: -dy})
+	clients := s.gameClients(game)
+
+	// Missed entities are only tracked in this game's change goroutine, so
+	// the clients' missed entities don't need to be locked.
+	responses := make(map[*client][]*proto.Response, len(clients))
+	game.Mu.RLock()
+	for _, currentClient := range clients {
+		if !s.interestedIn(currentClient, from) && !s.interestedIn(currentClient, change.Position) {
+			currentClient.missed[id] = true
+			continue
+		}
+		if currentClient.missed[id] {
+			delete(currentClient.missed, id)
+			responses[currentClient] = append(responses[currentClient], entityUpdate(change.Entity))
+		} else {
+			responses[currentClient] = append(responses[currentClient], resp)
+		}
+		if id == currentClient.playerID {
+			responses[currentClient] = append(responses[currentClient], s.catchUp(currentClient)...)
+		}
+	}
+	game.Mu.RUnlock()
+	s.sendResponses(responses)
+}
+
+// catchUp returns the missed entities that are now in a client's area of
+// interest, and removes missed entities that were removed from the game. The
+// game should be read locked by the caller.
+func (s *GameServer) catchUp(currentClient *client) []*proto.Response {
+	responses := make([]*proto.Response, 0)
+	for id := range currentClient.missed {
+		entity := currentClient.game.GetEntity(id)
+		if entity == nil {
+			delete(currentClient.missed, id)
+			responses = append(responses, entityRemoval(id))
+			continue
+		}
+		if !s.interestedInEntity(currentClient, entity) {
+			continue
+		}
+		delete(currentClient.missed, id)
+		responses = append(responses, entityUpdate(entity))
+	}
+	return responses
+}
+
+// entityUpdate builds a response that updates an entity for clients.
+func entityUpdate(entity backend.Identifier) *proto.Response {
+	return &proto.Response{
+		Action: &proto.Response_UpdateEntity{
+			UpdateEntity: &proto.UpdateEntity{
+				Entity: proto.GetProtoEntity(entity),
+			},
+		},
+	}
+}
+
+// entityRemoval builds a response that removes an entity for clients.
+func entityRemoval(id uuid.UUID) *proto.Response {
+	return &proto.Response{
+		Action: &proto.Response_RemoveEntity{
+			RemoveEntity: &proto.RemoveEntity{
+				Id: id.String(),
+			},
+		},
+	}
+}
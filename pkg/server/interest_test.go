@@ -0,0 +1,233 @@
+package server
+
+import (
+	"context"
+	"reflect"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mortenson/grpc-game-example/pkg/backend"
+	"github.com/mortenson/grpc-game-example/pkg/backend/testutil"
+	"github.com/mortenson/grpc-game-example/proto"
+)
+
+// movesUntil returns the moves and entity updates sent to a stream before the
+// spectator count marker, described as "delta" or "update" and the name of
+// the entity.
+func movesUntil(t *testing.T, game *backend.Game, stream *fakeStream, marker int32) []string {
+	t.Helper()
+	moves := []string{}
+	name := func(id string) string {
+		game.Mu.RLock()
+		defer game.Mu.RUnlock()
+		return game.GetEntity(uuid.MustParse(id)).(*backend.Player).Name
+	}
+	resp := stream.waitForResponse(func(resp *proto.Response) bool {
+		switch {
+		case resp.GetMoveDelta() != nil:
+			moves = append(moves, "delta "+name(resp.GetMoveDelta().Id))
+		case resp.GetUpdateEntity() != nil:
+			moves = append(moves, "update "+name(resp.GetUpdateEntity().Entity.GetPlayer().Id))
+		}
+		return resp.GetSpectatorCount() != nil && resp.GetSpectatorCount().Count == marker
+	})
+	if resp == nil {
+		t.Fatal("the marker was not sent")
+	}
+	return moves
+}
+
+func TestInterestRadius(t *testing.T) {
+	game := testutil.NewGame()
+	s := NewGameServer(game, "", 0)
+	s.SetInterestRadius(5)
+	req := connectRequest("alice", "")
+	alice, err := s.Connect(context.Background(), req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	spectateReq := connectRequest("dave", "")
+	spectateReq.Spectate = true
+	spectator, err := s.Connect(context.Background(), spectateReq)
+	if err != nil {
+		t.Fatal(err)
+	}
+	aliceStream := startStream(t, s, alice.Token)
+	spectatorStream := startStream(t, s, spectator.Token)
+	flushChanges(t, game, aliceStream)
+	game.Mu.Lock()
+	game.MoveEntity(game.GetEntity(uuid.MustParse(req.Id)), backend.Coordinate{})
+	testutil.WithPlayerAt("bob", 20, 0)(game)
+	testutil.WithPlayerAt("carol", 2, 0)(game)
+	game.Mu.Unlock()
+
+	// The steps move players in order, in a game where alice is at the origin.
+	steps := []struct {
+		name     string
+		player   string
+		to       backend.Coordinate
+		teleport bool
+		want     []string
+	}{
+		{name: "distant moves aren't sent", player: "bob", to: backend.Coordinate{X: 21}, want: []string{}},
+		{name: "nearby moves are sent", player: "carol", to: backend.Coordinate{X: 3}, want: []string{"delta carol"}},
+		{name: "moves into range send the position", player: "bob", to: backend.Coordinate{X: 4}, teleport: true, want: []string{"update bob"}},
+		{name: "later moves are sent", player: "bob", to: backend.Coordinate{X: 5}, want: []string{"delta bob"}},
+		{name: "moves out of range are sent", player: "bob", to: backend.Coordinate{X: 6}, want: []string{"delta bob"}},
+		{name: "moves outside of range aren't sent", player: "bob", to: backend.Coordinate{X: 7}, want: []string{}},
+		{name: "moving catches up on missed moves", player: "alice", to: backend.Coordinate{X: 2}, teleport: true, want: []string{"delta alice", "update bob"}},
+	}
+	for i, step := range steps {
+		game.Mu.Lock()
+		player := testutil.Player(game, step.player)
+		from := player.Position()
+		game.MoveEntity(player, step.to)
+		game.Mu.Unlock()
+		game.ChangeChannel <- backend.MoveChange{
+			Entity:    player,
+			Direction: backend.DirectionRight,
+			Distance:  step.to.X - from.X,
+			Position:  step.to,
+			From:      from,
+			Teleport:  step.teleport,
+		}
+		marker := int32(100 + i)
+		game.ChangeChannel <- backend.SpectatorCountChange{Count: int(marker)}
+		if moves := movesUntil(t, game, aliceStream, marker); !reflect.DeepEqual(moves, step.want) {
+			t.Errorf("%s: alice was sent %v, want %v", step.name, moves, step.want)
+		}
+		// Spectators are sent every move.
+		if moves := movesUntil(t, game, spectatorStream, marker); !reflect.DeepEqual(moves, []string{"delta " + step.player}) {
+			t.Errorf("%s: the spectator was sent %v, want the move", step.name, moves)
+		}
+	}
+}
+
+func TestInterestRadiusEntities(t *testing.T) {
+	game := testutil.NewGame()
+	s := NewGameServer(game, "", 0)
+	s.SetInterestRadius(5)
+	req := connectRequest("alice", "")
+	alice, err := s.Connect(context.Background(), req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	aliceStream := startStream(t, s, alice.Token)
+	flushChanges(t, game, aliceStream)
+	game.Mu.Lock()
+	game.MoveEntity(game.GetEntity(uuid.MustParse(req.Id)), backend.Coordinate{})
+	game.Mu.Unlock()
+
+	names := map[string]string{req.Id: "alice"}
+	add := func(name string, entity backend.Identifier) backend.Change {
+		names[entity.ID().String()] = name
+		game.Mu.Lock()
+		game.AddEntity(entity)
+		game.Mu.Unlock()
+		return backend.AddEntityChange{Entity: entity}
+	}
+	remove := func(name string) backend.Change {
+		game.Mu.Lock()
+		defer game.Mu.Unlock()
+		for id, entityName := range names {
+			if entityName == name {
+				entity := game.GetEntity(uuid.MustParse(id))
+				game.RemoveEntity(entity.ID())
+				return backend.RemoveEntityChange{Entity: entity}
+			}
+		}
+		t.Fatalf("there is no %s", name)
+		return nil
+	}
+	laser := func(x, y int, direction backend.Direction) *backend.Laser {
+		return &backend.Laser{
+			IdentifierBase:  backend.IdentifierBase{UUID: uuid.New()},
+			InitialPosition: backend.Coordinate{X: x, Y: y},
+			Direction:       direction,
+			StartTime:       time.Now(),
+		}
+	}
+	moveAlice := func(to backend.Coordinate) backend.Change {
+		game.Mu.Lock()
+		defer game.Mu.Unlock()
+		player := testutil.Player(game, "alice")
+		from := player.Position()
+		game.MoveEntity(player, to)
+		return backend.MoveChange{Entity: player, Position: to, From: from, Teleport: true}
+	}
+
+	steps := []struct {
+		name   string
+		change func() backend.Change
+		want   []string
+	}{
+		{
+			name:   "distant lasers aren't added",
+			change: func() backend.Change { return add("receding laser", laser(20, 0, backend.DirectionRight)) },
+			want:   []string{},
+		},
+		{
+			name:   "lasers heading into range are added",
+			change: func() backend.Change { return add("incoming laser", laser(20, 0, backend.DirectionLeft)) },
+			want:   []string{"add incoming laser"},
+		},
+		{
+			name:   "lasers passing by at a distance aren't added",
+			change: func() backend.Change { return add("passing laser", laser(20, -20, backend.DirectionLeft)) },
+			want:   []string{},
+		},
+		{
+			name: "distant mines aren't added",
+			change: func() backend.Change {
+				return add("mine", &backend.Mine{
+					IdentifierBase:  backend.IdentifierBase{UUID: uuid.New()},
+					CurrentPosition: backend.Coordinate{Y: 20},
+				})
+			},
+			want: []string{},
+		},
+		{
+			name:   "distant entities aren't removed",
+			change: func() backend.Change { return remove("receding laser") },
+			want:   []string{},
+		},
+		{
+			name:   "moving catches up on missed entities",
+			change: func() backend.Change { return moveAlice(backend.Coordinate{Y: 18}) },
+			want:   []string{"delta alice", "remove receding laser", "update mine"},
+		},
+		{
+			name:   "nearby entities are removed",
+			change: func() backend.Change { return remove("mine") },
+			want:   []string{"remove mine"},
+		},
+	}
+	for i, step := range steps {
+		game.ChangeChannel <- step.change()
+		marker := int32(100 + i)
+		game.ChangeChannel <- backend.SpectatorCountChange{Count: int(marker)}
+		sent := []string{}
+		resp := aliceStream.waitForResponse(func(resp *proto.Response) bool {
+			switch {
+			case resp.GetMoveDelta() != nil:
+				sent = append(sent, "delta "+names[resp.GetMoveDelta().Id])
+			case resp.GetAddEntity() != nil:
+				sent = append(sent, "add "+names[resp.GetAddEntity().Entity.GetLaser().GetId()])
+			case resp.GetUpdateEntity() != nil:
+				sent = append(sent, "update "+names[resp.GetUpdateEntity().Entity.GetMine().GetId()])
+			case resp.GetRemoveEntity() != nil:
+				sent = append(sent, "remove "+names[resp.GetRemoveEntity().Id])
+			}
+			return resp.GetSpectatorCount() != nil && resp.GetSpectatorCount().Count == marker
+		})
+		if resp == nil {
+			t.Fatal("the marker was not sent")
+		}
+		sort.Strings(sent)
+		if !reflect.DeepEqual(sent, step.want) {
+			t.Errorf("%s: alice was sent %v, want %v", step.name, sent, step.want)
+		}
+	}
+}
@@ -0,0 +1,122 @@
+package server
+
+import (
+	"context"
+	"io"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/mortenson/grpc-game-example/pkg/backend"
+	"github.com/mortenson/grpc-game-example/proto"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// fakeStream is a server stream for a client with a token, which records the
+// responses sent to it.
+type fakeStream struct {
+	grpc.ServerStream
+	ctx       context.Context
+	requests  chan *proto.Request
+	responses chan *proto.Response
+	mu        sync.Mutex
+	closed    bool
+	// stateSync is the state sync sent when the stream started.
+	stateSync *proto.StateSync
+}
+
+func newFakeStream(ctx context.Context, token string) *fakeStream {
+	return &fakeStream{
+		ctx:       metadata.NewIncomingContext(ctx, metadata.Pairs("authorization", token)),
+		requests:  make(chan *proto.Request, 16),
+		responses: make(chan *proto.Response, 256),
+	}
+}
+
+func (stream *fakeStream) Context() context.Context {
+	return stream.ctx
+}
+
+func (stream *fakeStream) Send(resp *proto.Response) error {
+	select {
+	case stream.responses <- resp:
+	default:
+	}
+	return nil
+}
+
+func (stream *fakeStream) Recv() (*proto.Request, error) {
+	req, ok := <-stream.requests
+	if !ok {
+		return nil, io.EOF
+	}
+	return req, nil
+}
+
+// close makes Recv fail, as if the client went away.
+func (stream *fakeStream) close() {
+	stream.mu.Lock()
+	defer stream.mu.Unlock()
+	if !stream.closed {
+		stream.closed = true
+		close(stream.requests)
+	}
+}
+
+// waitForResponse waits for a response matching a predicate, skipping others.
+// Nil is returned if none was sent in time.
+func (stream *fakeStream) waitForResponse(match func(resp *proto.Response) bool) *proto.Response {
+	timeout := time.After(2 * time.Second)
+	for {
+		select {
+		case resp := <-stream.responses:
+			if match(resp) {
+				return resp
+			}
+		case <-timeout:
+			return nil
+		}
+	}
+}
+
+// startStream starts streaming to a client that connected with the given
+// token, until the test ends. It returns once the server sent the initial
+// state sync, so the client will be sent any later changes.
+func startStream(t *testing.T, s *GameServer, token string) *fakeStream {
+	t.Helper()
+	ctx, cancel := context.WithCancel(context.Background())
+	stream := newFakeStream(ctx, token)
+	done := make(chan struct{})
+	go func() {
+		s.Stream(stream)
+		close(done)
+	}()
+	t.Cleanup(func() {
+		cancel()
+		stream.close()
+		<-done
+	})
+	resp := stream.waitForResponse(func(resp *proto.Response) bool {
+		return resp.GetStateSync() != nil
+	})
+	if resp == nil {
+		t.Fatal("no state sync was sent")
+	}
+	stream.stateSync = resp.GetStateSync()
+	return stream
+}
+
+// flushChanges waits until the server handled every change sent so far, so
+// that tests can change entities the server may still be reading from
+// earlier changes.
+func flushChanges(t *testing.T, game *backend.Game, stream *fakeStream) {
+	t.Helper()
+	game.ChangeChannel <- backend.SpectatorCountChange{Count: -1}
+	resp := stream.waitForResponse(func(resp *proto.Response) bool {
+		return resp.GetSpectatorCount() != nil && resp.GetSpectatorCount().Count == -1
+	})
+	if resp == nil {
+		t.Fatal("the changes were not handled")
+	}
+}
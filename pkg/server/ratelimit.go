@@ -0,0 +1,43 @@
+package server
+
+import (
+	"time"
+)
+
+// tokenBucket limits how often a client can send messages. Tokens are added
+// at a steady rate up to a maximum burst, and each message uses one token.
+type tokenBucket struct {
+	rate   float64
+	burst  float64
+	tokens float64
+	last   time.Time
+}
+
+// newTokenBucket constructs a new tokenBucket struct, starting with a full
+// burst of tokens.
+func newTokenBucket(rate float64, burst int) *tokenBucket {
+	return &tokenBucket{
+		rate:   rate,
+		burst:  float64(burst),
+		tokens: float64(burst),
+		last:   time.Now(),
+	}
+}
+
+// allow checks if a message sent at the given time is within the limit, using
+// a token if it is.
+func (bucket *tokenBucket) allow(now time.Time) bool {
+	elapsed := now.Sub(bucket.last).Seconds()
+	if elapsed > 0 {
+		bucket.tokens += elapsed * bucket.rate
+		if bucket.tokens > bucket.burst {
+			bucket.tokens = bucket.burst
+		}
+		bucket.last = now
+	}
+	if bucket.tokens < 1 {
+		return false
+	}
+	bucket.tokens--
+	return true
+}
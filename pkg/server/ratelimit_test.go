@@ -0,0 +1,214 @@
+package server
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mortenson/grpc-game-example/pkg/backend"
+	"github.com/mortenson/grpc-game-example/proto"
+)
+
+func TestTokenBucket(t *testing.T) {
+	ms := time.Millisecond
+	tests := []struct {
+		name  string
+		rate  float64
+		burst int
+		// sent is when each message was sent, after the bucket was created.
+		sent    []time.Duration
+		allowed []bool
+	}{
+		{
+			name:    "burst",
+			rate:    1,
+			burst:   3,
+			sent:    []time.Duration{0, 0, 0, 0},
+			allowed: []bool{true, true, true, false},
+		},
+		{
+			name:    "steady rate",
+			rate:    10,
+			burst:   1,
+			sent:    []time.Duration{0, 100 * ms, 200 * ms, 300 * ms},
+			allowed: []bool{true, true, true, true},
+		},
+		{
+			name:    "too fast",
+			rate:    10,
+			burst:   1,
+			sent:    []time.Duration{0, 50 * ms, 100 * ms, 120 * ms, 200 * ms},
+			allowed: []bool{true, false, true, false, true},
+		},
+		{
+			name:    "tokens stop at the burst",
+			rate:    10,
+			burst:   2,
+			sent:    []time.Duration{10000 * ms, 10000 * ms, 10000 * ms},
+			allowed: []bool{true, true, false},
+		},
+		{
+			name:    "messages from the past",
+			rate:    10,
+			burst:   1,
+			sent:    []time.Duration{1000 * ms, 0, 1050 * ms},
+			allowed: []bool{true, false, false},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			bucket := newTokenBucket(test.rate, test.burst)
+			start := bucket.last
+			for i, sent := range test.sent {
+				if allowed := bucket.allow(start.Add(sent)); allowed != test.allowed[i] {
+					t.Errorf("message %d allowed is %v, want %v", i, allowed, test.allowed[i])
+				}
+			}
+		})
+	}
+}
+
+func TestStreamRateLimit(t *testing.T) {
+	move := func() *proto.Request {
+		return &proto.Request{Action: &proto.Request_Move{Move: &proto.Move{}}}
+	}
+	laser := func() *proto.Request {
+		return &proto.Request{Action: &proto.Request_Laser{Laser: &proto.Laser{Id: uuid.New().String()}}}
+	}
+	dash := func() *proto.Request {
+		return &proto.Request{Action: &proto.Request_Dash{Dash: &proto.Dash{}}}
+	}
+	heartbeat := func() *proto.Request {
+		return &proto.Request{Action: &proto.Request_Heartbeat{Heartbeat: &proto.Heartbeat{}}}
+	}
+	ping := func() *proto.Request {
+		return &proto.Request{Action: &proto.Request_Ping{Ping: &proto.Ping{}}}
+	}
+	tests := []struct {
+		name    string
+		rate    float64
+		burst   int
+		request func() *proto.Request
+		// actions is how many requests reached the game engine, and replies
+		// is how many requests the server replied to, besides heartbeats.
+		actions int
+		replies int
+	}{
+		{name: "no limit", rate: 0, burst: 0, request: move, actions: 5},
+		{name: "moves", rate: 0.001, burst: 2, request: move, actions: 2},
+		{name: "lasers", rate: 0.001, burst: 2, request: laser, actions: 2},
+		{name: "dashes", rate: 0.001, burst: 2, request: dash, actions: 2},
+		{name: "pings", rate: 0.001, burst: 2, request: ping, replies: 2},
+		{name: "heartbeats aren't limited", rate: 0.001, burst: 2, request: heartbeat},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			s := newTestServer(t, "")
+			s.SetRateLimit(test.rate, test.burst)
+			// The game isn't started, so actions stay in the channel.
+			game := s.defaultRoom.Game
+			game.ActionChannel = make(chan backend.Action, 5)
+			resp, err := s.Connect(context.Background(), connectRequest("alice", ""))
+			if err != nil {
+				t.Fatal(err)
+			}
+			stream := startStream(t, s, resp.Token)
+			for i := 0; i < 5; i++ {
+				stream.requests <- test.request()
+			}
+			// Requests are handled in order, so the last heartbeat is echoed
+			// last.
+			stream.requests <- heartbeat()
+			heartbeats := 1
+			if test.request().GetHeartbeat() != nil {
+				heartbeats += 5
+			}
+			replies := 0
+			last := stream.waitForResponse(func(resp *proto.Response) bool {
+				if resp.GetHeartbeat() == nil {
+					replies++
+					return false
+				}
+				heartbeats--
+				return heartbeats == 0
+			})
+			if last == nil {
+				t.Fatal("a heartbeat was rate limited")
+			}
+			if actions := len(game.ActionChannel); actions != test.actions {
+				t.Errorf("%d actions reached the game, want %d", actions, test.actions)
+			}
+			if replies != test.replies {
+				t.Errorf("%d messages were replied to, want %d", replies, test.replies)
+			}
+		})
+	}
+}
+
+func TestStateSyncRequests(t *testing.T) {
+	tests := []struct {
+		name  string
+		rate  float64
+		burst int
+		// lock holds the game's lock while the requests are sent, so that
+		// state syncs can't be sent until every request was received.
+		lock bool
+		// min and max are how many state syncs may be sent for five requests.
+		min, max int
+	}{
+		{name: "pending requests are collapsed", lock: true, min: 1, max: 2},
+		{name: "requests are rate limited", rate: 0.001, burst: 1, min: 1, max: 1},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			s := newTestServer(t, "")
+			s.SetRateLimit(test.rate, test.burst)
+			game := s.defaultRoom.Game
+			resp, err := s.Connect(context.Background(), connectRequest("alice", ""))
+			if err != nil {
+				t.Fatal(err)
+			}
+			stream := startStream(t, s, resp.Token)
+			if test.lock {
+				game.Mu.Lock()
+			}
+			for i := 0; i < 5; i++ {
+				stream.requests <- &proto.Request{
+					Action: &proto.Request_StateSyncRequest{StateSyncRequest: &proto.StateSyncRequest{}},
+				}
+			}
+			stream.requests <- &proto.Request{
+				Action: &proto.Request_Heartbeat{Heartbeat: &proto.Heartbeat{}},
+			}
+			stateSyncs := 0
+			heartbeat := stream.waitForResponse(func(resp *proto.Response) bool {
+				if resp.GetStateSync() != nil {
+					stateSyncs++
+				}
+				return resp.GetHeartbeat() != nil
+			})
+			if test.lock {
+				game.Mu.Unlock()
+			}
+			if heartbeat == nil {
+				t.Fatal("the requests were not received")
+			}
+			// State syncs are sent separately from replies to other requests.
+			timeout := time.After(100 * time.Millisecond)
+			for waiting := true; waiting; {
+				select {
+				case resp := <-stream.responses:
+					if resp.GetStateSync() != nil {
+						stateSyncs++
+					}
+				case <-timeout:
+					waiting = false
+				}
+			}
+			if stateSyncs < test.min || stateSyncs > test.max {
+				t.Errorf("sent %d state syncs, want %d to %d", stateSyncs, test.min, test.max)
+			}
+		})
+	}
+}
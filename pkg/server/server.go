@@ -4,16 +4,17 @@ import (
 	"context"
 	"errors"
 	"log"
-	"math/rand"
-	"regexp"
-	"strings"
 	"sync"
 	"time"
-	"unicode/utf8"
 
 	"github.com/golang/protobuf/ptypes"
 	"github.com/google/uuid"
+	"golang.org/x/crypto/bcrypt"
+	"google.golang.org/grpc/codes"
+	// Registers the gzip compressor, so clients can choose to use it.
+	_ "google.golang.org/grpc/encoding/gzip"
 	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
 
 	"github.com/mortenson/grpc-game-example/pkg/backend"
 	"github.com/mortenson/grpc-game-example/proto"
@@ -21,7 +22,10 @@ import (
 
 const (
 	clientTimeout = 15
+	// maxClients is how many players' clients can be connected, and
+	// maxSpectators is how many spectators can watch on top of them.
 	maxClients    = 8
+	maxSpectators = 8
 )
 
 // client contains information about connected clients.
@@ -31,48 +35,106 @@ type client struct {
 	done         chan error
 	playerID     uuid.UUID
 	id           uuid.UUID
+	game         *backend.Game
+	// Spectators receive changes, but have no player and can't act.
+	spectator bool
+	// missed are the entities whose moves weren't sent to the client because
+	// they were outside of the client's area of interest.
+	missed map[uuid.UUID]bool
+	// reconnectToken identifies the player's session, and is uuid.Nil for
+	// spectators.
+	reconnectToken uuid.UUID
+}
+
+// stop ends a client's stream, with nil if it ended on purpose. Only the first
+// reason is kept, and it never blocks, so it can be called with locks held.
+func (c *client) stop(err error) {
+	select {
+	case c.done <- err:
+	default:
+	}
 }
 
 // GameServer is used to stream game information with clients.
 type GameServer struct {
 	proto.UnimplementedGameServer
-	game     *backend.Game
-	clients  map[uuid.UUID]*client
-	mu       sync.RWMutex
-	password string
+	rooms        *backend.RoomManager
+	defaultRoom  *backend.Room
+	clients      map[uuid.UUID]*client
+	mu           sync.RWMutex
+	passwordHash []byte
+	// Clients sending more than messageRate messages per second, after an
+	// initial burst, have them dropped. Zero disables the limit.
+	messageRate  float64
+	messageBurst int
+	// When broadcastInterval is set, changes are batched and broadcast once
+	// per interval.
+	broadcastInterval time.Duration
+	// Clients are only sent moves of entities within interestRadius of their
+	// player. Zero sends every move.
+	interestRadius int
+	// sessions are keyed by reconnect token. Players stay in the game for
+	// reconnectWindow after losing their connection.
+	sessions        map[uuid.UUID]*session
+	reconnectWindow time.Duration
 }
 
-// NewGameServer constructs a new game server struct.
-func NewGameServer(game *backend.Game, password string) *GameServer {
+// NewGameServer constructs a new game server struct. The password hash is a
+// bcrypt hash of the server password - if empty, no password is required.
+// The game is used for the default room, which clients join when they don't
+// pick a room. If the broadcast interval is zero, changes are broadcast as
+// soon as they happen, otherwise they are batched and broadcast at a steady
+// rate.
+func NewGameServer(game *backend.Game, passwordHash string, broadcastInterval time.Duration) *GameServer {
 	server := &GameServer{
-		game:     game,
-		clients:  make(map[uuid.UUID]*client),
-		password: password,
+		rooms:             backend.NewRoomManager(),
+		clients:           make(map[uuid.UUID]*client),
+		passwordHash:      []byte(passwordHash),
+		broadcastInterval: broadcastInterval,
+		sessions:          make(map[uuid.UUID]*session),
+		reconnectWindow:   defaultReconnectWindow,
 	}
-	server.watchChanges()
+	server.defaultRoom = server.AddRoom("Default", game)
 	server.watchTimeout()
+	server.watchSuspiciousPlayers()
 	return server
 }
 
+// AddRoom adds another game that clients can join. The game should already
+// be started.
+func (s *GameServer) AddRoom(name string, game *backend.Game) *backend.Room {
+	room := s.rooms.AddRoom(name, game)
+	s.watchChanges(game)
+	return room
+}
+
+// SetRateLimit limits how many messages per second each client can send,
+// allowing short bursts of up to burst messages. Messages over the limit are
+// dropped before they are handled. Heartbeats are never limited, so that
+// limited clients aren't disconnected. A rate of zero disables the limit. This
+// should be called before serving.
+func (s *GameServer) SetRateLimit(rate float64, burst int) {
+	s.messageRate = rate
+	s.messageBurst = burst
+}
+
 func (s *GameServer) removeClient(id uuid.UUID) {
 	s.mu.Lock()
 	delete(s.clients, id)
 	s.mu.Unlock()
 }
 
-func (s *GameServer) removePlayer(playerID uuid.UUID) {
-	s.game.Mu.Lock()
-	s.game.RemoveEntity(playerID)
-	s.game.Mu.Unlock()
+// removePlayer removes a player and their lasers, informing all clients.
+func (s *GameServer) removePlayer(game *backend.Game, playerID uuid.UUID) {
+	game.Mu.Lock()
+	removed := game.RemovePlayerAndOwned(playerID)
+	game.Mu.Unlock()
 
-	resp := proto.Response{
-		Action: &proto.Response_RemoveEntity{
-			RemoveEntity: &proto.RemoveEntity{
-				Id: playerID.String(),
-			},
-		},
+	for _, entity := range removed {
+		s.handleRemoveEntityChange(game, backend.RemoveEntityChange{
+			Entity: entity,
+		})
 	}
-	s.broadcast(&resp)
 }
 
 func (s *GameServer) getClientFromContext(ctx context.Context) (*client, error) {
@@ -101,30 +163,88 @@ func (s *GameServer) Stream(srv proto.Game_StreamServer) error {
 	if err != nil {
 		return err
 	}
+	s.mu.Lock()
 	if currentClient.streamServer != nil {
+		s.mu.Unlock()
 		return errors.New("stream already active")
 	}
 	currentClient.streamServer = srv
+	s.mu.Unlock()
+	if currentClient.spectator {
+		currentClient.game.Mu.Lock()
+		currentClient.game.AddSpectator()
+		currentClient.game.Mu.Unlock()
+	}
+
+	// Changes that happened between connecting and starting the stream were
+	// not sent to the client, so send the full state.
+	s.sendStateSync(currentClient)
 
 	log.Println("start new server")
 
+	var limiter *tokenBucket
+	if s.messageRate > 0 {
+		limiter = newTokenBucket(s.messageRate, s.messageBurst)
+	}
+
+	// State syncs are expensive, so they are sent from their own goroutine
+	// and requests made while one is pending are collapsed into it.
+	stateSyncs := make(chan struct{}, 1)
+	streamDone := make(chan struct{})
+	defer close(streamDone)
+	go func() {
+		for {
+			select {
+			case <-stateSyncs:
+				s.sendStateSync(currentClient)
+			case <-streamDone:
+				return
+			}
+		}
+	}()
+
 	// Wait for stream requests.
 	go func() {
 		for {
 			req, err := srv.Recv()
 			if err != nil {
 				log.Printf("receive error %v", err)
-				currentClient.done <- errors.New("failed to receive request")
+				currentClient.stop(errors.New("failed to receive request"))
 				return
 			}
 			log.Printf("got message %+v", req)
+			s.mu.Lock()
 			currentClient.lastMessage = time.Now()
+			s.mu.Unlock()
+			switch req.GetAction().(type) {
+			case *proto.Request_Heartbeat:
+				// Heartbeats keep the stream alive, so they are never limited.
+				s.handleHeartbeatRequest(currentClient)
+				continue
+			case *proto.Request_Disconnect:
+				// The client is leaving, so end the stream right away.
+				currentClient.stop(nil)
+				return
+			}
+			if limiter != nil && !limiter.allow(time.Now()) {
+				log.Printf("%s - rate limited, dropping message", currentClient.id)
+				continue
+			}
 
 			switch req.GetAction().(type) {
-			case *proto.Request_Move:
-				s.handleMoveRequest(req, currentClient)
-			case *proto.Request_Laser:
-				s.handleLaserRequest(req, currentClient)
+			case *proto.Request_Ping:
+				s.handlePingRequest(req, currentClient)
+			case *proto.Request_StateSyncRequest:
+				select {
+				case stateSyncs <- struct{}{}:
+				default:
+				}
+			default:
+				// Spectators can measure latency and ask for state syncs, but
+				// can't act.
+				if !currentClient.spectator {
+					s.handleActionRequest(req, currentClient)
+				}
 			}
 		}
 	}()
@@ -140,13 +260,88 @@ func (s *GameServer) Stream(srv proto.Game_StreamServer) error {
 
 	log.Printf("%s - removing client", currentClient.id)
 	s.removeClient(currentClient.id)
-	s.removePlayer(currentClient.playerID)
+	if currentClient.spectator {
+		currentClient.game.Mu.Lock()
+		currentClient.game.RemoveSpectator()
+		currentClient.game.Mu.Unlock()
+	} else {
+		// Streams only end without an error when the client disconnects on
+		// purpose.
+		s.detachPlayer(currentClient, doneError == nil)
+	}
 
 	return doneError
 }
 
+// checkPassword compares a password provided by a client with the server's
+// password hash.
+func (s *GameServer) checkPassword(password string) bool {
+	if len(s.passwordHash) == 0 {
+		return true
+	}
+	return bcrypt.CompareHashAndPassword(s.passwordHash, []byte(password)) == nil
+}
+
+// Connect adds a player to the default room.
 func (s *GameServer) Connect(ctx context.Context, req *proto.ConnectRequest) (*proto.ConnectResponse, error) {
-	if len(s.clients) >= maxClients {
+	return s.connect(s.defaultRoom.Game, req)
+}
+
+// JoinRoom adds a player to the given room.
+func (s *GameServer) JoinRoom(ctx context.Context, req *proto.JoinRoomRequest) (*proto.ConnectResponse, error) {
+	room, err := s.getRoom(req.RoomId)
+	if err != nil {
+		return nil, err
+	}
+	if req.Connect == nil {
+		return nil, errors.New("no connect request provided")
+	}
+	return s.connect(room.Game, req.Connect)
+}
+
+// ListRooms returns every room and how many players are in it.
+func (s *GameServer) ListRooms(ctx context.Context, req *proto.ListRoomsRequest) (*proto.ListRoomsResponse, error) {
+	rooms := s.rooms.Rooms()
+	protoRooms := make([]*proto.Room, 0, len(rooms))
+	for _, room := range rooms {
+		room.Game.Mu.RLock()
+		players := room.Game.CountPlayers()
+		room.Game.Mu.RUnlock()
+		protoRooms = append(protoRooms, &proto.Room{
+			Id:      room.ID.String(),
+			Name:    room.Name,
+			Players: int32(players),
+		})
+	}
+	return &proto.ListRoomsResponse{
+		Rooms: protoRooms,
+	}, nil
+}
+
+// getRoom finds a room by ID, falling back to the default room if no ID is
+// provided.
+func (s *GameServer) getRoom(roomID string) (*backend.Room, error) {
+	if roomID == "" {
+		return s.defaultRoom, nil
+	}
+	id, err := uuid.Parse(roomID)
+	if err != nil {
+		return nil, errors.New("cannot parse room ID")
+	}
+	room := s.rooms.GetRoom(id)
+	if room == nil {
+		return nil, status.Error(codes.NotFound, "room not found")
+	}
+	return room, nil
+}
+
+// connect adds a player to a game.
+func (s *GameServer) connect(game *backend.Game, req *proto.ConnectRequest) (*proto.ConnectResponse, error) {
+	if req.Spectate {
+		if s.countClients(true) >= maxSpectators {
+			return nil, errors.New("The server has too many spectators")
+		}
+	} else if s.countClients(false) >= maxClients {
 		return nil, errors.New("The server is full")
 	}
 
@@ -156,132 +351,337 @@ func (s *GameServer) Connect(ctx context.Context, req *proto.ConnectRequest) (*p
 	}
 
 	// Exit as early as possible if password is wrong.
-	if req.Password != s.password {
-		return nil, errors.New("invalid password provided")
+	if !s.checkPassword(req.Password) {
+		return nil, status.Error(codes.Unauthenticated, "invalid password provided")
+	}
+
+	// Clients that lost their connection can resume their player.
+	if !req.Spectate && req.ReconnectToken != "" {
+		if resp, ok := s.resumeSession(game, playerID, req.ReconnectToken); ok {
+			s.addCareer(game, playerID, resp)
+			return resp, nil
+		}
 	}
 
 	// Check if player already exists.
-	s.game.Mu.RLock()
-	if s.game.GetEntity(playerID) != nil {
+	game.Mu.RLock()
+	if game.GetEntity(playerID) != nil {
+		game.Mu.RUnlock()
 		return nil, errors.New("duplicate player ID provided")
 	}
-	s.game.Mu.RUnlock()
+	game.Mu.RUnlock()
 
-	re := regexp.MustCompile("^[a-zA-Z0-9]+$")
-	if !re.MatchString(req.Name) {
-		return nil, errors.New("invalid name provided")
+	// Spectators don't need a player.
+	if req.Spectate {
+		return s.addClient(game, playerID, true, uuid.Nil), nil
 	}
-	icon, _ := utf8.DecodeRuneInString(strings.ToUpper(req.Name))
-
-	// Choose a random spawn point.
-	spawnPoints := s.game.GetMapByType()[backend.MapTypeSpawn]
-	rand.Seed(time.Now().Unix())
-	i := rand.Int() % len(spawnPoints)
-	startCoordinate := spawnPoints[i]
 
 	// Add the player.
-	player := &backend.Player{
-		Name:            req.Name,
-		Icon:            icon,
-		IdentifierBase:  backend.IdentifierBase{UUID: playerID},
-		CurrentPosition: startCoordinate,
-	}
-	s.game.Mu.Lock()
-	s.game.AddEntity(player)
-	s.game.Mu.Unlock()
-
-	// Build a slice of current entities.
-	s.game.Mu.RLock()
-	entities := make([]*proto.Entity, 0)
-	for _, entity := range s.game.Entities {
-		protoEntity := proto.GetProtoEntity(entity)
-		if protoEntity != nil {
-			entities = append(entities, protoEntity)
-		}
+	action := backend.ConnectAction{
+		PlayerID: playerID,
+		Name:     req.Name,
+		Color:    req.Color,
 	}
-	s.game.Mu.RUnlock()
-
-	// Inform all other clients of the new player.
-	resp := proto.Response{
+	game.Mu.Lock()
+	if err := action.Validate(game); err != nil {
+		game.Mu.Unlock()
+		return nil, err
+	}
+	player := action.AddPlayer(game)
+	addResp := proto.Response{
 		Action: &proto.Response_AddEntity{
 			AddEntity: &proto.AddEntity{
 				Entity: proto.GetProtoEntity(player),
 			},
 		},
 	}
-	s.broadcast(&resp)
+	game.Mu.Unlock()
+
+	// Inform all other clients of the new player directly, since the change
+	// channel drops changes when it's full.
+	s.broadcast(game, &addResp)
+
+	resp := s.addClient(game, playerID, false, s.startSession(game, playerID))
+	s.addCareer(game, playerID, resp)
+	return resp, nil
+}
+
+// countClients returns how many spectators' or players' clients are connected.
+func (s *GameServer) countClients(spectator bool) int {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	count := 0
+	for _, client := range s.clients {
+		if client.spectator == spectator {
+			count++
+		}
+	}
+	return count
+}
+
+// addCareer adds a player's career stats to their connect response, if the
+// game keeps career stats. Stats are kept by name.
+func (s *GameServer) addCareer(game *backend.Game, playerID uuid.UUID, resp *proto.ConnectResponse) {
+	if game.StatsStore == nil {
+		return
+	}
+	game.Mu.RLock()
+	player, ok := game.GetEntity(playerID).(*backend.Player)
+	var name string
+	if ok {
+		name = player.Name
+	}
+	game.Mu.RUnlock()
+	if !ok {
+		return
+	}
+	career, err := game.StatsStore.Load(name)
+	if err != nil {
+		log.Printf("failed to load career stats for %s: %v", name, err)
+		return
+	}
+	resp.Career = proto.GetProtoCareerStats(career)
+}
+
+// addClient adds a new client, and builds a connect response that contains
+// the client's token and the current entities. Players' clients are attached
+// to the session with the reconnect token, which is included in the response.
+func (s *GameServer) addClient(game *backend.Game, playerID uuid.UUID, spectator bool, reconnectToken uuid.UUID) *proto.ConnectResponse {
+	entities := s.getProtoEntities(game)
 
 	// Add the new client.
 	s.mu.Lock()
 	token := uuid.New()
-	s.clients[token] = &client{
-		id:          token,
-		playerID:    playerID,
-		done:        make(chan error),
-		lastMessage: time.Now(),
+	newClient := &client{
+		id:             token,
+		playerID:       playerID,
+		done:           make(chan error, 1),
+		lastMessage:    time.Now(),
+		spectator:      spectator,
+		game:           game,
+		missed:         make(map[uuid.UUID]bool),
+		reconnectToken: reconnectToken,
+	}
+	s.clients[token] = newClient
+	if currentSession, ok := s.sessions[reconnectToken]; ok {
+		currentSession.client = newClient
 	}
 	s.mu.Unlock()
 
-	return &proto.ConnectResponse{
-		Token:    token.String(),
-		Entities: entities,
+	resp := &proto.ConnectResponse{
+		Token:            token.String(),
+		Entities:         entities,
+		DefaultDirection: proto.GetProtoDirection(game.DefaultDirection),
+		Modifier:         game.Modifier,
+		Phase:            proto.GetProtoPhase(game.Phase),
+		TeamColors:       game.TeamColors,
+		SpeedMultiplier:  game.SpeedMultiplier,
+	}
+	game.Mu.RLock()
+	resp.MoveThrottleMillis = uint32(game.MoveThrottle().Milliseconds())
+	game.Mu.RUnlock()
+	if reconnectToken != uuid.Nil {
+		resp.ReconnectToken = reconnectToken.String()
+	}
+	return resp
+}
+
+// GetLeaderboard returns the score of every player in a room, highest first.
+func (s *GameServer) GetLeaderboard(ctx context.Context, req *proto.LeaderboardRequest) (*proto.LeaderboardResponse, error) {
+	room, err := s.getRoom(req.RoomId)
+	if err != nil {
+		return nil, err
+	}
+	room.Game.Mu.RLock()
+	leaderboard := room.Game.Leaderboard()
+	room.Game.Mu.RUnlock()
+	entries := make([]*proto.LeaderboardEntry, 0, len(leaderboard))
+	for _, entry := range leaderboard {
+		entries = append(entries, proto.GetProtoLeaderboardEntry(entry))
+	}
+	return &proto.LeaderboardResponse{
+		Entries: entries,
 	}, nil
 }
 
+// getProtoEntities builds a slice of current entities. The map's cells are
+// left out, as clients already have them.
+func (s *GameServer) getProtoEntities(game *backend.Game) []*proto.Entity {
+	game.Mu.RLock()
+	defer game.Mu.RUnlock()
+	entities := make([]*proto.Entity, 0)
+	for _, entity := range game.DynamicSnapshot() {
+		protoEntity := proto.GetProtoEntity(entity)
+		if protoEntity != nil {
+			entities = append(entities, protoEntity)
+		}
+	}
+	return entities
+}
+
+// sendStateSync sends all current entities to a client.
+func (s *GameServer) sendStateSync(currentClient *client) {
+	resp := proto.Response{
+		Action: &proto.Response_StateSync{
+			StateSync: &proto.StateSync{
+				Entities: s.getProtoEntities(currentClient.game),
+			},
+		},
+	}
+	// Lock to avoid sending at the same time as broadcasts.
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err := currentClient.streamServer.Send(&resp); err != nil {
+		log.Printf("%s - state sync error %v", currentClient.id, err)
+	}
+}
+
 func (s *GameServer) watchTimeout() {
 	timeoutTicker := time.NewTicker(1 * time.Minute)
 	go func() {
 		for {
+			// Find timed out clients with the lock held, since streams update
+			// when clients last sent a message.
+			timedOut := make([]*client, 0)
+			s.mu.RLock()
 			for _, client := range s.clients {
+				// Spectators never send messages, so can't time out.
+				if client.spectator {
+					continue
+				}
 				if time.Now().Sub(client.lastMessage).Minutes() > clientTimeout {
-					client.done <- errors.New("you have been timed out")
-					return
+					timedOut = append(timedOut, client)
 				}
 			}
+			s.mu.RUnlock()
+			for _, client := range timedOut {
+				client.stop(errors.New("you have been timed out"))
+			}
 			<-timeoutTicker.C
 		}
 	}()
 }
 
+// watchSuspiciousPlayers periodically logs players who are firing faster than
+// allowed, so that operators can review them.
+func (s *GameServer) watchSuspiciousPlayers() {
+	ticker := time.NewTicker(1 * time.Minute)
+	go func() {
+		for {
+			<-ticker.C
+			for _, room := range s.rooms.Rooms() {
+				room.Game.Mu.RLock()
+				suspicious := room.Game.SuspiciousPlayers()
+				room.Game.Mu.RUnlock()
+				for _, playerID := range suspicious {
+					log.Printf("%s - suspicious fire rate in room %s", playerID, room.Name)
+				}
+			}
+		}
+	}()
+}
+
 // WatchChanges waits for new game engine changes and broadcasts to clients.
-func (s *GameServer) watchChanges() {
+func (s *GameServer) watchChanges(game *backend.Game) {
+	if s.broadcastInterval > 0 {
+		s.watchChangesTicked(game)
+		return
+	}
 	go func() {
 		for {
-			change := <-s.game.ChangeChannel
-			switch change.(type) {
-			case backend.MoveChange:
-				change := change.(backend.MoveChange)
-				s.handleMoveChange(change)
-			case backend.AddEntityChange:
-				change := change.(backend.AddEntityChange)
-				s.handleAddEntityChange(change)
-			case backend.RemoveEntityChange:
-				change := change.(backend.RemoveEntityChange)
-				s.handleRemoveEntityChange(change)
-			case backend.PlayerRespawnChange:
-				change := change.(backend.PlayerRespawnChange)
-				s.handlePlayerRespawnChange(change)
-			case backend.RoundOverChange:
-				change := change.(backend.RoundOverChange)
-				s.handleRoundOverChange(change)
-			case backend.RoundStartChange:
-				change := change.(backend.RoundStartChange)
-				s.handleRoundStartChange(change)
+			change := <-game.ChangeChannel
+			s.handleChange(game, change)
+		}
+	}()
+}
+
+// watchChangesTicked batches changes and broadcasts them once per broadcast
+// interval, coalescing moves to reduce redundant updates.
+func (s *GameServer) watchChangesTicked(game *backend.Game) {
+	go func() {
+		ticker := time.NewTicker(s.broadcastInterval)
+		batch := newChangeBatch()
+		for {
+			select {
+			case change := <-game.ChangeChannel:
+				batch.add(change)
+			case <-ticker.C:
+				for _, change := range batch.flush() {
+					if move, ok := change.(*coalescedMove); ok {
+						s.sendMoveDelta(game, move.change, move.dx, move.dy, move.moves, move.teleport)
+						continue
+					}
+					s.handleChange(game, change)
+				}
 			}
 		}
 	}()
 }
 
-// broadcast sends a response to all clients.
-func (s *GameServer) broadcast(resp *proto.Response) {
+// handleChange broadcasts a game engine change.
+func (s *GameServer) handleChange(game *backend.Game, change backend.Change) {
+	switch change.(type) {
+	case backend.MoveChange:
+		change := change.(backend.MoveChange)
+		s.handleMoveChange(game, change)
+	case backend.AddEntityChange:
+		change := change.(backend.AddEntityChange)
+		s.handleAddEntityChange(game, change)
+	case backend.RemoveEntityChange:
+		change := change.(backend.RemoveEntityChange)
+		s.handleRemoveEntityChange(game, change)
+	case backend.PlayerRespawnChange:
+		change := change.(backend.PlayerRespawnChange)
+		s.handlePlayerRespawnChange(game, change)
+	case backend.RoundOverChange:
+		change := change.(backend.RoundOverChange)
+		s.handleRoundOverChange(game, change)
+	case backend.RoundStartChange:
+		change := change.(backend.RoundStartChange)
+		s.handleRoundStartChange(game, change)
+	case backend.DamageChange:
+		change := change.(backend.DamageChange)
+		s.handleDamageChange(game, change)
+	case backend.ScoreChange:
+		change := change.(backend.ScoreChange)
+		s.handleScoreChange(game, change)
+	case backend.StatusChange:
+		change := change.(backend.StatusChange)
+		s.handleStatusChange(game, change)
+	case backend.WaveChange:
+		change := change.(backend.WaveChange)
+		s.handleWaveChange(game, change)
+	case backend.WeaponChange:
+		change := change.(backend.WeaponChange)
+		s.handleWeaponChange(game, change)
+	case backend.ModifierChange:
+		change := change.(backend.ModifierChange)
+		s.handleModifierChange(game, change)
+	case backend.PhaseChange:
+		change := change.(backend.PhaseChange)
+		s.handlePhaseChange(game, change)
+	case backend.ReadyChange:
+		change := change.(backend.ReadyChange)
+		s.broadcast(game, statusResponse(game, change.Player))
+	case backend.SpectatorCountChange:
+		change := change.(backend.SpectatorCountChange)
+		s.handleSpectatorCountChange(game, change)
+	case backend.ExplosionChange:
+		change := change.(backend.ExplosionChange)
+		s.handleExplosionChange(game, change)
+	}
+}
+
+// broadcast sends a response to all clients in a game.
+func (s *GameServer) broadcast(game *backend.Game, resp *proto.Response) {
 	s.mu.Lock()
 	for id, currentClient := range s.clients {
-		if currentClient.streamServer == nil {
+		if currentClient.streamServer == nil || currentClient.game != game {
 			continue
 		}
 		if err := currentClient.streamServer.Send(resp); err != nil {
 			log.Printf("%s - broadcast error %v", id, err)
-			currentClient.done <- errors.New("failed to broadcast message")
+			currentClient.stop(errors.New("failed to broadcast message"))
 			continue
 		}
 		log.Printf("%s - broadcasted %+v", resp, id)
@@ -289,13 +689,110 @@ func (s *GameServer) broadcast(resp *proto.Response) {
 	s.mu.Unlock()
 }
 
+// handlePingRequest echoes a ping back to the client that sent it.
+func (s *GameServer) handlePingRequest(req *proto.Request, currentClient *client) {
+	resp := proto.Response{
+		Action: &proto.Response_Pong{
+			Pong: &proto.Pong{
+				Sent: req.GetPing().Sent,
+			},
+		},
+	}
+	// Lock to avoid sending at the same time as broadcasts.
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err := currentClient.streamServer.Send(&resp); err != nil {
+		log.Printf("%s - pong error %v", currentClient.id, err)
+	}
+}
+
+// handleHeartbeatRequest echoes a heartbeat back to the client that sent it,
+// letting the client know the stream is still alive.
+func (s *GameServer) handleHeartbeatRequest(currentClient *client) {
+	resp := proto.Response{
+		Action: &proto.Response_Heartbeat{
+			Heartbeat: &proto.Heartbeat{},
+		},
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err := currentClient.streamServer.Send(&resp); err != nil {
+		log.Printf("%s - heartbeat error %v", currentClient.id, err)
+	}
+}
+
+// handleActionRequest passes a request from a player to the game engine.
+func (s *GameServer) handleActionRequest(req *proto.Request, currentClient *client) {
+	switch req.GetAction().(type) {
+	case *proto.Request_Move:
+		s.handleMoveRequest(req, currentClient)
+	case *proto.Request_Laser:
+		s.handleLaserRequest(req, currentClient)
+	case *proto.Request_Dash:
+		s.handleDashRequest(req, currentClient)
+	case *proto.Request_SwitchWeapon:
+		s.handleSwitchWeaponRequest(currentClient)
+	case *proto.Request_Ready:
+		s.handleReadyRequest(currentClient)
+	case *proto.Request_Deploy:
+		s.handleDeployRequest(req, currentClient)
+	}
+}
+
 // handleMoveRequest makes a request to the game engine to move a player.
 func (s *GameServer) handleMoveRequest(req *proto.Request, currentClient *client) {
 	move := req.GetMove()
-	s.game.ActionChannel <- backend.MoveAction{
+	currentClient.game.ActionChannel <- backend.MoveAction{
 		ID:        currentClient.playerID,
 		Direction: proto.GetBackendDirection(move.Direction),
 		Created:   time.Now(),
+		Sequence:  move.Sequence,
+	}
+}
+
+// handleDashRequest makes a request to the game engine to dash a player.
+func (s *GameServer) handleDashRequest(req *proto.Request, currentClient *client) {
+	currentClient.game.ActionChannel <- backend.DashAction{
+		PlayerID: currentClient.playerID,
+		Created:  time.Now(),
+		Sequence: req.GetDash().Sequence,
+	}
+}
+
+// handleSwitchWeaponRequest makes a request to the game engine to switch a
+// player's weapon.
+func (s *GameServer) handleSwitchWeaponRequest(currentClient *client) {
+	currentClient.game.ActionChannel <- backend.SwitchWeaponAction{
+		PlayerID: currentClient.playerID,
+	}
+}
+
+// handleReadyRequest makes a request to the game engine to ready a player up.
+func (s *GameServer) handleReadyRequest(currentClient *client) {
+	currentClient.game.ActionChannel <- backend.ReadyAction{
+		PlayerID: currentClient.playerID,
+	}
+}
+
+// handleDeployRequest makes a request to the game engine to place a mine
+// under a player.
+func (s *GameServer) handleDeployRequest(req *proto.Request, currentClient *client) {
+	id, err := uuid.Parse(req.GetDeploy().Id)
+	if err != nil {
+		currentClient.stop(errors.New("invalid mine ID provided"))
+		return
+	}
+	game := currentClient.game
+	game.Mu.RLock()
+	if game.GetEntity(id) != nil {
+		game.Mu.RUnlock()
+		currentClient.stop(errors.New("duplicate mine ID provided"))
+		return
+	}
+	game.Mu.RUnlock()
+	game.ActionChannel <- backend.DeployAction{
+		ID:       id,
+		PlayerID: currentClient.playerID,
 	}
 }
 
@@ -303,35 +800,57 @@ func (s *GameServer) handleLaserRequest(req *proto.Request, currentClient *clien
 	laser := req.GetLaser()
 	id, err := uuid.Parse(laser.Id)
 	if err != nil {
-		currentClient.done <- errors.New("invalid laser ID provided")
+		currentClient.stop(errors.New("invalid laser ID provided"))
 		return
 	}
-	s.game.Mu.RLock()
-	if s.game.GetEntity(id) != nil {
-		currentClient.done <- errors.New("duplicate laser ID provided")
+	game := currentClient.game
+	game.Mu.RLock()
+	if game.GetEntity(id) != nil {
+		game.Mu.RUnlock()
+		currentClient.stop(errors.New("duplicate laser ID provided"))
 		return
 	}
-	s.game.Mu.RUnlock()
-	s.game.ActionChannel <- backend.LaserAction{
+	game.Mu.RUnlock()
+	game.ActionChannel <- backend.LaserAction{
 		OwnerID:   currentClient.playerID,
 		ID:        id,
 		Direction: proto.GetBackendDirection(laser.Direction),
 		Created:   time.Now(),
+		Piercing:  laser.Piercing,
+		Swap:      laser.Swap,
 	}
 }
 
-func (s *GameServer) handleMoveChange(change backend.MoveChange) {
+// handleMoveChange sends clients how far an entity moved, rather than its
+// new position. Deltas are numbered with the engine's move sequence, so that
+// clients can detect when one was missed, even if the engine dropped it.
+func (s *GameServer) handleMoveChange(game *backend.Game, change backend.MoveChange) {
+	dx, dy := moveDelta(change)
+	s.sendMoveDelta(game, change, dx, dy, 1, change.Teleport)
+}
+
+// sendMoveDelta broadcasts how far an entity moved, which may be the sum of
+// several moves, ending with the given change. Teleport should be true if any
+// of the moves was a teleport, so that clients don't ignore it.
+func (s *GameServer) sendMoveDelta(game *backend.Game, change backend.MoveChange, dx int, dy int, moves int, teleport bool) {
+	id := change.Entity.ID()
 	resp := proto.Response{
-		Action: &proto.Response_UpdateEntity{
-			UpdateEntity: &proto.UpdateEntity{
-				Entity: proto.GetProtoEntity(change.Entity),
+		Action: &proto.Response_MoveDelta{
+			MoveDelta: &proto.MoveDelta{
+				Id:             id.String(),
+				Dx:             int32(dx),
+				Dy:             int32(dy),
+				Sequence:       change.MoveSequence,
+				ClientSequence: change.Sequence,
+				Moves:          uint32(moves),
+				Teleport:       teleport,
 			},
 		},
 	}
-	s.broadcast(&resp)
+	s.broadcastMove(game, change, dx, dy, &resp)
 }
 
-func (s *GameServer) handleAddEntityChange(change backend.AddEntityChange) {
+func (s *GameServer) handleAddEntityChange(game *backend.Game, change backend.AddEntityChange) {
 	resp := proto.Response{
 		Action: &proto.Response_AddEntity{
 			AddEntity: &proto.AddEntity{
@@ -339,61 +858,64 @@ func (s *GameServer) handleAddEntityChange(change backend.AddEntityChange) {
 			},
 		},
 	}
-	s.broadcast(&resp)
+	s.broadcastNear(game, change.Entity, &resp)
 }
 
-func (s *GameServer) handleRemoveEntityChange(change backend.RemoveEntityChange) {
-	resp := proto.Response{
-		Action: &proto.Response_RemoveEntity{
-			RemoveEntity: &proto.RemoveEntity{
-				Id: change.Entity.ID().String(),
-			},
-		},
-	}
-	s.broadcast(&resp)
+func (s *GameServer) handleRemoveEntityChange(game *backend.Game, change backend.RemoveEntityChange) {
+	s.broadcastNear(game, change.Entity, entityRemoval(change.Entity.ID()))
 }
 
-func (s *GameServer) handlePlayerRespawnChange(change backend.PlayerRespawnChange) {
+func (s *GameServer) handlePlayerRespawnChange(game *backend.Game, change backend.PlayerRespawnChange) {
+	killerScore := game.GetScore(change.KilledByID)
+	victimScore := game.GetScore(change.Player.ID())
 	resp := proto.Response{
 		Action: &proto.Response_PlayerRespawn{
 			PlayerRespawn: &proto.PlayerRespawn{
-				Player:     proto.GetProtoPlayer(change.Player),
-				KilledById: change.KilledByID.String(),
+				Player:      proto.GetProtoPlayer(change.Player),
+				KilledById:  change.KilledByID.String(),
+				KillerScore: int32(killerScore),
+				VictimScore: int32(victimScore),
 			},
 		},
 	}
-	s.broadcast(&resp)
+	// Clients that aren't interested in the player are still sent the scores.
+	scores := []*proto.Response{scoreResponse(change.Player.ID(), victimScore)}
+	if change.KilledByID != uuid.Nil {
+		scores = append(scores, scoreResponse(change.KilledByID, killerScore))
+	}
+	s.broadcastNear(game, change.Player, &resp, scores...)
 }
 
-func (s *GameServer) handleRoundOverChange(change backend.RoundOverChange) {
-	s.game.Mu.RLock()
-	defer s.game.Mu.RUnlock()
-	timestamp, err := ptypes.TimestampProto(s.game.NewRoundAt)
+func (s *GameServer) handleRoundOverChange(game *backend.Game, change backend.RoundOverChange) {
+	game.Mu.RLock()
+	defer game.Mu.RUnlock()
+	timestamp, err := ptypes.TimestampProto(game.NewRoundAt)
 	if err != nil {
-		log.Fatalf("unable to parse new round timestamp %v", s.game.NewRoundAt)
+		log.Fatalf("unable to parse new round timestamp %v", game.NewRoundAt)
 	}
 	resp := proto.Response{
 		Action: &proto.Response_RoundOver{
 			RoundOver: &proto.RoundOver{
-				RoundWinnerId: s.game.RoundWinner.String(),
+				RoundWinnerId: game.RoundWinner.String(),
 				NewRoundAt:    timestamp,
+				Summary:       proto.GetProtoSummary(game.RoundSummary),
 			},
 		},
 	}
-	s.broadcast(&resp)
+	s.broadcast(game, &resp)
 }
 
-func (s *GameServer) handleRoundStartChange(change backend.RoundStartChange) {
+func (s *GameServer) handleRoundStartChange(game *backend.Game, change backend.RoundStartChange) {
 	players := []*proto.Player{}
-	s.game.Mu.RLock()
-	for _, entity := range s.game.Entities {
+	game.Mu.RLock()
+	for _, entity := range game.Entities {
 		player, ok := entity.(*backend.Player)
 		if !ok {
 			continue
 		}
 		players = append(players, proto.GetProtoPlayer(player))
 	}
-	s.game.Mu.RUnlock()
+	game.Mu.RUnlock()
 	resp := proto.Response{
 		Action: &proto.Response_RoundStart{
 			RoundStart: &proto.RoundStart{
@@ -401,5 +923,140 @@ func (s *GameServer) handleRoundStartChange(change backend.RoundStartChange) {
 			},
 		},
 	}
-	s.broadcast(&resp)
+	s.broadcast(game, &resp)
+}
+
+func (s *GameServer) handleDamageChange(game *backend.Game, change backend.DamageChange) {
+	game.Mu.RLock()
+	damage := change.Player.Damage
+	game.Mu.RUnlock()
+	resp := proto.Response{
+		Action: &proto.Response_Damage{
+			Damage: &proto.Damage{
+				PlayerId: change.Player.ID().String(),
+				Damage:   int32(damage),
+			},
+		},
+	}
+	s.broadcastNear(game, change.Player, &resp)
+}
+
+func (s *GameServer) handleScoreChange(game *backend.Game, change backend.ScoreChange) {
+	s.broadcast(game, scoreResponse(change.PlayerID, change.Score))
+}
+
+// scoreResponse builds a response that sets a player's score.
+func scoreResponse(playerID uuid.UUID, score int) *proto.Response {
+	return &proto.Response{
+		Action: &proto.Response_Score{
+			Score: &proto.Score{
+				PlayerId: playerID.String(),
+				Score:    int32(score),
+			},
+		},
+	}
+}
+
+// handleStatusChange sends a player's status to clients interested in them.
+// Ready changes are sent to every client instead, as the lobby shows who is
+// ready.
+func (s *GameServer) handleStatusChange(game *backend.Game, change backend.StatusChange) {
+	s.broadcastNear(game, change.Player, statusResponse(game, change.Player))
+}
+
+// statusResponse builds a response with a player's status, such as whether
+// they are frozen or ready.
+func statusResponse(game *backend.Game, player *backend.Player) *proto.Response {
+	game.Mu.RLock()
+	frozen := player.Frozen
+	protected := player.Protected(time.Now())
+	blinking := player.Blinking
+	ready := player.Ready
+	protectedUntil := proto.GetProtoTime(player.ProtectedUntil)
+	game.Mu.RUnlock()
+	resp := proto.Response{
+		Action: &proto.Response_Status{
+			Status: &proto.Status{
+				PlayerId:       player.ID().String(),
+				Frozen:         frozen,
+				Protected:      protected,
+				Blinking:       blinking,
+				Ready:          ready,
+				ProtectedUntil: protectedUntil,
+			},
+		},
+	}
+	return &resp
+}
+
+func (s *GameServer) handleWaveChange(game *backend.Game, change backend.WaveChange) {
+	resp := proto.Response{
+		Action: &proto.Response_Wave{
+			Wave: &proto.Wave{
+				Wave:    int32(change.Wave),
+				Enemies: int32(change.Enemies),
+			},
+		},
+	}
+	s.broadcast(game, &resp)
+}
+
+func (s *GameServer) handleWeaponChange(game *backend.Game, change backend.WeaponChange) {
+	game.Mu.RLock()
+	activeWeapon := change.Player.ActiveWeapon
+	game.Mu.RUnlock()
+	resp := proto.Response{
+		Action: &proto.Response_WeaponSwitch{
+			WeaponSwitch: &proto.WeaponSwitch{
+				PlayerId:     change.Player.ID().String(),
+				ActiveWeapon: int32(activeWeapon),
+			},
+		},
+	}
+	s.broadcast(game, &resp)
+}
+
+func (s *GameServer) handleModifierChange(game *backend.Game, change backend.ModifierChange) {
+	resp := proto.Response{
+		Action: &proto.Response_Modifier{
+			Modifier: &proto.Modifier{
+				Name: change.Name,
+			},
+		},
+	}
+	s.broadcast(game, &resp)
+}
+
+func (s *GameServer) handlePhaseChange(game *backend.Game, change backend.PhaseChange) {
+	resp := proto.Response{
+		Action: &proto.Response_Phase{
+			Phase: &proto.Phase{
+				Phase: proto.GetProtoPhase(change.Phase),
+			},
+		},
+	}
+	s.broadcast(game, &resp)
+}
+
+func (s *GameServer) handleExplosionChange(game *backend.Game, change backend.ExplosionChange) {
+	resp := proto.Response{
+		Action: &proto.Response_Explosion{
+			Explosion: &proto.Explosion{
+				Position: proto.GetProtoCoordinate(change.Explosion.Position),
+				Radius:   int32(change.Explosion.Radius),
+			},
+		},
+	}
+	s.broadcast(game, &resp)
+}
+
+func (s *GameServer) handleSpectatorCountChange(game *backend.Game, change backend.SpectatorCountChange) {
+	resp := proto.Response{
+		Action: &proto.Response_SpectatorCount{
+			SpectatorCount: &proto.SpectatorCount{
+				Count: int32(change.Count),
+			},
+		},
+	}
+	s.broadcast(game, &resp)
 }
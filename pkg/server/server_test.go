@@ -0,0 +1,751 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mortenson/grpc-game-example/pkg/backend"
+	"github.com/mortenson/grpc-game-example/pkg/backend/testutil"
+	"github.com/mortenson/grpc-game-example/proto"
+	"golang.org/x/crypto/bcrypt"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// newTestServer returns a server for a game that hasn't been started, with no
+// broadcast interval.
+func newTestServer(t *testing.T, passwordHash string) *GameServer {
+	t.Helper()
+	return NewGameServer(testutil.NewGame(), passwordHash, 0)
+}
+
+// connectRequest builds a request for a new player to connect.
+func connectRequest(name string, password string) *proto.ConnectRequest {
+	return &proto.ConnectRequest{
+		Id:       uuid.New().String(),
+		Name:     name,
+		Password: password,
+	}
+}
+
+func TestConnectPassword(t *testing.T) {
+	hash, err := bcrypt.GenerateFromPassword([]byte("hunter2"), bcrypt.MinCost)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name     string
+		hash     string
+		password string
+		code     codes.Code
+	}{
+		{name: "no password required", hash: "", password: "anything", code: codes.OK},
+		{name: "correct password", hash: string(hash), password: "hunter2", code: codes.OK},
+		{name: "incorrect password", hash: string(hash), password: "hunter3", code: codes.Unauthenticated},
+		{name: "missing password", hash: string(hash), password: "", code: codes.Unauthenticated},
+		{name: "password is the hash", hash: string(hash), password: string(hash), code: codes.Unauthenticated},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			s := newTestServer(t, test.hash)
+			resp, err := s.Connect(context.Background(), connectRequest("alice", test.password))
+			if code := status.Code(err); code != test.code {
+				t.Fatalf("got code %v, want %v (error %v)", code, test.code, err)
+			}
+			if test.code == codes.OK && resp.Token == "" {
+				t.Error("no token was returned")
+			}
+		})
+	}
+}
+
+func TestConnectColor(t *testing.T) {
+	tests := []struct {
+		name  string
+		color string
+		ok    bool
+	}{
+		{name: "default color", color: "", ok: true},
+		{name: "player color", color: "fuchsia", ok: true},
+		{name: "unknown color", color: "mauve", ok: false},
+		{name: "colors are case sensitive", color: "Red", ok: false},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			s := newTestServer(t, "")
+			req := connectRequest("alice", "")
+			req.Color = test.color
+			resp, err := s.Connect(context.Background(), req)
+			if (err == nil) != test.ok {
+				t.Fatalf("got error %v, want ok %v", err, test.ok)
+			}
+			if !test.ok {
+				return
+			}
+			for _, entity := range resp.Entities {
+				if player := entity.GetPlayer(); player != nil && player.Id == req.Id {
+					if player.Color != test.color {
+						t.Errorf("player has color %q, want %q", player.Color, test.color)
+					}
+					return
+				}
+			}
+			t.Error("the player was not in the connect response")
+		})
+	}
+}
+
+func TestConnectSpectator(t *testing.T) {
+	tests := []struct {
+		name     string
+		spectate bool
+		players  int
+	}{
+		{name: "player", spectate: false, players: 1},
+		{name: "spectator", spectate: true, players: 0},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			s := newTestServer(t, "")
+			req := connectRequest("alice", "")
+			req.Spectate = test.spectate
+			resp, err := s.Connect(context.Background(), req)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if resp.Token == "" {
+				t.Error("no token was returned")
+			}
+			players := 0
+			for _, entity := range resp.Entities {
+				if entity.GetPlayer() != nil {
+					players++
+				}
+			}
+			if players != test.players {
+				t.Errorf("got %d players, want %d", players, test.players)
+			}
+		})
+	}
+}
+
+func TestStreamStateSync(t *testing.T) {
+	s := newTestServer(t, "")
+	req := connectRequest("alice", "")
+	resp, err := s.Connect(context.Background(), req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Bob joins after alice connected, but before her stream started.
+	bob, err := s.Connect(context.Background(), connectRequest("bob", ""))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(resp.Entities) != 1 || len(bob.Entities) != 2 {
+		t.Fatalf("got %d and %d entities on connect, want 1 and 2", len(resp.Entities), len(bob.Entities))
+	}
+	stream := startStream(t, s, resp.Token)
+	names := make(map[string]bool)
+	for _, entity := range stream.stateSync.Entities {
+		if player := entity.GetPlayer(); player != nil {
+			names[player.Name] = true
+		}
+	}
+	if !names["alice"] || !names["bob"] || len(names) != 2 {
+		t.Errorf("state sync has players %v, want alice and bob", names)
+	}
+}
+
+func TestStreamEchoes(t *testing.T) {
+	tests := []struct {
+		name  string
+		req   *proto.Request
+		match func(resp *proto.Response) bool
+	}{
+		{
+			name: "heartbeat",
+			req:  &proto.Request{Action: &proto.Request_Heartbeat{Heartbeat: &proto.Heartbeat{}}},
+			match: func(resp *proto.Response) bool {
+				return resp.GetHeartbeat() != nil
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			s := newTestServer(t, "")
+			resp, err := s.Connect(context.Background(), connectRequest("alice", ""))
+			if err != nil {
+				t.Fatal(err)
+			}
+			stream := startStream(t, s, resp.Token)
+			stream.requests <- test.req
+			if stream.waitForResponse(test.match) == nil {
+				t.Error("the request was not echoed")
+			}
+		})
+	}
+}
+
+func TestGetLeaderboard(t *testing.T) {
+	tests := []struct {
+		name   string
+		scores map[string]int
+		want   []string
+	}{
+		{name: "no scores", scores: map[string]int{}, want: []string{"alice", "bob", "carol"}},
+		{name: "highest first", scores: map[string]int{"alice": 2, "bob": 5}, want: []string{"bob", "alice", "carol"}},
+		{name: "ties by name", scores: map[string]int{"carol": 3, "alice": 3, "bob": 1}, want: []string{"alice", "carol", "bob"}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			game := testutil.NewGame(
+				testutil.WithPlayerAt("carol", 0, 0),
+				testutil.WithPlayerAt("bob", 1, 0),
+				testutil.WithPlayerAt("alice", 2, 0),
+			)
+			for name, score := range test.scores {
+				game.SetScore(testutil.Player(game, name).ID(), score)
+			}
+			s := NewGameServer(game, "", 0)
+			resp, err := s.GetLeaderboard(context.Background(), &proto.LeaderboardRequest{})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(resp.Entries) != len(test.want) {
+				t.Fatalf("got %d entries, want %d", len(resp.Entries), len(test.want))
+			}
+			for i, entry := range resp.Entries {
+				player := testutil.Player(game, test.want[i])
+				if entry.Name != player.Name || entry.PlayerId != player.ID().String() || int(entry.Score) != test.scores[player.Name] {
+					t.Errorf("entry %d is %+v, want %s with score %d", i, entry, player.Name, test.scores[player.Name])
+				}
+			}
+		})
+	}
+}
+
+func TestListRooms(t *testing.T) {
+	s := newTestServer(t, "")
+	red := s.AddRoom("red", testutil.NewGame(
+		testutil.WithPlayerAt("alice", 0, 0),
+		testutil.WithPlayerAt("bob", 1, 0),
+	))
+	resp, err := s.ListRooms(context.Background(), &proto.ListRoomsRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []*proto.Room{
+		{Id: s.defaultRoom.ID.String(), Name: "Default", Players: 0},
+		{Id: red.ID.String(), Name: "red", Players: 2},
+	}
+	if len(resp.Rooms) != len(want) {
+		t.Fatalf("got %d rooms, want %d", len(resp.Rooms), len(want))
+	}
+	for i, room := range resp.Rooms {
+		if room.Id != want[i].Id || room.Name != want[i].Name || room.Players != want[i].Players {
+			t.Errorf("room %d is %+v, want %+v", i, room, want[i])
+		}
+	}
+}
+
+func TestJoinRoom(t *testing.T) {
+	tests := []struct {
+		name string
+		// room is the name of the room to join, or an ID if it isn't one.
+		room    string
+		connect bool
+		code    codes.Code
+	}{
+		{name: "default room", room: "", connect: true, code: codes.OK},
+		{name: "other room", room: "red", connect: true, code: codes.OK},
+		{name: "unknown room", room: uuid.New().String(), connect: true, code: codes.NotFound},
+		{name: "invalid room", room: "not an ID", connect: true, code: codes.Unknown},
+		{name: "no connect request", room: "red", connect: false, code: codes.Unknown},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			s := newTestServer(t, "")
+			red := s.AddRoom("red", testutil.NewGame(testutil.WithPlayerAt("bob", 1, 0)))
+			roomID := test.room
+			want := s.defaultRoom.Game
+			if test.room == "red" {
+				roomID = red.ID.String()
+				want = red.Game
+			}
+			req := &proto.JoinRoomRequest{RoomId: roomID}
+			if test.connect {
+				req.Connect = connectRequest("alice", "")
+			}
+			resp, err := s.JoinRoom(context.Background(), req)
+			if code := status.Code(err); code != test.code {
+				t.Fatalf("got code %v, want %v (error %v)", code, test.code, err)
+			}
+			if err != nil {
+				return
+			}
+			playerID := uuid.MustParse(req.Connect.Id)
+			for _, game := range []*backend.Game{s.defaultRoom.Game, red.Game} {
+				game.Mu.RLock()
+				joined := game.GetEntity(playerID) != nil
+				game.Mu.RUnlock()
+				if joined != (game == want) {
+					t.Errorf("player in game is %v, want only in the joined room", joined)
+				}
+			}
+			// The client's stream is for the joined room.
+			stream := startStream(t, s, resp.Token)
+			want.Mu.RLock()
+			defer want.Mu.RUnlock()
+			if entities := len(stream.stateSync.Entities); entities != len(want.Entities) {
+				t.Errorf("got %d entities, want the room's %d", entities, len(want.Entities))
+			}
+		})
+	}
+}
+
+// waitFor polls a condition, returning false if it isn't met in time.
+func waitFor(condition func() bool) bool {
+	deadline := time.Now().Add(2 * time.Second)
+	for time.Now().Before(deadline) {
+		if condition() {
+			return true
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	return false
+}
+
+func TestStreamDisconnect(t *testing.T) {
+	tests := []struct {
+		name string
+		// leave sends a disconnect message, instead of closing the stream.
+		leave bool
+	}{
+		{name: "disconnect message", leave: true},
+		{name: "stream closed", leave: false},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			game := testutil.NewGame()
+			s := NewGameServer(game, "", 0)
+			s.SetReconnectWindow(0)
+			req := connectRequest("alice", "")
+			alice, err := s.Connect(context.Background(), req)
+			if err != nil {
+				t.Fatal(err)
+			}
+			bob, err := s.Connect(context.Background(), connectRequest("bob", ""))
+			if err != nil {
+				t.Fatal(err)
+			}
+			playerID := uuid.MustParse(req.Id)
+			laser := &backend.Laser{
+				IdentifierBase: backend.IdentifierBase{UUID: uuid.New()},
+				Direction:      backend.DirectionStop,
+				StartTime:      time.Now(),
+				OwnerID:        playerID,
+			}
+			game.Mu.Lock()
+			game.AddEntity(laser)
+			game.Mu.Unlock()
+			aliceStream := startStream(t, s, alice.Token)
+			bobStream := startStream(t, s, bob.Token)
+
+			if test.leave {
+				aliceStream.requests <- &proto.Request{
+					Action: &proto.Request_Disconnect{Disconnect: &proto.Disconnect{}},
+				}
+			} else {
+				aliceStream.close()
+			}
+			removed := waitFor(func() bool {
+				game.Mu.RLock()
+				defer game.Mu.RUnlock()
+				return game.GetEntity(playerID) == nil && game.GetEntity(laser.ID()) == nil
+			})
+			if !removed {
+				t.Fatal("the player and their laser were not removed")
+			}
+			notRemoved := map[string]bool{req.Id: true, laser.ID().String(): true}
+			bobStream.waitForResponse(func(resp *proto.Response) bool {
+				if remove := resp.GetRemoveEntity(); remove != nil {
+					delete(notRemoved, remove.Id)
+				}
+				return len(notRemoved) == 0
+			})
+			if len(notRemoved) != 0 {
+				t.Errorf("other clients were not told %v were removed", notRemoved)
+			}
+		})
+	}
+}
+
+func TestPlayerRespawnScores(t *testing.T) {
+	game := testutil.NewGame()
+	s := NewGameServer(game, "", 0)
+	aliceReq := connectRequest("alice", "")
+	alice, err := s.Connect(context.Background(), aliceReq)
+	if err != nil {
+		t.Fatal(err)
+	}
+	bobReq := connectRequest("bob", "")
+	if _, err := s.Connect(context.Background(), bobReq); err != nil {
+		t.Fatal(err)
+	}
+	stream := startStream(t, s, alice.Token)
+	aliceID := uuid.MustParse(aliceReq.Id)
+	bobID := uuid.MustParse(bobReq.Id)
+	game.Mu.Lock()
+	game.Score[aliceID] = 3
+	game.Score[bobID] = 7
+	player := game.GetEntity(aliceID).(*backend.Player)
+	game.Mu.Unlock()
+	game.ChangeChannel <- backend.PlayerRespawnChange{
+		Player:     player,
+		KilledByID: bobID,
+	}
+	resp := stream.waitForResponse(func(resp *proto.Response) bool {
+		return resp.GetPlayerRespawn() != nil
+	})
+	if resp == nil {
+		t.Fatal("the respawn was not sent")
+	}
+	respawn := resp.GetPlayerRespawn()
+	if respawn.KilledById != bobReq.Id || respawn.KillerScore != 7 || respawn.VictimScore != 3 {
+		t.Errorf("got respawn %+v, want bob with 7 killing alice with 3", respawn)
+	}
+}
+
+func TestSpectatorCount(t *testing.T) {
+	game := testutil.NewGame()
+	s := NewGameServer(game, "", 0)
+	alice, err := s.Connect(context.Background(), connectRequest("alice", ""))
+	if err != nil {
+		t.Fatal(err)
+	}
+	aliceStream := startStream(t, s, alice.Token)
+	spectators := []*fakeStream{}
+	for _, name := range []string{"bob", "carol"} {
+		req := connectRequest(name, "")
+		req.Spectate = true
+		resp, err := s.Connect(context.Background(), req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		spectators = append(spectators, startStream(t, s, resp.Token))
+	}
+	waitForCount := func(count int32) {
+		t.Helper()
+		resp := aliceStream.waitForResponse(func(resp *proto.Response) bool {
+			return resp.GetSpectatorCount() != nil && resp.GetSpectatorCount().Count == count
+		})
+		if resp == nil {
+			t.Fatalf("the count of %d spectators was not sent", count)
+		}
+		game.Mu.RLock()
+		defer game.Mu.RUnlock()
+		if got := game.SpectatorCount(); got != int(count) {
+			t.Errorf("got %d spectators, want %d", got, count)
+		}
+	}
+	waitForCount(2)
+	spectators[0].close()
+	waitForCount(1)
+}
+
+func TestConnectDefaultDirection(t *testing.T) {
+	game := testutil.NewGame()
+	game.DefaultDirection = backend.DirectionRight
+	s := NewGameServer(game, "", 0)
+	resp, err := s.Connect(context.Background(), connectRequest("alice", ""))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.DefaultDirection != proto.Direction_RIGHT {
+		t.Errorf("got default direction %v, want right", resp.DefaultDirection)
+	}
+}
+
+func TestConnectSpeedMultiplier(t *testing.T) {
+	game := testutil.NewGame()
+	game.SpeedMultiplier = 2
+	s := NewGameServer(game, "", 0)
+	resp, err := s.Connect(context.Background(), connectRequest("alice", ""))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.SpeedMultiplier != 2 {
+		t.Errorf("got speed multiplier %v, want 2", resp.SpeedMultiplier)
+	}
+}
+
+func TestConnectMoveThrottle(t *testing.T) {
+	config := backend.DefaultConfig()
+	config.MoveThrottle = 80 * time.Millisecond
+	game := testutil.NewGameFromConfig(t, config)
+	game.Mu.Lock()
+	game.ApplyModifier("fast movement")
+	game.Mu.Unlock()
+	s := NewGameServer(game, "", 0)
+	resp, err := s.Connect(context.Background(), connectRequest("alice", ""))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.MoveThrottleMillis != 40 {
+		t.Errorf("got move throttle %dms, want 40ms", resp.MoveThrottleMillis)
+	}
+}
+
+func TestStatusChange(t *testing.T) {
+	tests := []struct {
+		name      string
+		protected bool
+		blinking  bool
+	}{
+		{name: "blinking", protected: true, blinking: true},
+		{name: "not blinking", protected: true, blinking: false},
+		{name: "vulnerable", protected: false, blinking: false},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			game := testutil.NewGame()
+			s := NewGameServer(game, "", 0)
+			req := connectRequest("alice", "")
+			resp, err := s.Connect(context.Background(), req)
+			if err != nil {
+				t.Fatal(err)
+			}
+			stream := startStream(t, s, resp.Token)
+			game.Mu.Lock()
+			player := game.GetEntity(uuid.MustParse(req.Id)).(*backend.Player)
+			player.ProtectedUntil = time.Time{}
+			if test.protected {
+				player.ProtectedUntil = time.Now().Add(time.Minute)
+			}
+			player.Blinking = test.blinking
+			game.Mu.Unlock()
+			game.ChangeChannel <- backend.StatusChange{Player: player}
+			statusResp := stream.waitForResponse(func(resp *proto.Response) bool {
+				return resp.GetStatus() != nil
+			})
+			if statusResp == nil {
+				t.Fatal("the status was not sent")
+			}
+			got := statusResp.GetStatus()
+			if got.PlayerId != req.Id || got.Protected != test.protected || got.Blinking != test.blinking {
+				t.Errorf("got status %+v, want protected %v and blinking %v", got, test.protected, test.blinking)
+			}
+		})
+	}
+}
+
+func TestConnectModifier(t *testing.T) {
+	game := testutil.NewGame()
+	game.ApplyModifier("fast movement")
+	s := NewGameServer(game, "", 0)
+	resp, err := s.Connect(context.Background(), connectRequest("alice", ""))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.Modifier != "fast movement" {
+		t.Errorf("got modifier %q, want fast movement", resp.Modifier)
+	}
+}
+
+func TestConnectDynamicEntities(t *testing.T) {
+	config := testutil.MapConfig(
+		"S █  ",
+		"  █  ",
+		"    S",
+	)
+	game := testutil.NewGameFromConfig(t, config)
+	game.AddEntity(&backend.Wall{
+		IdentifierBase:  backend.IdentifierBase{UUID: uuid.New()},
+		CurrentPosition: backend.Coordinate{X: 1, Y: -1},
+		Destructible:    true,
+		Health:          2,
+	})
+	testutil.WithWallAt(2, -1)(game)
+	s := NewGameServer(game, "", 0)
+	resp, err := s.Connect(context.Background(), connectRequest("alice", ""))
+	if err != nil {
+		t.Fatal(err)
+	}
+	players, walls := 0, 0
+	for _, entity := range resp.Entities {
+		switch {
+		case entity.GetPlayer() != nil:
+			players++
+		case entity.GetWall() != nil:
+			walls++
+		}
+	}
+	// Static walls are left out, as they never change.
+	if len(resp.Entities) != 2 || players != 1 || walls != 1 {
+		t.Errorf("got %d players and %d walls of %d entities, want alice and the destructible wall", players, walls, len(resp.Entities))
+	}
+}
+
+func TestLobbyResponses(t *testing.T) {
+	game := testutil.NewGame()
+	game.Phase = backend.PhaseLobby
+	s := NewGameServer(game, "", 0)
+	req := connectRequest("alice", "")
+	resp, err := s.Connect(context.Background(), req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.Phase != proto.GamePhase_LOBBY {
+		t.Errorf("got phase %v on connect, want the lobby", resp.Phase)
+	}
+	stream := startStream(t, s, resp.Token)
+	flushChanges(t, game, stream)
+	game.Mu.Lock()
+	player := game.GetEntity(uuid.MustParse(req.Id)).(*backend.Player)
+	player.Ready = true
+	game.Mu.Unlock()
+	game.ChangeChannel <- backend.ReadyChange{Player: player}
+	statusResp := stream.waitForResponse(func(resp *proto.Response) bool {
+		return resp.GetStatus() != nil
+	})
+	if statusResp == nil || !statusResp.GetStatus().Ready || statusResp.GetStatus().PlayerId != req.Id {
+		t.Errorf("got %v, want alice to be ready", statusResp)
+	}
+	game.ChangeChannel <- backend.PhaseChange{Phase: backend.PhasePlaying}
+	phaseResp := stream.waitForResponse(func(resp *proto.Response) bool {
+		return resp.GetPhase() != nil
+	})
+	if phaseResp == nil || phaseResp.GetPhase().Phase != proto.GamePhase_PLAYING {
+		t.Errorf("got %v, want the match to start", phaseResp)
+	}
+}
+
+func TestConnectDuplicateNames(t *testing.T) {
+	tests := []struct {
+		name string
+		mode backend.DuplicateNameMode
+		ok   bool
+		want string
+	}{
+		{name: "rejected", mode: backend.DuplicateNameReject, ok: false},
+		{name: "renamed", mode: backend.DuplicateNameRename, ok: true, want: "Alice2"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			game := testutil.NewGame()
+			game.DuplicateNames = test.mode
+			s := NewGameServer(game, "", 0)
+			if _, err := s.Connect(context.Background(), connectRequest("alice", "")); err != nil {
+				t.Fatal(err)
+			}
+			req := connectRequest("Alice", "")
+			_, err := s.Connect(context.Background(), req)
+			if (err == nil) != test.ok {
+				t.Fatalf("got error %v, want ok %v", err, test.ok)
+			}
+			game.Mu.RLock()
+			defer game.Mu.RUnlock()
+			player, added := game.GetEntity(uuid.MustParse(req.Id)).(*backend.Player)
+			if added != test.ok {
+				t.Fatalf("added is %v, want %v", added, test.ok)
+			}
+			if added && player.Name != test.want {
+				t.Errorf("player joined as %q, want %q", player.Name, test.want)
+			}
+		})
+	}
+}
+
+func TestConnectMaxPlayers(t *testing.T) {
+	game := testutil.NewGame()
+	game.MaxPlayers = 1
+	s := NewGameServer(game, "", 0)
+	s.SetReconnectWindow(0)
+	req := connectRequest("alice", "")
+	alice, err := s.Connect(context.Background(), req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Spectators don't take a slot.
+	spectateReq := connectRequest("carol", "")
+	spectateReq.Spectate = true
+	if _, err := s.Connect(context.Background(), spectateReq); err != nil {
+		t.Fatalf("got error %v for a spectator, want none", err)
+	}
+	if _, err := s.Connect(context.Background(), connectRequest("bob", "")); !errors.Is(err, backend.ErrGameFull) {
+		t.Fatalf("got error %v, want the game to be full", err)
+	}
+
+	// Players who disconnect free their slot.
+	aliceStream := startStream(t, s, alice.Token)
+	aliceStream.requests <- &proto.Request{
+		Action: &proto.Request_Disconnect{Disconnect: &proto.Disconnect{}},
+	}
+	removed := waitFor(func() bool {
+		game.Mu.RLock()
+		defer game.Mu.RUnlock()
+		return game.GetEntity(uuid.MustParse(req.Id)) == nil
+	})
+	if !removed {
+		t.Fatal("alice was not removed")
+	}
+	if _, err := s.Connect(context.Background(), connectRequest("bob", "")); err != nil {
+		t.Errorf("got error %v after alice left, want none", err)
+	}
+}
+
+func TestConnectMaxClients(t *testing.T) {
+	s := newTestServer(t, "")
+	for i := 0; i < maxClients; i++ {
+		if _, err := s.Connect(context.Background(), connectRequest(fmt.Sprintf("player%d", i), "")); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if _, err := s.Connect(context.Background(), connectRequest("alice", "")); err == nil {
+		t.Error("got no error for a player over the limit")
+	}
+
+	// Spectators are limited separately, so they can watch a full server.
+	for i := 0; i < maxSpectators; i++ {
+		req := connectRequest(fmt.Sprintf("spectator%d", i), "")
+		req.Spectate = true
+		if _, err := s.Connect(context.Background(), req); err != nil {
+			t.Fatalf("got error %v for spectator %d, want none", err, i)
+		}
+	}
+	req := connectRequest("bob", "")
+	req.Spectate = true
+	if _, err := s.Connect(context.Background(), req); err == nil {
+		t.Error("got no error for a spectator over the limit")
+	}
+}
+
+func TestConnectFullChangeChannel(t *testing.T) {
+	// The game isn't in a room, so nothing reads its changes and the change
+	// channel stays full.
+	s := newTestServer(t, "")
+	game := testutil.NewGame()
+	game.ChangeChannel <- backend.SpectatorCountChange{}
+	alice, err := s.connect(game, connectRequest("alice", ""))
+	if err != nil {
+		t.Fatal(err)
+	}
+	stream := startStream(t, s, alice.Token)
+
+	for _, name := range []string{"bob", "carol", "dave"} {
+		req := connectRequest(name, "")
+		if _, err := s.connect(game, req); err != nil {
+			t.Fatal(err)
+		}
+		resp := stream.waitForResponse(func(resp *proto.Response) bool {
+			return resp.GetAddEntity() != nil && resp.GetAddEntity().Entity.GetPlayer().GetId() == req.Id
+		})
+		if resp == nil {
+			t.Errorf("alice was not told that %s joined", name)
+		}
+	}
+}
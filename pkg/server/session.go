@@ -0,0 +1,124 @@
+package server
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mortenson/grpc-game-example/pkg/backend"
+	"github.com/mortenson/grpc-game-example/proto"
+)
+
+const defaultReconnectWindow = 30 * time.Second
+
+// session lets a client that lost its connection reattach to its player. Each
+// session has a reconnect token, which clients send when connecting again.
+type session struct {
+	playerID uuid.UUID
+	game     *backend.Game
+	// client is the client attached to the player, which is nil while the
+	// player waits for a client to reconnect.
+	client *client
+	// generation changes whenever the player is detached or resumed, so that
+	// timers from earlier detaches don't remove the player.
+	generation int
+}
+
+// SetReconnectWindow sets how long players stay in the game after their
+// client loses its connection, so that the client can reconnect to them.
+// Players who disconnect on purpose are removed right away. A window of zero
+// always removes players right away. This should be called before serving.
+func (s *GameServer) SetReconnectWindow(window time.Duration) {
+	s.reconnectWindow = window
+}
+
+// startSession starts a session for a newly connected player, and returns its
+// reconnect token.
+func (s *GameServer) startSession(game *backend.Game, playerID uuid.UUID) uuid.UUID {
+	token := uuid.New()
+	s.mu.Lock()
+	s.sessions[token] = &session{
+		playerID: playerID,
+		game:     game,
+	}
+	s.mu.Unlock()
+	return token
+}
+
+// resumeSession reattaches a player to a new client, if the reconnect token
+// belongs to the player and they are still in the game. A client that is
+// still attached to the player, and may not have noticed that its connection
+// was lost, is replaced.
+func (s *GameServer) resumeSession(game *backend.Game, playerID uuid.UUID, reconnectToken string) (*proto.ConnectResponse, bool) {
+	token, err := uuid.Parse(reconnectToken)
+	if err != nil {
+		return nil, false
+	}
+	game.Mu.RLock()
+	_, ok := game.GetEntity(playerID).(*backend.Player)
+	game.Mu.RUnlock()
+	s.mu.Lock()
+	currentSession, found := s.sessions[token]
+	if !found || currentSession.playerID != playerID || currentSession.game != game {
+		s.mu.Unlock()
+		return nil, false
+	}
+	if !ok {
+		// The player was removed some other way, so they can't be resumed.
+		delete(s.sessions, token)
+		s.mu.Unlock()
+		return nil, false
+	}
+	currentSession.generation++
+	previous := currentSession.client
+	currentSession.client = nil
+	if previous != nil {
+		delete(s.clients, previous.id)
+	}
+	s.mu.Unlock()
+	if previous != nil && previous.streamServer != nil {
+		// End the previous stream if it is still waiting for requests.
+		previous.stop(errors.New("replaced by a reconnecting client"))
+	}
+	return s.addClient(game, playerID, false, token), true
+}
+
+// detachPlayer handles a player's stream ending. Players who left on purpose
+// are removed right away, while others wait for a client to reconnect until
+// the reconnect window passes. Players already resumed by another client are
+// left alone.
+func (s *GameServer) detachPlayer(currentClient *client, left bool) {
+	token := currentClient.reconnectToken
+	s.mu.Lock()
+	currentSession, ok := s.sessions[token]
+	if ok && currentSession.client != currentClient {
+		s.mu.Unlock()
+		return
+	}
+	if !ok || left || s.reconnectWindow <= 0 {
+		delete(s.sessions, token)
+		s.mu.Unlock()
+		s.removePlayer(currentClient.game, currentClient.playerID)
+		return
+	}
+	currentSession.client = nil
+	currentSession.generation++
+	generation := currentSession.generation
+	s.mu.Unlock()
+	time.AfterFunc(s.reconnectWindow, func() {
+		s.expireSession(token, currentSession, generation)
+	})
+}
+
+// expireSession removes a detached player if no client reconnected to them
+// since they were detached.
+func (s *GameServer) expireSession(token uuid.UUID, currentSession *session, generation int) {
+	s.mu.Lock()
+	if s.sessions[token] != currentSession || currentSession.generation != generation {
+		s.mu.Unlock()
+		return
+	}
+	delete(s.sessions, token)
+	s.mu.Unlock()
+	s.removePlayer(currentSession.game, currentSession.playerID)
+}
@@ -0,0 +1,100 @@
+package server
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mortenson/grpc-game-example/pkg/backend/testutil"
+	"github.com/mortenson/grpc-game-example/proto"
+)
+
+func TestReconnect(t *testing.T) {
+	tests := []struct {
+		name   string
+		window time.Duration
+		// leave sends a disconnect message, close ends the stream and
+		// otherwise the client reconnects while its stream is still open.
+		leave, close bool
+		wrongToken   bool
+		// expire waits for the player to be removed before reconnecting.
+		expire  bool
+		resumed bool
+	}{
+		{name: "lost connections", window: time.Minute, close: true, resumed: true},
+		{name: "streams that are still open", window: time.Minute, resumed: true},
+		{name: "wrong tokens", window: time.Minute, close: true, wrongToken: true, resumed: false},
+		{name: "players who left", window: time.Minute, leave: true, resumed: false},
+		{name: "expired sessions", window: 50 * time.Millisecond, close: true, expire: true, resumed: false},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			game := testutil.NewGame()
+			s := NewGameServer(game, "", 0)
+			s.SetReconnectWindow(test.window)
+			req := connectRequest("alice", "")
+			resp, err := s.Connect(context.Background(), req)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if resp.ReconnectToken == "" {
+				t.Fatal("no reconnect token was sent")
+			}
+			playerID := uuid.MustParse(req.Id)
+			game.Mu.Lock()
+			player := game.GetEntity(playerID)
+			game.Score[playerID] = 3
+			game.Mu.Unlock()
+			stream := startStream(t, s, resp.Token)
+			switch {
+			case test.leave:
+				stream.requests <- &proto.Request{
+					Action: &proto.Request_Disconnect{Disconnect: &proto.Disconnect{}},
+				}
+			case test.close:
+				stream.close()
+			}
+			removed := func() bool {
+				game.Mu.RLock()
+				defer game.Mu.RUnlock()
+				return game.GetEntity(playerID) == nil
+			}
+			if (test.leave || test.expire) && !waitFor(removed) {
+				t.Fatal("the player was not removed")
+			}
+
+			reconnect := connectRequest("alice", "")
+			reconnect.Id = req.Id
+			reconnect.ReconnectToken = resp.ReconnectToken
+			if test.wrongToken {
+				reconnect.ReconnectToken = uuid.New().String()
+			}
+			resumed, err := s.Connect(context.Background(), reconnect)
+			if test.wrongToken {
+				if err == nil {
+					t.Error("a wrong token took over the player, want an error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			game.Mu.RLock()
+			score := game.Score[playerID]
+			same := game.GetEntity(playerID) == player
+			game.Mu.RUnlock()
+			if same != test.resumed {
+				t.Errorf("same player is %v, want %v", same, test.resumed)
+			}
+			if test.resumed && score != 3 {
+				t.Errorf("player has score %d, want it to be kept", score)
+			}
+			if resumed.ReconnectToken == "" {
+				t.Error("no reconnect token was sent")
+			}
+			// Resumed players keep streaming with the new client.
+			startStream(t, s, resumed.Token)
+		})
+	}
+}
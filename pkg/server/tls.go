@@ -0,0 +1,45 @@
+package server
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"io/ioutil"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+)
+
+// TLSConfig configures how the server secures connections.
+type TLSConfig struct {
+	CertFile string
+	KeyFile  string
+	// ClientCAFile is an optional PEM encoded CA certificate. If set, clients
+	// must present a certificate signed by it (mutual TLS).
+	ClientCAFile string
+}
+
+// ServerOption returns a grpc.ServerOption with TLS transport credentials.
+func (config TLSConfig) ServerOption() (grpc.ServerOption, error) {
+	certificate, err := tls.LoadX509KeyPair(config.CertFile, config.KeyFile)
+	if err != nil {
+		return nil, fmt.Errorf("could not load server certificate: %v", err)
+	}
+	tlsConfig := &tls.Config{
+		Certificates: []tls.Certificate{certificate},
+	}
+	if config.ClientCAFile != "" {
+		pem, err := ioutil.ReadFile(config.ClientCAFile)
+		if err != nil {
+			return nil, fmt.Errorf("could not read client CA certificate: %v", err)
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, errors.New("could not parse client CA certificate")
+		}
+		tlsConfig.ClientCAs = pool
+		tlsConfig.ClientAuth = tls.RequireAndVerifyClientCert
+	}
+	return grpc.Creds(credentials.NewTLS(tlsConfig)), nil
+}
@@ -2,9 +2,11 @@ package proto
 
 import (
 	"log"
+	"time"
 	"unicode/utf8"
 
 	"github.com/golang/protobuf/ptypes"
+	"github.com/golang/protobuf/ptypes/timestamp"
 	"github.com/google/uuid"
 	"github.com/mortenson/grpc-game-example/pkg/backend"
 )
@@ -39,6 +41,50 @@ func GetProtoDirection(direction backend.Direction) Direction {
 	return protoDirection
 }
 
+func GetBackendWeaponKind(protoWeapon WeaponKind) backend.WeaponKind {
+	weapon := backend.WeaponLaser
+	switch protoWeapon {
+	case WeaponKind_PIERCING_LASER:
+		weapon = backend.WeaponPiercingLaser
+	case WeaponKind_SWAP_LASER:
+		weapon = backend.WeaponSwapLaser
+	}
+	return weapon
+}
+
+func GetProtoWeaponKind(weapon backend.WeaponKind) WeaponKind {
+	protoWeapon := WeaponKind_LASER
+	switch weapon {
+	case backend.WeaponPiercingLaser:
+		protoWeapon = WeaponKind_PIERCING_LASER
+	case backend.WeaponSwapLaser:
+		protoWeapon = WeaponKind_SWAP_LASER
+	}
+	return protoWeapon
+}
+
+func GetBackendPhase(protoPhase GamePhase) backend.Phase {
+	phase := backend.PhasePlaying
+	switch protoPhase {
+	case GamePhase_LOBBY:
+		phase = backend.PhaseLobby
+	case GamePhase_ENDED:
+		phase = backend.PhaseEnded
+	}
+	return phase
+}
+
+func GetProtoPhase(phase backend.Phase) GamePhase {
+	protoPhase := GamePhase_PLAYING
+	switch phase {
+	case backend.PhaseLobby:
+		protoPhase = GamePhase_LOBBY
+	case backend.PhaseEnded:
+		protoPhase = GamePhase_ENDED
+	}
+	return protoPhase
+}
+
 func GetBackendCoordinate(protoCoordinate *Coordinate) backend.Coordinate {
 	return backend.Coordinate{
 		X: int(protoCoordinate.X),
@@ -53,6 +99,33 @@ func GetProtoCoordinate(coordinate backend.Coordinate) *Coordinate {
 	}
 }
 
+// GetBackendTime converts an optional proto timestamp, returning the zero time
+// if it isn't set or is invalid.
+func GetBackendTime(protoTimestamp *timestamp.Timestamp) time.Time {
+	if protoTimestamp == nil {
+		return time.Time{}
+	}
+	backendTime, err := ptypes.Timestamp(protoTimestamp)
+	if err != nil {
+		log.Printf("failed to convert proto timestamp to time: %+v", err)
+		return time.Time{}
+	}
+	return backendTime
+}
+
+// GetProtoTime converts an optional time, returning nil for the zero time.
+func GetProtoTime(backendTime time.Time) *timestamp.Timestamp {
+	if backendTime.IsZero() {
+		return nil
+	}
+	protoTimestamp, err := ptypes.TimestampProto(backendTime)
+	if err != nil {
+		log.Printf("failed to convert time to proto timestamp: %+v", err)
+		return nil
+	}
+	return protoTimestamp
+}
+
 func GetBackendEntity(protoEntity *Entity) backend.Identifier {
 	switch protoEntity.Entity.(type) {
 	case *Entity_Player:
@@ -61,6 +134,18 @@ func GetBackendEntity(protoEntity *Entity) backend.Identifier {
 	case *Entity_Laser:
 		protoLaser := protoEntity.Entity.(*Entity_Laser).Laser
 		return GetBackendLaser(protoLaser)
+	case *Entity_PowerUp:
+		protoPowerUp := protoEntity.Entity.(*Entity_PowerUp).PowerUp
+		return GetBackendPowerUp(protoPowerUp)
+	case *Entity_HealthPack:
+		protoHealthPack := protoEntity.Entity.(*Entity_HealthPack).HealthPack
+		return GetBackendHealthPack(protoHealthPack)
+	case *Entity_Mine:
+		protoMine := protoEntity.Entity.(*Entity_Mine).Mine
+		return GetBackendMine(protoMine)
+	case *Entity_Wall:
+		protoWall := protoEntity.Entity.(*Entity_Wall).Wall
+		return GetBackendWall(protoWall)
 	}
 	log.Printf("cannot get backend entity for %T -> %+v", protoEntity, protoEntity)
 	return nil
@@ -74,9 +159,20 @@ func GetBackendPlayer(protoPlayer *Player) *backend.Player {
 	}
 	icon, _ := utf8.DecodeRuneInString(protoPlayer.Icon)
 	player := &backend.Player{
-		IdentifierBase: backend.IdentifierBase{UUID: entityID},
-		Name:           protoPlayer.Name,
-		Icon:           icon,
+		IdentifierBase:  backend.IdentifierBase{UUID: entityID},
+		Name:            protoPlayer.Name,
+		Icon:            icon,
+		Color:           protoPlayer.Color,
+		Damage:          int(protoPlayer.Damage),
+		Team:            int(protoPlayer.Team),
+		Frozen:          protoPlayer.Frozen,
+		ActiveWeapon:    int(protoPlayer.ActiveWeapon),
+		Ready:           protoPlayer.Ready,
+		SpeedMultiplier: protoPlayer.SpeedMultiplier,
+		ProtectedUntil:  GetBackendTime(protoPlayer.ProtectedUntil),
+	}
+	for _, protoWeapon := range protoPlayer.Loadout {
+		player.Loadout = append(player.Loadout, GetBackendWeaponKind(protoWeapon))
 	}
 	player.Move(GetBackendCoordinate(protoPlayer.Position))
 	return player
@@ -104,10 +200,70 @@ func GetBackendLaser(protoLaser *Laser) *backend.Laser {
 		Direction:       GetBackendDirection(protoLaser.Direction),
 		StartTime:       timestamp,
 		OwnerID:         ownerID,
+		Piercing:        protoLaser.Piercing,
+		Team:            int(protoLaser.Team),
+		Swap:            protoLaser.Swap,
+		SpeedMultiplier: protoLaser.SpeedMultiplier,
 	}
 	return laser
 }
 
+func GetBackendPowerUp(protoPowerUp *PowerUp) *backend.PowerUp {
+	entityID, err := uuid.Parse(protoPowerUp.Id)
+	if err != nil {
+		log.Printf("failed to convert proto UUID: %+v", err)
+		return nil
+	}
+	return &backend.PowerUp{
+		IdentifierBase:  backend.IdentifierBase{UUID: entityID},
+		CurrentPosition: GetBackendCoordinate(protoPowerUp.Position),
+	}
+}
+
+func GetBackendHealthPack(protoHealthPack *HealthPack) *backend.HealthPack {
+	entityID, err := uuid.Parse(protoHealthPack.Id)
+	if err != nil {
+		log.Printf("failed to convert proto UUID: %+v", err)
+		return nil
+	}
+	return &backend.HealthPack{
+		IdentifierBase:  backend.IdentifierBase{UUID: entityID},
+		CurrentPosition: GetBackendCoordinate(protoHealthPack.Position),
+	}
+}
+
+func GetBackendMine(protoMine *Mine) *backend.Mine {
+	entityID, err := uuid.Parse(protoMine.Id)
+	if err != nil {
+		log.Printf("failed to convert proto UUID: %+v", err)
+		return nil
+	}
+	ownerID, err := uuid.Parse(protoMine.OwnerId)
+	if err != nil {
+		log.Printf("failed to convert proto UUID: %+v", err)
+		return nil
+	}
+	return &backend.Mine{
+		IdentifierBase:  backend.IdentifierBase{UUID: entityID},
+		CurrentPosition: GetBackendCoordinate(protoMine.Position),
+		OwnerID:         ownerID,
+	}
+}
+
+func GetBackendWall(protoWall *Wall) *backend.Wall {
+	entityID, err := uuid.Parse(protoWall.Id)
+	if err != nil {
+		log.Printf("failed to convert proto UUID: %+v", err)
+		return nil
+	}
+	return &backend.Wall{
+		IdentifierBase:  backend.IdentifierBase{UUID: entityID},
+		CurrentPosition: GetBackendCoordinate(protoWall.Position),
+		Destructible:    protoWall.Destructible,
+		Health:          int(protoWall.Health),
+	}
+}
+
 func GetProtoEntity(entity backend.Identifier) *Entity {
 	switch entity.(type) {
 	case *backend.Player:
@@ -122,17 +278,84 @@ func GetProtoEntity(entity backend.Identifier) *Entity {
 			Laser: GetProtoLaser(laser),
 		}
 		return &Entity{Entity: &protoLaser}
+	case *backend.PowerUp:
+		powerUp := entity.(*backend.PowerUp)
+		protoPowerUp := Entity_PowerUp{
+			PowerUp: GetProtoPowerUp(powerUp),
+		}
+		return &Entity{Entity: &protoPowerUp}
+	case *backend.HealthPack:
+		healthPack := entity.(*backend.HealthPack)
+		protoHealthPack := Entity_HealthPack{
+			HealthPack: GetProtoHealthPack(healthPack),
+		}
+		return &Entity{Entity: &protoHealthPack}
+	case *backend.Mine:
+		mine := entity.(*backend.Mine)
+		protoMine := Entity_Mine{
+			Mine: GetProtoMine(mine),
+		}
+		return &Entity{Entity: &protoMine}
+	case *backend.Wall:
+		wall := entity.(*backend.Wall)
+		protoWall := Entity_Wall{
+			Wall: GetProtoWall(wall),
+		}
+		return &Entity{Entity: &protoWall}
 	}
 	log.Printf("cannot get proto entity for %T -> %+v", entity, entity)
 	return nil
 }
 
 func GetProtoPlayer(player *backend.Player) *Player {
-	return &Player{
-		Id:       player.ID().String(),
-		Name:     player.Name,
-		Position: GetProtoCoordinate(player.Position()),
-		Icon:     string(player.Icon),
+	protoPlayer := &Player{
+		Id:              player.ID().String(),
+		Name:            player.Name,
+		Position:        GetProtoCoordinate(player.Position()),
+		Icon:            string(player.Icon),
+		Color:           player.Color,
+		Damage:          int32(player.Damage),
+		Team:            int32(player.Team),
+		Frozen:          player.Frozen,
+		ActiveWeapon:    int32(player.ActiveWeapon),
+		Ready:           player.Ready,
+		SpeedMultiplier: player.SpeedMultiplier,
+		ProtectedUntil:  GetProtoTime(player.ProtectedUntil),
+	}
+	for _, weapon := range player.Loadout {
+		protoPlayer.Loadout = append(protoPlayer.Loadout, GetProtoWeaponKind(weapon))
+	}
+	return protoPlayer
+}
+
+func GetProtoPowerUp(powerUp *backend.PowerUp) *PowerUp {
+	return &PowerUp{
+		Id:       powerUp.ID().String(),
+		Position: GetProtoCoordinate(powerUp.Position()),
+	}
+}
+
+func GetProtoHealthPack(healthPack *backend.HealthPack) *HealthPack {
+	return &HealthPack{
+		Id:       healthPack.ID().String(),
+		Position: GetProtoCoordinate(healthPack.Position()),
+	}
+}
+
+func GetProtoMine(mine *backend.Mine) *Mine {
+	return &Mine{
+		Id:       mine.ID().String(),
+		Position: GetProtoCoordinate(mine.Position()),
+		OwnerId:  mine.OwnerID.String(),
+	}
+}
+
+func GetProtoWall(wall *backend.Wall) *Wall {
+	return &Wall{
+		Id:           wall.ID().String(),
+		Position:     GetProtoCoordinate(wall.Position()),
+		Destructible: wall.Destructible,
+		Health:       int32(wall.Health),
 	}
 }
 
@@ -148,5 +371,108 @@ func GetProtoLaser(laser *backend.Laser) *Laser {
 		InitialPosition: GetProtoCoordinate(laser.InitialPosition),
 		Direction:       GetProtoDirection(laser.Direction),
 		OwnerId:         laser.OwnerID.String(),
+		Piercing:        laser.Piercing,
+		Team:            int32(laser.Team),
+		Swap:            laser.Swap,
+		SpeedMultiplier: laser.SpeedMultiplier,
+	}
+}
+
+func GetProtoLeaderboardEntry(entry backend.LeaderboardEntry) *LeaderboardEntry {
+	return &LeaderboardEntry{
+		PlayerId: entry.PlayerID.String(),
+		Name:     entry.Name,
+		Score:    int32(entry.Score),
+	}
+}
+
+func GetBackendLeaderboardEntry(protoEntry *LeaderboardEntry) *backend.LeaderboardEntry {
+	playerID, err := uuid.Parse(protoEntry.PlayerId)
+	if err != nil {
+		log.Printf("failed to convert proto UUID: %+v", err)
+		return nil
+	}
+	return &backend.LeaderboardEntry{
+		PlayerID: playerID,
+		Name:     protoEntry.Name,
+		Score:    int(protoEntry.Score),
+	}
+}
+
+func GetProtoSummary(summary backend.Summary) *Summary {
+	standings := make([]*LeaderboardEntry, 0, len(summary.Standings))
+	for _, entry := range summary.Standings {
+		standings = append(standings, GetProtoLeaderboardEntry(entry))
+	}
+	return &Summary{
+		Standings:      standings,
+		TopFragger:     GetProtoSummaryStat(summary.TopFragger),
+		LongestStreak:  GetProtoSummaryStat(summary.LongestStreak),
+		DurationMillis: summary.Duration.Milliseconds(),
+	}
+}
+
+func GetProtoSummaryStat(stat backend.SummaryStat) *SummaryStat {
+	return &SummaryStat{
+		PlayerId: stat.PlayerID.String(),
+		Name:     stat.Name,
+		Value:    int32(stat.Value),
+	}
+}
+
+func GetBackendSummary(protoSummary *Summary) *backend.Summary {
+	standings := make([]backend.LeaderboardEntry, 0, len(protoSummary.Standings))
+	for _, protoEntry := range protoSummary.Standings {
+		entry := GetBackendLeaderboardEntry(protoEntry)
+		if entry == nil {
+			return nil
+		}
+		standings = append(standings, *entry)
+	}
+	topFragger := GetBackendSummaryStat(protoSummary.TopFragger)
+	longestStreak := GetBackendSummaryStat(protoSummary.LongestStreak)
+	if topFragger == nil || longestStreak == nil {
+		return nil
+	}
+	return &backend.Summary{
+		Standings:     standings,
+		TopFragger:    *topFragger,
+		LongestStreak: *longestStreak,
+		Duration:      time.Duration(protoSummary.DurationMillis) * time.Millisecond,
+	}
+}
+
+func GetBackendSummaryStat(protoStat *SummaryStat) *backend.SummaryStat {
+	if protoStat == nil {
+		return &backend.SummaryStat{}
+	}
+	playerID, err := uuid.Parse(protoStat.PlayerId)
+	if err != nil {
+		log.Printf("failed to convert proto UUID: %+v", err)
+		return nil
+	}
+	return &backend.SummaryStat{
+		PlayerID: playerID,
+		Name:     protoStat.Name,
+		Value:    int(protoStat.Value),
+	}
+}
+
+func GetProtoCareerStats(stats backend.CareerStats) *CareerStats {
+	return &CareerStats{
+		Kills:  int32(stats.Kills),
+		Deaths: int32(stats.Deaths),
+		Wins:   int32(stats.Wins),
+	}
+}
+
+func GetBackendCareerStats(protoStats *CareerStats) *backend.CareerStats {
+	if protoStats == nil {
+		return nil
+	}
+	return &backend.CareerStats{
+		Kills:  int(protoStats.Kills),
+		Deaths: int(protoStats.Deaths),
+		Wins:   int(protoStats.Wins),
 	}
 }
@@ -59,6 +59,62 @@ func (Direction) EnumDescriptor() ([]byte, []int) {
 	return fileDescriptor_098391ad7281b52b, []int{0}
 }
 
+type GamePhase int32
+
+const (
+	GamePhase_PLAYING GamePhase = 0
+	GamePhase_LOBBY   GamePhase = 1
+	GamePhase_ENDED   GamePhase = 2
+)
+
+var GamePhase_name = map[int32]string{
+	0: "PLAYING",
+	1: "LOBBY",
+	2: "ENDED",
+}
+
+var GamePhase_value = map[string]int32{
+	"PLAYING": 0,
+	"LOBBY":   1,
+	"ENDED":   2,
+}
+
+func (x GamePhase) String() string {
+	return proto.EnumName(GamePhase_name, int32(x))
+}
+
+func (GamePhase) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_098391ad7281b52b, []int{1}
+}
+
+type WeaponKind int32
+
+const (
+	WeaponKind_LASER          WeaponKind = 0
+	WeaponKind_PIERCING_LASER WeaponKind = 1
+	WeaponKind_SWAP_LASER     WeaponKind = 2
+)
+
+var WeaponKind_name = map[int32]string{
+	0: "LASER",
+	1: "PIERCING_LASER",
+	2: "SWAP_LASER",
+}
+
+var WeaponKind_value = map[string]int32{
+	"LASER":          0,
+	"PIERCING_LASER": 1,
+	"SWAP_LASER":     2,
+}
+
+func (x WeaponKind) String() string {
+	return proto.EnumName(WeaponKind_name, int32(x))
+}
+
+func (WeaponKind) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_098391ad7281b52b, []int{2}
+}
+
 type Coordinate struct {
 	X                    int32    `protobuf:"varint,1,opt,name=x,proto3" json:"x,omitempty"`
 	Y                    int32    `protobuf:"varint,2,opt,name=y,proto3" json:"y,omitempty"`
@@ -107,13 +163,22 @@ func (m *Coordinate) GetY() int32 {
 }
 
 type Player struct {
-	Id                   string      `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	Name                 string      `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
-	Position             *Coordinate `protobuf:"bytes,3,opt,name=position,proto3" json:"position,omitempty"`
-	Icon                 string      `protobuf:"bytes,4,opt,name=icon,proto3" json:"icon,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}    `json:"-"`
-	XXX_unrecognized     []byte      `json:"-"`
-	XXX_sizecache        int32       `json:"-"`
+	Id                   string               `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name                 string               `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Position             *Coordinate          `protobuf:"bytes,3,opt,name=position,proto3" json:"position,omitempty"`
+	Icon                 string               `protobuf:"bytes,4,opt,name=icon,proto3" json:"icon,omitempty"`
+	Color                string               `protobuf:"bytes,5,opt,name=color,proto3" json:"color,omitempty"`
+	Damage               int32                `protobuf:"varint,6,opt,name=damage,proto3" json:"damage,omitempty"`
+	Team                 int32                `protobuf:"varint,7,opt,name=team,proto3" json:"team,omitempty"`
+	Frozen               bool                 `protobuf:"varint,8,opt,name=frozen,proto3" json:"frozen,omitempty"`
+	Loadout              []WeaponKind         `protobuf:"varint,9,rep,name=loadout,packed,proto3,enum=proto.WeaponKind" json:"loadout,omitempty"`
+	ActiveWeapon         int32                `protobuf:"varint,10,opt,name=activeWeapon,proto3" json:"activeWeapon,omitempty"`
+	Ready                bool                 `protobuf:"varint,11,opt,name=ready,proto3" json:"ready,omitempty"`
+	SpeedMultiplier      float64              `protobuf:"fixed64,12,opt,name=speedMultiplier,proto3" json:"speedMultiplier,omitempty"`
+	ProtectedUntil       *timestamp.Timestamp `protobuf:"bytes,13,opt,name=protectedUntil,proto3" json:"protectedUntil,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
 }
 
 func (m *Player) Reset()         { *m = Player{} }
@@ -169,12 +234,79 @@ func (m *Player) GetIcon() string {
 	return ""
 }
 
+func (m *Player) GetColor() string {
+	if m != nil {
+		return m.Color
+	}
+	return ""
+}
+
+func (m *Player) GetDamage() int32 {
+	if m != nil {
+		return m.Damage
+	}
+	return 0
+}
+
+func (m *Player) GetTeam() int32 {
+	if m != nil {
+		return m.Team
+	}
+	return 0
+}
+
+func (m *Player) GetFrozen() bool {
+	if m != nil {
+		return m.Frozen
+	}
+	return false
+}
+
+func (m *Player) GetLoadout() []WeaponKind {
+	if m != nil {
+		return m.Loadout
+	}
+	return nil
+}
+
+func (m *Player) GetActiveWeapon() int32 {
+	if m != nil {
+		return m.ActiveWeapon
+	}
+	return 0
+}
+
+func (m *Player) GetReady() bool {
+	if m != nil {
+		return m.Ready
+	}
+	return false
+}
+
+func (m *Player) GetSpeedMultiplier() float64 {
+	if m != nil {
+		return m.SpeedMultiplier
+	}
+	return 0
+}
+
+func (m *Player) GetProtectedUntil() *timestamp.Timestamp {
+	if m != nil {
+		return m.ProtectedUntil
+	}
+	return nil
+}
+
 type Laser struct {
 	Id                   string               `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
 	Direction            Direction            `protobuf:"varint,2,opt,name=direction,proto3,enum=proto.Direction" json:"direction,omitempty"`
 	StartTime            *timestamp.Timestamp `protobuf:"bytes,3,opt,name=startTime,proto3" json:"startTime,omitempty"`
 	InitialPosition      *Coordinate          `protobuf:"bytes,4,opt,name=initialPosition,proto3" json:"initialPosition,omitempty"`
 	OwnerId              string               `protobuf:"bytes,5,opt,name=ownerId,proto3" json:"ownerId,omitempty"`
+	Piercing             bool                 `protobuf:"varint,6,opt,name=piercing,proto3" json:"piercing,omitempty"`
+	Team                 int32                `protobuf:"varint,7,opt,name=team,proto3" json:"team,omitempty"`
+	Swap                 bool                 `protobuf:"varint,8,opt,name=swap,proto3" json:"swap,omitempty"`
+	SpeedMultiplier      float64              `protobuf:"fixed64,9,opt,name=speedMultiplier,proto3" json:"speedMultiplier,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
 	XXX_unrecognized     []byte               `json:"-"`
 	XXX_sizecache        int32                `json:"-"`
@@ -240,10 +372,254 @@ func (m *Laser) GetOwnerId() string {
 	return ""
 }
 
+func (m *Laser) GetPiercing() bool {
+	if m != nil {
+		return m.Piercing
+	}
+	return false
+}
+
+func (m *Laser) GetTeam() int32 {
+	if m != nil {
+		return m.Team
+	}
+	return 0
+}
+
+func (m *Laser) GetSwap() bool {
+	if m != nil {
+		return m.Swap
+	}
+	return false
+}
+
+func (m *Laser) GetSpeedMultiplier() float64 {
+	if m != nil {
+		return m.SpeedMultiplier
+	}
+	return 0
+}
+
+type PowerUp struct {
+	Id                   string      `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Position             *Coordinate `protobuf:"bytes,2,opt,name=position,proto3" json:"position,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}    `json:"-"`
+	XXX_unrecognized     []byte      `json:"-"`
+	XXX_sizecache        int32       `json:"-"`
+}
+
+func (m *PowerUp) Reset()         { *m = PowerUp{} }
+func (m *PowerUp) String() string { return proto.CompactTextString(m) }
+func (*PowerUp) ProtoMessage()    {}
+func (*PowerUp) Descriptor() ([]byte, []int) {
+	return fileDescriptor_098391ad7281b52b, []int{3}
+}
+
+func (m *PowerUp) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PowerUp.Unmarshal(m, b)
+}
+func (m *PowerUp) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PowerUp.Marshal(b, m, deterministic)
+}
+func (m *PowerUp) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PowerUp.Merge(m, src)
+}
+func (m *PowerUp) XXX_Size() int {
+	return xxx_messageInfo_PowerUp.Size(m)
+}
+func (m *PowerUp) XXX_DiscardUnknown() {
+	xxx_messageInfo_PowerUp.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PowerUp proto.InternalMessageInfo
+
+func (m *PowerUp) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *PowerUp) GetPosition() *Coordinate {
+	if m != nil {
+		return m.Position
+	}
+	return nil
+}
+
+type HealthPack struct {
+	Id                   string      `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Position             *Coordinate `protobuf:"bytes,2,opt,name=position,proto3" json:"position,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}    `json:"-"`
+	XXX_unrecognized     []byte      `json:"-"`
+	XXX_sizecache        int32       `json:"-"`
+}
+
+func (m *HealthPack) Reset()         { *m = HealthPack{} }
+func (m *HealthPack) String() string { return proto.CompactTextString(m) }
+func (*HealthPack) ProtoMessage()    {}
+func (*HealthPack) Descriptor() ([]byte, []int) {
+	return fileDescriptor_098391ad7281b52b, []int{4}
+}
+
+func (m *HealthPack) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_HealthPack.Unmarshal(m, b)
+}
+func (m *HealthPack) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_HealthPack.Marshal(b, m, deterministic)
+}
+func (m *HealthPack) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_HealthPack.Merge(m, src)
+}
+func (m *HealthPack) XXX_Size() int {
+	return xxx_messageInfo_HealthPack.Size(m)
+}
+func (m *HealthPack) XXX_DiscardUnknown() {
+	xxx_messageInfo_HealthPack.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_HealthPack proto.InternalMessageInfo
+
+func (m *HealthPack) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *HealthPack) GetPosition() *Coordinate {
+	if m != nil {
+		return m.Position
+	}
+	return nil
+}
+
+type Mine struct {
+	Id                   string      `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Position             *Coordinate `protobuf:"bytes,2,opt,name=position,proto3" json:"position,omitempty"`
+	OwnerId              string      `protobuf:"bytes,3,opt,name=ownerId,proto3" json:"ownerId,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}    `json:"-"`
+	XXX_unrecognized     []byte      `json:"-"`
+	XXX_sizecache        int32       `json:"-"`
+}
+
+func (m *Mine) Reset()         { *m = Mine{} }
+func (m *Mine) String() string { return proto.CompactTextString(m) }
+func (*Mine) ProtoMessage()    {}
+func (*Mine) Descriptor() ([]byte, []int) {
+	return fileDescriptor_098391ad7281b52b, []int{5}
+}
+
+func (m *Mine) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Mine.Unmarshal(m, b)
+}
+func (m *Mine) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Mine.Marshal(b, m, deterministic)
+}
+func (m *Mine) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Mine.Merge(m, src)
+}
+func (m *Mine) XXX_Size() int {
+	return xxx_messageInfo_Mine.Size(m)
+}
+func (m *Mine) XXX_DiscardUnknown() {
+	xxx_messageInfo_Mine.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Mine proto.InternalMessageInfo
+
+func (m *Mine) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *Mine) GetPosition() *Coordinate {
+	if m != nil {
+		return m.Position
+	}
+	return nil
+}
+
+func (m *Mine) GetOwnerId() string {
+	if m != nil {
+		return m.OwnerId
+	}
+	return ""
+}
+
+type Wall struct {
+	Id                   string      `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Position             *Coordinate `protobuf:"bytes,2,opt,name=position,proto3" json:"position,omitempty"`
+	Destructible         bool        `protobuf:"varint,3,opt,name=destructible,proto3" json:"destructible,omitempty"`
+	Health               int32       `protobuf:"varint,4,opt,name=health,proto3" json:"health,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}    `json:"-"`
+	XXX_unrecognized     []byte      `json:"-"`
+	XXX_sizecache        int32       `json:"-"`
+}
+
+func (m *Wall) Reset()         { *m = Wall{} }
+func (m *Wall) String() string { return proto.CompactTextString(m) }
+func (*Wall) ProtoMessage()    {}
+func (*Wall) Descriptor() ([]byte, []int) {
+	return fileDescriptor_098391ad7281b52b, []int{6}
+}
+
+func (m *Wall) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Wall.Unmarshal(m, b)
+}
+func (m *Wall) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Wall.Marshal(b, m, deterministic)
+}
+func (m *Wall) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Wall.Merge(m, src)
+}
+func (m *Wall) XXX_Size() int {
+	return xxx_messageInfo_Wall.Size(m)
+}
+func (m *Wall) XXX_DiscardUnknown() {
+	xxx_messageInfo_Wall.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Wall proto.InternalMessageInfo
+
+func (m *Wall) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *Wall) GetPosition() *Coordinate {
+	if m != nil {
+		return m.Position
+	}
+	return nil
+}
+
+func (m *Wall) GetDestructible() bool {
+	if m != nil {
+		return m.Destructible
+	}
+	return false
+}
+
+func (m *Wall) GetHealth() int32 {
+	if m != nil {
+		return m.Health
+	}
+	return 0
+}
+
 type Entity struct {
 	// Types that are valid to be assigned to Entity:
 	//	*Entity_Player
 	//	*Entity_Laser
+	//	*Entity_PowerUp
+	//	*Entity_HealthPack
+	//	*Entity_Mine
+	//	*Entity_Wall
 	Entity               isEntity_Entity `protobuf_oneof:"entity"`
 	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
 	XXX_unrecognized     []byte          `json:"-"`
@@ -254,7 +630,7 @@ func (m *Entity) Reset()         { *m = Entity{} }
 func (m *Entity) String() string { return proto.CompactTextString(m) }
 func (*Entity) ProtoMessage()    {}
 func (*Entity) Descriptor() ([]byte, []int) {
-	return fileDescriptor_098391ad7281b52b, []int{3}
+	return fileDescriptor_098391ad7281b52b, []int{7}
 }
 
 func (m *Entity) XXX_Unmarshal(b []byte) error {
@@ -287,10 +663,34 @@ type Entity_Laser struct {
 	Laser *Laser `protobuf:"bytes,3,opt,name=laser,proto3,oneof"`
 }
 
+type Entity_PowerUp struct {
+	PowerUp *PowerUp `protobuf:"bytes,4,opt,name=powerUp,proto3,oneof"`
+}
+
+type Entity_HealthPack struct {
+	HealthPack *HealthPack `protobuf:"bytes,5,opt,name=healthPack,proto3,oneof"`
+}
+
+type Entity_Mine struct {
+	Mine *Mine `protobuf:"bytes,6,opt,name=mine,proto3,oneof"`
+}
+
+type Entity_Wall struct {
+	Wall *Wall `protobuf:"bytes,7,opt,name=wall,proto3,oneof"`
+}
+
 func (*Entity_Player) isEntity_Entity() {}
 
 func (*Entity_Laser) isEntity_Entity() {}
 
+func (*Entity_PowerUp) isEntity_Entity() {}
+
+func (*Entity_HealthPack) isEntity_Entity() {}
+
+func (*Entity_Mine) isEntity_Entity() {}
+
+func (*Entity_Wall) isEntity_Entity() {}
+
 func (m *Entity) GetEntity() isEntity_Entity {
 	if m != nil {
 		return m.Entity
@@ -312,11 +712,43 @@ func (m *Entity) GetLaser() *Laser {
 	return nil
 }
 
+func (m *Entity) GetPowerUp() *PowerUp {
+	if x, ok := m.GetEntity().(*Entity_PowerUp); ok {
+		return x.PowerUp
+	}
+	return nil
+}
+
+func (m *Entity) GetHealthPack() *HealthPack {
+	if x, ok := m.GetEntity().(*Entity_HealthPack); ok {
+		return x.HealthPack
+	}
+	return nil
+}
+
+func (m *Entity) GetMine() *Mine {
+	if x, ok := m.GetEntity().(*Entity_Mine); ok {
+		return x.Mine
+	}
+	return nil
+}
+
+func (m *Entity) GetWall() *Wall {
+	if x, ok := m.GetEntity().(*Entity_Wall); ok {
+		return x.Wall
+	}
+	return nil
+}
+
 // XXX_OneofWrappers is for the internal use of the proto package.
 func (*Entity) XXX_OneofWrappers() []interface{} {
 	return []interface{}{
 		(*Entity_Player)(nil),
 		(*Entity_Laser)(nil),
+		(*Entity_PowerUp)(nil),
+		(*Entity_HealthPack)(nil),
+		(*Entity_Mine)(nil),
+		(*Entity_Wall)(nil),
 	}
 }
 
@@ -324,6 +756,9 @@ type ConnectRequest struct {
 	Id                   string   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
 	Name                 string   `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
 	Password             string   `protobuf:"bytes,3,opt,name=password,proto3" json:"password,omitempty"`
+	Color                string   `protobuf:"bytes,4,opt,name=color,proto3" json:"color,omitempty"`
+	Spectate             bool     `protobuf:"varint,5,opt,name=spectate,proto3" json:"spectate,omitempty"`
+	ReconnectToken       string   `protobuf:"bytes,6,opt,name=reconnectToken,proto3" json:"reconnectToken,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -333,7 +768,7 @@ func (m *ConnectRequest) Reset()         { *m = ConnectRequest{} }
 func (m *ConnectRequest) String() string { return proto.CompactTextString(m) }
 func (*ConnectRequest) ProtoMessage()    {}
 func (*ConnectRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_098391ad7281b52b, []int{4}
+	return fileDescriptor_098391ad7281b52b, []int{8}
 }
 
 func (m *ConnectRequest) XXX_Unmarshal(b []byte) error {
@@ -375,19 +810,48 @@ func (m *ConnectRequest) GetPassword() string {
 	return ""
 }
 
-type ConnectResponse struct {
-	Token                string    `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
-	Entities             []*Entity `protobuf:"bytes,2,rep,name=entities,proto3" json:"entities,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}  `json:"-"`
-	XXX_unrecognized     []byte    `json:"-"`
-	XXX_sizecache        int32     `json:"-"`
+func (m *ConnectRequest) GetColor() string {
+	if m != nil {
+		return m.Color
+	}
+	return ""
+}
+
+func (m *ConnectRequest) GetSpectate() bool {
+	if m != nil {
+		return m.Spectate
+	}
+	return false
+}
+
+func (m *ConnectRequest) GetReconnectToken() string {
+	if m != nil {
+		return m.ReconnectToken
+	}
+	return ""
+}
+
+type ConnectResponse struct {
+	Token                string       `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
+	Entities             []*Entity    `protobuf:"bytes,2,rep,name=entities,proto3" json:"entities,omitempty"`
+	DefaultDirection     Direction    `protobuf:"varint,3,opt,name=defaultDirection,proto3,enum=proto.Direction" json:"defaultDirection,omitempty"`
+	Modifier             string       `protobuf:"bytes,4,opt,name=modifier,proto3" json:"modifier,omitempty"`
+	Phase                GamePhase    `protobuf:"varint,5,opt,name=phase,proto3,enum=proto.GamePhase" json:"phase,omitempty"`
+	Career               *CareerStats `protobuf:"bytes,6,opt,name=career,proto3" json:"career,omitempty"`
+	ReconnectToken       string       `protobuf:"bytes,7,opt,name=reconnectToken,proto3" json:"reconnectToken,omitempty"`
+	TeamColors           []string     `protobuf:"bytes,8,rep,name=teamColors,proto3" json:"teamColors,omitempty"`
+	SpeedMultiplier      float64      `protobuf:"fixed64,9,opt,name=speedMultiplier,proto3" json:"speedMultiplier,omitempty"`
+	MoveThrottleMillis   uint32       `protobuf:"varint,10,opt,name=moveThrottleMillis,proto3" json:"moveThrottleMillis,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}     `json:"-"`
+	XXX_unrecognized     []byte       `json:"-"`
+	XXX_sizecache        int32        `json:"-"`
 }
 
 func (m *ConnectResponse) Reset()         { *m = ConnectResponse{} }
 func (m *ConnectResponse) String() string { return proto.CompactTextString(m) }
 func (*ConnectResponse) ProtoMessage()    {}
 func (*ConnectResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_098391ad7281b52b, []int{5}
+	return fileDescriptor_098391ad7281b52b, []int{9}
 }
 
 func (m *ConnectResponse) XXX_Unmarshal(b []byte) error {
@@ -422,291 +886,1725 @@ func (m *ConnectResponse) GetEntities() []*Entity {
 	return nil
 }
 
-type Move struct {
-	Direction            Direction `protobuf:"varint,1,opt,name=direction,proto3,enum=proto.Direction" json:"direction,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}  `json:"-"`
-	XXX_unrecognized     []byte    `json:"-"`
-	XXX_sizecache        int32     `json:"-"`
+func (m *ConnectResponse) GetDefaultDirection() Direction {
+	if m != nil {
+		return m.DefaultDirection
+	}
+	return Direction_UP
 }
 
-func (m *Move) Reset()         { *m = Move{} }
-func (m *Move) String() string { return proto.CompactTextString(m) }
-func (*Move) ProtoMessage()    {}
-func (*Move) Descriptor() ([]byte, []int) {
-	return fileDescriptor_098391ad7281b52b, []int{6}
+func (m *ConnectResponse) GetModifier() string {
+	if m != nil {
+		return m.Modifier
+	}
+	return ""
 }
 
-func (m *Move) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_Move.Unmarshal(m, b)
-}
-func (m *Move) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_Move.Marshal(b, m, deterministic)
+func (m *ConnectResponse) GetPhase() GamePhase {
+	if m != nil {
+		return m.Phase
+	}
+	return GamePhase_PLAYING
 }
-func (m *Move) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_Move.Merge(m, src)
+
+func (m *ConnectResponse) GetCareer() *CareerStats {
+	if m != nil {
+		return m.Career
+	}
+	return nil
 }
-func (m *Move) XXX_Size() int {
-	return xxx_messageInfo_Move.Size(m)
+
+func (m *ConnectResponse) GetReconnectToken() string {
+	if m != nil {
+		return m.ReconnectToken
+	}
+	return ""
 }
-func (m *Move) XXX_DiscardUnknown() {
-	xxx_messageInfo_Move.DiscardUnknown(m)
+
+func (m *ConnectResponse) GetTeamColors() []string {
+	if m != nil {
+		return m.TeamColors
+	}
+	return nil
 }
 
-var xxx_messageInfo_Move proto.InternalMessageInfo
+func (m *ConnectResponse) GetSpeedMultiplier() float64 {
+	if m != nil {
+		return m.SpeedMultiplier
+	}
+	return 0
+}
 
-func (m *Move) GetDirection() Direction {
+func (m *ConnectResponse) GetMoveThrottleMillis() uint32 {
 	if m != nil {
-		return m.Direction
+		return m.MoveThrottleMillis
 	}
-	return Direction_UP
+	return 0
 }
 
-type AddEntity struct {
-	Entity               *Entity  `protobuf:"bytes,1,opt,name=entity,proto3" json:"entity,omitempty"`
+type CareerStats struct {
+	Kills                int32    `protobuf:"varint,1,opt,name=kills,proto3" json:"kills,omitempty"`
+	Deaths               int32    `protobuf:"varint,2,opt,name=deaths,proto3" json:"deaths,omitempty"`
+	Wins                 int32    `protobuf:"varint,3,opt,name=wins,proto3" json:"wins,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *AddEntity) Reset()         { *m = AddEntity{} }
-func (m *AddEntity) String() string { return proto.CompactTextString(m) }
-func (*AddEntity) ProtoMessage()    {}
-func (*AddEntity) Descriptor() ([]byte, []int) {
-	return fileDescriptor_098391ad7281b52b, []int{7}
+func (m *CareerStats) Reset()         { *m = CareerStats{} }
+func (m *CareerStats) String() string { return proto.CompactTextString(m) }
+func (*CareerStats) ProtoMessage()    {}
+func (*CareerStats) Descriptor() ([]byte, []int) {
+	return fileDescriptor_098391ad7281b52b, []int{10}
 }
 
-func (m *AddEntity) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_AddEntity.Unmarshal(m, b)
+func (m *CareerStats) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CareerStats.Unmarshal(m, b)
 }
-func (m *AddEntity) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_AddEntity.Marshal(b, m, deterministic)
+func (m *CareerStats) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CareerStats.Marshal(b, m, deterministic)
 }
-func (m *AddEntity) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_AddEntity.Merge(m, src)
+func (m *CareerStats) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CareerStats.Merge(m, src)
 }
-func (m *AddEntity) XXX_Size() int {
-	return xxx_messageInfo_AddEntity.Size(m)
+func (m *CareerStats) XXX_Size() int {
+	return xxx_messageInfo_CareerStats.Size(m)
 }
-func (m *AddEntity) XXX_DiscardUnknown() {
-	xxx_messageInfo_AddEntity.DiscardUnknown(m)
+func (m *CareerStats) XXX_DiscardUnknown() {
+	xxx_messageInfo_CareerStats.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_AddEntity proto.InternalMessageInfo
+var xxx_messageInfo_CareerStats proto.InternalMessageInfo
 
-func (m *AddEntity) GetEntity() *Entity {
+func (m *CareerStats) GetKills() int32 {
 	if m != nil {
-		return m.Entity
+		return m.Kills
 	}
-	return nil
+	return 0
 }
 
-type UpdateEntity struct {
-	Entity               *Entity  `protobuf:"bytes,1,opt,name=entity,proto3" json:"entity,omitempty"`
+func (m *CareerStats) GetDeaths() int32 {
+	if m != nil {
+		return m.Deaths
+	}
+	return 0
+}
+
+func (m *CareerStats) GetWins() int32 {
+	if m != nil {
+		return m.Wins
+	}
+	return 0
+}
+
+type Room struct {
+	Id                   string   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name                 string   `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Players              int32    `protobuf:"varint,3,opt,name=players,proto3" json:"players,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *UpdateEntity) Reset()         { *m = UpdateEntity{} }
-func (m *UpdateEntity) String() string { return proto.CompactTextString(m) }
-func (*UpdateEntity) ProtoMessage()    {}
-func (*UpdateEntity) Descriptor() ([]byte, []int) {
-	return fileDescriptor_098391ad7281b52b, []int{8}
+func (m *Room) Reset()         { *m = Room{} }
+func (m *Room) String() string { return proto.CompactTextString(m) }
+func (*Room) ProtoMessage()    {}
+func (*Room) Descriptor() ([]byte, []int) {
+	return fileDescriptor_098391ad7281b52b, []int{11}
 }
 
-func (m *UpdateEntity) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_UpdateEntity.Unmarshal(m, b)
+func (m *Room) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Room.Unmarshal(m, b)
 }
-func (m *UpdateEntity) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_UpdateEntity.Marshal(b, m, deterministic)
+func (m *Room) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Room.Marshal(b, m, deterministic)
 }
-func (m *UpdateEntity) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_UpdateEntity.Merge(m, src)
+func (m *Room) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Room.Merge(m, src)
 }
-func (m *UpdateEntity) XXX_Size() int {
-	return xxx_messageInfo_UpdateEntity.Size(m)
+func (m *Room) XXX_Size() int {
+	return xxx_messageInfo_Room.Size(m)
 }
-func (m *UpdateEntity) XXX_DiscardUnknown() {
-	xxx_messageInfo_UpdateEntity.DiscardUnknown(m)
+func (m *Room) XXX_DiscardUnknown() {
+	xxx_messageInfo_Room.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_UpdateEntity proto.InternalMessageInfo
+var xxx_messageInfo_Room proto.InternalMessageInfo
 
-func (m *UpdateEntity) GetEntity() *Entity {
+func (m *Room) GetId() string {
 	if m != nil {
-		return m.Entity
+		return m.Id
 	}
-	return nil
+	return ""
 }
 
-type RemoveEntity struct {
-	Id                   string   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+func (m *Room) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *Room) GetPlayers() int32 {
+	if m != nil {
+		return m.Players
+	}
+	return 0
+}
+
+type ListRoomsRequest struct {
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *RemoveEntity) Reset()         { *m = RemoveEntity{} }
-func (m *RemoveEntity) String() string { return proto.CompactTextString(m) }
-func (*RemoveEntity) ProtoMessage()    {}
-func (*RemoveEntity) Descriptor() ([]byte, []int) {
-	return fileDescriptor_098391ad7281b52b, []int{9}
+func (m *ListRoomsRequest) Reset()         { *m = ListRoomsRequest{} }
+func (m *ListRoomsRequest) String() string { return proto.CompactTextString(m) }
+func (*ListRoomsRequest) ProtoMessage()    {}
+func (*ListRoomsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_098391ad7281b52b, []int{12}
 }
 
-func (m *RemoveEntity) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_RemoveEntity.Unmarshal(m, b)
+func (m *ListRoomsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListRoomsRequest.Unmarshal(m, b)
 }
-func (m *RemoveEntity) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_RemoveEntity.Marshal(b, m, deterministic)
+func (m *ListRoomsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListRoomsRequest.Marshal(b, m, deterministic)
 }
-func (m *RemoveEntity) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_RemoveEntity.Merge(m, src)
+func (m *ListRoomsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListRoomsRequest.Merge(m, src)
 }
-func (m *RemoveEntity) XXX_Size() int {
-	return xxx_messageInfo_RemoveEntity.Size(m)
+func (m *ListRoomsRequest) XXX_Size() int {
+	return xxx_messageInfo_ListRoomsRequest.Size(m)
 }
-func (m *RemoveEntity) XXX_DiscardUnknown() {
-	xxx_messageInfo_RemoveEntity.DiscardUnknown(m)
+func (m *ListRoomsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListRoomsRequest.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_RemoveEntity proto.InternalMessageInfo
+var xxx_messageInfo_ListRoomsRequest proto.InternalMessageInfo
 
-func (m *RemoveEntity) GetId() string {
+type ListRoomsResponse struct {
+	Rooms                []*Room  `protobuf:"bytes,1,rep,name=rooms,proto3" json:"rooms,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ListRoomsResponse) Reset()         { *m = ListRoomsResponse{} }
+func (m *ListRoomsResponse) String() string { return proto.CompactTextString(m) }
+func (*ListRoomsResponse) ProtoMessage()    {}
+func (*ListRoomsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_098391ad7281b52b, []int{13}
+}
+
+func (m *ListRoomsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListRoomsResponse.Unmarshal(m, b)
+}
+func (m *ListRoomsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListRoomsResponse.Marshal(b, m, deterministic)
+}
+func (m *ListRoomsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListRoomsResponse.Merge(m, src)
+}
+func (m *ListRoomsResponse) XXX_Size() int {
+	return xxx_messageInfo_ListRoomsResponse.Size(m)
+}
+func (m *ListRoomsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListRoomsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListRoomsResponse proto.InternalMessageInfo
+
+func (m *ListRoomsResponse) GetRooms() []*Room {
 	if m != nil {
-		return m.Id
+		return m.Rooms
 	}
-	return ""
+	return nil
 }
 
-type PlayerRespawn struct {
-	Player               *Player  `protobuf:"bytes,1,opt,name=player,proto3" json:"player,omitempty"`
-	KilledById           string   `protobuf:"bytes,2,opt,name=killedById,proto3" json:"killedById,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+type JoinRoomRequest struct {
+	RoomId               string          `protobuf:"bytes,1,opt,name=roomId,proto3" json:"roomId,omitempty"`
+	Connect              *ConnectRequest `protobuf:"bytes,2,opt,name=connect,proto3" json:"connect,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
+	XXX_unrecognized     []byte          `json:"-"`
+	XXX_sizecache        int32           `json:"-"`
 }
 
-func (m *PlayerRespawn) Reset()         { *m = PlayerRespawn{} }
-func (m *PlayerRespawn) String() string { return proto.CompactTextString(m) }
-func (*PlayerRespawn) ProtoMessage()    {}
-func (*PlayerRespawn) Descriptor() ([]byte, []int) {
-	return fileDescriptor_098391ad7281b52b, []int{10}
+func (m *JoinRoomRequest) Reset()         { *m = JoinRoomRequest{} }
+func (m *JoinRoomRequest) String() string { return proto.CompactTextString(m) }
+func (*JoinRoomRequest) ProtoMessage()    {}
+func (*JoinRoomRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_098391ad7281b52b, []int{14}
 }
 
-func (m *PlayerRespawn) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_PlayerRespawn.Unmarshal(m, b)
+func (m *JoinRoomRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_JoinRoomRequest.Unmarshal(m, b)
 }
-func (m *PlayerRespawn) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_PlayerRespawn.Marshal(b, m, deterministic)
+func (m *JoinRoomRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_JoinRoomRequest.Marshal(b, m, deterministic)
 }
-func (m *PlayerRespawn) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_PlayerRespawn.Merge(m, src)
+func (m *JoinRoomRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_JoinRoomRequest.Merge(m, src)
 }
-func (m *PlayerRespawn) XXX_Size() int {
-	return xxx_messageInfo_PlayerRespawn.Size(m)
+func (m *JoinRoomRequest) XXX_Size() int {
+	return xxx_messageInfo_JoinRoomRequest.Size(m)
 }
-func (m *PlayerRespawn) XXX_DiscardUnknown() {
-	xxx_messageInfo_PlayerRespawn.DiscardUnknown(m)
+func (m *JoinRoomRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_JoinRoomRequest.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_PlayerRespawn proto.InternalMessageInfo
+var xxx_messageInfo_JoinRoomRequest proto.InternalMessageInfo
 
-func (m *PlayerRespawn) GetPlayer() *Player {
+func (m *JoinRoomRequest) GetRoomId() string {
 	if m != nil {
-		return m.Player
+		return m.RoomId
+	}
+	return ""
+}
+
+func (m *JoinRoomRequest) GetConnect() *ConnectRequest {
+	if m != nil {
+		return m.Connect
 	}
 	return nil
 }
 
-func (m *PlayerRespawn) GetKilledById() string {
+type LeaderboardRequest struct {
+	RoomId               string   `protobuf:"bytes,1,opt,name=roomId,proto3" json:"roomId,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *LeaderboardRequest) Reset()         { *m = LeaderboardRequest{} }
+func (m *LeaderboardRequest) String() string { return proto.CompactTextString(m) }
+func (*LeaderboardRequest) ProtoMessage()    {}
+func (*LeaderboardRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_098391ad7281b52b, []int{15}
+}
+
+func (m *LeaderboardRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_LeaderboardRequest.Unmarshal(m, b)
+}
+func (m *LeaderboardRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_LeaderboardRequest.Marshal(b, m, deterministic)
+}
+func (m *LeaderboardRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_LeaderboardRequest.Merge(m, src)
+}
+func (m *LeaderboardRequest) XXX_Size() int {
+	return xxx_messageInfo_LeaderboardRequest.Size(m)
+}
+func (m *LeaderboardRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_LeaderboardRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_LeaderboardRequest proto.InternalMessageInfo
+
+func (m *LeaderboardRequest) GetRoomId() string {
 	if m != nil {
-		return m.KilledById
+		return m.RoomId
 	}
 	return ""
 }
 
-type RoundOver struct {
-	RoundWinnerId        string               `protobuf:"bytes,1,opt,name=roundWinnerId,proto3" json:"roundWinnerId,omitempty"`
-	NewRoundAt           *timestamp.Timestamp `protobuf:"bytes,2,opt,name=newRoundAt,proto3" json:"newRoundAt,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
-	XXX_unrecognized     []byte               `json:"-"`
-	XXX_sizecache        int32                `json:"-"`
+type LeaderboardEntry struct {
+	PlayerId             string   `protobuf:"bytes,1,opt,name=playerId,proto3" json:"playerId,omitempty"`
+	Name                 string   `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Score                int32    `protobuf:"varint,3,opt,name=score,proto3" json:"score,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *RoundOver) Reset()         { *m = RoundOver{} }
-func (m *RoundOver) String() string { return proto.CompactTextString(m) }
-func (*RoundOver) ProtoMessage()    {}
-func (*RoundOver) Descriptor() ([]byte, []int) {
-	return fileDescriptor_098391ad7281b52b, []int{11}
+func (m *LeaderboardEntry) Reset()         { *m = LeaderboardEntry{} }
+func (m *LeaderboardEntry) String() string { return proto.CompactTextString(m) }
+func (*LeaderboardEntry) ProtoMessage()    {}
+func (*LeaderboardEntry) Descriptor() ([]byte, []int) {
+	return fileDescriptor_098391ad7281b52b, []int{16}
 }
 
-func (m *RoundOver) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_RoundOver.Unmarshal(m, b)
+func (m *LeaderboardEntry) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_LeaderboardEntry.Unmarshal(m, b)
 }
-func (m *RoundOver) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_RoundOver.Marshal(b, m, deterministic)
+func (m *LeaderboardEntry) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_LeaderboardEntry.Marshal(b, m, deterministic)
 }
-func (m *RoundOver) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_RoundOver.Merge(m, src)
+func (m *LeaderboardEntry) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_LeaderboardEntry.Merge(m, src)
 }
-func (m *RoundOver) XXX_Size() int {
-	return xxx_messageInfo_RoundOver.Size(m)
+func (m *LeaderboardEntry) XXX_Size() int {
+	return xxx_messageInfo_LeaderboardEntry.Size(m)
 }
-func (m *RoundOver) XXX_DiscardUnknown() {
-	xxx_messageInfo_RoundOver.DiscardUnknown(m)
+func (m *LeaderboardEntry) XXX_DiscardUnknown() {
+	xxx_messageInfo_LeaderboardEntry.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_LeaderboardEntry proto.InternalMessageInfo
+
+func (m *LeaderboardEntry) GetPlayerId() string {
+	if m != nil {
+		return m.PlayerId
+	}
+	return ""
+}
+
+func (m *LeaderboardEntry) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *LeaderboardEntry) GetScore() int32 {
+	if m != nil {
+		return m.Score
+	}
+	return 0
+}
+
+type LeaderboardResponse struct {
+	Entries              []*LeaderboardEntry `protobuf:"bytes,1,rep,name=entries,proto3" json:"entries,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}            `json:"-"`
+	XXX_unrecognized     []byte              `json:"-"`
+	XXX_sizecache        int32               `json:"-"`
+}
+
+func (m *LeaderboardResponse) Reset()         { *m = LeaderboardResponse{} }
+func (m *LeaderboardResponse) String() string { return proto.CompactTextString(m) }
+func (*LeaderboardResponse) ProtoMessage()    {}
+func (*LeaderboardResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_098391ad7281b52b, []int{17}
+}
+
+func (m *LeaderboardResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_LeaderboardResponse.Unmarshal(m, b)
+}
+func (m *LeaderboardResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_LeaderboardResponse.Marshal(b, m, deterministic)
+}
+func (m *LeaderboardResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_LeaderboardResponse.Merge(m, src)
+}
+func (m *LeaderboardResponse) XXX_Size() int {
+	return xxx_messageInfo_LeaderboardResponse.Size(m)
+}
+func (m *LeaderboardResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_LeaderboardResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_LeaderboardResponse proto.InternalMessageInfo
+
+func (m *LeaderboardResponse) GetEntries() []*LeaderboardEntry {
+	if m != nil {
+		return m.Entries
+	}
+	return nil
+}
+
+type Move struct {
+	Direction            Direction `protobuf:"varint,1,opt,name=direction,proto3,enum=proto.Direction" json:"direction,omitempty"`
+	Sequence             uint32    `protobuf:"varint,2,opt,name=sequence,proto3" json:"sequence,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}  `json:"-"`
+	XXX_unrecognized     []byte    `json:"-"`
+	XXX_sizecache        int32     `json:"-"`
+}
+
+func (m *Move) Reset()         { *m = Move{} }
+func (m *Move) String() string { return proto.CompactTextString(m) }
+func (*Move) ProtoMessage()    {}
+func (*Move) Descriptor() ([]byte, []int) {
+	return fileDescriptor_098391ad7281b52b, []int{18}
+}
+
+func (m *Move) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Move.Unmarshal(m, b)
+}
+func (m *Move) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Move.Marshal(b, m, deterministic)
+}
+func (m *Move) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Move.Merge(m, src)
+}
+func (m *Move) XXX_Size() int {
+	return xxx_messageInfo_Move.Size(m)
+}
+func (m *Move) XXX_DiscardUnknown() {
+	xxx_messageInfo_Move.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Move proto.InternalMessageInfo
+
+func (m *Move) GetDirection() Direction {
+	if m != nil {
+		return m.Direction
+	}
+	return Direction_UP
+}
+
+func (m *Move) GetSequence() uint32 {
+	if m != nil {
+		return m.Sequence
+	}
+	return 0
+}
+
+type AddEntity struct {
+	Entity               *Entity  `protobuf:"bytes,1,opt,name=entity,proto3" json:"entity,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *AddEntity) Reset()         { *m = AddEntity{} }
+func (m *AddEntity) String() string { return proto.CompactTextString(m) }
+func (*AddEntity) ProtoMessage()    {}
+func (*AddEntity) Descriptor() ([]byte, []int) {
+	return fileDescriptor_098391ad7281b52b, []int{19}
+}
+
+func (m *AddEntity) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_AddEntity.Unmarshal(m, b)
+}
+func (m *AddEntity) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_AddEntity.Marshal(b, m, deterministic)
+}
+func (m *AddEntity) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AddEntity.Merge(m, src)
+}
+func (m *AddEntity) XXX_Size() int {
+	return xxx_messageInfo_AddEntity.Size(m)
+}
+func (m *AddEntity) XXX_DiscardUnknown() {
+	xxx_messageInfo_AddEntity.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_AddEntity proto.InternalMessageInfo
+
+func (m *AddEntity) GetEntity() *Entity {
+	if m != nil {
+		return m.Entity
+	}
+	return nil
+}
+
+type UpdateEntity struct {
+	Entity               *Entity  `protobuf:"bytes,1,opt,name=entity,proto3" json:"entity,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *UpdateEntity) Reset()         { *m = UpdateEntity{} }
+func (m *UpdateEntity) String() string { return proto.CompactTextString(m) }
+func (*UpdateEntity) ProtoMessage()    {}
+func (*UpdateEntity) Descriptor() ([]byte, []int) {
+	return fileDescriptor_098391ad7281b52b, []int{20}
+}
+
+func (m *UpdateEntity) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_UpdateEntity.Unmarshal(m, b)
+}
+func (m *UpdateEntity) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_UpdateEntity.Marshal(b, m, deterministic)
+}
+func (m *UpdateEntity) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UpdateEntity.Merge(m, src)
+}
+func (m *UpdateEntity) XXX_Size() int {
+	return xxx_messageInfo_UpdateEntity.Size(m)
+}
+func (m *UpdateEntity) XXX_DiscardUnknown() {
+	xxx_messageInfo_UpdateEntity.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_UpdateEntity proto.InternalMessageInfo
+
+func (m *UpdateEntity) GetEntity() *Entity {
+	if m != nil {
+		return m.Entity
+	}
+	return nil
+}
+
+type Dash struct {
+	Sequence             uint32   `protobuf:"varint,1,opt,name=sequence,proto3" json:"sequence,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Dash) Reset()         { *m = Dash{} }
+func (m *Dash) String() string { return proto.CompactTextString(m) }
+func (*Dash) ProtoMessage()    {}
+func (*Dash) Descriptor() ([]byte, []int) {
+	return fileDescriptor_098391ad7281b52b, []int{21}
+}
+
+func (m *Dash) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Dash.Unmarshal(m, b)
+}
+func (m *Dash) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Dash.Marshal(b, m, deterministic)
+}
+func (m *Dash) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Dash.Merge(m, src)
+}
+func (m *Dash) XXX_Size() int {
+	return xxx_messageInfo_Dash.Size(m)
+}
+func (m *Dash) XXX_DiscardUnknown() {
+	xxx_messageInfo_Dash.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Dash proto.InternalMessageInfo
+
+func (m *Dash) GetSequence() uint32 {
+	if m != nil {
+		return m.Sequence
+	}
+	return 0
+}
+
+type SwitchWeapon struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SwitchWeapon) Reset()         { *m = SwitchWeapon{} }
+func (m *SwitchWeapon) String() string { return proto.CompactTextString(m) }
+func (*SwitchWeapon) ProtoMessage()    {}
+func (*SwitchWeapon) Descriptor() ([]byte, []int) {
+	return fileDescriptor_098391ad7281b52b, []int{22}
+}
+
+func (m *SwitchWeapon) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SwitchWeapon.Unmarshal(m, b)
+}
+func (m *SwitchWeapon) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SwitchWeapon.Marshal(b, m, deterministic)
+}
+func (m *SwitchWeapon) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SwitchWeapon.Merge(m, src)
+}
+func (m *SwitchWeapon) XXX_Size() int {
+	return xxx_messageInfo_SwitchWeapon.Size(m)
+}
+func (m *SwitchWeapon) XXX_DiscardUnknown() {
+	xxx_messageInfo_SwitchWeapon.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SwitchWeapon proto.InternalMessageInfo
+
+type Deploy struct {
+	Id                   string   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Deploy) Reset()         { *m = Deploy{} }
+func (m *Deploy) String() string { return proto.CompactTextString(m) }
+func (*Deploy) ProtoMessage()    {}
+func (*Deploy) Descriptor() ([]byte, []int) {
+	return fileDescriptor_098391ad7281b52b, []int{23}
+}
+
+func (m *Deploy) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Deploy.Unmarshal(m, b)
+}
+func (m *Deploy) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Deploy.Marshal(b, m, deterministic)
+}
+func (m *Deploy) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Deploy.Merge(m, src)
+}
+func (m *Deploy) XXX_Size() int {
+	return xxx_messageInfo_Deploy.Size(m)
+}
+func (m *Deploy) XXX_DiscardUnknown() {
+	xxx_messageInfo_Deploy.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Deploy proto.InternalMessageInfo
+
+func (m *Deploy) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+type Explosion struct {
+	Position             *Coordinate `protobuf:"bytes,1,opt,name=position,proto3" json:"position,omitempty"`
+	Radius               int32       `protobuf:"varint,2,opt,name=radius,proto3" json:"radius,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}    `json:"-"`
+	XXX_unrecognized     []byte      `json:"-"`
+	XXX_sizecache        int32       `json:"-"`
+}
+
+func (m *Explosion) Reset()         { *m = Explosion{} }
+func (m *Explosion) String() string { return proto.CompactTextString(m) }
+func (*Explosion) ProtoMessage()    {}
+func (*Explosion) Descriptor() ([]byte, []int) {
+	return fileDescriptor_098391ad7281b52b, []int{24}
+}
+
+func (m *Explosion) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Explosion.Unmarshal(m, b)
+}
+func (m *Explosion) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Explosion.Marshal(b, m, deterministic)
+}
+func (m *Explosion) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Explosion.Merge(m, src)
+}
+func (m *Explosion) XXX_Size() int {
+	return xxx_messageInfo_Explosion.Size(m)
+}
+func (m *Explosion) XXX_DiscardUnknown() {
+	xxx_messageInfo_Explosion.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Explosion proto.InternalMessageInfo
+
+func (m *Explosion) GetPosition() *Coordinate {
+	if m != nil {
+		return m.Position
+	}
+	return nil
+}
+
+func (m *Explosion) GetRadius() int32 {
+	if m != nil {
+		return m.Radius
+	}
+	return 0
+}
+
+type WeaponSwitch struct {
+	PlayerId             string   `protobuf:"bytes,1,opt,name=playerId,proto3" json:"playerId,omitempty"`
+	ActiveWeapon         int32    `protobuf:"varint,2,opt,name=activeWeapon,proto3" json:"activeWeapon,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *WeaponSwitch) Reset()         { *m = WeaponSwitch{} }
+func (m *WeaponSwitch) String() string { return proto.CompactTextString(m) }
+func (*WeaponSwitch) ProtoMessage()    {}
+func (*WeaponSwitch) Descriptor() ([]byte, []int) {
+	return fileDescriptor_098391ad7281b52b, []int{25}
+}
+
+func (m *WeaponSwitch) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_WeaponSwitch.Unmarshal(m, b)
+}
+func (m *WeaponSwitch) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_WeaponSwitch.Marshal(b, m, deterministic)
+}
+func (m *WeaponSwitch) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_WeaponSwitch.Merge(m, src)
+}
+func (m *WeaponSwitch) XXX_Size() int {
+	return xxx_messageInfo_WeaponSwitch.Size(m)
+}
+func (m *WeaponSwitch) XXX_DiscardUnknown() {
+	xxx_messageInfo_WeaponSwitch.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_WeaponSwitch proto.InternalMessageInfo
+
+func (m *WeaponSwitch) GetPlayerId() string {
+	if m != nil {
+		return m.PlayerId
+	}
+	return ""
+}
+
+func (m *WeaponSwitch) GetActiveWeapon() int32 {
+	if m != nil {
+		return m.ActiveWeapon
+	}
+	return 0
+}
+
+type MoveDelta struct {
+	Id                   string   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Dx                   int32    `protobuf:"zigzag32,2,opt,name=dx,proto3" json:"dx,omitempty"`
+	Dy                   int32    `protobuf:"zigzag32,3,opt,name=dy,proto3" json:"dy,omitempty"`
+	Sequence             uint32   `protobuf:"varint,4,opt,name=sequence,proto3" json:"sequence,omitempty"`
+	ClientSequence       uint32   `protobuf:"varint,5,opt,name=clientSequence,proto3" json:"clientSequence,omitempty"`
+	Moves                uint32   `protobuf:"varint,6,opt,name=moves,proto3" json:"moves,omitempty"`
+	Teleport             bool     `protobuf:"varint,7,opt,name=teleport,proto3" json:"teleport,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *MoveDelta) Reset()         { *m = MoveDelta{} }
+func (m *MoveDelta) String() string { return proto.CompactTextString(m) }
+func (*MoveDelta) ProtoMessage()    {}
+func (*MoveDelta) Descriptor() ([]byte, []int) {
+	return fileDescriptor_098391ad7281b52b, []int{26}
+}
+
+func (m *MoveDelta) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_MoveDelta.Unmarshal(m, b)
+}
+func (m *MoveDelta) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_MoveDelta.Marshal(b, m, deterministic)
+}
+func (m *MoveDelta) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_MoveDelta.Merge(m, src)
+}
+func (m *MoveDelta) XXX_Size() int {
+	return xxx_messageInfo_MoveDelta.Size(m)
+}
+func (m *MoveDelta) XXX_DiscardUnknown() {
+	xxx_messageInfo_MoveDelta.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_MoveDelta proto.InternalMessageInfo
+
+func (m *MoveDelta) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *MoveDelta) GetDx() int32 {
+	if m != nil {
+		return m.Dx
+	}
+	return 0
+}
+
+func (m *MoveDelta) GetDy() int32 {
+	if m != nil {
+		return m.Dy
+	}
+	return 0
+}
+
+func (m *MoveDelta) GetSequence() uint32 {
+	if m != nil {
+		return m.Sequence
+	}
+	return 0
+}
+
+func (m *MoveDelta) GetClientSequence() uint32 {
+	if m != nil {
+		return m.ClientSequence
+	}
+	return 0
+}
+
+func (m *MoveDelta) GetMoves() uint32 {
+	if m != nil {
+		return m.Moves
+	}
+	return 0
+}
+
+func (m *MoveDelta) GetTeleport() bool {
+	if m != nil {
+		return m.Teleport
+	}
+	return false
+}
+
+type RemoveEntity struct {
+	Id                   string   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RemoveEntity) Reset()         { *m = RemoveEntity{} }
+func (m *RemoveEntity) String() string { return proto.CompactTextString(m) }
+func (*RemoveEntity) ProtoMessage()    {}
+func (*RemoveEntity) Descriptor() ([]byte, []int) {
+	return fileDescriptor_098391ad7281b52b, []int{27}
+}
+
+func (m *RemoveEntity) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RemoveEntity.Unmarshal(m, b)
+}
+func (m *RemoveEntity) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RemoveEntity.Marshal(b, m, deterministic)
+}
+func (m *RemoveEntity) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RemoveEntity.Merge(m, src)
+}
+func (m *RemoveEntity) XXX_Size() int {
+	return xxx_messageInfo_RemoveEntity.Size(m)
+}
+func (m *RemoveEntity) XXX_DiscardUnknown() {
+	xxx_messageInfo_RemoveEntity.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RemoveEntity proto.InternalMessageInfo
+
+func (m *RemoveEntity) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+type PlayerRespawn struct {
+	Player               *Player  `protobuf:"bytes,1,opt,name=player,proto3" json:"player,omitempty"`
+	KilledById           string   `protobuf:"bytes,2,opt,name=killedById,proto3" json:"killedById,omitempty"`
+	KillerScore          int32    `protobuf:"varint,3,opt,name=killerScore,proto3" json:"killerScore,omitempty"`
+	VictimScore          int32    `protobuf:"varint,4,opt,name=victimScore,proto3" json:"victimScore,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *PlayerRespawn) Reset()         { *m = PlayerRespawn{} }
+func (m *PlayerRespawn) String() string { return proto.CompactTextString(m) }
+func (*PlayerRespawn) ProtoMessage()    {}
+func (*PlayerRespawn) Descriptor() ([]byte, []int) {
+	return fileDescriptor_098391ad7281b52b, []int{28}
+}
+
+func (m *PlayerRespawn) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PlayerRespawn.Unmarshal(m, b)
+}
+func (m *PlayerRespawn) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PlayerRespawn.Marshal(b, m, deterministic)
+}
+func (m *PlayerRespawn) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PlayerRespawn.Merge(m, src)
+}
+func (m *PlayerRespawn) XXX_Size() int {
+	return xxx_messageInfo_PlayerRespawn.Size(m)
+}
+func (m *PlayerRespawn) XXX_DiscardUnknown() {
+	xxx_messageInfo_PlayerRespawn.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PlayerRespawn proto.InternalMessageInfo
+
+func (m *PlayerRespawn) GetPlayer() *Player {
+	if m != nil {
+		return m.Player
+	}
+	return nil
+}
+
+func (m *PlayerRespawn) GetKilledById() string {
+	if m != nil {
+		return m.KilledById
+	}
+	return ""
+}
+
+func (m *PlayerRespawn) GetKillerScore() int32 {
+	if m != nil {
+		return m.KillerScore
+	}
+	return 0
+}
+
+func (m *PlayerRespawn) GetVictimScore() int32 {
+	if m != nil {
+		return m.VictimScore
+	}
+	return 0
+}
+
+type RoundOver struct {
+	RoundWinnerId        string               `protobuf:"bytes,1,opt,name=roundWinnerId,proto3" json:"roundWinnerId,omitempty"`
+	NewRoundAt           *timestamp.Timestamp `protobuf:"bytes,2,opt,name=newRoundAt,proto3" json:"newRoundAt,omitempty"`
+	Summary              *Summary             `protobuf:"bytes,3,opt,name=summary,proto3" json:"summary,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *RoundOver) Reset()         { *m = RoundOver{} }
+func (m *RoundOver) String() string { return proto.CompactTextString(m) }
+func (*RoundOver) ProtoMessage()    {}
+func (*RoundOver) Descriptor() ([]byte, []int) {
+	return fileDescriptor_098391ad7281b52b, []int{29}
+}
+
+func (m *RoundOver) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RoundOver.Unmarshal(m, b)
+}
+func (m *RoundOver) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RoundOver.Marshal(b, m, deterministic)
+}
+func (m *RoundOver) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RoundOver.Merge(m, src)
+}
+func (m *RoundOver) XXX_Size() int {
+	return xxx_messageInfo_RoundOver.Size(m)
+}
+func (m *RoundOver) XXX_DiscardUnknown() {
+	xxx_messageInfo_RoundOver.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RoundOver proto.InternalMessageInfo
+
+func (m *RoundOver) GetRoundWinnerId() string {
+	if m != nil {
+		return m.RoundWinnerId
+	}
+	return ""
+}
+
+func (m *RoundOver) GetNewRoundAt() *timestamp.Timestamp {
+	if m != nil {
+		return m.NewRoundAt
+	}
+	return nil
+}
+
+func (m *RoundOver) GetSummary() *Summary {
+	if m != nil {
+		return m.Summary
+	}
+	return nil
+}
+
+type SummaryStat struct {
+	PlayerId             string   `protobuf:"bytes,1,opt,name=playerId,proto3" json:"playerId,omitempty"`
+	Name                 string   `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Value                int32    `protobuf:"varint,3,opt,name=value,proto3" json:"value,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SummaryStat) Reset()         { *m = SummaryStat{} }
+func (m *SummaryStat) String() string { return proto.CompactTextString(m) }
+func (*SummaryStat) ProtoMessage()    {}
+func (*SummaryStat) Descriptor() ([]byte, []int) {
+	return fileDescriptor_098391ad7281b52b, []int{30}
+}
+
+func (m *SummaryStat) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SummaryStat.Unmarshal(m, b)
+}
+func (m *SummaryStat) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SummaryStat.Marshal(b, m, deterministic)
+}
+func (m *SummaryStat) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SummaryStat.Merge(m, src)
+}
+func (m *SummaryStat) XXX_Size() int {
+	return xxx_messageInfo_SummaryStat.Size(m)
+}
+func (m *SummaryStat) XXX_DiscardUnknown() {
+	xxx_messageInfo_SummaryStat.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SummaryStat proto.InternalMessageInfo
+
+func (m *SummaryStat) GetPlayerId() string {
+	if m != nil {
+		return m.PlayerId
+	}
+	return ""
+}
+
+func (m *SummaryStat) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *SummaryStat) GetValue() int32 {
+	if m != nil {
+		return m.Value
+	}
+	return 0
+}
+
+type Summary struct {
+	Standings            []*LeaderboardEntry `protobuf:"bytes,1,rep,name=standings,proto3" json:"standings,omitempty"`
+	TopFragger           *SummaryStat        `protobuf:"bytes,2,opt,name=topFragger,proto3" json:"topFragger,omitempty"`
+	LongestStreak        *SummaryStat        `protobuf:"bytes,3,opt,name=longestStreak,proto3" json:"longestStreak,omitempty"`
+	DurationMillis       int64               `protobuf:"varint,4,opt,name=durationMillis,proto3" json:"durationMillis,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}            `json:"-"`
+	XXX_unrecognized     []byte              `json:"-"`
+	XXX_sizecache        int32               `json:"-"`
+}
+
+func (m *Summary) Reset()         { *m = Summary{} }
+func (m *Summary) String() string { return proto.CompactTextString(m) }
+func (*Summary) ProtoMessage()    {}
+func (*Summary) Descriptor() ([]byte, []int) {
+	return fileDescriptor_098391ad7281b52b, []int{31}
+}
+
+func (m *Summary) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Summary.Unmarshal(m, b)
+}
+func (m *Summary) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Summary.Marshal(b, m, deterministic)
+}
+func (m *Summary) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Summary.Merge(m, src)
+}
+func (m *Summary) XXX_Size() int {
+	return xxx_messageInfo_Summary.Size(m)
+}
+func (m *Summary) XXX_DiscardUnknown() {
+	xxx_messageInfo_Summary.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Summary proto.InternalMessageInfo
+
+func (m *Summary) GetStandings() []*LeaderboardEntry {
+	if m != nil {
+		return m.Standings
+	}
+	return nil
+}
+
+func (m *Summary) GetTopFragger() *SummaryStat {
+	if m != nil {
+		return m.TopFragger
+	}
+	return nil
+}
+
+func (m *Summary) GetLongestStreak() *SummaryStat {
+	if m != nil {
+		return m.LongestStreak
+	}
+	return nil
+}
+
+func (m *Summary) GetDurationMillis() int64 {
+	if m != nil {
+		return m.DurationMillis
+	}
+	return 0
+}
+
+type RoundStart struct {
+	Players              []*Player `protobuf:"bytes,1,rep,name=players,proto3" json:"players,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}  `json:"-"`
+	XXX_unrecognized     []byte    `json:"-"`
+	XXX_sizecache        int32     `json:"-"`
+}
+
+func (m *RoundStart) Reset()         { *m = RoundStart{} }
+func (m *RoundStart) String() string { return proto.CompactTextString(m) }
+func (*RoundStart) ProtoMessage()    {}
+func (*RoundStart) Descriptor() ([]byte, []int) {
+	return fileDescriptor_098391ad7281b52b, []int{32}
+}
+
+func (m *RoundStart) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RoundStart.Unmarshal(m, b)
+}
+func (m *RoundStart) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RoundStart.Marshal(b, m, deterministic)
+}
+func (m *RoundStart) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RoundStart.Merge(m, src)
+}
+func (m *RoundStart) XXX_Size() int {
+	return xxx_messageInfo_RoundStart.Size(m)
+}
+func (m *RoundStart) XXX_DiscardUnknown() {
+	xxx_messageInfo_RoundStart.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RoundStart proto.InternalMessageInfo
+
+func (m *RoundStart) GetPlayers() []*Player {
+	if m != nil {
+		return m.Players
+	}
+	return nil
+}
+
+type StateSync struct {
+	Entities             []*Entity `protobuf:"bytes,1,rep,name=entities,proto3" json:"entities,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}  `json:"-"`
+	XXX_unrecognized     []byte    `json:"-"`
+	XXX_sizecache        int32     `json:"-"`
+}
+
+func (m *StateSync) Reset()         { *m = StateSync{} }
+func (m *StateSync) String() string { return proto.CompactTextString(m) }
+func (*StateSync) ProtoMessage()    {}
+func (*StateSync) Descriptor() ([]byte, []int) {
+	return fileDescriptor_098391ad7281b52b, []int{33}
+}
+
+func (m *StateSync) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_StateSync.Unmarshal(m, b)
+}
+func (m *StateSync) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_StateSync.Marshal(b, m, deterministic)
+}
+func (m *StateSync) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_StateSync.Merge(m, src)
+}
+func (m *StateSync) XXX_Size() int {
+	return xxx_messageInfo_StateSync.Size(m)
+}
+func (m *StateSync) XXX_DiscardUnknown() {
+	xxx_messageInfo_StateSync.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_StateSync proto.InternalMessageInfo
+
+func (m *StateSync) GetEntities() []*Entity {
+	if m != nil {
+		return m.Entities
+	}
+	return nil
+}
+
+type StateSyncRequest struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *StateSyncRequest) Reset()         { *m = StateSyncRequest{} }
+func (m *StateSyncRequest) String() string { return proto.CompactTextString(m) }
+func (*StateSyncRequest) ProtoMessage()    {}
+func (*StateSyncRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_098391ad7281b52b, []int{34}
+}
+
+func (m *StateSyncRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_StateSyncRequest.Unmarshal(m, b)
+}
+func (m *StateSyncRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_StateSyncRequest.Marshal(b, m, deterministic)
+}
+func (m *StateSyncRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_StateSyncRequest.Merge(m, src)
+}
+func (m *StateSyncRequest) XXX_Size() int {
+	return xxx_messageInfo_StateSyncRequest.Size(m)
+}
+func (m *StateSyncRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_StateSyncRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_StateSyncRequest proto.InternalMessageInfo
+
+type Heartbeat struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Heartbeat) Reset()         { *m = Heartbeat{} }
+func (m *Heartbeat) String() string { return proto.CompactTextString(m) }
+func (*Heartbeat) ProtoMessage()    {}
+func (*Heartbeat) Descriptor() ([]byte, []int) {
+	return fileDescriptor_098391ad7281b52b, []int{35}
+}
+
+func (m *Heartbeat) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Heartbeat.Unmarshal(m, b)
+}
+func (m *Heartbeat) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Heartbeat.Marshal(b, m, deterministic)
+}
+func (m *Heartbeat) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Heartbeat.Merge(m, src)
+}
+func (m *Heartbeat) XXX_Size() int {
+	return xxx_messageInfo_Heartbeat.Size(m)
+}
+func (m *Heartbeat) XXX_DiscardUnknown() {
+	xxx_messageInfo_Heartbeat.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Heartbeat proto.InternalMessageInfo
+
+type Disconnect struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Disconnect) Reset()         { *m = Disconnect{} }
+func (m *Disconnect) String() string { return proto.CompactTextString(m) }
+func (*Disconnect) ProtoMessage()    {}
+func (*Disconnect) Descriptor() ([]byte, []int) {
+	return fileDescriptor_098391ad7281b52b, []int{36}
+}
+
+func (m *Disconnect) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Disconnect.Unmarshal(m, b)
+}
+func (m *Disconnect) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Disconnect.Marshal(b, m, deterministic)
+}
+func (m *Disconnect) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Disconnect.Merge(m, src)
+}
+func (m *Disconnect) XXX_Size() int {
+	return xxx_messageInfo_Disconnect.Size(m)
+}
+func (m *Disconnect) XXX_DiscardUnknown() {
+	xxx_messageInfo_Disconnect.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Disconnect proto.InternalMessageInfo
+
+type Damage struct {
+	PlayerId             string   `protobuf:"bytes,1,opt,name=playerId,proto3" json:"playerId,omitempty"`
+	Damage               int32    `protobuf:"varint,2,opt,name=damage,proto3" json:"damage,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Damage) Reset()         { *m = Damage{} }
+func (m *Damage) String() string { return proto.CompactTextString(m) }
+func (*Damage) ProtoMessage()    {}
+func (*Damage) Descriptor() ([]byte, []int) {
+	return fileDescriptor_098391ad7281b52b, []int{37}
+}
+
+func (m *Damage) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Damage.Unmarshal(m, b)
+}
+func (m *Damage) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Damage.Marshal(b, m, deterministic)
+}
+func (m *Damage) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Damage.Merge(m, src)
+}
+func (m *Damage) XXX_Size() int {
+	return xxx_messageInfo_Damage.Size(m)
+}
+func (m *Damage) XXX_DiscardUnknown() {
+	xxx_messageInfo_Damage.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Damage proto.InternalMessageInfo
+
+func (m *Damage) GetPlayerId() string {
+	if m != nil {
+		return m.PlayerId
+	}
+	return ""
+}
+
+func (m *Damage) GetDamage() int32 {
+	if m != nil {
+		return m.Damage
+	}
+	return 0
+}
+
+type Score struct {
+	PlayerId             string   `protobuf:"bytes,1,opt,name=playerId,proto3" json:"playerId,omitempty"`
+	Score                int32    `protobuf:"varint,2,opt,name=score,proto3" json:"score,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Score) Reset()         { *m = Score{} }
+func (m *Score) String() string { return proto.CompactTextString(m) }
+func (*Score) ProtoMessage()    {}
+func (*Score) Descriptor() ([]byte, []int) {
+	return fileDescriptor_098391ad7281b52b, []int{38}
+}
+
+func (m *Score) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Score.Unmarshal(m, b)
+}
+func (m *Score) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Score.Marshal(b, m, deterministic)
+}
+func (m *Score) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Score.Merge(m, src)
+}
+func (m *Score) XXX_Size() int {
+	return xxx_messageInfo_Score.Size(m)
+}
+func (m *Score) XXX_DiscardUnknown() {
+	xxx_messageInfo_Score.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Score proto.InternalMessageInfo
+
+func (m *Score) GetPlayerId() string {
+	if m != nil {
+		return m.PlayerId
+	}
+	return ""
+}
+
+func (m *Score) GetScore() int32 {
+	if m != nil {
+		return m.Score
+	}
+	return 0
+}
+
+type Status struct {
+	PlayerId             string               `protobuf:"bytes,1,opt,name=playerId,proto3" json:"playerId,omitempty"`
+	Frozen               bool                 `protobuf:"varint,2,opt,name=frozen,proto3" json:"frozen,omitempty"`
+	Protected            bool                 `protobuf:"varint,3,opt,name=protected,proto3" json:"protected,omitempty"`
+	Blinking             bool                 `protobuf:"varint,4,opt,name=blinking,proto3" json:"blinking,omitempty"`
+	Ready                bool                 `protobuf:"varint,5,opt,name=ready,proto3" json:"ready,omitempty"`
+	ProtectedUntil       *timestamp.Timestamp `protobuf:"bytes,6,opt,name=protectedUntil,proto3" json:"protectedUntil,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *Status) Reset()         { *m = Status{} }
+func (m *Status) String() string { return proto.CompactTextString(m) }
+func (*Status) ProtoMessage()    {}
+func (*Status) Descriptor() ([]byte, []int) {
+	return fileDescriptor_098391ad7281b52b, []int{39}
+}
+
+func (m *Status) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Status.Unmarshal(m, b)
+}
+func (m *Status) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Status.Marshal(b, m, deterministic)
+}
+func (m *Status) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Status.Merge(m, src)
+}
+func (m *Status) XXX_Size() int {
+	return xxx_messageInfo_Status.Size(m)
+}
+func (m *Status) XXX_DiscardUnknown() {
+	xxx_messageInfo_Status.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Status proto.InternalMessageInfo
+
+func (m *Status) GetPlayerId() string {
+	if m != nil {
+		return m.PlayerId
+	}
+	return ""
+}
+
+func (m *Status) GetFrozen() bool {
+	if m != nil {
+		return m.Frozen
+	}
+	return false
+}
+
+func (m *Status) GetProtected() bool {
+	if m != nil {
+		return m.Protected
+	}
+	return false
+}
+
+func (m *Status) GetBlinking() bool {
+	if m != nil {
+		return m.Blinking
+	}
+	return false
+}
+
+func (m *Status) GetReady() bool {
+	if m != nil {
+		return m.Ready
+	}
+	return false
+}
+
+func (m *Status) GetProtectedUntil() *timestamp.Timestamp {
+	if m != nil {
+		return m.ProtectedUntil
+	}
+	return nil
+}
+
+type Wave struct {
+	Wave                 int32    `protobuf:"varint,1,opt,name=wave,proto3" json:"wave,omitempty"`
+	Enemies              int32    `protobuf:"varint,2,opt,name=enemies,proto3" json:"enemies,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Wave) Reset()         { *m = Wave{} }
+func (m *Wave) String() string { return proto.CompactTextString(m) }
+func (*Wave) ProtoMessage()    {}
+func (*Wave) Descriptor() ([]byte, []int) {
+	return fileDescriptor_098391ad7281b52b, []int{40}
+}
+
+func (m *Wave) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Wave.Unmarshal(m, b)
+}
+func (m *Wave) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Wave.Marshal(b, m, deterministic)
+}
+func (m *Wave) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Wave.Merge(m, src)
+}
+func (m *Wave) XXX_Size() int {
+	return xxx_messageInfo_Wave.Size(m)
+}
+func (m *Wave) XXX_DiscardUnknown() {
+	xxx_messageInfo_Wave.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Wave proto.InternalMessageInfo
+
+func (m *Wave) GetWave() int32 {
+	if m != nil {
+		return m.Wave
+	}
+	return 0
+}
+
+func (m *Wave) GetEnemies() int32 {
+	if m != nil {
+		return m.Enemies
+	}
+	return 0
+}
+
+type Phase struct {
+	Phase                GamePhase `protobuf:"varint,1,opt,name=phase,proto3,enum=proto.GamePhase" json:"phase,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}  `json:"-"`
+	XXX_unrecognized     []byte    `json:"-"`
+	XXX_sizecache        int32     `json:"-"`
+}
+
+func (m *Phase) Reset()         { *m = Phase{} }
+func (m *Phase) String() string { return proto.CompactTextString(m) }
+func (*Phase) ProtoMessage()    {}
+func (*Phase) Descriptor() ([]byte, []int) {
+	return fileDescriptor_098391ad7281b52b, []int{41}
+}
+
+func (m *Phase) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Phase.Unmarshal(m, b)
+}
+func (m *Phase) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Phase.Marshal(b, m, deterministic)
+}
+func (m *Phase) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Phase.Merge(m, src)
+}
+func (m *Phase) XXX_Size() int {
+	return xxx_messageInfo_Phase.Size(m)
+}
+func (m *Phase) XXX_DiscardUnknown() {
+	xxx_messageInfo_Phase.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Phase proto.InternalMessageInfo
+
+func (m *Phase) GetPhase() GamePhase {
+	if m != nil {
+		return m.Phase
+	}
+	return GamePhase_PLAYING
+}
+
+type Ready struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Ready) Reset()         { *m = Ready{} }
+func (m *Ready) String() string { return proto.CompactTextString(m) }
+func (*Ready) ProtoMessage()    {}
+func (*Ready) Descriptor() ([]byte, []int) {
+	return fileDescriptor_098391ad7281b52b, []int{42}
+}
+
+func (m *Ready) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Ready.Unmarshal(m, b)
+}
+func (m *Ready) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Ready.Marshal(b, m, deterministic)
+}
+func (m *Ready) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Ready.Merge(m, src)
+}
+func (m *Ready) XXX_Size() int {
+	return xxx_messageInfo_Ready.Size(m)
+}
+func (m *Ready) XXX_DiscardUnknown() {
+	xxx_messageInfo_Ready.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Ready proto.InternalMessageInfo
+
+type Modifier struct {
+	Name                 string   `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Modifier) Reset()         { *m = Modifier{} }
+func (m *Modifier) String() string { return proto.CompactTextString(m) }
+func (*Modifier) ProtoMessage()    {}
+func (*Modifier) Descriptor() ([]byte, []int) {
+	return fileDescriptor_098391ad7281b52b, []int{43}
+}
+
+func (m *Modifier) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Modifier.Unmarshal(m, b)
+}
+func (m *Modifier) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Modifier.Marshal(b, m, deterministic)
+}
+func (m *Modifier) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Modifier.Merge(m, src)
+}
+func (m *Modifier) XXX_Size() int {
+	return xxx_messageInfo_Modifier.Size(m)
+}
+func (m *Modifier) XXX_DiscardUnknown() {
+	xxx_messageInfo_Modifier.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Modifier proto.InternalMessageInfo
+
+func (m *Modifier) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+type SpectatorCount struct {
+	Count                int32    `protobuf:"varint,1,opt,name=count,proto3" json:"count,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SpectatorCount) Reset()         { *m = SpectatorCount{} }
+func (m *SpectatorCount) String() string { return proto.CompactTextString(m) }
+func (*SpectatorCount) ProtoMessage()    {}
+func (*SpectatorCount) Descriptor() ([]byte, []int) {
+	return fileDescriptor_098391ad7281b52b, []int{44}
+}
+
+func (m *SpectatorCount) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SpectatorCount.Unmarshal(m, b)
+}
+func (m *SpectatorCount) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SpectatorCount.Marshal(b, m, deterministic)
+}
+func (m *SpectatorCount) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SpectatorCount.Merge(m, src)
+}
+func (m *SpectatorCount) XXX_Size() int {
+	return xxx_messageInfo_SpectatorCount.Size(m)
+}
+func (m *SpectatorCount) XXX_DiscardUnknown() {
+	xxx_messageInfo_SpectatorCount.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SpectatorCount proto.InternalMessageInfo
+
+func (m *SpectatorCount) GetCount() int32 {
+	if m != nil {
+		return m.Count
+	}
+	return 0
+}
+
+type Ping struct {
+	Sent                 *timestamp.Timestamp `protobuf:"bytes,1,opt,name=sent,proto3" json:"sent,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
 }
 
-var xxx_messageInfo_RoundOver proto.InternalMessageInfo
+func (m *Ping) Reset()         { *m = Ping{} }
+func (m *Ping) String() string { return proto.CompactTextString(m) }
+func (*Ping) ProtoMessage()    {}
+func (*Ping) Descriptor() ([]byte, []int) {
+	return fileDescriptor_098391ad7281b52b, []int{45}
+}
 
-func (m *RoundOver) GetRoundWinnerId() string {
-	if m != nil {
-		return m.RoundWinnerId
-	}
-	return ""
+func (m *Ping) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Ping.Unmarshal(m, b)
+}
+func (m *Ping) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Ping.Marshal(b, m, deterministic)
+}
+func (m *Ping) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Ping.Merge(m, src)
+}
+func (m *Ping) XXX_Size() int {
+	return xxx_messageInfo_Ping.Size(m)
+}
+func (m *Ping) XXX_DiscardUnknown() {
+	xxx_messageInfo_Ping.DiscardUnknown(m)
 }
 
-func (m *RoundOver) GetNewRoundAt() *timestamp.Timestamp {
+var xxx_messageInfo_Ping proto.InternalMessageInfo
+
+func (m *Ping) GetSent() *timestamp.Timestamp {
 	if m != nil {
-		return m.NewRoundAt
+		return m.Sent
 	}
 	return nil
 }
 
-type RoundStart struct {
-	Players              []*Player `protobuf:"bytes,1,rep,name=players,proto3" json:"players,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}  `json:"-"`
-	XXX_unrecognized     []byte    `json:"-"`
-	XXX_sizecache        int32     `json:"-"`
+type Pong struct {
+	Sent                 *timestamp.Timestamp `protobuf:"bytes,1,opt,name=sent,proto3" json:"sent,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
 }
 
-func (m *RoundStart) Reset()         { *m = RoundStart{} }
-func (m *RoundStart) String() string { return proto.CompactTextString(m) }
-func (*RoundStart) ProtoMessage()    {}
-func (*RoundStart) Descriptor() ([]byte, []int) {
-	return fileDescriptor_098391ad7281b52b, []int{12}
+func (m *Pong) Reset()         { *m = Pong{} }
+func (m *Pong) String() string { return proto.CompactTextString(m) }
+func (*Pong) ProtoMessage()    {}
+func (*Pong) Descriptor() ([]byte, []int) {
+	return fileDescriptor_098391ad7281b52b, []int{46}
 }
 
-func (m *RoundStart) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_RoundStart.Unmarshal(m, b)
+func (m *Pong) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Pong.Unmarshal(m, b)
 }
-func (m *RoundStart) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_RoundStart.Marshal(b, m, deterministic)
+func (m *Pong) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Pong.Marshal(b, m, deterministic)
 }
-func (m *RoundStart) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_RoundStart.Merge(m, src)
+func (m *Pong) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Pong.Merge(m, src)
 }
-func (m *RoundStart) XXX_Size() int {
-	return xxx_messageInfo_RoundStart.Size(m)
+func (m *Pong) XXX_Size() int {
+	return xxx_messageInfo_Pong.Size(m)
 }
-func (m *RoundStart) XXX_DiscardUnknown() {
-	xxx_messageInfo_RoundStart.DiscardUnknown(m)
+func (m *Pong) XXX_DiscardUnknown() {
+	xxx_messageInfo_Pong.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_RoundStart proto.InternalMessageInfo
+var xxx_messageInfo_Pong proto.InternalMessageInfo
 
-func (m *RoundStart) GetPlayers() []*Player {
+func (m *Pong) GetSent() *timestamp.Timestamp {
 	if m != nil {
-		return m.Players
+		return m.Sent
 	}
 	return nil
 }
@@ -715,6 +2613,14 @@ type Request struct {
 	// Types that are valid to be assigned to Action:
 	//	*Request_Move
 	//	*Request_Laser
+	//	*Request_Ping
+	//	*Request_Heartbeat
+	//	*Request_StateSyncRequest
+	//	*Request_Disconnect
+	//	*Request_Dash
+	//	*Request_SwitchWeapon
+	//	*Request_Ready
+	//	*Request_Deploy
 	Action               isRequest_Action `protobuf_oneof:"action"`
 	XXX_NoUnkeyedLiteral struct{}         `json:"-"`
 	XXX_unrecognized     []byte           `json:"-"`
@@ -725,7 +2631,7 @@ func (m *Request) Reset()         { *m = Request{} }
 func (m *Request) String() string { return proto.CompactTextString(m) }
 func (*Request) ProtoMessage()    {}
 func (*Request) Descriptor() ([]byte, []int) {
-	return fileDescriptor_098391ad7281b52b, []int{13}
+	return fileDescriptor_098391ad7281b52b, []int{47}
 }
 
 func (m *Request) XXX_Unmarshal(b []byte) error {
@@ -758,10 +2664,58 @@ type Request_Laser struct {
 	Laser *Laser `protobuf:"bytes,2,opt,name=laser,proto3,oneof"`
 }
 
+type Request_Ping struct {
+	Ping *Ping `protobuf:"bytes,3,opt,name=ping,proto3,oneof"`
+}
+
+type Request_Heartbeat struct {
+	Heartbeat *Heartbeat `protobuf:"bytes,4,opt,name=heartbeat,proto3,oneof"`
+}
+
+type Request_StateSyncRequest struct {
+	StateSyncRequest *StateSyncRequest `protobuf:"bytes,5,opt,name=stateSyncRequest,proto3,oneof"`
+}
+
+type Request_Disconnect struct {
+	Disconnect *Disconnect `protobuf:"bytes,6,opt,name=disconnect,proto3,oneof"`
+}
+
+type Request_Dash struct {
+	Dash *Dash `protobuf:"bytes,7,opt,name=dash,proto3,oneof"`
+}
+
+type Request_SwitchWeapon struct {
+	SwitchWeapon *SwitchWeapon `protobuf:"bytes,8,opt,name=switchWeapon,proto3,oneof"`
+}
+
+type Request_Ready struct {
+	Ready *Ready `protobuf:"bytes,9,opt,name=ready,proto3,oneof"`
+}
+
+type Request_Deploy struct {
+	Deploy *Deploy `protobuf:"bytes,10,opt,name=deploy,proto3,oneof"`
+}
+
 func (*Request_Move) isRequest_Action() {}
 
 func (*Request_Laser) isRequest_Action() {}
 
+func (*Request_Ping) isRequest_Action() {}
+
+func (*Request_Heartbeat) isRequest_Action() {}
+
+func (*Request_StateSyncRequest) isRequest_Action() {}
+
+func (*Request_Disconnect) isRequest_Action() {}
+
+func (*Request_Dash) isRequest_Action() {}
+
+func (*Request_SwitchWeapon) isRequest_Action() {}
+
+func (*Request_Ready) isRequest_Action() {}
+
+func (*Request_Deploy) isRequest_Action() {}
+
 func (m *Request) GetAction() isRequest_Action {
 	if m != nil {
 		return m.Action
@@ -783,11 +2737,75 @@ func (m *Request) GetLaser() *Laser {
 	return nil
 }
 
+func (m *Request) GetPing() *Ping {
+	if x, ok := m.GetAction().(*Request_Ping); ok {
+		return x.Ping
+	}
+	return nil
+}
+
+func (m *Request) GetHeartbeat() *Heartbeat {
+	if x, ok := m.GetAction().(*Request_Heartbeat); ok {
+		return x.Heartbeat
+	}
+	return nil
+}
+
+func (m *Request) GetStateSyncRequest() *StateSyncRequest {
+	if x, ok := m.GetAction().(*Request_StateSyncRequest); ok {
+		return x.StateSyncRequest
+	}
+	return nil
+}
+
+func (m *Request) GetDisconnect() *Disconnect {
+	if x, ok := m.GetAction().(*Request_Disconnect); ok {
+		return x.Disconnect
+	}
+	return nil
+}
+
+func (m *Request) GetDash() *Dash {
+	if x, ok := m.GetAction().(*Request_Dash); ok {
+		return x.Dash
+	}
+	return nil
+}
+
+func (m *Request) GetSwitchWeapon() *SwitchWeapon {
+	if x, ok := m.GetAction().(*Request_SwitchWeapon); ok {
+		return x.SwitchWeapon
+	}
+	return nil
+}
+
+func (m *Request) GetReady() *Ready {
+	if x, ok := m.GetAction().(*Request_Ready); ok {
+		return x.Ready
+	}
+	return nil
+}
+
+func (m *Request) GetDeploy() *Deploy {
+	if x, ok := m.GetAction().(*Request_Deploy); ok {
+		return x.Deploy
+	}
+	return nil
+}
+
 // XXX_OneofWrappers is for the internal use of the proto package.
 func (*Request) XXX_OneofWrappers() []interface{} {
 	return []interface{}{
 		(*Request_Move)(nil),
 		(*Request_Laser)(nil),
+		(*Request_Ping)(nil),
+		(*Request_Heartbeat)(nil),
+		(*Request_StateSyncRequest)(nil),
+		(*Request_Disconnect)(nil),
+		(*Request_Dash)(nil),
+		(*Request_SwitchWeapon)(nil),
+		(*Request_Ready)(nil),
+		(*Request_Deploy)(nil),
 	}
 }
 
@@ -799,6 +2817,19 @@ type Response struct {
 	//	*Response_PlayerRespawn
 	//	*Response_RoundOver
 	//	*Response_RoundStart
+	//	*Response_Pong
+	//	*Response_StateSync
+	//	*Response_Heartbeat
+	//	*Response_MoveDelta
+	//	*Response_SpectatorCount
+	//	*Response_Damage
+	//	*Response_Score
+	//	*Response_Status
+	//	*Response_Wave
+	//	*Response_WeaponSwitch
+	//	*Response_Modifier
+	//	*Response_Phase
+	//	*Response_Explosion
 	Action               isResponse_Action `protobuf_oneof:"action"`
 	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
 	XXX_unrecognized     []byte            `json:"-"`
@@ -809,7 +2840,7 @@ func (m *Response) Reset()         { *m = Response{} }
 func (m *Response) String() string { return proto.CompactTextString(m) }
 func (*Response) ProtoMessage()    {}
 func (*Response) Descriptor() ([]byte, []int) {
-	return fileDescriptor_098391ad7281b52b, []int{14}
+	return fileDescriptor_098391ad7281b52b, []int{48}
 }
 
 func (m *Response) XXX_Unmarshal(b []byte) error {
@@ -858,6 +2889,58 @@ type Response_RoundStart struct {
 	RoundStart *RoundStart `protobuf:"bytes,6,opt,name=roundStart,proto3,oneof"`
 }
 
+type Response_Pong struct {
+	Pong *Pong `protobuf:"bytes,7,opt,name=pong,proto3,oneof"`
+}
+
+type Response_StateSync struct {
+	StateSync *StateSync `protobuf:"bytes,8,opt,name=stateSync,proto3,oneof"`
+}
+
+type Response_Heartbeat struct {
+	Heartbeat *Heartbeat `protobuf:"bytes,9,opt,name=heartbeat,proto3,oneof"`
+}
+
+type Response_MoveDelta struct {
+	MoveDelta *MoveDelta `protobuf:"bytes,10,opt,name=moveDelta,proto3,oneof"`
+}
+
+type Response_SpectatorCount struct {
+	SpectatorCount *SpectatorCount `protobuf:"bytes,11,opt,name=spectatorCount,proto3,oneof"`
+}
+
+type Response_Damage struct {
+	Damage *Damage `protobuf:"bytes,12,opt,name=damage,proto3,oneof"`
+}
+
+type Response_Score struct {
+	Score *Score `protobuf:"bytes,13,opt,name=score,proto3,oneof"`
+}
+
+type Response_Status struct {
+	Status *Status `protobuf:"bytes,14,opt,name=status,proto3,oneof"`
+}
+
+type Response_Wave struct {
+	Wave *Wave `protobuf:"bytes,15,opt,name=wave,proto3,oneof"`
+}
+
+type Response_WeaponSwitch struct {
+	WeaponSwitch *WeaponSwitch `protobuf:"bytes,16,opt,name=weaponSwitch,proto3,oneof"`
+}
+
+type Response_Modifier struct {
+	Modifier *Modifier `protobuf:"bytes,17,opt,name=modifier,proto3,oneof"`
+}
+
+type Response_Phase struct {
+	Phase *Phase `protobuf:"bytes,18,opt,name=phase,proto3,oneof"`
+}
+
+type Response_Explosion struct {
+	Explosion *Explosion `protobuf:"bytes,19,opt,name=explosion,proto3,oneof"`
+}
+
 func (*Response_AddEntity) isResponse_Action() {}
 
 func (*Response_UpdateEntity) isResponse_Action() {}
@@ -870,6 +2953,32 @@ func (*Response_RoundOver) isResponse_Action() {}
 
 func (*Response_RoundStart) isResponse_Action() {}
 
+func (*Response_Pong) isResponse_Action() {}
+
+func (*Response_StateSync) isResponse_Action() {}
+
+func (*Response_Heartbeat) isResponse_Action() {}
+
+func (*Response_MoveDelta) isResponse_Action() {}
+
+func (*Response_SpectatorCount) isResponse_Action() {}
+
+func (*Response_Damage) isResponse_Action() {}
+
+func (*Response_Score) isResponse_Action() {}
+
+func (*Response_Status) isResponse_Action() {}
+
+func (*Response_Wave) isResponse_Action() {}
+
+func (*Response_WeaponSwitch) isResponse_Action() {}
+
+func (*Response_Modifier) isResponse_Action() {}
+
+func (*Response_Phase) isResponse_Action() {}
+
+func (*Response_Explosion) isResponse_Action() {}
+
 func (m *Response) GetAction() isResponse_Action {
 	if m != nil {
 		return m.Action
@@ -919,6 +3028,97 @@ func (m *Response) GetRoundStart() *RoundStart {
 	return nil
 }
 
+func (m *Response) GetPong() *Pong {
+	if x, ok := m.GetAction().(*Response_Pong); ok {
+		return x.Pong
+	}
+	return nil
+}
+
+func (m *Response) GetStateSync() *StateSync {
+	if x, ok := m.GetAction().(*Response_StateSync); ok {
+		return x.StateSync
+	}
+	return nil
+}
+
+func (m *Response) GetHeartbeat() *Heartbeat {
+	if x, ok := m.GetAction().(*Response_Heartbeat); ok {
+		return x.Heartbeat
+	}
+	return nil
+}
+
+func (m *Response) GetMoveDelta() *MoveDelta {
+	if x, ok := m.GetAction().(*Response_MoveDelta); ok {
+		return x.MoveDelta
+	}
+	return nil
+}
+
+func (m *Response) GetSpectatorCount() *SpectatorCount {
+	if x, ok := m.GetAction().(*Response_SpectatorCount); ok {
+		return x.SpectatorCount
+	}
+	return nil
+}
+
+func (m *Response) GetDamage() *Damage {
+	if x, ok := m.GetAction().(*Response_Damage); ok {
+		return x.Damage
+	}
+	return nil
+}
+
+func (m *Response) GetScore() *Score {
+	if x, ok := m.GetAction().(*Response_Score); ok {
+		return x.Score
+	}
+	return nil
+}
+
+func (m *Response) GetStatus() *Status {
+	if x, ok := m.GetAction().(*Response_Status); ok {
+		return x.Status
+	}
+	return nil
+}
+
+func (m *Response) GetWave() *Wave {
+	if x, ok := m.GetAction().(*Response_Wave); ok {
+		return x.Wave
+	}
+	return nil
+}
+
+func (m *Response) GetWeaponSwitch() *WeaponSwitch {
+	if x, ok := m.GetAction().(*Response_WeaponSwitch); ok {
+		return x.WeaponSwitch
+	}
+	return nil
+}
+
+func (m *Response) GetModifier() *Modifier {
+	if x, ok := m.GetAction().(*Response_Modifier); ok {
+		return x.Modifier
+	}
+	return nil
+}
+
+func (m *Response) GetPhase() *Phase {
+	if x, ok := m.GetAction().(*Response_Phase); ok {
+		return x.Phase
+	}
+	return nil
+}
+
+func (m *Response) GetExplosion() *Explosion {
+	if x, ok := m.GetAction().(*Response_Explosion); ok {
+		return x.Explosion
+	}
+	return nil
+}
+
 // XXX_OneofWrappers is for the internal use of the proto package.
 func (*Response) XXX_OneofWrappers() []interface{} {
 	return []interface{}{
@@ -928,24 +3128,73 @@ func (*Response) XXX_OneofWrappers() []interface{} {
 		(*Response_PlayerRespawn)(nil),
 		(*Response_RoundOver)(nil),
 		(*Response_RoundStart)(nil),
+		(*Response_Pong)(nil),
+		(*Response_StateSync)(nil),
+		(*Response_Heartbeat)(nil),
+		(*Response_MoveDelta)(nil),
+		(*Response_SpectatorCount)(nil),
+		(*Response_Damage)(nil),
+		(*Response_Score)(nil),
+		(*Response_Status)(nil),
+		(*Response_Wave)(nil),
+		(*Response_WeaponSwitch)(nil),
+		(*Response_Modifier)(nil),
+		(*Response_Phase)(nil),
+		(*Response_Explosion)(nil),
 	}
 }
 
 func init() {
 	proto.RegisterEnum("proto.Direction", Direction_name, Direction_value)
+	proto.RegisterEnum("proto.GamePhase", GamePhase_name, GamePhase_value)
+	proto.RegisterEnum("proto.WeaponKind", WeaponKind_name, WeaponKind_value)
 	proto.RegisterType((*Coordinate)(nil), "proto.Coordinate")
 	proto.RegisterType((*Player)(nil), "proto.Player")
 	proto.RegisterType((*Laser)(nil), "proto.Laser")
+	proto.RegisterType((*PowerUp)(nil), "proto.PowerUp")
+	proto.RegisterType((*HealthPack)(nil), "proto.HealthPack")
+	proto.RegisterType((*Mine)(nil), "proto.Mine")
+	proto.RegisterType((*Wall)(nil), "proto.Wall")
 	proto.RegisterType((*Entity)(nil), "proto.Entity")
 	proto.RegisterType((*ConnectRequest)(nil), "proto.ConnectRequest")
 	proto.RegisterType((*ConnectResponse)(nil), "proto.ConnectResponse")
+	proto.RegisterType((*CareerStats)(nil), "proto.CareerStats")
+	proto.RegisterType((*Room)(nil), "proto.Room")
+	proto.RegisterType((*ListRoomsRequest)(nil), "proto.ListRoomsRequest")
+	proto.RegisterType((*ListRoomsResponse)(nil), "proto.ListRoomsResponse")
+	proto.RegisterType((*JoinRoomRequest)(nil), "proto.JoinRoomRequest")
+	proto.RegisterType((*LeaderboardRequest)(nil), "proto.LeaderboardRequest")
+	proto.RegisterType((*LeaderboardEntry)(nil), "proto.LeaderboardEntry")
+	proto.RegisterType((*LeaderboardResponse)(nil), "proto.LeaderboardResponse")
 	proto.RegisterType((*Move)(nil), "proto.Move")
 	proto.RegisterType((*AddEntity)(nil), "proto.AddEntity")
 	proto.RegisterType((*UpdateEntity)(nil), "proto.UpdateEntity")
+	proto.RegisterType((*Dash)(nil), "proto.Dash")
+	proto.RegisterType((*SwitchWeapon)(nil), "proto.SwitchWeapon")
+	proto.RegisterType((*Deploy)(nil), "proto.Deploy")
+	proto.RegisterType((*Explosion)(nil), "proto.Explosion")
+	proto.RegisterType((*WeaponSwitch)(nil), "proto.WeaponSwitch")
+	proto.RegisterType((*MoveDelta)(nil), "proto.MoveDelta")
 	proto.RegisterType((*RemoveEntity)(nil), "proto.RemoveEntity")
 	proto.RegisterType((*PlayerRespawn)(nil), "proto.PlayerRespawn")
 	proto.RegisterType((*RoundOver)(nil), "proto.RoundOver")
+	proto.RegisterType((*SummaryStat)(nil), "proto.SummaryStat")
+	proto.RegisterType((*Summary)(nil), "proto.Summary")
 	proto.RegisterType((*RoundStart)(nil), "proto.RoundStart")
+	proto.RegisterType((*StateSync)(nil), "proto.StateSync")
+	proto.RegisterType((*StateSyncRequest)(nil), "proto.StateSyncRequest")
+	proto.RegisterType((*Heartbeat)(nil), "proto.Heartbeat")
+	proto.RegisterType((*Disconnect)(nil), "proto.Disconnect")
+	proto.RegisterType((*Damage)(nil), "proto.Damage")
+	proto.RegisterType((*Score)(nil), "proto.Score")
+	proto.RegisterType((*Status)(nil), "proto.Status")
+	proto.RegisterType((*Wave)(nil), "proto.Wave")
+	proto.RegisterType((*Phase)(nil), "proto.Phase")
+	proto.RegisterType((*Ready)(nil), "proto.Ready")
+	proto.RegisterType((*Modifier)(nil), "proto.Modifier")
+	proto.RegisterType((*SpectatorCount)(nil), "proto.SpectatorCount")
+	proto.RegisterType((*Ping)(nil), "proto.Ping")
+	proto.RegisterType((*Pong)(nil), "proto.Pong")
 	proto.RegisterType((*Request)(nil), "proto.Request")
 	proto.RegisterType((*Response)(nil), "proto.Response")
 }
@@ -955,55 +3204,155 @@ func init() {
 }
 
 var fileDescriptor_098391ad7281b52b = []byte{
-	// 768 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x94, 0x54, 0x4d, 0x6f, 0xeb, 0x44,
-	0x14, 0xb5, 0x5d, 0xc7, 0x89, 0x6f, 0x93, 0x34, 0x0c, 0x0f, 0x64, 0x65, 0xf1, 0x28, 0x16, 0xe8,
-	0x05, 0x24, 0x92, 0xa7, 0x3c, 0x15, 0x41, 0xe9, 0xa6, 0x5f, 0xd4, 0x95, 0x0a, 0x8d, 0xa6, 0x29,
-	0xdd, 0xb0, 0x71, 0xeb, 0xa1, 0x1a, 0x35, 0x9e, 0x31, 0xf6, 0xa4, 0x69, 0xfe, 0x29, 0x5b, 0xfe,
-	0x09, 0x9a, 0x0f, 0x7f, 0xa4, 0x14, 0x0a, 0x2b, 0xcf, 0x9d, 0x39, 0xf7, 0xde, 0xb9, 0xe7, 0x1c,
-	0x0f, 0x0c, 0xb2, 0x9c, 0x0b, 0x3e, 0x49, 0x63, 0xca, 0xc6, 0x6a, 0x89, 0x5a, 0xea, 0x33, 0xfc,
-	0xec, 0x9e, 0xf3, 0xfb, 0x05, 0x99, 0xa8, 0xe8, 0x76, 0xf9, 0xdb, 0x44, 0xd0, 0x94, 0x14, 0x22,
-	0x4e, 0x33, 0x8d, 0x0b, 0x47, 0x00, 0xc7, 0x9c, 0xe7, 0x09, 0x65, 0xb1, 0x20, 0xa8, 0x0b, 0xf6,
-	0x53, 0x60, 0xef, 0xda, 0xa3, 0x16, 0xb6, 0x9f, 0x64, 0xb4, 0x0e, 0x1c, 0x1d, 0xad, 0x43, 0x0e,
-	0xde, 0x6c, 0x11, 0xaf, 0x49, 0x8e, 0xfa, 0xe0, 0xd0, 0x44, 0xc1, 0x7c, 0xec, 0xd0, 0x04, 0x21,
-	0x70, 0x59, 0x9c, 0x12, 0x05, 0xf5, 0xb1, 0x5a, 0xa3, 0x6f, 0xa0, 0x93, 0xf1, 0x82, 0x0a, 0xca,
-	0x59, 0xb0, 0xb5, 0x6b, 0x8f, 0xb6, 0xa7, 0x1f, 0xe9, 0x8e, 0xe3, 0xba, 0x1d, 0xae, 0x20, 0xb2,
-	0x04, 0xbd, 0xe3, 0x2c, 0x70, 0x75, 0x09, 0xb9, 0x0e, 0xff, 0xb0, 0xa1, 0x75, 0x11, 0x17, 0x2f,
-	0x34, 0x1c, 0x83, 0x9f, 0xd0, 0x9c, 0xdc, 0xa9, 0xea, 0xb2, 0x6b, 0x7f, 0x3a, 0x30, 0xd5, 0x4f,
-	0xca, 0x7d, 0x5c, 0x43, 0xd0, 0x77, 0xe0, 0x17, 0x22, 0xce, 0xc5, 0x9c, 0xa6, 0xc4, 0xdc, 0x66,
-	0x38, 0xd6, 0xcc, 0x8c, 0x4b, 0x66, 0xc6, 0xf3, 0x92, 0x19, 0x5c, 0x83, 0xd1, 0x0f, 0xb0, 0x43,
-	0x19, 0x15, 0x34, 0x5e, 0xcc, 0xca, 0x69, 0xdc, 0x7f, 0x9a, 0xe6, 0x39, 0x12, 0x05, 0xd0, 0xe6,
-	0x2b, 0x46, 0xf2, 0xf3, 0x24, 0x68, 0xa9, 0xbb, 0x97, 0x61, 0x18, 0x83, 0x77, 0xca, 0x04, 0x15,
-	0x6b, 0xf4, 0x0e, 0xbc, 0x4c, 0xb1, 0xaa, 0xe6, 0xd8, 0x9e, 0xf6, 0x4c, 0x5d, 0x4d, 0x75, 0x64,
-	0x61, 0x73, 0x8c, 0xbe, 0x80, 0xd6, 0x42, 0x92, 0x61, 0xee, 0xdf, 0x35, 0x38, 0x45, 0x50, 0x64,
-	0x61, 0x7d, 0x78, 0xd4, 0x01, 0x8f, 0xa8, 0xc2, 0xe1, 0x0c, 0xfa, 0xc7, 0x9c, 0x31, 0x72, 0x27,
-	0x30, 0xf9, 0x7d, 0x49, 0x0a, 0xf1, 0x9f, 0x64, 0x1b, 0x42, 0x27, 0x8b, 0x8b, 0x62, 0xc5, 0xf3,
-	0x44, 0x35, 0xf2, 0x71, 0x15, 0x87, 0x18, 0x76, 0xaa, 0x8a, 0x45, 0xc6, 0x59, 0x41, 0xd0, 0x1b,
-	0x68, 0x09, 0xfe, 0x40, 0x98, 0xa9, 0xaa, 0x03, 0xf4, 0x15, 0x74, 0xd4, 0x25, 0x28, 0x29, 0x02,
-	0x67, 0x77, 0xab, 0x31, 0x95, 0x1e, 0x1a, 0x57, 0xc7, 0xe1, 0xb7, 0xe0, 0xfe, 0xc4, 0x1f, 0xc9,
-	0xa6, 0xa2, 0xf6, 0xab, 0x8a, 0x86, 0x53, 0xf0, 0x0f, 0x93, 0xc4, 0x70, 0xf8, 0x65, 0x39, 0xb4,
-	0xca, 0xfc, 0x5b, 0xb7, 0x92, 0x91, 0x3d, 0xe8, 0x5e, 0x67, 0x49, 0x2c, 0xc8, 0xff, 0x4b, 0x7b,
-	0x0b, 0x5d, 0x4c, 0x52, 0xfe, 0x58, 0xa6, 0x3d, 0xa3, 0x31, 0xfc, 0x05, 0x7a, 0x5a, 0x2c, 0xc9,
-	0x4a, 0xbc, 0x62, 0xb2, 0xae, 0x91, 0xd4, 0x7e, 0x41, 0xd2, 0x4a, 0xd0, 0xb7, 0x00, 0x0f, 0x74,
-	0xb1, 0x20, 0xc9, 0xd1, 0xfa, 0x3c, 0x31, 0x22, 0x34, 0x76, 0xc2, 0x14, 0x7c, 0xcc, 0x97, 0x2c,
-	0xb9, 0x7c, 0x54, 0xea, 0xf7, 0x72, 0x19, 0xdc, 0x50, 0xa6, 0x0d, 0xa5, 0xfb, 0x6f, 0x6e, 0xa2,
-	0x7d, 0x00, 0x46, 0x56, 0x2a, 0xeb, 0x50, 0x18, 0x43, 0xfd, 0x9b, 0xd1, 0x1b, 0xe8, 0x70, 0x0f,
-	0x40, 0x2d, 0xaf, 0xa4, 0xf7, 0xd1, 0x3b, 0x68, 0xeb, 0x6b, 0x16, 0x81, 0xbd, 0xa1, 0xa0, 0x19,
-	0xa2, 0x3c, 0x0d, 0x7f, 0x85, 0x76, 0xe9, 0xaf, 0xcf, 0xc1, 0x95, 0x34, 0x99, 0xa9, 0xb7, 0x4d,
-	0x82, 0x94, 0x37, 0xb2, 0xb0, 0x3a, 0xaa, 0x4d, 0xec, 0xbc, 0x62, 0xe2, 0x58, 0xcb, 0xfc, 0xa7,
-	0x03, 0x9d, 0xca, 0x6c, 0xef, 0xc1, 0x8f, 0x4b, 0xcd, 0x4d, 0x93, 0xd2, 0x23, 0x95, 0x17, 0x22,
-	0x0b, 0xd7, 0x20, 0xf4, 0x3d, 0x74, 0x97, 0x0d, 0xc5, 0x4d, 0xd7, 0x8f, 0x4d, 0x52, 0xd3, 0x0c,
-	0x91, 0x85, 0x37, 0xa0, 0x32, 0x35, 0x6f, 0xa8, 0x6e, 0xfe, 0xba, 0x32, 0xb5, 0x69, 0x08, 0x99,
-	0xda, 0x84, 0xa2, 0x03, 0xe8, 0x65, 0x4d, 0x43, 0x98, 0x17, 0xe3, 0xcd, 0x26, 0x83, 0xfa, 0x2c,
-	0xb2, 0xf0, 0x26, 0x58, 0x4e, 0x99, 0x97, 0xb2, 0xab, 0x67, 0xa3, 0x9e, 0xb2, 0xb2, 0x83, 0x9c,
-	0xb2, 0x02, 0xa1, 0x0f, 0x00, 0x79, 0xa5, 0x5c, 0xe0, 0x6d, 0x3c, 0x4f, 0xb5, 0xa4, 0x91, 0x85,
-	0x1b, 0xb0, 0x9a, 0xe3, 0xaf, 0x0f, 0xc0, 0xaf, 0x7e, 0x31, 0xe4, 0x81, 0x73, 0x3d, 0x1b, 0x58,
-	0xa8, 0x03, 0xee, 0xc9, 0xe5, 0xcd, 0xcf, 0x03, 0x5b, 0xae, 0x2e, 0x4e, 0x7f, 0x9c, 0x0f, 0x1c,
-	0xe4, 0x43, 0x0b, 0x9f, 0x9f, 0x45, 0xf3, 0xc1, 0x96, 0xdc, 0xbc, 0x9a, 0x5f, 0xce, 0x06, 0xee,
-	0xb4, 0x00, 0xf7, 0x4c, 0x3e, 0x1c, 0xfb, 0xd0, 0x36, 0x8f, 0x03, 0xfa, 0xa4, 0x7a, 0x1a, 0x9b,
-	0xcf, 0xcf, 0xf0, 0xd3, 0xe7, 0xdb, 0x5a, 0xd6, 0xd0, 0x42, 0x13, 0xf0, 0xae, 0x44, 0x4e, 0xe2,
-	0x14, 0xf5, 0x2b, 0x7e, 0x75, 0xce, 0x4e, 0x15, 0x97, 0xe0, 0x91, 0xfd, 0xde, 0xbe, 0xf5, 0xd4,
-	0xee, 0x87, 0xbf, 0x02, 0x00, 0x00, 0xff, 0xff, 0x4f, 0x54, 0x08, 0x41, 0xf7, 0x06, 0x00, 0x00,
+	// 2368 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xa4, 0x58, 0xcf, 0x73, 0x1b, 0x49,
+	0xf5, 0xd7, 0xe8, 0xf7, 0x3c, 0xcb, 0xb2, 0xd2, 0xc9, 0x37, 0xdf, 0xc1, 0x45, 0x05, 0x67, 0x6a,
+	0xc9, 0x9a, 0x00, 0x76, 0xf0, 0xb2, 0xa9, 0xdd, 0x4d, 0xaa, 0x20, 0x89, 0xbd, 0x91, 0x77, 0x9d,
+	0x44, 0xd5, 0x72, 0x2a, 0x2c, 0x97, 0xad, 0xb6, 0xa6, 0x63, 0x4f, 0x65, 0x34, 0x2d, 0x66, 0x5a,
+	0x56, 0xc4, 0x8d, 0x1b, 0xff, 0x01, 0x55, 0x1c, 0x38, 0x70, 0xe4, 0xc6, 0x8d, 0x13, 0x47, 0xce,
+	0x9c, 0x38, 0xf0, 0xd7, 0x50, 0xaf, 0xbb, 0xa7, 0xe7, 0x87, 0x15, 0x3b, 0xbb, 0x39, 0x49, 0xef,
+	0xbd, 0x4f, 0xff, 0x78, 0xfd, 0x7e, 0x0f, 0x0c, 0x66, 0x89, 0x90, 0x62, 0x77, 0xca, 0xc2, 0x78,
+	0x47, 0xfd, 0x25, 0x2d, 0xf5, 0xb3, 0xf9, 0xa3, 0x53, 0x21, 0x4e, 0x23, 0xbe, 0xab, 0xa8, 0x93,
+	0xf9, 0xeb, 0x5d, 0x19, 0x4e, 0x79, 0x2a, 0xd9, 0x74, 0xa6, 0x71, 0xfe, 0x36, 0xc0, 0x13, 0x21,
+	0x92, 0x20, 0x8c, 0x99, 0xe4, 0xa4, 0x07, 0xce, 0x5b, 0xcf, 0xd9, 0x72, 0xb6, 0x5b, 0xd4, 0x79,
+	0x8b, 0xd4, 0xd2, 0xab, 0x6b, 0x6a, 0xe9, 0xff, 0xa5, 0x01, 0xed, 0x51, 0xc4, 0x96, 0x3c, 0x21,
+	0x7d, 0xa8, 0x87, 0x81, 0xc2, 0xb9, 0xb4, 0x1e, 0x06, 0x84, 0x40, 0x33, 0x66, 0x53, 0xae, 0xb0,
+	0x2e, 0x55, 0xff, 0xc9, 0xcf, 0xa1, 0x3b, 0x13, 0x69, 0x28, 0x43, 0x11, 0x7b, 0x8d, 0x2d, 0x67,
+	0x7b, 0x6d, 0xef, 0x9a, 0x3e, 0x72, 0x27, 0x3f, 0x8f, 0x5a, 0x08, 0x6e, 0x11, 0x4e, 0x44, 0xec,
+	0x35, 0xf5, 0x16, 0xf8, 0x9f, 0xdc, 0x80, 0xd6, 0x44, 0x44, 0x22, 0xf1, 0x5a, 0x8a, 0xa9, 0x09,
+	0x72, 0x13, 0xda, 0x01, 0x9b, 0xb2, 0x53, 0xee, 0xb5, 0xd5, 0xd5, 0x0c, 0x85, 0x3b, 0x48, 0xce,
+	0xa6, 0x5e, 0x47, 0x71, 0xd5, 0x7f, 0xc4, 0xbe, 0x4e, 0xc4, 0xef, 0x79, 0xec, 0x75, 0xb7, 0x9c,
+	0xed, 0x2e, 0x35, 0x14, 0xf9, 0x29, 0x74, 0x22, 0xc1, 0x02, 0x31, 0x97, 0x9e, 0xbb, 0xd5, 0xd8,
+	0xee, 0xdb, 0xbb, 0xbd, 0xe2, 0x6c, 0x26, 0xe2, 0xaf, 0xc3, 0x38, 0xa0, 0x19, 0x82, 0xf8, 0xd0,
+	0x63, 0x13, 0x19, 0x9e, 0x73, 0x2d, 0xf4, 0x40, 0x1d, 0x50, 0xe2, 0xe1, 0x55, 0x13, 0xce, 0x82,
+	0xa5, 0xb7, 0xa6, 0xce, 0xd1, 0x04, 0xd9, 0x86, 0x8d, 0x74, 0xc6, 0x79, 0xf0, 0x6c, 0x1e, 0xc9,
+	0x70, 0x16, 0x85, 0x3c, 0xf1, 0x7a, 0x5b, 0xce, 0xb6, 0x43, 0xab, 0x6c, 0xf2, 0x18, 0xfa, 0x78,
+	0x01, 0x3e, 0x91, 0x3c, 0x78, 0x19, 0xcb, 0x30, 0xf2, 0xd6, 0xd5, 0x9b, 0x6d, 0xee, 0x68, 0x03,
+	0xee, 0x64, 0x06, 0xdc, 0x39, 0xce, 0x0c, 0x48, 0x2b, 0x2b, 0xfc, 0x7f, 0xd5, 0xa1, 0x75, 0xc4,
+	0xd2, 0x15, 0xf6, 0xd9, 0x01, 0x37, 0x08, 0x13, 0x3e, 0x51, 0xc6, 0x40, 0x23, 0xf5, 0xf7, 0x06,
+	0x46, 0xe1, 0xfd, 0x8c, 0x4f, 0x73, 0x08, 0xf9, 0x0c, 0xdc, 0x54, 0xb2, 0x44, 0xe2, 0x59, 0x5e,
+	0xe3, 0xca, 0x8b, 0xe4, 0x60, 0xf2, 0x00, 0x36, 0xc2, 0x38, 0x94, 0x21, 0x8b, 0x46, 0x99, 0xf1,
+	0x9b, 0xef, 0x32, 0x7e, 0x15, 0x49, 0x3c, 0xe8, 0x88, 0x45, 0xcc, 0x93, 0xc3, 0xc0, 0x58, 0x3c,
+	0x23, 0xc9, 0x26, 0x74, 0x67, 0x21, 0x4f, 0x26, 0x61, 0x7c, 0xaa, 0xac, 0xde, 0xa5, 0x96, 0x5e,
+	0x69, 0x77, 0x02, 0xcd, 0x74, 0xc1, 0x66, 0xc6, 0xea, 0xea, 0xff, 0x2a, 0x63, 0xb8, 0x2b, 0x8d,
+	0xe1, 0x0f, 0xa1, 0x33, 0x12, 0x0b, 0x9e, 0xbc, 0x9c, 0x5d, 0x78, 0xc9, 0xa2, 0x57, 0xd7, 0xaf,
+	0xf4, 0x6a, 0xff, 0x6b, 0x80, 0x21, 0x67, 0x91, 0x3c, 0x1b, 0xb1, 0xc9, 0x9b, 0x0f, 0xdd, 0xec,
+	0x5b, 0x68, 0x3e, 0x0b, 0x63, 0xfe, 0x81, 0xdb, 0x14, 0x5f, 0xb9, 0x51, 0x7a, 0x65, 0xff, 0x0f,
+	0x0e, 0x34, 0x5f, 0xb1, 0x28, 0xfa, 0xd0, 0x13, 0x7c, 0xe8, 0x05, 0x3c, 0x95, 0xc9, 0x7c, 0x22,
+	0xc3, 0x93, 0x48, 0x7b, 0x50, 0x97, 0x96, 0x78, 0x18, 0x99, 0x67, 0xea, 0x65, 0x94, 0x7f, 0xb4,
+	0xa8, 0xa1, 0xfc, 0x3f, 0xd6, 0xa1, 0x7d, 0x10, 0xcb, 0x50, 0x2e, 0xc9, 0xc7, 0xd0, 0x9e, 0xa9,
+	0x7c, 0x63, 0xce, 0x5c, 0x37, 0x67, 0xea, 0x24, 0x34, 0xac, 0x51, 0x23, 0x26, 0x1f, 0x41, 0x2b,
+	0x42, 0xbf, 0x37, 0xae, 0xda, 0x33, 0x38, 0x15, 0x0b, 0xc3, 0x1a, 0xd5, 0x42, 0x72, 0x17, 0x3a,
+	0x33, 0x6d, 0x55, 0xe3, 0x92, 0xfd, 0x6c, 0x3f, 0xcd, 0x1d, 0xd6, 0x68, 0x06, 0x20, 0x9f, 0x00,
+	0x9c, 0x59, 0xbb, 0x79, 0xad, 0x92, 0xca, 0xb9, 0x41, 0x87, 0x35, 0x5a, 0x80, 0x91, 0xdb, 0xd0,
+	0x9c, 0x86, 0xb1, 0x4e, 0x4b, 0x6b, 0x7b, 0x6b, 0x06, 0x8e, 0x26, 0x1b, 0xd6, 0xa8, 0x12, 0x21,
+	0x64, 0xc1, 0xa2, 0xc8, 0xeb, 0x94, 0x20, 0xf8, 0xe6, 0x08, 0x41, 0xd1, 0xe3, 0x2e, 0xb4, 0xb9,
+	0xd2, 0xdf, 0xff, 0x9b, 0x03, 0xfd, 0x27, 0x22, 0x8e, 0xf9, 0x44, 0x52, 0xfe, 0xbb, 0x39, 0x4f,
+	0xe5, 0x7b, 0x25, 0x5e, 0x8c, 0x15, 0x96, 0xa6, 0x0b, 0x91, 0x64, 0x06, 0xb6, 0x74, 0x9e, 0x51,
+	0x9b, 0xc5, 0x8c, 0xba, 0x09, 0xdd, 0x74, 0xc6, 0x27, 0x92, 0x49, 0xae, 0x74, 0xed, 0x52, 0x4b,
+	0x93, 0x3b, 0xd0, 0x4f, 0xf8, 0x44, 0xdf, 0xe2, 0x58, 0xbc, 0xe1, 0xb1, 0x52, 0xcf, 0xa5, 0x15,
+	0xae, 0xff, 0xd7, 0x06, 0x6c, 0xd8, 0xcb, 0xa6, 0x33, 0x11, 0xa7, 0x1c, 0x4f, 0x93, 0x6a, 0x89,
+	0xbe, 0xb0, 0x26, 0xc8, 0x4f, 0xa0, 0xab, 0x14, 0x0c, 0x79, 0xea, 0xd5, 0xb7, 0x1a, 0x05, 0xc3,
+	0x6a, 0xbb, 0x53, 0x2b, 0x26, 0x0f, 0x61, 0x10, 0xf0, 0xd7, 0x6c, 0x1e, 0x49, 0x9b, 0xa6, 0xbc,
+	0xc6, 0x3b, 0xd2, 0xd7, 0x05, 0x24, 0xaa, 0x35, 0x15, 0x41, 0xf8, 0x3a, 0xe4, 0x99, 0xbe, 0x96,
+	0x26, 0x77, 0xa0, 0x35, 0x3b, 0x63, 0xa9, 0xd6, 0x37, 0xdf, 0xee, 0x29, 0x9b, 0xf2, 0x11, 0xf2,
+	0xa9, 0x16, 0x93, 0xbb, 0xd0, 0x9e, 0xb0, 0x84, 0xf3, 0xc4, 0x58, 0x95, 0x64, 0x7e, 0xaf, 0x98,
+	0x63, 0xc9, 0x64, 0x4a, 0x0d, 0x62, 0xc5, 0x53, 0x75, 0x56, 0x3d, 0x15, 0xb9, 0x05, 0x80, 0x49,
+	0xea, 0x09, 0xbe, 0x7d, 0xea, 0x75, 0xb7, 0x1a, 0xdb, 0x2e, 0x2d, 0x70, 0xde, 0x3f, 0x51, 0x91,
+	0x1d, 0x20, 0x53, 0x71, 0xce, 0x8f, 0xcf, 0x12, 0x21, 0x65, 0xc4, 0x9f, 0x85, 0x51, 0x14, 0xa6,
+	0xaa, 0x3e, 0xad, 0xd3, 0x15, 0x12, 0xff, 0x05, 0xac, 0x15, 0x2e, 0x8e, 0xf6, 0x79, 0x13, 0x46,
+	0x51, 0x6a, 0x2a, 0xbe, 0x26, 0x54, 0x7d, 0xe5, 0x4c, 0x9e, 0xa5, 0xa6, 0xf4, 0x1b, 0x0a, 0x7d,
+	0x6d, 0x11, 0xc6, 0xa9, 0x32, 0x40, 0x8b, 0xaa, 0xff, 0xfe, 0x3e, 0x34, 0xa9, 0x10, 0xd3, 0xf7,
+	0xf2, 0x4b, 0x0f, 0x3a, 0x3a, 0x5e, 0xb3, 0x2d, 0x32, 0xd2, 0x27, 0x30, 0x38, 0x0a, 0x53, 0x89,
+	0x3b, 0xa5, 0xc6, 0xd3, 0xfd, 0xfb, 0x70, 0xad, 0xc0, 0x33, 0x0e, 0x75, 0x1b, 0x5a, 0x09, 0x32,
+	0x3c, 0x67, 0xab, 0x51, 0x88, 0x1f, 0x04, 0x51, 0x2d, 0xf1, 0x7f, 0x0b, 0x1b, 0x5f, 0x89, 0x30,
+	0x56, 0x2c, 0x13, 0x34, 0x37, 0xa1, 0x8d, 0xb2, 0xc3, 0xec, 0x82, 0x86, 0x22, 0xbb, 0xd0, 0x31,
+	0x76, 0x31, 0x09, 0xe6, 0xff, 0x6c, 0x52, 0x2b, 0x06, 0x1d, 0xcd, 0x50, 0xfe, 0xcf, 0x80, 0x1c,
+	0x71, 0x16, 0xf0, 0xe4, 0x44, 0xb0, 0x24, 0xb8, 0x62, 0x7b, 0xff, 0x37, 0x30, 0x28, 0xa0, 0x0f,
+	0x62, 0x99, 0x2c, 0x55, 0x6c, 0x2a, 0xa5, 0x2d, 0xda, 0xd2, 0x2b, 0xdf, 0xec, 0x06, 0xb4, 0xd2,
+	0x89, 0x48, 0xb8, 0x79, 0x31, 0x4d, 0xf8, 0x43, 0xb8, 0x5e, 0xba, 0x87, 0x79, 0x9d, 0x5f, 0x40,
+	0x87, 0xc7, 0x32, 0x09, 0x79, 0xf6, 0x3e, 0xff, 0x9f, 0x25, 0xc2, 0xca, 0x35, 0x68, 0x86, 0xf3,
+	0x29, 0x34, 0x9f, 0x89, 0x73, 0x5e, 0x6e, 0x10, 0x9c, 0xab, 0x1b, 0x04, 0xcc, 0x18, 0xa8, 0x7e,
+	0x3c, 0xd1, 0xf7, 0x5d, 0xa7, 0x96, 0xf6, 0xf7, 0xc0, 0x7d, 0x14, 0x04, 0x26, 0x87, 0xff, 0x38,
+	0xcb, 0x66, 0x6a, 0xd7, 0x0b, 0xa1, 0x9e, 0xa5, 0xba, 0x07, 0xd0, 0x7b, 0x39, 0x0b, 0x98, 0xe4,
+	0xdf, 0x69, 0xd9, 0x57, 0xcd, 0x6e, 0x7d, 0xd0, 0xf0, 0x7d, 0x68, 0xee, 0xb3, 0xf4, 0xac, 0x74,
+	0x29, 0xa7, 0x72, 0xa9, 0x3e, 0xf4, 0xc6, 0x8b, 0x50, 0x4e, 0xce, 0x74, 0xbf, 0xe6, 0x7b, 0xd0,
+	0xde, 0xe7, 0xb3, 0x48, 0x2c, 0xab, 0xae, 0xeb, 0x53, 0x70, 0x0f, 0xde, 0xce, 0x22, 0x91, 0xa2,
+	0x9e, 0xc5, 0xc2, 0xe7, 0x5c, 0x5d, 0xf8, 0xd0, 0x15, 0x58, 0x10, 0xce, 0x6d, 0xe8, 0x68, 0xca,
+	0x7f, 0x0e, 0x3d, 0x7d, 0xae, 0xbe, 0xc3, 0xa5, 0x6e, 0x50, 0xed, 0x36, 0xeb, 0x17, 0xbb, 0x4d,
+	0xff, 0xef, 0x0e, 0xb8, 0x68, 0xb7, 0x7d, 0x1e, 0x49, 0x76, 0x21, 0xf8, 0xfa, 0x50, 0x0f, 0xde,
+	0xaa, 0x75, 0xd7, 0x68, 0x3d, 0x78, 0xab, 0xe8, 0xa5, 0xd7, 0x30, 0xf4, 0xb2, 0xf4, 0x4e, 0xcd,
+	0xf2, 0x3b, 0x61, 0x0e, 0x9b, 0x44, 0x21, 0x8f, 0xe5, 0x38, 0x43, 0xb4, 0x14, 0xa2, 0xc2, 0x45,
+	0xc7, 0xc4, 0xfc, 0x92, 0xaa, 0xb4, 0xb8, 0x4e, 0x35, 0x81, 0x3b, 0x4b, 0x1e, 0xf1, 0x99, 0x48,
+	0xa4, 0xca, 0x7d, 0x5d, 0x6a, 0x69, 0xff, 0x16, 0xf4, 0x28, 0x47, 0x98, 0x31, 0x71, 0xf5, 0xdd,
+	0xff, 0xec, 0xc0, 0xba, 0xae, 0xec, 0xe8, 0xd0, 0x6c, 0x11, 0xa3, 0x13, 0x98, 0xfa, 0xef, 0xac,
+	0xa8, 0xff, 0xb6, 0xfa, 0xdf, 0x02, 0xc0, 0xc4, 0xc5, 0x83, 0xc7, 0xcb, 0xc3, 0xc0, 0x44, 0x4f,
+	0x81, 0x43, 0xb6, 0x60, 0x4d, 0x51, 0xc9, 0xb8, 0x10, 0x49, 0x45, 0x16, 0x22, 0xce, 0xc3, 0x89,
+	0x0c, 0xa7, 0x1a, 0xa1, 0x1b, 0x92, 0x22, 0xcb, 0xff, 0x93, 0x03, 0x2e, 0x15, 0xf3, 0x38, 0x78,
+	0x71, 0xae, 0xfa, 0x8d, 0xf5, 0x04, 0x89, 0x57, 0x61, 0x1c, 0x17, 0x6c, 0x58, 0x66, 0x92, 0x2f,
+	0x00, 0x62, 0xbe, 0x50, 0xab, 0x1e, 0x65, 0x19, 0xe6, 0xb2, 0x2e, 0xba, 0x80, 0x26, 0xdb, 0xd0,
+	0x49, 0xe7, 0xd3, 0x29, 0x4b, 0x96, 0x5e, 0xa3, 0xd4, 0xab, 0x8c, 0x35, 0x97, 0x66, 0x62, 0x7f,
+	0x0c, 0x6b, 0x86, 0x87, 0x39, 0xfd, 0xfb, 0x24, 0x98, 0x73, 0x16, 0xcd, 0x6d, 0x82, 0x51, 0x84,
+	0xff, 0x1f, 0x07, 0x3a, 0x66, 0x57, 0xf2, 0xa9, 0x9a, 0x05, 0xe2, 0x20, 0x8c, 0x4f, 0xaf, 0xcc,
+	0x2b, 0x39, 0x92, 0xec, 0x01, 0x48, 0x31, 0xfb, 0x32, 0x61, 0xa7, 0xa7, 0xb6, 0x81, 0x23, 0x65,
+	0x25, 0xf0, 0xc2, 0xb4, 0x80, 0x22, 0x9f, 0xc1, 0x7a, 0x24, 0xe2, 0x53, 0x9e, 0xca, 0xb1, 0x4c,
+	0x38, 0x7b, 0xe3, 0x35, 0xde, 0xb9, 0xac, 0x0c, 0x44, 0xb7, 0x0d, 0xe6, 0x09, 0xc3, 0x20, 0x34,
+	0x45, 0x10, 0x8d, 0xd8, 0xa0, 0x15, 0xae, 0xff, 0x29, 0x80, 0x7a, 0xe2, 0x31, 0x0e, 0x2c, 0xe4,
+	0xe3, 0xbc, 0x22, 0x39, 0x5b, 0x8d, 0x8b, 0x1e, 0x66, 0x0b, 0xd4, 0x7d, 0x70, 0xf1, 0x54, 0x3e,
+	0x5e, 0xc6, 0x93, 0x52, 0xff, 0xe2, 0x5c, 0xda, 0xbf, 0x60, 0x61, 0xb3, 0xeb, 0xb2, 0xc2, 0xb6,
+	0x06, 0xee, 0x90, 0xb3, 0x44, 0x9e, 0x70, 0x26, 0xfd, 0x1e, 0xc0, 0x7e, 0x98, 0x66, 0xf5, 0xe5,
+	0x21, 0xb4, 0xf7, 0xf5, 0x2c, 0x7b, 0x99, 0x19, 0xf3, 0xf9, 0xb7, 0x5e, 0x9c, 0x7f, 0xfd, 0xcf,
+	0xa1, 0xa5, 0xdd, 0xf9, 0xb2, 0xc5, 0xb6, 0xa0, 0xd4, 0x8b, 0x05, 0xe5, 0xdf, 0x0e, 0xb4, 0xf1,
+	0xa2, 0xf3, 0xf4, 0xaa, 0x93, 0xcd, 0x34, 0x5d, 0x2f, 0x4d, 0xd3, 0x3f, 0x04, 0xd7, 0x8e, 0xa2,
+	0xa6, 0xd9, 0xcf, 0x19, 0xb8, 0xe3, 0x49, 0x14, 0xc6, 0x6f, 0x70, 0x76, 0x6b, 0x2a, 0xa1, 0xa5,
+	0xf3, 0xb1, 0xb9, 0x55, 0x1c, 0x9b, 0x2f, 0x0e, 0xc3, 0xed, 0xef, 0x3c, 0x0c, 0xff, 0x12, 0x47,
+	0x99, 0x73, 0xf5, 0x55, 0x60, 0xc1, 0xce, 0xb9, 0x69, 0x71, 0xd4, 0x7f, 0xec, 0x44, 0x78, 0xcc,
+	0xa7, 0xba, 0x01, 0x55, 0x9d, 0x88, 0x21, 0xfd, 0x5d, 0x68, 0xa9, 0xf6, 0x2f, 0xef, 0x0f, 0x9d,
+	0x4b, 0xfb, 0x43, 0xbf, 0x03, 0x2d, 0x8a, 0x77, 0xf6, 0x6f, 0x41, 0xf7, 0x59, 0xd6, 0x5c, 0x66,
+	0x81, 0xe6, 0xe4, 0x81, 0xe6, 0xdf, 0x81, 0xfe, 0x58, 0xf7, 0xd4, 0x22, 0x79, 0x22, 0xe6, 0xb1,
+	0xd4, 0xbd, 0xf8, 0x3c, 0x96, 0x59, 0xf7, 0xa5, 0x08, 0xff, 0x3e, 0x34, 0x47, 0xf8, 0x32, 0x3b,
+	0xd0, 0x4c, 0xb9, 0x11, 0x5e, 0xae, 0xb9, 0xc2, 0xa9, 0x75, 0xe2, 0x7b, 0xac, 0xfb, 0x6f, 0x03,
+	0x3a, 0x59, 0x27, 0x83, 0x03, 0x8c, 0x30, 0x6f, 0x55, 0x18, 0x60, 0xc4, 0xb9, 0x1e, 0x60, 0xb0,
+	0x51, 0xb0, 0xa3, 0x56, 0xfd, 0xb2, 0x51, 0xeb, 0x36, 0x34, 0x67, 0x68, 0xee, 0x46, 0x69, 0x23,
+	0xd4, 0x0b, 0x37, 0x42, 0x11, 0xb9, 0x07, 0xee, 0x59, 0x16, 0x06, 0x66, 0x1e, 0x1b, 0xe4, 0x03,
+	0x96, 0xe6, 0x0f, 0x6b, 0x34, 0x07, 0x91, 0x03, 0x18, 0xa4, 0x95, 0x60, 0x32, 0x93, 0x59, 0x96,
+	0x8f, 0xaa, 0xb1, 0x36, 0xac, 0xd1, 0x0b, 0x4b, 0x70, 0xb4, 0x0b, 0x6c, 0xc8, 0x79, 0xed, 0x52,
+	0x51, 0xcf, 0x63, 0x11, 0x47, 0xbb, 0x1c, 0x86, 0x0a, 0x05, 0x2c, 0x3d, 0xab, 0xcc, 0x6d, 0xd8,
+	0x75, 0xa0, 0x42, 0x28, 0x22, 0x9f, 0x43, 0x2f, 0x2d, 0x74, 0x18, 0xea, 0xd3, 0xc3, 0xda, 0xde,
+	0xf5, 0xec, 0x6a, 0x05, 0xd1, 0xb0, 0x46, 0x4b, 0x50, 0x7c, 0x54, 0x1d, 0x05, 0x6e, 0xe9, 0x51,
+	0x95, 0x63, 0xe1, 0xa3, 0x2a, 0x21, 0x8e, 0xc3, 0x81, 0x6a, 0x59, 0x54, 0x83, 0x9f, 0x67, 0x1d,
+	0xdd, 0xc7, 0xe0, 0x38, 0xac, 0xc5, 0x38, 0x41, 0x32, 0xd5, 0xa6, 0xf9, 0xff, 0xe8, 0x40, 0xd7,
+	0xb6, 0x87, 0xf7, 0xc0, 0x65, 0x59, 0x5f, 0xe6, 0x39, 0xa5, 0x17, 0xb7, 0xfd, 0x1a, 0xbe, 0xb8,
+	0x05, 0xa1, 0x4a, 0xf3, 0x42, 0x57, 0xe6, 0xd5, 0x4b, 0x2a, 0x15, 0x1b, 0x36, 0x54, 0xa9, 0x08,
+	0xc5, 0xa5, 0x49, 0xa1, 0xda, 0x7b, 0x8d, 0xd2, 0xd2, 0x62, 0x23, 0x80, 0x4b, 0x8b, 0x50, 0xf2,
+	0x10, 0xd6, 0x67, 0xc5, 0x3e, 0xc0, 0x78, 0xc7, 0x8d, 0x72, 0x6e, 0xd6, 0xb2, 0x61, 0x8d, 0x96,
+	0xc1, 0xa8, 0x65, 0x92, 0x15, 0x6a, 0xaf, 0x55, 0xd2, 0xd2, 0x16, 0x70, 0xd4, 0xd2, 0x82, 0xd0,
+	0x21, 0x12, 0x5b, 0x13, 0x2a, 0x0e, 0x91, 0x17, 0x0b, 0x74, 0x88, 0x1c, 0xa6, 0x3c, 0x5c, 0xc4,
+	0xa7, 0x15, 0x87, 0xc0, 0x08, 0x54, 0x1e, 0x2e, 0xb4, 0x87, 0x5b, 0xe7, 0xf3, 0xba, 0xa5, 0x9b,
+	0x58, 0x47, 0xc5, 0x9b, 0x58, 0x50, 0x39, 0x26, 0xdc, 0xf7, 0x89, 0x89, 0x7b, 0xe0, 0x4e, 0xb3,
+	0x3e, 0xd0, 0x83, 0xd2, 0x0a, 0xdb, 0x1f, 0xe2, 0x0a, 0x0b, 0x22, 0xbf, 0x82, 0x7e, 0x5a, 0xca,
+	0x43, 0xde, 0x5a, 0x69, 0xf6, 0x29, 0x27, 0xa9, 0x61, 0x8d, 0x56, 0xe0, 0xca, 0x0d, 0x75, 0xf9,
+	0xe9, 0x95, 0xdd, 0x50, 0x31, 0x95, 0x1b, 0xaa, 0x7f, 0xe8, 0xd5, 0xba, 0xd4, 0xac, 0x97, 0xbc,
+	0x5a, 0xd5, 0x28, 0xf4, 0x6a, 0x25, 0xc4, 0xed, 0x52, 0x55, 0x79, 0xbc, 0x7e, 0x69, 0x3b, 0x5d,
+	0x8e, 0x70, 0x3b, 0x2d, 0xd6, 0x9f, 0x4e, 0xce, 0xb9, 0xb7, 0x51, 0xf9, 0x74, 0xa2, 0x93, 0x13,
+	0x8a, 0xd0, 0xe9, 0x16, 0x85, 0x36, 0xdb, 0x1b, 0x94, 0x9c, 0xae, 0xd8, 0x81, 0xa3, 0xd3, 0x15,
+	0xa1, 0xd8, 0xe8, 0xdb, 0x6f, 0x05, 0xd7, 0xd4, 0xb2, 0x0d, 0xfb, 0x8e, 0x9a, 0x3d, 0xac, 0x15,
+	0x3e, 0x1f, 0x7c, 0x94, 0x95, 0x07, 0x52, 0xd2, 0x4d, 0x95, 0x06, 0xd4, 0x4d, 0x09, 0xd1, 0x3a,
+	0x3c, 0x1b, 0x25, 0xbc, 0xeb, 0x25, 0xeb, 0xd8, 0x11, 0x03, 0xad, 0x63, 0x41, 0x79, 0xe8, 0xde,
+	0x7d, 0x08, 0x6e, 0xfe, 0x25, 0xa3, 0x0d, 0xf5, 0x97, 0xa3, 0x41, 0x8d, 0x74, 0xa1, 0xb9, 0xff,
+	0xe2, 0xd5, 0xf3, 0x81, 0x83, 0xff, 0x8e, 0x0e, 0xbe, 0x3c, 0x1e, 0xd4, 0x89, 0x0b, 0x2d, 0x7a,
+	0xf8, 0x74, 0x78, 0x3c, 0x68, 0x20, 0x73, 0x7c, 0xfc, 0x62, 0x34, 0x68, 0xde, 0xdd, 0x01, 0xd7,
+	0x96, 0x2a, 0xb2, 0x06, 0x9d, 0xd1, 0xd1, 0xa3, 0x6f, 0x0e, 0x9f, 0x3f, 0x1d, 0xd4, 0x10, 0x7e,
+	0xf4, 0xe2, 0xf1, 0xe3, 0x6f, 0x06, 0x0e, 0xfe, 0x3d, 0x78, 0xbe, 0x7f, 0xb0, 0x3f, 0xa8, 0xdf,
+	0x7d, 0x00, 0x90, 0x7f, 0xf9, 0x56, 0x98, 0x47, 0xe3, 0x03, 0x3a, 0xa8, 0x11, 0x02, 0xfd, 0xd1,
+	0xe1, 0x01, 0x7d, 0x72, 0xf8, 0xfc, 0xe9, 0xb7, 0x9a, 0xe7, 0x90, 0x3e, 0xc0, 0xf8, 0xd5, 0xa3,
+	0x91, 0xa1, 0xeb, 0x7b, 0xff, 0xac, 0x43, 0x13, 0x4f, 0x23, 0x5f, 0x40, 0xc7, 0x8c, 0xce, 0x64,
+	0xf5, 0x28, 0xbd, 0x79, 0xb3, 0xca, 0xd6, 0xb9, 0xc9, 0xaf, 0x91, 0x5d, 0xec, 0x40, 0x12, 0xfc,
+	0x76, 0xdb, 0xb7, 0x49, 0x42, 0xaf, 0xd9, 0xb0, 0x74, 0x06, 0xde, 0x76, 0xee, 0x39, 0xe4, 0x10,
+	0xfa, 0x4f, 0xb9, 0x2c, 0xb4, 0xa0, 0xe4, 0x07, 0x17, 0xdb, 0xd2, 0x6c, 0x8f, 0xcd, 0x55, 0x22,
+	0x7b, 0xf6, 0xaf, 0xc1, 0xb5, 0xdf, 0x1a, 0x88, 0x6d, 0x6e, 0x2b, 0x5f, 0x24, 0x36, 0xbd, 0x8b,
+	0x02, 0xbb, 0xc3, 0x43, 0xe8, 0x66, 0x5f, 0x1d, 0x48, 0xa6, 0x63, 0xe5, 0x33, 0xc4, 0xbb, 0x75,
+	0x3f, 0x69, 0x2b, 0xc1, 0x27, 0xff, 0x1b, 0x00, 0x47, 0xdb, 0xc4, 0xd9, 0xc6, 0x19, 0x00, 0x00,
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -1020,6 +3369,9 @@ const _ = grpc.SupportPackageIsVersion6
 type GameClient interface {
 	Connect(ctx context.Context, in *ConnectRequest, opts ...grpc.CallOption) (*ConnectResponse, error)
 	Stream(ctx context.Context, opts ...grpc.CallOption) (Game_StreamClient, error)
+	GetLeaderboard(ctx context.Context, in *LeaderboardRequest, opts ...grpc.CallOption) (*LeaderboardResponse, error)
+	ListRooms(ctx context.Context, in *ListRoomsRequest, opts ...grpc.CallOption) (*ListRoomsResponse, error)
+	JoinRoom(ctx context.Context, in *JoinRoomRequest, opts ...grpc.CallOption) (*ConnectResponse, error)
 }
 
 type gameClient struct {
@@ -1070,10 +3422,40 @@ func (x *gameStreamClient) Recv() (*Response, error) {
 	return m, nil
 }
 
+func (c *gameClient) GetLeaderboard(ctx context.Context, in *LeaderboardRequest, opts ...grpc.CallOption) (*LeaderboardResponse, error) {
+	out := new(LeaderboardResponse)
+	err := c.cc.Invoke(ctx, "/proto.Game/GetLeaderboard", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gameClient) ListRooms(ctx context.Context, in *ListRoomsRequest, opts ...grpc.CallOption) (*ListRoomsResponse, error) {
+	out := new(ListRoomsResponse)
+	err := c.cc.Invoke(ctx, "/proto.Game/ListRooms", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gameClient) JoinRoom(ctx context.Context, in *JoinRoomRequest, opts ...grpc.CallOption) (*ConnectResponse, error) {
+	out := new(ConnectResponse)
+	err := c.cc.Invoke(ctx, "/proto.Game/JoinRoom", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // GameServer is the server API for Game service.
 type GameServer interface {
 	Connect(context.Context, *ConnectRequest) (*ConnectResponse, error)
 	Stream(Game_StreamServer) error
+	GetLeaderboard(context.Context, *LeaderboardRequest) (*LeaderboardResponse, error)
+	ListRooms(context.Context, *ListRoomsRequest) (*ListRoomsResponse, error)
+	JoinRoom(context.Context, *JoinRoomRequest) (*ConnectResponse, error)
 }
 
 // UnimplementedGameServer can be embedded to have forward compatible implementations.
@@ -1086,6 +3468,15 @@ func (*UnimplementedGameServer) Connect(ctx context.Context, req *ConnectRequest
 func (*UnimplementedGameServer) Stream(srv Game_StreamServer) error {
 	return status.Errorf(codes.Unimplemented, "method Stream not implemented")
 }
+func (*UnimplementedGameServer) GetLeaderboard(ctx context.Context, req *LeaderboardRequest) (*LeaderboardResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetLeaderboard not implemented")
+}
+func (*UnimplementedGameServer) ListRooms(ctx context.Context, req *ListRoomsRequest) (*ListRoomsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListRooms not implemented")
+}
+func (*UnimplementedGameServer) JoinRoom(ctx context.Context, req *JoinRoomRequest) (*ConnectResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method JoinRoom not implemented")
+}
 
 func RegisterGameServer(s *grpc.Server, srv GameServer) {
 	s.RegisterService(&_Game_serviceDesc, srv)
@@ -1135,6 +3526,60 @@ func (x *gameStreamServer) Recv() (*Request, error) {
 	return m, nil
 }
 
+func _Game_GetLeaderboard_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LeaderboardRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GameServer).GetLeaderboard(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.Game/GetLeaderboard",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GameServer).GetLeaderboard(ctx, req.(*LeaderboardRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Game_ListRooms_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListRoomsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GameServer).ListRooms(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.Game/ListRooms",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GameServer).ListRooms(ctx, req.(*ListRoomsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Game_JoinRoom_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(JoinRoomRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GameServer).JoinRoom(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.Game/JoinRoom",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GameServer).JoinRoom(ctx, req.(*JoinRoomRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _Game_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "proto.Game",
 	HandlerType: (*GameServer)(nil),
@@ -1143,6 +3588,18 @@ var _Game_serviceDesc = grpc.ServiceDesc{
 			MethodName: "Connect",
 			Handler:    _Game_Connect_Handler,
 		},
+		{
+			MethodName: "GetLeaderboard",
+			Handler:    _Game_GetLeaderboard_Handler,
+		},
+		{
+			MethodName: "ListRooms",
+			Handler:    _Game_ListRooms_Handler,
+		},
+		{
+			MethodName: "JoinRoom",
+			Handler:    _Game_JoinRoom_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{